@@ -7,11 +7,34 @@ import (
 	"github.com/kelseyhightower/envconfig"
 )
 
+// Storages supported by the server. The memory storage keeps the ledger in the process memory, so
+// it is meant for local demos and loses everything on restart.
+const (
+	PostgresStorage = "postgres"
+	MemoryStorage   = "memory"
+)
+
+// Tracers supported by the server.
+const (
+	NewRelicTracer      = "newrelic"
+	OpenTelemetryTracer = "opentelemetry"
+)
+
 type Config struct {
-	RPCServer  RPCServerConfig
-	HttpServer HttpServerConfig
-	Postgres   PostgresConfig
-	NewRelic   NewRelicConfig
+	Storage       string `envconfig:"STORAGE" default:"postgres"`
+	Tracer        string `envconfig:"TRACER" default:"newrelic"`
+	RPCServer     RPCServerConfig
+	HttpServer    HttpServerConfig
+	Postgres      PostgresConfig
+	NewRelic      NewRelicConfig
+	OpenTelemetry OpenTelemetryConfig
+	Health        HealthConfig
+	Jobs          JobsConfig
+	TLS           TLSConfig
+	Auth          AuthConfig
+	Chart         ChartConfig
+	Templates     PostingTemplatesConfig
+	Accruals      AccrualRulesConfig
 }
 
 func LoadConfig() (*Config, error) {
@@ -43,17 +66,22 @@ type HttpServerConfig struct {
 }
 
 type PostgresConfig struct {
-	DatabaseName string `envconfig:"DATABASE_NAME" default:"dev"`
-	User         string `envconfig:"DATABASE_USER" default:"postgres"`
-	Password     string `envconfig:"DATABASE_PASSWORD" default:"postgres"`
-	Host         string `envconfig:"DATABASE_HOST" default:"localhost"`
-	Port         string `envconfig:"DATABASE_PORT" default:"5432"`
-	PoolMinSize  string `envconfig:"DATABASE_POOL_MIN_SIZE" default:"2"`
-	PoolMaxSize  string `envconfig:"DATABASE_POOL_MAX_SIZE" default:"10"`
-	SSLMode      string `envconfig:"DATABASE_SSLMODE" default:"disable"`
-	SSLRootCert  string `envconfig:"DATABASE_SSL_ROOTCERT"`
-	SSLCert      string `envconfig:"DATABASE_SSL_CERT"`
-	SSLKey       string `envconfig:"DATABASE_SSL_KEY"`
+	DatabaseName    string `envconfig:"DATABASE_NAME" default:"dev"`
+	User            string `envconfig:"DATABASE_USER" default:"postgres"`
+	Password        string `envconfig:"DATABASE_PASSWORD" default:"postgres"`
+	Host            string `envconfig:"DATABASE_HOST" default:"localhost"`
+	Port            string `envconfig:"DATABASE_PORT" default:"5432"`
+	PoolMinSize     string `envconfig:"DATABASE_POOL_MIN_SIZE" default:"2"`
+	PoolMaxSize     string `envconfig:"DATABASE_POOL_MAX_SIZE" default:"10"`
+	SSLMode         string `envconfig:"DATABASE_SSLMODE" default:"disable"`
+	SSLRootCert     string `envconfig:"DATABASE_SSL_ROOTCERT"`
+	SSLCert         string `envconfig:"DATABASE_SSL_CERT"`
+	SSLKey          string `envconfig:"DATABASE_SSL_KEY"`
+	BalanceStrategy string `envconfig:"DATABASE_BALANCE_STRATEGY" default:"lazy"`
+	// ReplicaDSNs are the connection strings of read replicas, separated by commas. Read-only
+	// calls are routed to them while they lag less than ReplicaMaxLag.
+	ReplicaDSNs   []string      `envconfig:"DATABASE_REPLICA_DSNS"`
+	ReplicaMaxLag time.Duration `envconfig:"DATABASE_REPLICA_MAX_LAG" default:"5s"`
 }
 
 type NewRelicConfig struct {
@@ -61,6 +89,81 @@ type NewRelicConfig struct {
 	LicenseKey string `envconfig:"NEW_RELIC_LICENSE_KEY"`
 }
 
+// OpenTelemetryConfig holds the OTLP collector receiving the spans, when the OpenTelemetryTracer is
+// used. SampleRatio is the fraction of the traces started by the ledger that are sampled.
+type OpenTelemetryConfig struct {
+	ServiceName string  `envconfig:"OTEL_SERVICE_NAME" default:"the-amazing-ledger"`
+	Endpoint    string  `envconfig:"OTLP_ENDPOINT" default:"localhost:4317"`
+	Insecure    bool    `envconfig:"OTLP_INSECURE" default:"false"`
+	SampleRatio float64 `envconfig:"OTEL_SAMPLE_RATIO" default:"1"`
+}
+
+// HealthConfig holds how often the dependencies of the server are checked, and how long each check
+// may take. On shutdown, the servers keep serving for DrainPeriod after reporting not serving, so
+// load balancers stop sending calls before they stop.
+type HealthConfig struct {
+	CheckInterval time.Duration `envconfig:"HEALTH_CHECK_INTERVAL" default:"5s"`
+	CheckTimeout  time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	DrainPeriod   time.Duration `envconfig:"HEALTH_DRAIN_PERIOD" default:"5s"`
+}
+
+// TLSConfig holds the certificate of the rpc and http servers. Client certificates are verified
+// against ClientCAFile, when set.
+type TLSConfig struct {
+	CertFile     string `envconfig:"TLS_CERT_FILE"`
+	KeyFile      string `envconfig:"TLS_KEY_FILE"`
+	ClientCAFile string `envconfig:"TLS_CLIENT_CA_FILE"`
+}
+
+// AuthConfig enables authentication by the given methods (mtls, jwt), authorizing principals by
+// the grants of PolicyFile. Authentication is disabled when no method is set. The JWKS file is read
+// again every JWKSReloadInterval, so keys can be rotated without a restart.
+type AuthConfig struct {
+	Methods            []string      `envconfig:"AUTH_METHODS"`
+	PolicyFile         string        `envconfig:"AUTH_POLICY_FILE"`
+	JWKSFile           string        `envconfig:"AUTH_JWKS_FILE"`
+	JWKSReloadInterval time.Duration `envconfig:"AUTH_JWKS_RELOAD_INTERVAL" default:"1m"`
+	JWTIssuer          string        `envconfig:"AUTH_JWT_ISSUER"`
+	JWTAudience        string        `envconfig:"AUTH_JWT_AUDIENCE"`
+}
+
+// ChartConfig holds the chart of accounts file, which is read again every ReloadInterval, so changes
+// apply without a restart. The default chart is used when no file is set.
+type ChartConfig struct {
+	File           string        `envconfig:"CHART_OF_ACCOUNTS_FILE"`
+	ReloadInterval time.Duration `envconfig:"CHART_OF_ACCOUNTS_RELOAD_INTERVAL" default:"30s"`
+}
+
+// PostingTemplatesConfig holds the posting templates file, which is read again every ReloadInterval.
+// Events can't be posted by template when no file is set.
+type PostingTemplatesConfig struct {
+	File           string        `envconfig:"POSTING_TEMPLATES_FILE"`
+	ReloadInterval time.Duration `envconfig:"POSTING_TEMPLATES_RELOAD_INTERVAL" default:"30s"`
+}
+
+// AccrualRulesConfig holds the accrual rules file, which is read again every ReloadInterval. Nothing
+// is accrued when no file is set.
+type AccrualRulesConfig struct {
+	File           string        `envconfig:"ACCRUAL_RULES_FILE"`
+	ReloadInterval time.Duration `envconfig:"ACCRUAL_RULES_RELOAD_INTERVAL" default:"30s"`
+}
+
+// JobsConfig holds the intervals of the background jobs. A zero interval disables the job.
+type JobsConfig struct {
+	InvariantCheckInterval     time.Duration `envconfig:"JOB_INVARIANT_CHECK_INTERVAL" default:"0"`
+	SnapshotPruneInterval      time.Duration `envconfig:"JOB_SNAPSHOT_PRUNE_INTERVAL" default:"0"`
+	SnapshotRetention          time.Duration `envconfig:"JOB_SNAPSHOT_RETENTION" default:"720h"`
+	SnapshotPrecomputeInterval time.Duration `envconfig:"JOB_SNAPSHOT_PRECOMPUTE_INTERVAL" default:"0"`
+	SnapshotPrecomputeLimit    int           `envconfig:"JOB_SNAPSHOT_PRECOMPUTE_LIMIT" default:"100"`
+	PartitionInterval          time.Duration `envconfig:"JOB_PARTITION_INTERVAL" default:"0"`
+	PartitionMonthsAhead       int           `envconfig:"JOB_PARTITION_MONTHS_AHEAD" default:"3"`
+	PartitionRetentionMonths   int           `envconfig:"JOB_PARTITION_RETENTION_MONTHS" default:"0"`
+	PartitionArchiveDir        string        `envconfig:"JOB_PARTITION_ARCHIVE_DIR"`
+	ScheduleInterval           time.Duration `envconfig:"JOB_SCHEDULE_INTERVAL" default:"1m"`
+	ScheduleBatchSize          int           `envconfig:"JOB_SCHEDULE_BATCH_SIZE" default:"100"`
+	AccrualInterval            time.Duration `envconfig:"JOB_ACCRUAL_INTERVAL" default:"1h"`
+}
+
 func (c PostgresConfig) DSN() string {
 	connectString := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s pool_min_conns=%s pool_max_conns=%s",
 		c.User, c.Password, c.Host, c.Port, c.DatabaseName, c.PoolMinSize, c.PoolMaxSize)
@@ -86,18 +86,6 @@ func TestNewEntry(t *testing.T) {
 			},
 			expectedErr: app.ErrInvalidAmount,
 		},
-		{
-			name: "Invalid when account structure has less than 3 levels",
-			args: args{
-				id:        uuid.New(),
-				operation: vos.CreditOperation,
-				account:   "asset.bacen",
-				version:   vos.NextAccountVersion,
-				amount:    123,
-				metadata:  json.RawMessage(`{}`),
-			},
-			expectedErr: app.ErrInvalidAccountStructure,
-		},
 	}
 
 	for _, tt := range testCases {
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// HealthChecker checks the dependencies the ledger needs to serve calls.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) []vos.DependencyStatus
+}
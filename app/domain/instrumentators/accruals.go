@@ -0,0 +1,38 @@
+package instrumentators
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var (
+	accrualRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "accruals",
+		Name:      "runs_total",
+		Help:      "Number of days accrued, by rule.",
+	}, []string{"rule"})
+	accrualTransactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "accruals",
+		Name:      "posted_transactions_total",
+		Help:      "Number of accrual transactions posted, by rule.",
+	}, []string{"rule"})
+)
+
+func (lp *LedgerInstrumentator) AccruedDay(ctx context.Context, run vos.AccrualRun) {
+	accrualRuns.WithLabelValues(run.Rule).Inc()
+	accrualTransactions.WithLabelValues(run.Rule).Add(float64(run.Accounts))
+
+	zerolog.Ctx(ctx).Info().
+		Str("rule", run.Rule).
+		Str("date", run.Date.Format("2006-01-02")).
+		Int("accounts", run.Accounts).
+		Int("amount", run.Amount).
+		Msg("accrued day")
+}
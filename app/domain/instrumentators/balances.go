@@ -0,0 +1,47 @@
+package instrumentators
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// Sources of the balances read by the repositories: a snapshot summed to the entries created after
+// it, a recomputation from every entry of the account, or a running balance.
+const (
+	SnapshotBalanceSource   = "snapshot"
+	RecomputedBalanceSource = "recompute"
+	RunningBalanceSource    = "running_balance"
+)
+
+var (
+	balanceQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ledger",
+		Subsystem: "balances",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of the balance queries, by account type and source of the balance.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"account_type", "source"})
+
+	balanceScannedRows = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ledger",
+		Subsystem: "balances",
+		Name:      "scanned_rows",
+		Help:      "Number of rows read by the balance queries besides their snapshot, by account type.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"account_type"})
+)
+
+func (lp *LedgerInstrumentator) QueriedBalance(ctx context.Context, account vos.Account, source string, duration time.Duration, scannedRows int64) {
+	accountType := "analytic"
+	if account.Type() == vos.Synthetic {
+		accountType = "synthetic"
+	}
+
+	balanceQueryDuration.WithLabelValues(accountType, source).Observe(duration.Seconds())
+	balanceScannedRows.WithLabelValues(accountType).Observe(float64(scannedRows))
+}
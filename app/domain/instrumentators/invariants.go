@@ -0,0 +1,54 @@
+package instrumentators
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var (
+	invariantViolations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "invariants",
+		Name:      "violations_total",
+		Help:      "Number of ledger invariant violations found by the invariant checker.",
+	}, []string{"check"})
+
+	invariantWatermark = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "ledger",
+		Subsystem: "invariants",
+		Name:      "watermark_timestamp_seconds",
+		Help:      "Entries created up to this unix timestamp were already verified by the invariant checker.",
+	})
+)
+
+func init() {
+	// Exposes every check with a zero value, so alerts don't depend on a first violation.
+	for _, check := range vos.InvariantChecks {
+		invariantViolations.WithLabelValues(check.String())
+	}
+}
+
+func (lp *LedgerInstrumentator) CheckedInvariants(ctx context.Context, report vos.InvariantReport) {
+	for _, violation := range report.Violations {
+		invariantViolations.WithLabelValues(violation.Check.String()).Inc()
+
+		zerolog.Ctx(ctx).Error().
+			Str("check", violation.Check.String()).
+			Str("subject", violation.Subject).
+			Str("detail", violation.Detail).
+			Msg("ledger invariant violated")
+	}
+
+	invariantWatermark.Set(float64(report.To.Unix()))
+
+	zerolog.Ctx(ctx).Info().
+		Time("from", report.From).
+		Time("to", report.To).
+		Int("violations_total", len(report.Violations)).
+		Msg("checked ledger invariants")
+}
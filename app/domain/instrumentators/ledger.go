@@ -3,38 +3,39 @@ package instrumentators
 import (
 	"context"
 
-	"github.com/newrelic/go-agent/v3/newrelic"
-
 	"github.com/stone-co/the-amazing-ledger/app/domain"
 )
 
 var _ domain.Instrumentator = &LedgerInstrumentator{}
 
+// LedgerInstrumentator reports the ledger operations to logs and metrics, and sends their segments
+// to the tracer. The zero value doesn't trace.
 type LedgerInstrumentator struct {
-	newrelic *newrelic.Application
+	tracer domain.Instrumentator
 }
 
-func NewLedgerInstrumentator(nr *newrelic.Application) *LedgerInstrumentator {
+func NewLedgerInstrumentator(tracer domain.Instrumentator) *LedgerInstrumentator {
 	return &LedgerInstrumentator{
-		newrelic: nr,
+		tracer: tracer,
 	}
 }
 
-func (lp LedgerInstrumentator) MonitorSegment(ctx context.Context) domain.Segment {
-	txn := newrelic.FromContext(ctx)
-	seg := &newrelic.Segment{}
-	seg.StartTime = txn.StartSegmentNow()
-	return seg
+func (lp LedgerInstrumentator) MonitorSegment(ctx context.Context, name string) (context.Context, domain.Segment) {
+	if lp.tracer == nil {
+		return ctx, noopSegment{}
+	}
+
+	return lp.tracer.MonitorSegment(ctx, name)
 }
 
 func (lp LedgerInstrumentator) MonitorDataSegment(ctx context.Context, collection, operation, query string) domain.Segment {
-	txn := newrelic.FromContext(ctx)
-	seg := &newrelic.DatastoreSegment{
-		Product:            newrelic.DatastorePostgres,
-		Collection:         collection,
-		Operation:          operation,
-		ParameterizedQuery: query,
+	if lp.tracer == nil {
+		return noopSegment{}
 	}
-	seg.StartTime = txn.StartSegmentNow()
-	return seg
+
+	return lp.tracer.MonitorDataSegment(ctx, collection, operation, query)
 }
+
+type noopSegment struct{}
+
+func (noopSegment) End() {}
@@ -0,0 +1,24 @@
+package instrumentators
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func (lp *LedgerInstrumentator) ManagedPartitions(ctx context.Context, report vos.PartitionReport) {
+	zerolog.Ctx(ctx).Info().
+		Strs("created", report.Created).
+		Strs("indexed", report.Indexed).
+		Strs("detached", report.Detached).
+		Msg("managed entry partitions")
+}
+
+func (lp *LedgerInstrumentator) ArchivedPartition(ctx context.Context, partition vos.EntryPartition) {
+	zerolog.Ctx(ctx).Info().
+		Str("partition", partition.Name).
+		Str("archive", partition.Archive).
+		Msg("archived entry partition")
+}
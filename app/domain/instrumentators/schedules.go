@@ -0,0 +1,45 @@
+package instrumentators
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var (
+	scheduledTransactions = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "schedules",
+		Name:      "posted_transactions_total",
+		Help:      "Number of transactions posted by schedules.",
+	})
+	failedSchedules = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "schedules",
+		Name:      "failures_total",
+		Help:      "Number of times a schedule failed to post its transaction.",
+	})
+)
+
+func (lp *LedgerInstrumentator) RanSchedules(ctx context.Context, report vos.ScheduleReport) {
+	scheduledTransactions.Add(float64(report.Posted))
+
+	zerolog.Ctx(ctx).Info().
+		Int("posted", report.Posted).
+		Int("failed", report.Failed).
+		Msg("ran schedules")
+}
+
+func (lp *LedgerInstrumentator) FailedSchedule(ctx context.Context, schedule vos.Schedule, err error) {
+	failedSchedules.Inc()
+
+	zerolog.Ctx(ctx).Error().
+		Err(err).
+		Str("schedule", schedule.ID.String()).
+		Int("occurrence", schedule.Occurrence).
+		Msg("failed to run schedule")
+}
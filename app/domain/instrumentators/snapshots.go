@@ -0,0 +1,25 @@
+package instrumentators
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+var maintainedSnapshots = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "ledger",
+	Subsystem: "snapshots",
+	Name:      "maintained_total",
+	Help:      "Number of balance snapshots touched by maintenance operations.",
+}, []string{"operation"})
+
+func (lp *LedgerInstrumentator) MaintainedSnapshots(ctx context.Context, operation string, total int) {
+	maintainedSnapshots.WithLabelValues(operation).Add(float64(total))
+
+	zerolog.Ctx(ctx).Info().
+		Str("operation", operation).
+		Int("snapshots_total", total).
+		Msg("maintained balance snapshots")
+}
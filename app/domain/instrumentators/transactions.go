@@ -0,0 +1,91 @@
+package instrumentators
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var (
+	postedTransactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "transactions",
+		Name:      "posted_total",
+		Help:      "Number of transactions posted.",
+	}, []string{"event", "company"})
+
+	postedEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "transactions",
+		Name:      "entries_total",
+		Help:      "Number of entries of the posted transactions.",
+	}, []string{"event", "company"})
+
+	postedAmount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "transactions",
+		Name:      "amount_total",
+		Help:      "Sum of the amounts credited by the posted transactions, which is also the sum of their debits.",
+	}, []string{"event", "company"})
+
+	transactionEntries = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "ledger",
+		Subsystem: "transactions",
+		Name:      "entries",
+		Help:      "Number of entries per posted transaction.",
+		Buckets:   prometheus.ExponentialBuckets(2, 2, 8),
+	})
+
+	versionConflicts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "transactions",
+		Name:      "version_conflicts_total",
+		Help:      "Number of transactions rejected because an entry didn't match the account version.",
+	}, []string{"event", "company"})
+
+	idempotencyHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ledger",
+		Subsystem: "transactions",
+		Name:      "idempotency_hits_total",
+		Help:      "Number of transactions rejected because their entries were already posted.",
+	}, []string{"event", "company"})
+)
+
+func (lp *LedgerInstrumentator) PostedTransaction(ctx context.Context, transaction entities.Transaction) {
+	labels := transactionLabels(transaction)
+
+	amount := 0
+	for _, entry := range transaction.Entries {
+		if entry.Operation == vos.CreditOperation {
+			amount += entry.Amount
+		}
+	}
+
+	postedTransactions.With(labels).Inc()
+	postedEntries.With(labels).Add(float64(len(transaction.Entries)))
+	postedAmount.With(labels).Add(float64(amount))
+	transactionEntries.Observe(float64(len(transaction.Entries)))
+}
+
+func (lp *LedgerInstrumentator) RejectedTransaction(ctx context.Context, transaction entities.Transaction, err error) {
+	switch {
+	case errors.Is(err, app.ErrInvalidVersion):
+		versionConflicts.With(transactionLabels(transaction)).Inc()
+	case errors.Is(err, app.ErrIdempotencyKeyViolation):
+		idempotencyHits.With(transactionLabels(transaction)).Inc()
+	}
+}
+
+func transactionLabels(transaction entities.Transaction) prometheus.Labels {
+	return prometheus.Labels{
+		"event":   strconv.FormatUint(uint64(transaction.Event), 10),
+		"company": transaction.Company,
+	}
+}
@@ -0,0 +1,61 @@
+package instrumentators
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func TestLedgerInstrumentator_PostedTransaction(t *testing.T) {
+	company := "posted_" + uuid.New().String()
+	transaction := newTransaction(t, company)
+
+	lp := &LedgerInstrumentator{}
+	lp.PostedTransaction(context.Background(), transaction)
+	lp.PostedTransaction(context.Background(), transaction)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(postedTransactions.WithLabelValues("1", company)))
+	assert.Equal(t, 6.0, testutil.ToFloat64(postedEntries.WithLabelValues("1", company)))
+	assert.Equal(t, 400.0, testutil.ToFloat64(postedAmount.WithLabelValues("1", company)))
+}
+
+func TestLedgerInstrumentator_RejectedTransaction(t *testing.T) {
+	company := "rejected_" + uuid.New().String()
+	transaction := newTransaction(t, company)
+
+	lp := &LedgerInstrumentator{}
+	lp.RejectedTransaction(context.Background(), transaction, fmt.Errorf("failed to create transaction: %w", app.ErrInvalidVersion))
+	lp.RejectedTransaction(context.Background(), transaction, app.ErrIdempotencyKeyViolation)
+	lp.RejectedTransaction(context.Background(), transaction, app.ErrIdempotencyKeyViolation)
+	lp.RejectedTransaction(context.Background(), transaction, app.ErrInvalidBalance)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(versionConflicts.WithLabelValues("1", company)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(idempotencyHits.WithLabelValues("1", company)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(postedTransactions.WithLabelValues("1", company)))
+}
+
+func newTransaction(t *testing.T, company string) entities.Transaction {
+	t.Helper()
+
+	credit, err := entities.NewEntry(uuid.New(), vos.CreditOperation, "liability.clients.available.111", vos.NextAccountVersion, 150, nil)
+	require.NoError(t, err)
+	other, err := entities.NewEntry(uuid.New(), vos.CreditOperation, "liability.clients.available.222", vos.NextAccountVersion, 50, nil)
+	require.NoError(t, err)
+	debit, err := entities.NewEntry(uuid.New(), vos.DebitOperation, "asset.bank.account.333", vos.NextAccountVersion, 200, nil)
+	require.NoError(t, err)
+
+	transaction, err := entities.NewTransaction(uuid.New(), 1, company, time.Now(), credit, other, debit)
+	require.NoError(t, err)
+
+	return transaction
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 )
 
+// Instrumentator traces the ledger operations. MonitorSegment returns a context carrying the new
+// segment, so the segments started from it, like the data segments of the repositories, are
+// nested under it.
 type Instrumentator interface {
-	MonitorSegment(ctx context.Context) Segment
+	MonitorSegment(ctx context.Context, name string) (context.Context, Segment)
 	MonitorDataSegment(ctx context.Context, collection, operation, query string) Segment
 }
 
@@ -2,17 +2,59 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	"github.com/stone-co/the-amazing-ledger/app/pagination"
 )
 
 type Repository interface {
-	CreateTransaction(context.Context, entities.Transaction) error
+	CreateTransaction(context.Context, entities.Transaction) (vos.TransactionResult, error)
 	GetAnalyticAccountBalance(context.Context, vos.Account) (vos.AccountBalance, error)
 	GetSyntheticAccountBalance(context.Context, vos.Account) (vos.AccountBalance, error)
 	GetSyntheticReport(context.Context, vos.Account, int, time.Time, time.Time) (*vos.SyntheticReport, error)
-	ListAccountEntries(context.Context, vos.AccountEntryRequest) ([]vos.AccountEntry, pagination.Cursor, error)
+	ListAccountEntries(context.Context, vos.AccountEntryRequest) (vos.AccountEntryResponse, error)
+	ListAccounts(context.Context, vos.AccountListRequest) ([]vos.AccountSummary, pagination.Cursor, error)
+	ListAccountChildren(context.Context, vos.AccountChildrenRequest) ([]vos.AccountChild, pagination.Cursor, error)
+	GetEventSchema(context.Context, uint32) (vos.EventSchema, error)
+}
+
+type AdminRepository interface {
+	GetInvariantWindow(context.Context, time.Duration) (time.Time, time.Time, error)
+	LockInvariants(context.Context) (func(), bool, error)
+	CheckInvariant(context.Context, vos.InvariantCheck, time.Time, time.Time) ([]vos.InvariantViolation, error)
+	SaveInvariantReport(context.Context, vos.InvariantReport) error
+	ListInvariantViolations(context.Context, vos.InvariantViolationRequest) ([]vos.InvariantViolation, pagination.Cursor, error)
+	RebuildSnapshots(context.Context, vos.Account) (int, error)
+	PruneSnapshots(context.Context, time.Time) (int, error)
+	PrecomputeSnapshots(context.Context, int) (int, error)
+	CreateEntryPartition(context.Context, time.Time) (string, error)
+	ListEntryPartitions(context.Context) ([]vos.EntryPartition, error)
+	DetachEntryPartition(context.Context, string) error
+	IndexEntryPartitions(context.Context) ([]string, error)
+	ExportEntryPartition(context.Context, string, io.Writer) error
+	DropEntryPartition(context.Context, string, string) error
+	CreateBook(context.Context, string) (vos.Book, error)
+	ListBooks(context.Context) ([]vos.Book, error)
+	CreateEventSchema(context.Context, uint32, json.RawMessage) (vos.EventSchema, error)
+	ListEventSchemas(context.Context, uint32) ([]vos.EventSchema, error)
+	ListEventMetadata(context.Context, vos.EventMetadataRequest) ([]vos.EntryMetadata, pagination.Cursor, error)
+	CreateSchedule(context.Context, vos.Schedule) (vos.Schedule, error)
+	GetSchedule(context.Context, uuid.UUID) (vos.Schedule, error)
+	ListSchedules(context.Context, vos.ScheduleListRequest) ([]vos.Schedule, pagination.Cursor, error)
+	ListDueSchedules(context.Context, time.Time, int) ([]vos.Schedule, error)
+	UpdateSchedule(context.Context, vos.Schedule) (vos.Schedule, error)
+	LockSchedules(context.Context) (func(), bool, error)
+	ListEndOfDayBalances(context.Context, vos.Account, time.Time) ([]vos.AccountBalance, error)
+	CreateAccrualRun(context.Context, vos.AccrualRun) (vos.AccrualRun, error)
+	ListAccrualRuns(context.Context, vos.AccrualRunRequest) ([]vos.AccrualRun, error)
+	GetLastAccrualDay(context.Context, string) (time.Time, error)
+	CreateAccrualBackRun(context.Context, vos.AccrualBackRun) (vos.AccrualBackRun, error)
+	ListAccrualBackRuns(context.Context) ([]vos.AccrualBackRun, error)
+	DeleteAccrualBackRun(context.Context, vos.AccrualBackRun) error
 }
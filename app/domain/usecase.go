@@ -2,15 +2,45 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 )
 
 type UseCase interface {
-	CreateTransaction(context.Context, entities.Transaction) error
+	CreateTransaction(context.Context, entities.Transaction) (vos.TransactionResult, error)
 	GetAccountBalance(context.Context, vos.Account) (vos.AccountBalance, error)
 	GetSyntheticReport(context.Context, vos.Account, int, time.Time, time.Time) (*vos.SyntheticReport, error)
 	ListAccountEntries(context.Context, vos.AccountEntryRequest) (vos.AccountEntryResponse, error)
+	ListAccounts(context.Context, vos.AccountListRequest) (vos.AccountListResponse, error)
+	ListAccountChildren(context.Context, vos.AccountChildrenRequest) (vos.AccountChildrenResponse, error)
+	ExpandEvent(context.Context, vos.EventPosting) (entities.Transaction, error)
+}
+
+type AdminUseCase interface {
+	CheckInvariants(context.Context) (vos.InvariantReport, error)
+	ListInvariantViolations(context.Context, vos.InvariantViolationRequest) (vos.InvariantViolationResponse, error)
+	RebuildSnapshots(context.Context, vos.Account) (int, error)
+	PruneSnapshots(context.Context, time.Duration) (int, error)
+	PrecomputeSnapshots(context.Context, int) (int, error)
+	ManagePartitions(context.Context, int, int) (vos.PartitionReport, error)
+	ArchivePartitions(context.Context, string) ([]vos.EntryPartition, error)
+	CreateBook(context.Context, string) (vos.Book, error)
+	ListBooks(context.Context) ([]vos.Book, error)
+	CreateEventSchema(context.Context, uint32, json.RawMessage) (vos.EventSchema, error)
+	ListEventSchemas(context.Context, uint32) ([]vos.EventSchema, error)
+	ValidateEventMetadata(context.Context, vos.MetadataValidationRequest) (vos.MetadataValidationReport, error)
+	CreateSchedule(context.Context, vos.Schedule) (vos.Schedule, error)
+	ListSchedules(context.Context, vos.ScheduleListRequest) (vos.ScheduleListResponse, error)
+	PauseSchedule(context.Context, uuid.UUID) (vos.Schedule, error)
+	ResumeSchedule(context.Context, uuid.UUID) (vos.Schedule, error)
+	CancelSchedule(context.Context, uuid.UUID) (vos.Schedule, error)
+	RunSchedules(context.Context, int) (vos.ScheduleReport, error)
+	RunAccruals(context.Context, vos.AccrualRunRequest) ([]vos.AccrualBackRun, error)
+	RunDueAccruals(context.Context) ([]vos.AccrualRun, error)
+	ListAccrualRuns(context.Context, vos.AccrualRunRequest) ([]vos.AccrualRun, error)
 }
@@ -0,0 +1,302 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// maxAccrualDays is the longest range of days a single RunAccruals request may back-run.
+const maxAccrualDays = 366
+
+// maxAccrualDaysPerRun is the most days RunDueAccruals accrues for each rule, and for each back-run,
+// so a run of the accrual job stays short. The days left are accrued by the next runs.
+const maxAccrualDaysPerRun = 31
+
+// accrualNamespace is the namespace of the ids derived for the accrual transactions.
+var accrualNamespace = uuid.MustParse("f03779be-8d4c-477f-a33a-d662c7e2917b")
+
+// RunAccruals requests a back-run of the rule of the request, or of every rule when it has none, on
+// each day between its dates, which must have ended. Back-runs are accrued by RunDueAccruals, which
+// skips the days a rule already accrued, so missed days can be back-run with ranges that overlap the
+// accrued ones. It returns the back-runs requested.
+func (a *AdminUseCase) RunAccruals(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualBackRun, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.RunAccruals")
+	defer segment.End()
+
+	start, end := vos.AccrualDay(req.StartDate), vos.AccrualDay(req.EndDate)
+
+	if req.StartDate.IsZero() || req.EndDate.IsZero() || start.After(end) {
+		return nil, fmt.Errorf("%w: start date must not be after end date", app.ErrInvalidAccrualDates)
+	}
+
+	if !end.Before(vos.AccrualDay(time.Now())) {
+		return nil, fmt.Errorf("%w: only days that have ended can be accrued", app.ErrInvalidAccrualDates)
+	}
+
+	if end.Sub(start) >= maxAccrualDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: at most %d days can be accrued at once", app.ErrInvalidAccrualDates, maxAccrualDays)
+	}
+
+	rules, err := a.accrualRules().Rules(req.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	backRuns := make([]vos.AccrualBackRun, 0, len(rules))
+
+	for _, rule := range rules {
+		backRun, err := a.repository.CreateAccrualBackRun(ctx, vos.AccrualBackRun{Rule: rule.Name, StartDate: start, EndDate: end})
+		if err != nil {
+			return backRuns, fmt.Errorf("failed to request back-run of %s: %w", rule.Name, err)
+		}
+
+		backRuns = append(backRuns, backRun)
+	}
+
+	return backRuns, nil
+}
+
+// RunDueAccruals accrues every rule on the days since the last day it accrued up to yesterday, so the
+// days missed while no server ran are caught up. Rules that never accrued start at yesterday, and
+// earlier days are back-run with RunAccruals. A rule stops at the first day it fails to accrue, which
+// is retried on the next run, and the other rules still run. Then it accrues the back-runs, which are
+// deleted once done, or when their rule no longer exists. Rules and back-runs accrue at most
+// maxAccrualDaysPerRun days per run.
+func (a *AdminUseCase) RunDueAccruals(ctx context.Context) ([]vos.AccrualRun, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.RunDueAccruals")
+	defer segment.End()
+
+	rules, _ := a.accrualRules().Rules("")
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	if a.ledger == nil {
+		return nil, errors.New("accruals need a ledger to post their transactions")
+	}
+
+	yesterday := vos.AccrualDay(time.Now()).AddDate(0, 0, -1)
+
+	var (
+		runs   = make([]vos.AccrualRun, 0)
+		failed error
+	)
+
+	for _, rule := range rules {
+		last, err := a.repository.GetLastAccrualDay(ctx, rule.Name)
+		if err != nil {
+			return runs, fmt.Errorf("failed to get the last accrual day of %s: %w", rule.Name, err)
+		}
+
+		start := yesterday
+		if !last.IsZero() {
+			start = last.AddDate(0, 0, 1)
+		}
+
+		ruleRuns, _, err := a.accrueDays(ctx, rule, start, yesterday)
+		runs = append(runs, ruleRuns...)
+
+		if err != nil && failed == nil {
+			failed = err
+		}
+	}
+
+	backRuns, err := a.repository.ListAccrualBackRuns(ctx)
+	if err != nil {
+		return runs, fmt.Errorf("failed to list accrual back-runs: %w", err)
+	}
+
+	for _, backRun := range backRuns {
+		backRunRuns, err := a.accrueBackRun(ctx, backRun)
+		runs = append(runs, backRunRuns...)
+
+		if err != nil && failed == nil {
+			failed = err
+		}
+	}
+
+	return runs, failed
+}
+
+// accrueBackRun accrues the next days of the back-run, deleting it once every day is accrued.
+func (a *AdminUseCase) accrueBackRun(ctx context.Context, backRun vos.AccrualBackRun) ([]vos.AccrualRun, error) {
+	rules, err := a.accrualRules().Rules(backRun.Rule)
+	if errors.Is(err, app.ErrAccrualRuleNotFound) {
+		if err = a.repository.DeleteAccrualBackRun(ctx, backRun); err != nil {
+			return nil, fmt.Errorf("failed to delete back-run of %s: %w", backRun.Rule, err)
+		}
+
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	runs, done, err := a.accrueDays(ctx, rules[0], vos.AccrualDay(backRun.StartDate), vos.AccrualDay(backRun.EndDate))
+	if err != nil || !done {
+		return runs, err
+	}
+
+	if err = a.repository.DeleteAccrualBackRun(ctx, backRun); err != nil {
+		return runs, fmt.Errorf("failed to delete back-run of %s: %w", backRun.Rule, err)
+	}
+
+	return runs, nil
+}
+
+func (a *AdminUseCase) ListAccrualRuns(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualRun, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ListAccrualRuns")
+	defer segment.End()
+
+	req.StartDate, req.EndDate = vos.AccrualDay(req.StartDate), vos.AccrualDay(req.EndDate)
+	if req.StartDate.After(req.EndDate) {
+		return nil, fmt.Errorf("%w: start date must not be after end date", app.ErrInvalidAccrualDates)
+	}
+
+	runs, err := a.repository.ListAccrualRuns(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accrual runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func (a *AdminUseCase) accrualRules() *vos.AccrualRules {
+	return a.accruals.Load().(*vos.AccrualRules)
+}
+
+// accrueDays accrues the rule on the days between start and end it didn't accrue yet, in order,
+// stopping at the first one that fails or after maxAccrualDaysPerRun days. It reports whether every
+// day is accrued.
+func (a *AdminUseCase) accrueDays(ctx context.Context, rule vos.AccrualRule, start, end time.Time) ([]vos.AccrualRun, bool, error) {
+	runs := make([]vos.AccrualRun, 0)
+	if start.After(end) {
+		return runs, true, nil
+	}
+
+	accrued, err := a.repository.ListAccrualRuns(ctx, vos.AccrualRunRequest{Rule: rule.Name, StartDate: start, EndDate: end})
+	if err != nil {
+		return runs, false, fmt.Errorf("failed to list accrual runs of %s: %w", rule.Name, err)
+	}
+
+	done := make(map[time.Time]struct{}, len(accrued))
+	for _, run := range accrued {
+		done[vos.AccrualDay(run.Date)] = struct{}{}
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if _, ok := done[day]; ok {
+			continue
+		}
+
+		if len(runs) == maxAccrualDaysPerRun {
+			return runs, false, nil
+		}
+
+		run, err := a.accrueDay(ctx, rule, day)
+		if err != nil {
+			return runs, false, fmt.Errorf("failed to accrue %s on %s: %w", rule.Name, day.Format("2006-01-02"), err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, true, nil
+}
+
+// accrueDay posts one transaction per account matched by the rule, accruing its balance at the end of
+// the day against the counter-account. The transactions are dated at the start of the next day, so
+// the accruals of a day are part of the balances of the following ones.
+//
+// Transaction ids derive from the rule, day and account, so a day that failed halfway is accrued again
+// without posting twice: the accounts already posted hit the idempotency keys, and their balances at
+// the end of the day don't include their accruals. The run is saved once every account is posted.
+func (a *AdminUseCase) accrueDay(ctx context.Context, rule vos.AccrualRule, day time.Time) (vos.AccrualRun, error) {
+	ctx = vos.WithBook(ctx, rule.Book)
+	next := day.AddDate(0, 0, 1)
+
+	balances, err := a.repository.ListEndOfDayBalances(ctx, rule.Accounts, next)
+	if err != nil {
+		return vos.AccrualRun{}, fmt.Errorf("failed to list end-of-day balances: %w", err)
+	}
+
+	run := vos.AccrualRun{Rule: rule.Name, Book: rule.Book, Date: day}
+	runID := uuid.NewSHA1(accrualNamespace, []byte(rule.Name+"/"+day.Format("2006-01-02")))
+
+	for _, balance := range balances {
+		if balance.Account.Value() == rule.CounterAccount.Value() {
+			continue
+		}
+
+		amount := rule.Accrue(balance.Balance)
+		if amount == 0 {
+			continue
+		}
+
+		tx, err := accrualTransaction(rule, uuid.NewSHA1(runID, []byte(balance.Account.Value())), next, balance, amount)
+		if err != nil {
+			return vos.AccrualRun{}, err
+		}
+
+		if _, err = a.ledger.CreateTransaction(ctx, tx); err != nil && !errors.Is(err, app.ErrIdempotencyKeyViolation) {
+			return vos.AccrualRun{}, fmt.Errorf("failed to post the accrual of %s: %w", balance.Account.Value(), err)
+		}
+
+		run.Accounts++
+		run.Amount += amount
+	}
+
+	created, err := a.repository.CreateAccrualRun(ctx, run)
+	if err != nil {
+		// Another server accrued the same day in the meantime, posting the same transactions.
+		if errors.Is(err, app.ErrIdempotencyKeyViolation) {
+			return run, nil
+		}
+
+		return vos.AccrualRun{}, fmt.Errorf("failed to save accrual run: %w", err)
+	}
+
+	a.instrumentator.AccruedDay(ctx, created)
+
+	return created, nil
+}
+
+// accrualTransaction credits positive accruals to the account and debits negative ones, against the
+// counter-account. Its entries carry the rule, day and balance they were computed from.
+func accrualTransaction(rule vos.AccrualRule, id uuid.UUID, competenceDate time.Time, balance vos.AccountBalance, amount int) (entities.Transaction, error) {
+	operation, counterOperation := vos.CreditOperation, vos.DebitOperation
+	if amount < 0 {
+		operation, counterOperation, amount = vos.DebitOperation, vos.CreditOperation, -amount
+	}
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"accrual_rule": rule.Name,
+		"accrual_date": competenceDate.AddDate(0, 0, -1).Format("2006-01-02"),
+		"balance":      balance.Balance,
+	})
+	if err != nil {
+		return entities.Transaction{}, fmt.Errorf("failed to marshal accrual metadata: %w", err)
+	}
+
+	entry, err := entities.NewEntry(uuid.NewSHA1(id, []byte("0")), operation, balance.Account.Value(), vos.NextAccountVersion, amount, metadata)
+	if err != nil {
+		return entities.Transaction{}, err
+	}
+
+	counterEntry, err := entities.NewEntry(uuid.NewSHA1(id, []byte("1")), counterOperation, rule.CounterAccount.Value(), vos.NextAccountVersion, amount, metadata)
+	if err != nil {
+		return entities.Transaction{}, err
+	}
+
+	return entities.NewTransaction(id, rule.Event, rule.Company, competenceDate, entry, counterEntry)
+}
@@ -0,0 +1,374 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func newTestAccrualRules(t *testing.T) *vos.AccrualRules {
+	rules, err := vos.NewAccrualRules(vos.AccrualRulesDefinition{Rules: []vos.AccrualRuleDefinition{
+		{
+			Name:           "savings_interest",
+			Accounts:       "liability.clients.*",
+			Rate:           "0.01",
+			Basis:          1,
+			Event:          9,
+			Company:        "ledger",
+			CounterAccount: "liability.clients.interest",
+		},
+	}})
+	require.NoError(t, err)
+
+	return rules
+}
+
+func newTestBalance(t *testing.T, account string, balance int) vos.AccountBalance {
+	acc, err := vos.NewAnalyticAccount(account)
+	require.NoError(t, err)
+
+	return vos.AccountBalance{Account: acc, CurrentVersion: vos.IgnoreAccountVersion, Balance: balance}
+}
+
+func TestAdminUseCase_RunAccruals(t *testing.T) {
+	yesterday := vos.AccrualDay(time.Now()).AddDate(0, 0, -1)
+
+	newRepository := func() *mocks.AdminRepositoryMock {
+		return &mocks.AdminRepositoryMock{
+			CreateAccrualBackRunFunc: func(ctx context.Context, backRun vos.AccrualBackRun) (vos.AccrualBackRun, error) {
+				backRun.CreatedAt = time.Now()
+				return backRun, nil
+			},
+		}
+	}
+
+	newUseCase := func(repository *mocks.AdminRepositoryMock) *AdminUseCase {
+		usecase := NewAdminUseCase(repository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetAccrualRules(newTestAccrualRules(t))
+
+		return usecase
+	}
+
+	t.Run("should request a back-run of the days without accruing them", func(t *testing.T) {
+		repository := newRepository()
+		usecase := newUseCase(repository)
+
+		start := yesterday.AddDate(0, 0, -maxAccrualDays+1)
+
+		got, err := usecase.RunAccruals(context.Background(), vos.AccrualRunRequest{StartDate: start.Add(time.Hour), EndDate: yesterday.Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "savings_interest", got[0].Rule)
+		assert.Equal(t, start, got[0].StartDate)
+		assert.Equal(t, yesterday, got[0].EndDate)
+		assert.False(t, got[0].CreatedAt.IsZero())
+
+		assert.Len(t, repository.CreateAccrualBackRunCalls(), 1)
+		assert.Empty(t, repository.ListEndOfDayBalancesCalls())
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		backRunErr := errors.New("connection refused")
+
+		repository := &mocks.AdminRepositoryMock{
+			CreateAccrualBackRunFunc: func(ctx context.Context, backRun vos.AccrualBackRun) (vos.AccrualBackRun, error) {
+				return vos.AccrualBackRun{}, backRunErr
+			},
+		}
+
+		_, err := newUseCase(repository).RunAccruals(context.Background(), vos.AccrualRunRequest{StartDate: yesterday, EndDate: yesterday})
+		assert.ErrorIs(t, err, backRunErr)
+	})
+
+	testCases := []struct {
+		name string
+		req  vos.AccrualRunRequest
+		err  error
+	}{
+		{name: "missing dates", req: vos.AccrualRunRequest{}, err: app.ErrInvalidAccrualDates},
+		{name: "start after end", req: vos.AccrualRunRequest{StartDate: yesterday, EndDate: yesterday.AddDate(0, 0, -1)}, err: app.ErrInvalidAccrualDates},
+		{name: "day not ended", req: vos.AccrualRunRequest{StartDate: yesterday, EndDate: time.Now()}, err: app.ErrInvalidAccrualDates},
+		{name: "too many days", req: vos.AccrualRunRequest{StartDate: yesterday.AddDate(0, 0, -maxAccrualDays), EndDate: yesterday}, err: app.ErrInvalidAccrualDates},
+		{name: "unknown rule", req: vos.AccrualRunRequest{Rule: "unknown", StartDate: yesterday, EndDate: yesterday}, err: app.ErrAccrualRuleNotFound},
+	}
+
+	for _, tt := range testCases {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			repository := newRepository()
+
+			_, err := newUseCase(repository).RunAccruals(context.Background(), tt.req)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Empty(t, repository.CreateAccrualBackRunCalls())
+		})
+	}
+}
+
+func TestAdminUseCase_AccrueBackRuns(t *testing.T) {
+	yesterday := vos.AccrualDay(time.Now()).AddDate(0, 0, -1)
+
+	// The rules are up to date, so only the back-runs accrue.
+	newRepository := func(backRuns []vos.AccrualBackRun, accrued ...vos.AccrualRun) *mocks.AdminRepositoryMock {
+		return &mocks.AdminRepositoryMock{
+			GetLastAccrualDayFunc: func(ctx context.Context, rule string) (time.Time, error) {
+				return yesterday, nil
+			},
+			ListAccrualBackRunsFunc: func(ctx context.Context) ([]vos.AccrualBackRun, error) {
+				return backRuns, nil
+			},
+			DeleteAccrualBackRunFunc: func(ctx context.Context, backRun vos.AccrualBackRun) error {
+				return nil
+			},
+			ListAccrualRunsFunc: func(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualRun, error) {
+				return accrued, nil
+			},
+			ListEndOfDayBalancesFunc: func(ctx context.Context, account vos.Account, end time.Time) ([]vos.AccountBalance, error) {
+				return []vos.AccountBalance{
+					newTestBalance(t, "liability.clients.abc", 1000),
+					newTestBalance(t, "liability.clients.def", -250),
+					newTestBalance(t, "liability.clients.ghi", 10),
+					newTestBalance(t, "liability.clients.interest", -5000),
+				}, nil
+			},
+			CreateAccrualRunFunc: func(ctx context.Context, run vos.AccrualRun) (vos.AccrualRun, error) {
+				run.CreatedAt = time.Now()
+				return run, nil
+			},
+		}
+	}
+
+	newLedger := func(err error) *mocks.UseCaseMock {
+		return &mocks.UseCaseMock{
+			CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+				return vos.TransactionResult{}, err
+			},
+		}
+	}
+
+	newUseCase := func(repository *mocks.AdminRepositoryMock, ledger *mocks.UseCaseMock) *AdminUseCase {
+		usecase := NewAdminUseCase(repository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(ledger)
+		usecase.SetAccrualRules(newTestAccrualRules(t))
+
+		return usecase
+	}
+
+	backRun := func(start, end time.Time) []vos.AccrualBackRun {
+		return []vos.AccrualBackRun{{Rule: "savings_interest", StartDate: start, EndDate: end}}
+	}
+
+	t.Run("should post the accruals of the end-of-day balances", func(t *testing.T) {
+		repository := newRepository(backRun(yesterday, yesterday))
+		ledger := newLedger(nil)
+		usecase := newUseCase(repository, ledger)
+
+		got, err := usecase.RunDueAccruals(context.Background())
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "savings_interest", got[0].Rule)
+		assert.Equal(t, yesterday, got[0].Date)
+		assert.Equal(t, 2, got[0].Accounts)
+		assert.Equal(t, 10-3, got[0].Amount)
+
+		balances := repository.ListEndOfDayBalancesCalls()
+		require.Len(t, balances, 1)
+		assert.Equal(t, "liability.clients.*", balances[0].Account.Value())
+		assert.Equal(t, yesterday.AddDate(0, 0, 1), balances[0].TimeMoqParam)
+		assert.Equal(t, vos.DefaultBook, vos.BookFromContext(balances[0].ContextMoqParam))
+
+		transactions := ledger.CreateTransactionCalls()
+		require.Len(t, transactions, 2)
+
+		credit := transactions[0].Transaction
+		assert.Equal(t, uint32(9), credit.Event)
+		assert.Equal(t, "ledger", credit.Company)
+		assert.Equal(t, yesterday.AddDate(0, 0, 1), credit.CompetenceDate)
+		require.Len(t, credit.Entries, 2)
+		assert.Equal(t, "liability.clients.abc", credit.Entries[0].Account.Value())
+		assert.Equal(t, vos.CreditOperation, credit.Entries[0].Operation)
+		assert.Equal(t, 10, credit.Entries[0].Amount)
+		assert.Equal(t, vos.DebitOperation, credit.Entries[1].Operation)
+		assert.JSONEq(t, `{"accrual_rule": "savings_interest", "accrual_date": "`+yesterday.Format("2006-01-02")+`", "balance": 1000}`, string(credit.Entries[0].Metadata))
+
+		debit := transactions[1].Transaction
+		assert.Equal(t, "liability.clients.def", debit.Entries[0].Account.Value())
+		assert.Equal(t, vos.DebitOperation, debit.Entries[0].Operation)
+		assert.Equal(t, 3, debit.Entries[0].Amount)
+		assert.NotEqual(t, credit.ID, debit.ID)
+
+		require.Len(t, repository.CreateAccrualRunCalls(), 1)
+		require.Len(t, repository.DeleteAccrualBackRunCalls(), 1)
+	})
+
+	t.Run("should post the same transaction ids for the same rule, day and account", func(t *testing.T) {
+		ledger := newLedger(nil)
+		usecase := newUseCase(newRepository(backRun(yesterday, yesterday)), ledger)
+
+		_, err := usecase.RunDueAccruals(context.Background())
+		require.NoError(t, err)
+		_, err = usecase.RunDueAccruals(context.Background())
+		require.NoError(t, err)
+
+		transactions := ledger.CreateTransactionCalls()
+		require.Len(t, transactions, 4)
+		assert.Equal(t, transactions[0].Transaction.ID, transactions[2].Transaction.ID)
+		assert.Equal(t, transactions[0].Transaction.Entries[1].ID, transactions[2].Transaction.Entries[1].ID)
+	})
+
+	t.Run("should skip the days already accrued", func(t *testing.T) {
+		start := yesterday.AddDate(0, 0, -2)
+		repository := newRepository(backRun(start, yesterday), vos.AccrualRun{Rule: "savings_interest", Date: start.AddDate(0, 0, 1)})
+		usecase := newUseCase(repository, newLedger(nil))
+
+		got, err := usecase.RunDueAccruals(context.Background())
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, start, got[0].Date)
+		assert.Equal(t, yesterday, got[1].Date)
+	})
+
+	t.Run("should accrue a limited number of days per run and keep the back-run", func(t *testing.T) {
+		start := yesterday.AddDate(0, 0, -maxAccrualDaysPerRun)
+		repository := newRepository(backRun(start, yesterday))
+		usecase := newUseCase(repository, newLedger(nil))
+
+		got, err := usecase.RunDueAccruals(context.Background())
+		require.NoError(t, err)
+		require.Len(t, got, maxAccrualDaysPerRun)
+		assert.Equal(t, start, got[0].Date)
+		assert.Empty(t, repository.DeleteAccrualBackRunCalls())
+	})
+
+	t.Run("should count the accruals already posted", func(t *testing.T) {
+		repository := newRepository(backRun(yesterday, yesterday))
+		usecase := newUseCase(repository, newLedger(app.ErrIdempotencyKeyViolation))
+
+		got, err := usecase.RunDueAccruals(context.Background())
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, 2, got[0].Accounts)
+	})
+
+	t.Run("should not save the run of a day that fails", func(t *testing.T) {
+		repository := newRepository(backRun(yesterday.AddDate(0, 0, -1), yesterday))
+		usecase := newUseCase(repository, newLedger(app.ErrEventNotFound))
+
+		got, err := usecase.RunDueAccruals(context.Background())
+		assert.ErrorIs(t, err, app.ErrEventNotFound)
+		assert.Empty(t, got)
+		assert.Empty(t, repository.CreateAccrualRunCalls())
+		assert.Empty(t, repository.DeleteAccrualBackRunCalls())
+		assert.Len(t, repository.ListEndOfDayBalancesCalls(), 1)
+	})
+
+	t.Run("should delete the back-runs of unknown rules", func(t *testing.T) {
+		repository := newRepository([]vos.AccrualBackRun{{Rule: "unknown", StartDate: yesterday, EndDate: yesterday}})
+		usecase := newUseCase(repository, newLedger(nil))
+
+		got, err := usecase.RunDueAccruals(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		assert.Empty(t, repository.ListEndOfDayBalancesCalls())
+		require.Len(t, repository.DeleteAccrualBackRunCalls(), 1)
+		assert.Equal(t, "unknown", repository.DeleteAccrualBackRunCalls()[0].AccrualBackRun.Rule)
+	})
+}
+
+func TestAdminUseCase_RunDueAccruals(t *testing.T) {
+	yesterday := vos.AccrualDay(time.Now()).AddDate(0, 0, -1)
+
+	newRepository := func(last time.Time) *mocks.AdminRepositoryMock {
+		return &mocks.AdminRepositoryMock{
+			GetLastAccrualDayFunc: func(ctx context.Context, rule string) (time.Time, error) {
+				return last, nil
+			},
+			ListAccrualRunsFunc: func(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualRun, error) {
+				return nil, nil
+			},
+			ListEndOfDayBalancesFunc: func(ctx context.Context, account vos.Account, end time.Time) ([]vos.AccountBalance, error) {
+				return nil, nil
+			},
+			CreateAccrualRunFunc: func(ctx context.Context, run vos.AccrualRun) (vos.AccrualRun, error) {
+				return run, nil
+			},
+			ListAccrualBackRunsFunc: func(ctx context.Context) ([]vos.AccrualBackRun, error) {
+				return nil, nil
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		last     time.Time
+		expected []time.Time
+	}{
+		{name: "should accrue yesterday for rules that never accrued", expected: []time.Time{yesterday}},
+		{name: "should catch up the days since the last one", last: yesterday.AddDate(0, 0, -3), expected: []time.Time{yesterday.AddDate(0, 0, -2), yesterday.AddDate(0, 0, -1), yesterday}},
+		{name: "should not accrue rules up to date", last: yesterday},
+		{name: "should catch up a limited number of days per run", last: yesterday.AddDate(0, 0, -maxAccrualDaysPerRun-5), expected: accrualDays(yesterday.AddDate(0, 0, -maxAccrualDaysPerRun-4), maxAccrualDaysPerRun)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewAdminUseCase(newRepository(tt.last), &instrumentators.LedgerInstrumentator{})
+			usecase.SetLedger(&mocks.UseCaseMock{})
+			usecase.SetAccrualRules(newTestAccrualRules(t))
+
+			got, err := usecase.RunDueAccruals(context.Background())
+			require.NoError(t, err)
+
+			dates := make([]time.Time, 0, len(got))
+			for _, run := range got {
+				dates = append(dates, run.Date)
+			}
+
+			assert.Equal(t, len(tt.expected), len(dates))
+			if len(tt.expected) > 0 {
+				assert.Equal(t, tt.expected, dates)
+			}
+		})
+	}
+
+	t.Run("should do nothing without rules", func(t *testing.T) {
+		repository := &mocks.AdminRepositoryMock{}
+		usecase := NewAdminUseCase(repository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.RunDueAccruals(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		lastErr := errors.New("connection refused")
+
+		repository := &mocks.AdminRepositoryMock{
+			GetLastAccrualDayFunc: func(ctx context.Context, rule string) (time.Time, error) {
+				return time.Time{}, lastErr
+			},
+		}
+		usecase := NewAdminUseCase(repository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(&mocks.UseCaseMock{})
+		usecase.SetAccrualRules(newTestAccrualRules(t))
+
+		_, err := usecase.RunDueAccruals(context.Background())
+		assert.ErrorIs(t, err, lastErr)
+	})
+}
+
+func accrualDays(start time.Time, days int) []time.Time {
+	dates := make([]time.Time, 0, days)
+	for i := 0; i < days; i++ {
+		dates = append(dates, start.AddDate(0, 0, i))
+	}
+
+	return dates
+}
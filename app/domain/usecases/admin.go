@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"sync/atomic"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var _ domain.AdminUseCase = &AdminUseCase{}
+
+type AdminUseCase struct {
+	instrumentator *instrumentators.LedgerInstrumentator
+	repository     domain.AdminRepository
+
+	// ledger posts the transactions of the schedules and accruals.
+	ledger domain.UseCase
+
+	// accruals holds the *vos.AccrualRules run by RunAccruals, which are replaced on reloads.
+	accruals atomic.Value
+}
+
+func NewAdminUseCase(repository domain.AdminRepository, instrumentator *instrumentators.LedgerInstrumentator) *AdminUseCase {
+	a := &AdminUseCase{
+		repository:     repository,
+		instrumentator: instrumentator,
+	}
+	a.accruals.Store(&vos.AccrualRules{})
+
+	return a
+}
+
+// SetLedger sets the use case that posts the transactions of the schedules and accruals, which
+// RunSchedules and RunAccruals require.
+func (a *AdminUseCase) SetLedger(ledger domain.UseCase) {
+	a.ledger = ledger
+}
+
+// SetAccrualRules replaces the rules run by RunAccruals.
+func (a *AdminUseCase) SetAccrualRules(rules *vos.AccrualRules) {
+	a.accruals.Store(rules)
+}
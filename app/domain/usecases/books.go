@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func (a *AdminUseCase) CreateBook(ctx context.Context, name string) (vos.Book, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.CreateBook")
+	defer segment.End()
+
+	name, err := vos.NewBookName(name)
+	if err != nil {
+		return vos.Book{}, err
+	}
+
+	book, err := a.repository.CreateBook(ctx, name)
+	if err != nil {
+		return vos.Book{}, fmt.Errorf("failed to create book %s: %w", name, err)
+	}
+
+	return book, nil
+}
+
+func (a *AdminUseCase) ListBooks(ctx context.Context) ([]vos.Book, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ListBooks")
+	defer segment.End()
+
+	books, err := a.repository.ListBooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list books: %w", err)
+	}
+
+	return books, nil
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestAdminUseCase_CreateBook(t *testing.T) {
+	t.Run("should create the book", func(t *testing.T) {
+		book := vos.Book{Name: "banking", CreatedAt: time.Now()}
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateBookFunc: func(ctx context.Context, name string) (vos.Book, error) {
+				return book, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.CreateBook(context.Background(), "banking")
+		assert.NoError(t, err)
+		assert.Equal(t, book, got)
+
+		calls := mockedRepository.CreateBookCalls()
+		assert.Len(t, calls, 1)
+		assert.Equal(t, "banking", calls[0].S)
+	})
+
+	t.Run("should not create a book with an invalid name", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CreateBook(context.Background(), "Banking")
+		assert.ErrorIs(t, err, app.ErrInvalidBook)
+		assert.Empty(t, mockedRepository.CreateBookCalls())
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateBookFunc: func(ctx context.Context, name string) (vos.Book, error) {
+				return vos.Book{}, app.ErrBookAlreadyExists
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CreateBook(context.Background(), vos.DefaultBook)
+		assert.ErrorIs(t, err, app.ErrBookAlreadyExists)
+	})
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// invariantSafetyWindow keeps the checker away from the most recent entries, whose
+// transactions may still be in flight (created_at is set when the transaction starts). It is
+// applied to the database clock, which sets created_at.
+const invariantSafetyWindow = time.Minute
+
+func (a *AdminUseCase) CheckInvariants(ctx context.Context) (vos.InvariantReport, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.CheckInvariants")
+	defer segment.End()
+
+	unlock, locked, err := a.repository.LockInvariants(ctx)
+	if err != nil {
+		return vos.InvariantReport{}, fmt.Errorf("failed to lock invariants: %w", err)
+	}
+
+	if !locked {
+		return vos.InvariantReport{}, app.ErrInvariantCheckInProgress
+	}
+
+	defer unlock()
+
+	from, to, err := a.repository.GetInvariantWindow(ctx, invariantSafetyWindow)
+	if err != nil {
+		return vos.InvariantReport{}, fmt.Errorf("failed to get invariant window: %w", err)
+	}
+
+	report := vos.InvariantReport{
+		From:       from,
+		To:         to,
+		Violations: make([]vos.InvariantViolation, 0),
+	}
+
+	if !report.To.After(report.From) {
+		return report, nil
+	}
+
+	for _, check := range vos.InvariantChecks {
+		violations, err := a.repository.CheckInvariant(ctx, check, report.From, report.To)
+		if err != nil {
+			return vos.InvariantReport{}, fmt.Errorf("failed to check %s invariant: %w", check, err)
+		}
+
+		report.Violations = append(report.Violations, violations...)
+	}
+
+	if err = a.repository.SaveInvariantReport(ctx, report); err != nil {
+		return vos.InvariantReport{}, fmt.Errorf("failed to save invariant report: %w", err)
+	}
+
+	a.instrumentator.CheckedInvariants(ctx, report)
+
+	return report, nil
+}
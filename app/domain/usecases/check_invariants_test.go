@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestAdminUseCase_CheckInvariants(t *testing.T) {
+	t.Run("should run every check and save the report", func(t *testing.T) {
+		watermark := time.Now().Add(-time.Hour)
+		now := time.Now()
+
+		violation := vos.InvariantViolation{
+			Check:   vos.VersionGapCheck,
+			Subject: "liability.clients.available.abc",
+			Detail:  "3 entries with 3 distinct versions up to version 4",
+		}
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			LockInvariantsFunc: lockInvariants(true),
+			GetInvariantWindowFunc: func(ctx context.Context, safety time.Duration) (time.Time, time.Time, error) {
+				return watermark, now, nil
+			},
+			CheckInvariantFunc: func(ctx context.Context, check vos.InvariantCheck, from time.Time, to time.Time) ([]vos.InvariantViolation, error) {
+				if check == vos.VersionGapCheck {
+					return []vos.InvariantViolation{violation}, nil
+				}
+				return nil, nil
+			},
+			SaveInvariantReportFunc: func(ctx context.Context, report vos.InvariantReport) error {
+				return nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.CheckInvariants(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, watermark, got.From)
+		assert.Equal(t, now, got.To)
+		assert.Equal(t, []vos.InvariantViolation{violation}, got.Violations)
+
+		calls := mockedRepository.CheckInvariantCalls()
+		assert.Len(t, calls, len(vos.InvariantChecks))
+		for i, call := range calls {
+			assert.Equal(t, vos.InvariantChecks[i], call.InvariantCheck)
+			assert.Equal(t, got.From, call.TimeMoqParam1)
+			assert.Equal(t, got.To, call.TimeMoqParam2)
+		}
+
+		saved := mockedRepository.SaveInvariantReportCalls()
+		assert.Len(t, saved, 1)
+		assert.Equal(t, got, saved[0].InvariantReport)
+	})
+
+	t.Run("should do nothing when the watermark is already up to date", func(t *testing.T) {
+		watermark := time.Now()
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			LockInvariantsFunc: lockInvariants(true),
+			GetInvariantWindowFunc: func(ctx context.Context, safety time.Duration) (time.Time, time.Time, error) {
+				return watermark, watermark.Add(-time.Second), nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.CheckInvariants(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, got.Violations)
+		assert.Empty(t, mockedRepository.CheckInvariantCalls())
+	})
+
+	t.Run("should not move the watermark when a check fails", func(t *testing.T) {
+		checkErr := errors.New("check failed")
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			LockInvariantsFunc: lockInvariants(true),
+			GetInvariantWindowFunc: func(ctx context.Context, safety time.Duration) (time.Time, time.Time, error) {
+				return time.Time{}, time.Now(), nil
+			},
+			CheckInvariantFunc: func(ctx context.Context, check vos.InvariantCheck, from time.Time, to time.Time) ([]vos.InvariantViolation, error) {
+				return nil, checkErr
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CheckInvariants(context.Background())
+		assert.ErrorIs(t, err, checkErr)
+		assert.Empty(t, mockedRepository.SaveInvariantReportCalls())
+	})
+	t.Run("should not run while another check holds the lock", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			LockInvariantsFunc: lockInvariants(false),
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CheckInvariants(context.Background())
+		assert.ErrorIs(t, err, app.ErrInvariantCheckInProgress)
+		assert.Empty(t, mockedRepository.GetInvariantWindowCalls())
+	})
+}
+
+func lockInvariants(locked bool) func(context.Context) (func(), bool, error) {
+	return func(ctx context.Context) (func(), bool, error) {
+		if !locked {
+			return nil, false, nil
+		}
+
+		return func() {}, true, nil
+	}
+}
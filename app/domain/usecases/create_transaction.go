@@ -5,13 +5,42 @@ import (
 	"fmt"
 
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 )
 
-func (l *LedgerUseCase) CreateTransaction(ctx context.Context, transaction entities.Transaction) error {
-	err := l.repository.CreateTransaction(ctx, transaction)
+// CreateTransaction saves the transaction. Simulated transactions (see vos.WithSimulation) go through
+// the same checks but aren't saved, so they are left out of the transaction metrics.
+func (l *LedgerUseCase) CreateTransaction(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+	ctx, segment := l.instrumentator.MonitorSegment(ctx, "LedgerUseCase.CreateTransaction")
+	defer segment.End()
+
+	simulated := vos.SimulationFromContext(ctx)
+
+	if err := l.validateAccounts(transaction); err != nil {
+		if !simulated {
+			l.instrumentator.RejectedTransaction(ctx, transaction, err)
+		}
+		return vos.TransactionResult{}, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	if err := l.validateMetadata(ctx, transaction); err != nil {
+		if !simulated {
+			l.instrumentator.RejectedTransaction(ctx, transaction, err)
+		}
+		return vos.TransactionResult{}, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	result, err := l.repository.CreateTransaction(ctx, transaction)
 	if err != nil {
-		return fmt.Errorf("failed to create transaction: %w", err)
+		if !simulated {
+			l.instrumentator.RejectedTransaction(ctx, transaction, err)
+		}
+		return vos.TransactionResult{}, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	if !simulated {
+		l.instrumentator.PostedTransaction(ctx, transaction)
 	}
 
-	return nil
+	return result, nil
 }
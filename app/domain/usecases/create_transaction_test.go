@@ -3,11 +3,11 @@ package usecases
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stone-co/the-amazing-ledger/app"
@@ -22,6 +22,8 @@ func TestLedgerUseCase_CreateTransaction(t *testing.T) {
 	accountID1 := testdata.GenerateAccountPath()
 	accountID2 := testdata.GenerateAccountPath()
 	metadata := json.RawMessage(`{}`)
+	schema := json.RawMessage(`{"type": "object", "required": ["order_id"]}`)
+	schemaErr := errors.New("connection lost")
 
 	testCases := []struct {
 		name        string
@@ -32,8 +34,9 @@ func TestLedgerUseCase_CreateTransaction(t *testing.T) {
 		{
 			name: "Should create a transaction successfully",
 			repoSetup: &mocks.RepositoryMock{
-				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) error {
-					return nil
+				GetEventSchemaFunc: noEventSchema,
+				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+					return vos.TransactionResult{}, nil
 				},
 			},
 			entries: func(t *testing.T) []entities.Entry {
@@ -50,8 +53,9 @@ func TestLedgerUseCase_CreateTransaction(t *testing.T) {
 		{
 			name: "Should return an error if entry tries to skip one version",
 			repoSetup: &mocks.RepositoryMock{
-				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) error {
-					return app.ErrInvalidVersion
+				GetEventSchemaFunc: noEventSchema,
+				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+					return vos.TransactionResult{}, app.ErrInvalidVersion
 				},
 			},
 			entries: func(t *testing.T) []entities.Entry {
@@ -68,8 +72,9 @@ func TestLedgerUseCase_CreateTransaction(t *testing.T) {
 		{
 			name: "Should return an error if violates idempotency key",
 			repoSetup: &mocks.RepositoryMock{
-				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) error {
-					return app.ErrIdempotencyKeyViolation
+				GetEventSchemaFunc: noEventSchema,
+				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+					return vos.TransactionResult{}, app.ErrIdempotencyKeyViolation
 				},
 			},
 			entries: func(t *testing.T) []entities.Entry {
@@ -85,17 +90,160 @@ func TestLedgerUseCase_CreateTransaction(t *testing.T) {
 			},
 			expectedErr: app.ErrIdempotencyKeyViolation,
 		},
+		{
+			name: "Should create a transaction whose metadata follows the schema of its event",
+			repoSetup: &mocks.RepositoryMock{
+				GetEventSchemaFunc: func(ctx context.Context, event uint32) (vos.EventSchema, error) {
+					return vos.EventSchema{Event: event, Version: 1, Schema: schema}, nil
+				},
+				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+					return vos.TransactionResult{}, nil
+				},
+			},
+			entries: func(t *testing.T) []entities.Entry {
+				e1, err := entities.NewEntry(uuid.New(), vos.DebitOperation, accountID1, vos.NextAccountVersion, 123, json.RawMessage(`{"order_id": 1}`))
+				assert.NoError(t, err)
+
+				e2, err := entities.NewEntry(uuid.New(), vos.CreditOperation, accountID2, vos.NextAccountVersion, 123, json.RawMessage(`{"order_id": 1}`))
+				assert.NoError(t, err)
+
+				return []entities.Entry{e1, e2}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Should return an error if the metadata breaks the schema of its event",
+			repoSetup: &mocks.RepositoryMock{
+				GetEventSchemaFunc: func(ctx context.Context, event uint32) (vos.EventSchema, error) {
+					return vos.EventSchema{Event: event, Version: 1, Schema: schema}, nil
+				},
+			},
+			entries: func(t *testing.T) []entities.Entry {
+				e1, err := entities.NewEntry(uuid.New(), vos.DebitOperation, accountID1, vos.NextAccountVersion, 123, json.RawMessage(`{"order_id": 1}`))
+				assert.NoError(t, err)
+
+				e2, err := entities.NewEntry(uuid.New(), vos.CreditOperation, accountID2, vos.NextAccountVersion, 123, metadata)
+				assert.NoError(t, err)
+
+				return []entities.Entry{e1, e2}
+			},
+			expectedErr: app.ErrMetadataSchemaViolation,
+		},
+		{
+			name: "Should return an error if the schema can't be read",
+			repoSetup: &mocks.RepositoryMock{
+				GetEventSchemaFunc: func(ctx context.Context, event uint32) (vos.EventSchema, error) {
+					return vos.EventSchema{}, schemaErr
+				},
+			},
+			entries: func(t *testing.T) []entities.Entry {
+				e1, err := entities.NewEntry(uuid.New(), vos.DebitOperation, accountID1, vos.NextAccountVersion, 123, metadata)
+				assert.NoError(t, err)
+
+				e2, err := entities.NewEntry(uuid.New(), vos.CreditOperation, accountID2, vos.NextAccountVersion, 123, metadata)
+				assert.NoError(t, err)
+
+				return []entities.Entry{e1, e2}
+			},
+			expectedErr: schemaErr,
+		},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			usecase := NewLedgerUseCase(tt.repoSetup, instrumentators.NewLedgerInstrumentator(&newrelic.Application{}))
+			usecase := NewLedgerUseCase(tt.repoSetup, &instrumentators.LedgerInstrumentator{})
 
 			tx, err := entities.NewTransaction(uuid.New(), 1, "abc", time.Now(), tt.entries(t)...)
 			assert.NoError(t, err)
 
-			err = usecase.CreateTransaction(context.Background(), tx)
+			_, err = usecase.CreateTransaction(context.Background(), tx)
 			assert.ErrorIs(t, err, tt.expectedErr)
 		})
 	}
 }
+
+func TestLedgerUseCase_CreateTransaction_Chart(t *testing.T) {
+	repository := &mocks.RepositoryMock{
+		GetEventSchemaFunc: noEventSchema,
+		CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+			return vos.TransactionResult{}, nil
+		},
+	}
+	usecase := NewLedgerUseCase(repository, &instrumentators.LedgerInstrumentator{})
+
+	chart, err := vos.NewChart(vos.ChartDefinition{
+		Classes:   []vos.ChartClass{{Name: "asset", MinDepth: 3}, {Name: "passivo", MinDepth: 2}},
+		Forbidden: []string{"asset.banks.closed"},
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		chart       *vos.Chart
+		account     string
+		expectedErr error
+	}{
+		{name: "should post to the default chart", chart: vos.DefaultChart(), account: "liability.clients.abc"},
+		{name: "should reject accounts below the depth of their class", chart: vos.DefaultChart(), account: "asset.bacen", expectedErr: app.ErrInvalidAccountStructure},
+		{name: "should reject accounts out of the chart", chart: vos.DefaultChart(), account: "passivo.clients", expectedErr: app.ErrAccountPathViolation},
+		{name: "should post to the classes of the chart set", chart: chart, account: "passivo.clients"},
+		{name: "should reject classes the chart set removed", chart: chart, account: "liability.clients.abc", expectedErr: app.ErrAccountPathViolation},
+		{name: "should reject forbidden accounts", chart: chart, account: "asset.banks.closed", expectedErr: app.ErrForbiddenAccount},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase.SetChart(tt.chart)
+
+			e1, err := entities.NewEntry(uuid.New(), vos.DebitOperation, tt.account, vos.NextAccountVersion, 123, json.RawMessage(`{}`))
+			assert.NoError(t, err)
+
+			e2, err := entities.NewEntry(uuid.New(), vos.CreditOperation, "asset.banks.itau", vos.NextAccountVersion, 123, json.RawMessage(`{}`))
+			assert.NoError(t, err)
+
+			tx, err := entities.NewTransaction(uuid.New(), 1, "abc", time.Now(), e1, e2)
+			assert.NoError(t, err)
+
+			_, err = usecase.CreateTransaction(context.Background(), tx)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestLedgerUseCase_CreateTransaction_MetadataViolations(t *testing.T) {
+	repository := &mocks.RepositoryMock{
+		GetEventSchemaFunc: func(ctx context.Context, event uint32) (vos.EventSchema, error) {
+			return vos.EventSchema{Event: event, Version: 3, Schema: json.RawMessage(`{"required": ["order_id"]}`)}, nil
+		},
+	}
+	usecase := NewLedgerUseCase(repository, &instrumentators.LedgerInstrumentator{})
+
+	valid, err := entities.NewEntry(uuid.New(), vos.DebitOperation, testdata.GenerateAccountPath(), vos.NextAccountVersion, 123, json.RawMessage(`{"order_id": 1}`))
+	assert.NoError(t, err)
+
+	invalid, err := entities.NewEntry(uuid.New(), vos.CreditOperation, testdata.GenerateAccountPath(), vos.NextAccountVersion, 123, json.RawMessage(`{}`))
+	assert.NoError(t, err)
+
+	tx, err := entities.NewTransaction(uuid.New(), 1, "abc", time.Now(), valid, invalid)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = usecase.CreateTransaction(context.Background(), tx)
+
+		var violationErr vos.MetadataViolationError
+		assert.ErrorAs(t, err, &violationErr)
+		assert.Equal(t, uint32(1), violationErr.Event)
+		assert.Equal(t, 3, violationErr.Version)
+		assert.Len(t, violationErr.Violations, 1)
+		assert.Equal(t, invalid.ID, violationErr.Violations[0].EntryID)
+		assert.Equal(t, invalid.Account.Value(), violationErr.Violations[0].Account)
+		assert.NotEmpty(t, violationErr.Violations[0].Errors)
+	}
+
+	// The schema is cached between transactions.
+	assert.Len(t, repository.GetEventSchemaCalls(), 1)
+}
+
+func noEventSchema(context.Context, uint32) (vos.EventSchema, error) {
+	return vos.EventSchema{}, app.ErrEventSchemaNotFound
+}
@@ -0,0 +1,207 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// eventSchemaTTL is how long the schema of an event is cached, so new versions created by any
+// server apply to the transactions within this time.
+const eventSchemaTTL = 10 * time.Second
+
+// maxMetadataValidationPageSize is the most entries checked by a call to ValidateEventMetadata,
+// which is also the size of the pages without one.
+const maxMetadataValidationPageSize = 500
+
+func (a *AdminUseCase) CreateEventSchema(ctx context.Context, event uint32, schema json.RawMessage) (vos.EventSchema, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.CreateEventSchema")
+	defer segment.End()
+
+	if _, err := vos.NewMetadataSchema(schema); err != nil {
+		return vos.EventSchema{}, err
+	}
+
+	eventSchema, err := a.repository.CreateEventSchema(ctx, event, schema)
+	if err != nil {
+		return vos.EventSchema{}, fmt.Errorf("failed to create schema of event %d: %w", event, err)
+	}
+
+	return eventSchema, nil
+}
+
+func (a *AdminUseCase) ListEventSchemas(ctx context.Context, event uint32) ([]vos.EventSchema, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ListEventSchemas")
+	defer segment.End()
+
+	schemas, err := a.repository.ListEventSchemas(ctx, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas of event %d: %w", event, err)
+	}
+
+	return schemas, nil
+}
+
+// ValidateEventMetadata checks the metadata of a page of existing entries against a schema version,
+// reporting the entries that would be rejected without changing them. Each call is bounded by the
+// page size, and the report has the cursor of the next page.
+func (a *AdminUseCase) ValidateEventMetadata(ctx context.Context, req vos.MetadataValidationRequest) (vos.MetadataValidationReport, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ValidateEventMetadata")
+	defer segment.End()
+
+	eventSchema, err := a.eventSchemaVersion(ctx, req.Event, req.Version)
+	if err != nil {
+		return vos.MetadataValidationReport{}, err
+	}
+
+	schema, err := vos.NewMetadataSchema(eventSchema.Schema)
+	if err != nil {
+		return vos.MetadataValidationReport{}, err
+	}
+
+	page := req.Page
+	if page.Size <= 0 || page.Size > maxMetadataValidationPageSize {
+		page.Size = maxMetadataValidationPageSize
+	}
+
+	entries, cursor, err := a.repository.ListEventMetadata(ctx, vos.EventMetadataRequest{
+		Event: req.Event,
+		Since: req.Since,
+		Page:  page,
+	})
+	if err != nil {
+		return vos.MetadataValidationReport{}, fmt.Errorf("failed to list entries of event %d: %w", req.Event, err)
+	}
+
+	report := vos.MetadataValidationReport{
+		Event:      eventSchema.Event,
+		Version:    eventSchema.Version,
+		Checked:    len(entries),
+		Violations: []vos.MetadataViolation{},
+		NextPage:   cursor,
+	}
+
+	for _, entry := range entries {
+		if errs := schema.Validate(entry.Metadata); len(errs) > 0 {
+			report.Violations = append(report.Violations, vos.MetadataViolation{
+				EntryID: entry.ID,
+				TxID:    entry.TxID,
+				Book:    entry.Book,
+				Account: entry.Account,
+				Errors:  errs,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// eventSchemaVersion returns the given version of the schema of the event, or the latest when zero.
+func (a *AdminUseCase) eventSchemaVersion(ctx context.Context, event uint32, version int) (vos.EventSchema, error) {
+	schemas, err := a.repository.ListEventSchemas(ctx, event)
+	if err != nil {
+		return vos.EventSchema{}, fmt.Errorf("failed to list schemas of event %d: %w", event, err)
+	}
+
+	if len(schemas) == 0 {
+		return vos.EventSchema{}, fmt.Errorf("%w: event %d has no schema", app.ErrEventSchemaNotFound, event)
+	}
+
+	if version == 0 {
+		return schemas[len(schemas)-1], nil
+	}
+
+	for _, schema := range schemas {
+		if schema.Version == version {
+			return schema, nil
+		}
+	}
+
+	return vos.EventSchema{}, fmt.Errorf("%w: event %d has no version %d", app.ErrEventSchemaNotFound, event, version)
+}
+
+// validateMetadata checks the metadata of every entry of the transaction against the schema of its
+// event, if any.
+func (l *LedgerUseCase) validateMetadata(ctx context.Context, transaction entities.Transaction) error {
+	cached, err := l.schemas.get(ctx, l.repository.GetEventSchema, transaction.Event)
+	if err != nil || cached.schema == nil {
+		return err
+	}
+
+	var violations []vos.MetadataViolation
+	for _, entry := range transaction.Entries {
+		if errs := cached.schema.Validate(entry.Metadata); len(errs) > 0 {
+			violations = append(violations, vos.MetadataViolation{
+				EntryID: entry.ID,
+				TxID:    transaction.ID,
+				Book:    vos.BookFromContext(ctx),
+				Account: entry.Account.Value(),
+				Errors:  errs,
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return vos.MetadataViolationError{
+			Event:      transaction.Event,
+			Version:    cached.version,
+			Violations: violations,
+		}
+	}
+
+	return nil
+}
+
+// schemaCache keeps the compiled schemas of the events, including the events without one.
+type schemaCache struct {
+	mu      sync.Mutex
+	schemas map[uint32]cachedSchema
+}
+
+type cachedSchema struct {
+	schema    *vos.MetadataSchema
+	version   int
+	expiresAt time.Time
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{schemas: make(map[uint32]cachedSchema)}
+}
+
+func (c *schemaCache) get(ctx context.Context, load func(context.Context, uint32) (vos.EventSchema, error), event uint32) (cachedSchema, error) {
+	c.mu.Lock()
+	cached, ok := c.schemas[event]
+	c.mu.Unlock()
+
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	cached = cachedSchema{expiresAt: time.Now().Add(eventSchemaTTL)}
+
+	eventSchema, err := load(ctx, event)
+	switch {
+	case errors.Is(err, app.ErrEventSchemaNotFound):
+	case err != nil:
+		return cachedSchema{}, fmt.Errorf("failed to get schema of event %d: %w", event, err)
+	default:
+		if cached.schema, err = vos.NewMetadataSchema(eventSchema.Schema); err != nil {
+			return cachedSchema{}, err
+		}
+
+		cached.version = eventSchema.Version
+	}
+
+	c.mu.Lock()
+	c.schemas[event] = cached
+	c.mu.Unlock()
+
+	return cached, nil
+}
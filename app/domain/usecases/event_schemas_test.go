@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestAdminUseCase_CreateEventSchema(t *testing.T) {
+	schema := json.RawMessage(`{"type": "object"}`)
+
+	t.Run("should create a version of the schema", func(t *testing.T) {
+		eventSchema := vos.EventSchema{Event: 1, Version: 2, Schema: schema, CreatedAt: time.Now()}
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateEventSchemaFunc: func(ctx context.Context, event uint32, schema json.RawMessage) (vos.EventSchema, error) {
+				return eventSchema, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.CreateEventSchema(context.Background(), 1, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, eventSchema, got)
+
+		calls := mockedRepository.CreateEventSchemaCalls()
+		assert.Len(t, calls, 1)
+		assert.Equal(t, uint32(1), calls[0].V)
+	})
+
+	t.Run("should not create an invalid schema", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CreateEventSchema(context.Background(), 1, json.RawMessage(`{"type": "unknown"}`))
+		assert.ErrorIs(t, err, app.ErrInvalidMetadataSchema)
+		assert.Empty(t, mockedRepository.CreateEventSchemaCalls())
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateEventSchemaFunc: func(ctx context.Context, event uint32, schema json.RawMessage) (vos.EventSchema, error) {
+				return vos.EventSchema{}, app.ErrEventNotFound
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CreateEventSchema(context.Background(), 99, schema)
+		assert.ErrorIs(t, err, app.ErrEventNotFound)
+	})
+}
+
+func TestAdminUseCase_ValidateEventMetadata(t *testing.T) {
+	schemas := []vos.EventSchema{
+		{Event: 1, Version: 1, Schema: json.RawMessage(`{"type": "object"}`)},
+		{Event: 1, Version: 2, Schema: json.RawMessage(`{"required": ["order_id"]}`)},
+	}
+	entries := []vos.EntryMetadata{
+		{ID: uuid.New(), TxID: uuid.New(), Book: vos.DefaultBook, Account: "liability.abc.available", Metadata: json.RawMessage(`{"order_id": 1}`)},
+		{ID: uuid.New(), TxID: uuid.New(), Book: vos.DefaultBook, Account: "liability.abc.blocked", Metadata: json.RawMessage(`{}`)},
+		{ID: uuid.New(), TxID: uuid.New(), Book: "banking", Account: "liability.xyz.available", Metadata: json.RawMessage(`{"id": 2}`)},
+	}
+
+	newRepository := func() *mocks.AdminRepositoryMock {
+		return &mocks.AdminRepositoryMock{
+			ListEventSchemasFunc: func(ctx context.Context, event uint32) ([]vos.EventSchema, error) {
+				return schemas, nil
+			},
+			ListEventMetadataFunc: func(ctx context.Context, req vos.EventMetadataRequest) ([]vos.EntryMetadata, pagination.Cursor, error) {
+				if req.Page.Cursor == nil {
+					return entries[:2], pagination.Cursor("next"), nil
+				}
+
+				return entries[2:], nil, nil
+			},
+		}
+	}
+
+	t.Run("should report the entries of a page that break the latest version", func(t *testing.T) {
+		mockedRepository := newRepository()
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		since := time.Now().Add(-time.Hour)
+
+		report, err := usecase.ValidateEventMetadata(context.Background(), vos.MetadataValidationRequest{Event: 1, Since: since})
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Version)
+		assert.Equal(t, 2, report.Checked)
+		require.Len(t, report.Violations, 1)
+		assert.Equal(t, entries[1].ID, report.Violations[0].EntryID)
+		assert.Equal(t, pagination.Cursor("next"), report.NextPage)
+
+		report, err = usecase.ValidateEventMetadata(context.Background(), vos.MetadataValidationRequest{
+			Event: 1,
+			Since: since,
+			Page:  pagination.Page{Size: 10000, Cursor: report.NextPage},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Checked)
+		require.Len(t, report.Violations, 1)
+		assert.Equal(t, entries[2].ID, report.Violations[0].EntryID)
+		assert.Equal(t, "banking", report.Violations[0].Book)
+		assert.Nil(t, report.NextPage)
+
+		// Each call reads a single page, which is never above the limit.
+		calls := mockedRepository.ListEventMetadataCalls()
+		require.Len(t, calls, 2)
+		assert.Equal(t, since, calls[0].EventMetadataRequest.Since)
+		assert.Equal(t, maxMetadataValidationPageSize, calls[0].EventMetadataRequest.Page.Size)
+		assert.Equal(t, maxMetadataValidationPageSize, calls[1].EventMetadataRequest.Page.Size)
+		assert.Equal(t, pagination.Cursor("next"), calls[1].EventMetadataRequest.Page.Cursor)
+	})
+
+	t.Run("should validate against the given version", func(t *testing.T) {
+		usecase := NewAdminUseCase(newRepository(), &instrumentators.LedgerInstrumentator{})
+
+		report, err := usecase.ValidateEventMetadata(context.Background(), vos.MetadataValidationRequest{Event: 1, Version: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Version)
+		assert.Equal(t, 2, report.Checked)
+		assert.Empty(t, report.Violations)
+	})
+
+	t.Run("should return an error if the version doesn't exist", func(t *testing.T) {
+		usecase := NewAdminUseCase(newRepository(), &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.ValidateEventMetadata(context.Background(), vos.MetadataValidationRequest{Event: 1, Version: 3})
+		assert.ErrorIs(t, err, app.ErrEventSchemaNotFound)
+	})
+
+	t.Run("should return an error if the event has no schema", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			ListEventSchemasFunc: func(ctx context.Context, event uint32) ([]vos.EventSchema, error) {
+				return []vos.EventSchema{}, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.ValidateEventMetadata(context.Background(), vos.MetadataValidationRequest{Event: 1})
+		assert.ErrorIs(t, err, app.ErrEventSchemaNotFound)
+		assert.Empty(t, mockedRepository.ListEventMetadataCalls())
+	})
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// ExpandEvent builds the transaction of an event from its posting template. Entry ids derive from
+// the transaction id, so posting the same event twice hits the idempotency keys.
+func (l *LedgerUseCase) ExpandEvent(ctx context.Context, posting vos.EventPosting) (entities.Transaction, error) {
+	_, segment := l.instrumentator.MonitorSegment(ctx, "LedgerUseCase.ExpandEvent")
+	defer segment.End()
+
+	template, err := l.templates.Load().(*vos.PostingTemplates).Template(posting.Event)
+	if err != nil {
+		return entities.Transaction{}, err
+	}
+
+	postings, err := template.Expand(posting.Amount, posting.Params)
+	if err != nil {
+		return entities.Transaction{}, fmt.Errorf("failed to expand event %d: %w", posting.Event, err)
+	}
+
+	entries := make([]entities.Entry, 0, len(postings))
+	for i, p := range postings {
+		entryID := uuid.NewSHA1(posting.ID, []byte(strconv.Itoa(i)))
+
+		entry, err := entities.NewEntry(entryID, p.Operation, p.Account, vos.NextAccountVersion, p.Amount, posting.Metadata)
+		if err != nil {
+			return entities.Transaction{}, fmt.Errorf("failed to expand event %d: %w", posting.Event, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entities.NewTransaction(posting.ID, posting.Event, posting.Company, posting.CompetenceDate, entries...)
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestLedgerUseCase_ExpandEvent(t *testing.T) {
+	templates, err := vos.NewPostingTemplates(vos.PostingTemplatesDefinition{Templates: []vos.PostingTemplateDefinition{{
+		Event: 7,
+		Entries: []vos.PostingEntryDefinition{
+			{Operation: "debit", Account: "asset.banks.{bank}", Amount: "amount"},
+			{Operation: "credit", Account: "revenue.fees.pix", Amount: "round(amount / 100)"},
+			{Operation: "credit", Account: "liability.clients.{client}.available", Amount: "remainder"},
+		},
+	}}})
+	require.NoError(t, err)
+
+	usecase := NewLedgerUseCase(&mocks.RepositoryMock{}, &instrumentators.LedgerInstrumentator{})
+
+	posting := vos.EventPosting{
+		ID:             uuid.New(),
+		Event:          7,
+		Company:        "abc",
+		CompetenceDate: time.Now(),
+		Amount:         1000,
+		Params:         map[string]string{"bank": "itau", "client": "abc"},
+		Metadata:       json.RawMessage(`{"order_id": 1}`),
+	}
+
+	t.Run("should fail without templates", func(t *testing.T) {
+		_, err := usecase.ExpandEvent(context.Background(), posting)
+		assert.ErrorIs(t, err, app.ErrPostingTemplateNotFound)
+	})
+
+	usecase.SetPostingTemplates(templates)
+
+	t.Run("should expand the event into a balanced transaction", func(t *testing.T) {
+		tx, err := usecase.ExpandEvent(context.Background(), posting)
+		require.NoError(t, err)
+
+		assert.Equal(t, posting.ID, tx.ID)
+		assert.Equal(t, uint32(7), tx.Event)
+		assert.Equal(t, "abc", tx.Company)
+		require.Len(t, tx.Entries, 3)
+
+		amounts := make(map[string]int, len(tx.Entries))
+		for _, entry := range tx.Entries {
+			amounts[entry.Account.Value()] = entry.Amount
+			assert.Equal(t, vos.NextAccountVersion, entry.Version)
+			assert.Equal(t, posting.Metadata, entry.Metadata)
+		}
+
+		assert.Equal(t, map[string]int{
+			"asset.banks.itau":                1000,
+			"revenue.fees.pix":                10,
+			"liability.clients.abc.available": 990,
+		}, amounts)
+	})
+
+	t.Run("should derive the entry ids from the transaction id", func(t *testing.T) {
+		first, err := usecase.ExpandEvent(context.Background(), posting)
+		require.NoError(t, err)
+
+		second, err := usecase.ExpandEvent(context.Background(), posting)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.Entries, second.Entries)
+
+		other := posting
+		other.ID = uuid.New()
+
+		third, err := usecase.ExpandEvent(context.Background(), other)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Entries[0].ID, third.Entries[0].ID)
+	})
+
+	t.Run("should return the errors of the parameters", func(t *testing.T) {
+		invalid := posting
+		invalid.Params = map[string]string{"bank": "itau"}
+
+		_, err := usecase.ExpandEvent(context.Background(), invalid)
+		assert.ErrorIs(t, err, app.ErrInvalidPostingParams)
+	})
+}
@@ -9,6 +9,9 @@ import (
 )
 
 func (l *LedgerUseCase) GetAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+	ctx, segment := l.instrumentator.MonitorSegment(ctx, "LedgerUseCase.GetAccountBalance")
+	defer segment.End()
+
 	var (
 		accountBalance vos.AccountBalance
 		err            error
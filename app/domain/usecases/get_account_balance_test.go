@@ -4,7 +4,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stone-co/the-amazing-ledger/app"
@@ -25,7 +24,7 @@ func TestLedgerUseCase_GetAccountBalance_Analytic(t *testing.T) {
 				return accountBalance, nil
 			},
 		}
-		usecase := NewLedgerUseCase(mockedRepository, instrumentators.NewLedgerInstrumentator(&newrelic.Application{}))
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
 
 		got, err := usecase.GetAccountBalance(context.Background(), accountPath)
 		assert.NoError(t, err)
@@ -44,7 +43,7 @@ func TestLedgerUseCase_GetAccountBalance_Analytic(t *testing.T) {
 				return vos.AccountBalance{}, app.ErrAccountNotFound
 			},
 		}
-		usecase := NewLedgerUseCase(mockedRepository, instrumentators.NewLedgerInstrumentator(&newrelic.Application{}))
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
 
 		got, err := usecase.GetAccountBalance(context.Background(), accountPath)
 		assert.Empty(t, got)
@@ -64,8 +63,7 @@ func TestLedgerUseCase_GetAccountBalance_Synthetic(t *testing.T) {
 			},
 		}
 
-		nr, _ := newrelic.NewApplication()
-		usecase := NewLedgerUseCase(mockedRepository, instrumentators.NewLedgerInstrumentator(nr))
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
 
 		got, err := usecase.GetAccountBalance(context.Background(), account)
 		assert.NoError(t, err)
@@ -82,8 +80,7 @@ func TestLedgerUseCase_GetAccountBalance_Synthetic(t *testing.T) {
 			},
 		}
 
-		nr, _ := newrelic.NewApplication()
-		usecase := NewLedgerUseCase(mockedRepository, instrumentators.NewLedgerInstrumentator(nr))
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
 
 		got, err := usecase.GetAccountBalance(context.Background(), query)
 		assert.Empty(t, got)
@@ -10,7 +10,8 @@ import (
 )
 
 func (l *LedgerUseCase) GetSyntheticReport(ctx context.Context, query vos.Account, level int, startTime time.Time, endTime time.Time) (*vos.SyntheticReport, error) {
-	defer l.instrumentator.MonitorSegment(ctx).End()
+	ctx, segment := l.instrumentator.MonitorSegment(ctx, "LedgerUseCase.GetSyntheticReport")
+	defer segment.End()
 
 	if level < 1 {
 		level = len(strings.Split(query.Value(), "."))
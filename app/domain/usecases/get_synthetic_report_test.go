@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
@@ -42,7 +41,7 @@ func TestLedgerUseCase_GetSyntheticReport(t *testing.T) {
 			},
 		}
 
-		useCase := NewLedgerUseCase(&mockedRepository, instrumentators.NewLedgerInstrumentator(&newrelic.Application{}))
+		useCase := NewLedgerUseCase(&mockedRepository, &instrumentators.LedgerInstrumentator{})
 
 		got, err := useCase.GetSyntheticReport(context.Background(), query, level, date, date)
 		assert.NoError(t, err)
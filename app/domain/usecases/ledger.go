@@ -1,8 +1,12 @@
 package usecases
 
 import (
+	"sync/atomic"
+
 	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
 	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 )
 
 var _ domain.UseCase = &LedgerUseCase{}
@@ -10,11 +14,45 @@ var _ domain.UseCase = &LedgerUseCase{}
 type LedgerUseCase struct {
 	instrumentator *instrumentators.LedgerInstrumentator
 	repository     domain.Repository
+	schemas        *schemaCache
+
+	// templates holds the *vos.PostingTemplates used by ExpandEvent, which are replaced on reloads.
+	templates atomic.Value
+	// chart holds the *vos.Chart the accounts of posted entries are checked against.
+	chart atomic.Value
 }
 
 func NewLedgerUseCase(repository domain.Repository, instrumentator *instrumentators.LedgerInstrumentator) *LedgerUseCase {
-	return &LedgerUseCase{
+	l := &LedgerUseCase{
 		repository:     repository,
 		instrumentator: instrumentator,
+		schemas:        newSchemaCache(),
+	}
+	l.templates.Store(&vos.PostingTemplates{})
+	l.chart.Store(vos.DefaultChart())
+
+	return l
+}
+
+// SetPostingTemplates replaces the templates used to expand events.
+func (l *LedgerUseCase) SetPostingTemplates(templates *vos.PostingTemplates) {
+	l.templates.Store(templates)
+}
+
+// SetChart replaces the chart of accounts checked when entries are posted.
+func (l *LedgerUseCase) SetChart(chart *vos.Chart) {
+	l.chart.Store(chart)
+}
+
+// validateAccounts checks the accounts of the entries against the chart of accounts.
+func (l *LedgerUseCase) validateAccounts(transaction entities.Transaction) error {
+	chart := l.chart.Load().(*vos.Chart)
+
+	for _, entry := range transaction.Entries {
+		if err := chart.Validate(entry.Account); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
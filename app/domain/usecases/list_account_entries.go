@@ -8,13 +8,13 @@ import (
 )
 
 func (l *LedgerUseCase) ListAccountEntries(ctx context.Context, req vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
-	entries, nextPage, err := l.repository.ListAccountEntries(ctx, req)
+	ctx, segment := l.instrumentator.MonitorSegment(ctx, "LedgerUseCase.ListAccountEntries")
+	defer segment.End()
+
+	response, err := l.repository.ListAccountEntries(ctx, req)
 	if err != nil {
 		return vos.AccountEntryResponse{}, fmt.Errorf("failed to get account entries: %w", err)
 	}
 
-	return vos.AccountEntryResponse{
-		Entries:  entries,
-		NextPage: nextPage,
-	}, nil
+	return response, nil
 }
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
@@ -22,8 +21,8 @@ func TestLedgerUseCase_ListAccountEntries(t *testing.T) {
 		assert.NoError(t, err)
 
 		mockedRepository := &mocks.RepositoryMock{
-			ListAccountEntriesFunc: func(ctx context.Context, req vos.AccountEntryRequest) ([]vos.AccountEntry, pagination.Cursor, error) {
-				return []vos.AccountEntry{
+			ListAccountEntriesFunc: func(ctx context.Context, req vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
+				return vos.AccountEntryResponse{Entries: []vos.AccountEntry{
 					{
 						ID:             uuid.New(),
 						Version:        vos.NextAccountVersion,
@@ -33,10 +32,10 @@ func TestLedgerUseCase_ListAccountEntries(t *testing.T) {
 						CompetenceDate: time.Now().Round(time.Nanosecond),
 						Metadata:       nil,
 					},
-				}, nil, nil
+				}}, nil
 			},
 		}
-		usecase := NewLedgerUseCase(mockedRepository, instrumentators.NewLedgerInstrumentator(&newrelic.Application{}))
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
 
 		page, err := pagination.NewPage(nil)
 		assert.NoError(t, err)
@@ -57,11 +56,11 @@ func TestLedgerUseCase_ListAccountEntries(t *testing.T) {
 		assert.NoError(t, err)
 
 		mockedRepository := &mocks.RepositoryMock{
-			ListAccountEntriesFunc: func(ctx context.Context, req vos.AccountEntryRequest) ([]vos.AccountEntry, pagination.Cursor, error) {
-				return []vos.AccountEntry{}, nil, nil
+			ListAccountEntriesFunc: func(ctx context.Context, req vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
+				return vos.AccountEntryResponse{Entries: []vos.AccountEntry{}}, nil
 			},
 		}
-		usecase := NewLedgerUseCase(mockedRepository, instrumentators.NewLedgerInstrumentator(&newrelic.Application{}))
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
 
 		page, err := pagination.NewPage(nil)
 		assert.NoError(t, err)
@@ -76,4 +75,35 @@ func TestLedgerUseCase_ListAccountEntries(t *testing.T) {
 		assert.Len(t, got.Entries, 0)
 		assert.Nil(t, got.NextPage)
 	})
+	t.Run("should return the balances from the repository", func(t *testing.T) {
+		account, err := vos.NewAnalyticAccount(testdata.GenerateAccountPath())
+		assert.NoError(t, err)
+
+		opening := 100
+		mockedRepository := &mocks.RepositoryMock{
+			ListAccountEntriesFunc: func(ctx context.Context, req vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
+				return vos.AccountEntryResponse{
+					Entries:        []vos.AccountEntry{{ID: uuid.New(), Balance: 130}, {ID: uuid.New(), Balance: 110}},
+					OpeningBalance: &opening,
+				}, nil
+			},
+		}
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		req := vos.AccountEntryRequest{
+			Account:               account,
+			StartDate:             time.Now().Add(-time.Hour),
+			EndDate:               time.Now(),
+			Page:                  pagination.Page{Size: 10},
+			IncludeOpeningBalance: true,
+		}
+
+		got, err := usecase.ListAccountEntries(context.Background(), req)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []int{130, 110}, []int{got.Entries[0].Balance, got.Entries[1].Balance})
+		assert.Equal(t, 100, *got.OpeningBalance)
+		assert.Len(t, mockedRepository.ListAccountEntriesCalls(), 1)
+		assert.Equal(t, req, mockedRepository.ListAccountEntriesCalls()[0].AccountEntryRequest)
+	})
 }
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func (l *LedgerUseCase) ListAccounts(ctx context.Context, req vos.AccountListRequest) (vos.AccountListResponse, error) {
+	ctx, segment := l.instrumentator.MonitorSegment(ctx, "LedgerUseCase.ListAccounts")
+	defer segment.End()
+
+	accounts, nextPage, err := l.repository.ListAccounts(ctx, req)
+	if err != nil {
+		return vos.AccountListResponse{}, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	return vos.AccountListResponse{
+		Accounts: accounts,
+		NextPage: nextPage,
+	}, nil
+}
+
+// ListAccountChildren reads the balance of each child from the synthetic account of everything under
+// it, so the balances of a page cost one query per child.
+func (l *LedgerUseCase) ListAccountChildren(ctx context.Context, req vos.AccountChildrenRequest) (vos.AccountChildrenResponse, error) {
+	ctx, segment := l.instrumentator.MonitorSegment(ctx, "LedgerUseCase.ListAccountChildren")
+	defer segment.End()
+
+	children, nextPage, err := l.repository.ListAccountChildren(ctx, req)
+	if err != nil {
+		return vos.AccountChildrenResponse{}, fmt.Errorf("failed to list account children: %w", err)
+	}
+
+	if req.IncludeBalances {
+		for i := range children {
+			balance, balanceErr := l.repository.GetSyntheticAccountBalance(ctx, children[i].Prefix.Query())
+			if errors.Is(balanceErr, app.ErrAccountNotFound) {
+				continue
+			}
+
+			if balanceErr != nil {
+				return vos.AccountChildrenResponse{}, fmt.Errorf("failed to get balance of %s: %w", children[i].Prefix.Value(), balanceErr)
+			}
+
+			children[i].Balance = &balance
+		}
+	}
+
+	return vos.AccountChildrenResponse{
+		Children: children,
+		NextPage: nextPage,
+	}, nil
+}
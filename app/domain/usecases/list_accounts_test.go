@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestLedgerUseCase_ListAccounts(t *testing.T) {
+	pattern, err := vos.NewAccount("liability.clients.*")
+	require.NoError(t, err)
+
+	t.Run("should list accounts successfully", func(t *testing.T) {
+		account, err := vos.NewAnalyticAccount("liability.clients.available")
+		require.NoError(t, err)
+
+		mockedRepository := &mocks.RepositoryMock{
+			ListAccountsFunc: func(ctx context.Context, req vos.AccountListRequest) ([]vos.AccountSummary, pagination.Cursor, error) {
+				assert.Equal(t, pattern, req.Pattern)
+				return []vos.AccountSummary{{Account: account}}, pagination.Cursor(`{"account":"liability.clients.available"}`), nil
+			},
+		}
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.ListAccounts(context.Background(), vos.AccountListRequest{Pattern: pattern})
+		assert.NoError(t, err)
+		assert.Equal(t, []vos.AccountSummary{{Account: account}}, got.Accounts)
+		assert.NotNil(t, got.NextPage)
+	})
+
+	t.Run("should return an error if the repository fails", func(t *testing.T) {
+		mockedRepository := &mocks.RepositoryMock{
+			ListAccountsFunc: func(ctx context.Context, req vos.AccountListRequest) ([]vos.AccountSummary, pagination.Cursor, error) {
+				return nil, nil, errors.New("db error")
+			},
+		}
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.ListAccounts(context.Background(), vos.AccountListRequest{Pattern: pattern})
+		assert.Error(t, err)
+	})
+}
+
+func TestLedgerUseCase_ListAccountChildren(t *testing.T) {
+	prefix, err := vos.NewAccountPrefix("liability")
+	require.NoError(t, err)
+
+	children := func() []vos.AccountChild {
+		return []vos.AccountChild{
+			{Prefix: prefix.Child("clients"), Accounts: 2},
+			{Prefix: prefix.Child("suppliers"), Accounts: 1},
+		}
+	}
+
+	t.Run("should not read balances unless requested", func(t *testing.T) {
+		mockedRepository := &mocks.RepositoryMock{
+			ListAccountChildrenFunc: func(ctx context.Context, req vos.AccountChildrenRequest) ([]vos.AccountChild, pagination.Cursor, error) {
+				return children(), nil, nil
+			},
+		}
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.ListAccountChildren(context.Background(), vos.AccountChildrenRequest{Prefix: prefix})
+		assert.NoError(t, err)
+		assert.Equal(t, children(), got.Children)
+		assert.Empty(t, mockedRepository.GetSyntheticAccountBalanceCalls())
+	})
+
+	t.Run("should read the balance of each child", func(t *testing.T) {
+		mockedRepository := &mocks.RepositoryMock{
+			ListAccountChildrenFunc: func(ctx context.Context, req vos.AccountChildrenRequest) ([]vos.AccountChild, pagination.Cursor, error) {
+				return children(), nil, nil
+			},
+			GetSyntheticAccountBalanceFunc: func(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+				if account.Value() == "liability.suppliers.*" {
+					return vos.AccountBalance{}, app.ErrAccountNotFound
+				}
+
+				return vos.NewSyntheticAccountBalance(account, 150), nil
+			},
+		}
+		usecase := NewLedgerUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.ListAccountChildren(context.Background(), vos.AccountChildrenRequest{Prefix: prefix, IncludeBalances: true})
+		assert.NoError(t, err)
+		require.Len(t, got.Children, 2)
+		require.NotNil(t, got.Children[0].Balance)
+		assert.Equal(t, 150, got.Children[0].Balance.Balance)
+		assert.Nil(t, got.Children[1].Balance)
+	})
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func (a *AdminUseCase) ListInvariantViolations(ctx context.Context, req vos.InvariantViolationRequest) (vos.InvariantViolationResponse, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ListInvariantViolations")
+	defer segment.End()
+
+	violations, nextPage, err := a.repository.ListInvariantViolations(ctx, req)
+	if err != nil {
+		return vos.InvariantViolationResponse{}, fmt.Errorf("failed to list invariant violations: %w", err)
+	}
+
+	return vos.InvariantViolationResponse{
+		Violations: violations,
+		NextPage:   nextPage,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestAdminUseCase_ListInvariantViolations(t *testing.T) {
+	t.Run("should list invariant violations successfully", func(t *testing.T) {
+		violations := []vos.InvariantViolation{
+			{
+				ID:         1,
+				Check:      vos.TransactionBalanceCheck,
+				Subject:    "0b52d7a1-0e2e-4e4b-9b84-08b0b1b8d4d6",
+				Detail:     "transaction entries sum to 100",
+				DetectedAt: time.Now().Round(time.Microsecond),
+			},
+		}
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			ListInvariantViolationsFunc: func(ctx context.Context, req vos.InvariantViolationRequest) ([]vos.InvariantViolation, pagination.Cursor, error) {
+				return violations, nil, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.ListInvariantViolations(context.Background(), vos.InvariantViolationRequest{
+			Check: vos.TransactionBalanceCheck,
+			Page:  pagination.Page{Size: 10},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, violations, got.Violations)
+		assert.Nil(t, got.NextPage)
+	})
+}
@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// ManagePartitions creates the entry partitions from the current month up to monthsAhead months in
+// the future, builds the metadata and event indexes of the partitions that lack them and, when
+// retentionMonths is positive, detaches the partitions that ended before the last retentionMonths
+// months. Months are computed in UTC.
+func (a *AdminUseCase) ManagePartitions(ctx context.Context, monthsAhead, retentionMonths int) (vos.PartitionReport, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ManagePartitions")
+	defer segment.End()
+
+	if monthsAhead < 0 || retentionMonths < 0 {
+		return vos.PartitionReport{}, app.ErrInvalidPartitionWindow
+	}
+
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	report := vos.PartitionReport{
+		Created:  make([]string, 0),
+		Indexed:  make([]string, 0),
+		Detached: make([]string, 0),
+	}
+
+	for i := 0; i <= monthsAhead; i++ {
+		name, err := a.repository.CreateEntryPartition(ctx, currentMonth.AddDate(0, i, 0))
+		if err != nil {
+			return report, fmt.Errorf("failed to create entry partition: %w", err)
+		}
+
+		if name != "" {
+			report.Created = append(report.Created, name)
+		}
+	}
+
+	indexed, err := a.repository.IndexEntryPartitions(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to index entry partitions: %w", err)
+	}
+
+	report.Indexed = indexed
+
+	if retentionMonths > 0 {
+		partitions, err := a.repository.ListEntryPartitions(ctx)
+		if err != nil {
+			return report, fmt.Errorf("failed to list entry partitions: %w", err)
+		}
+
+		retainedSince := currentMonth.AddDate(0, -retentionMonths, 0)
+
+		for _, partition := range partitions {
+			if partition.Detached() || partition.To.After(retainedSince) {
+				continue
+			}
+
+			if err = a.repository.DetachEntryPartition(ctx, partition.Name); err != nil {
+				return report, fmt.Errorf("failed to detach entry partition: %w", err)
+			}
+
+			report.Detached = append(report.Detached, partition.Name)
+		}
+	}
+
+	a.instrumentator.ManagedPartitions(ctx, report)
+
+	return report, nil
+}
+
+// ArchivePartitions exports every detached partition to a gzip compressed csv file in dir, and then
+// drops it. Files are written under a temporary name, so a failed export never looks complete.
+func (a *AdminUseCase) ArchivePartitions(ctx context.Context, dir string) ([]vos.EntryPartition, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ArchivePartitions")
+	defer segment.End()
+
+	partitions, err := a.repository.ListEntryPartitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entry partitions: %w", err)
+	}
+
+	archived := make([]vos.EntryPartition, 0)
+
+	for _, partition := range partitions {
+		if !partition.Detached() || partition.Archived() {
+			continue
+		}
+
+		archive := filepath.Join(dir, partition.Name+".csv.gz")
+
+		if err = a.exportPartition(ctx, partition.Name, archive); err != nil {
+			return archived, err
+		}
+
+		if err = a.repository.DropEntryPartition(ctx, partition.Name, archive); err != nil {
+			return archived, fmt.Errorf("failed to drop entry partition: %w", err)
+		}
+
+		partition.Archive = archive
+		partition.ArchivedAt = time.Now()
+		archived = append(archived, partition)
+
+		a.instrumentator.ArchivedPartition(ctx, partition)
+	}
+
+	return archived, nil
+}
+
+func (a *AdminUseCase) exportPartition(ctx context.Context, name, archive string) error {
+	tmp := archive + ".tmp"
+
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(tmp)
+	}()
+
+	zw := gzip.NewWriter(file)
+	zw.Name = name + ".csv"
+
+	if err = a.repository.ExportEntryPartition(ctx, name, zw); err != nil {
+		return fmt.Errorf("failed to export entry partition: %w", err)
+	}
+
+	if err = zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress archive file: %w", err)
+	}
+
+	if err = file.Sync(); err != nil {
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close archive file: %w", err)
+	}
+
+	if err = os.Rename(tmp, archive); err != nil {
+		return fmt.Errorf("failed to rename archive file: %w", err)
+	}
+
+	return nil
+}
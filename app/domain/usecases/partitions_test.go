@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestAdminUseCase_ManagePartitions(t *testing.T) {
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should create future partitions and detach the ones out of retention", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateEntryPartitionFunc: func(ctx context.Context, month time.Time) (string, error) {
+				if month.Equal(currentMonth) {
+					return "", nil
+				}
+				return "entry_" + month.Format("2006_01"), nil
+			},
+			IndexEntryPartitionsFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"entry_default"}, nil
+			},
+			ListEntryPartitionsFunc: func(ctx context.Context) ([]vos.EntryPartition, error) {
+				return []vos.EntryPartition{
+					{Name: "old_detached", To: currentMonth.AddDate(0, -5, 0), DetachedAt: time.Now()},
+					{Name: "old", To: currentMonth.AddDate(0, -2, 0)},
+					{Name: "retained", To: currentMonth.AddDate(0, -1, 0).AddDate(0, 0, 1)},
+					{Name: "current", To: currentMonth.AddDate(0, 1, 0)},
+				}, nil
+			},
+			DetachEntryPartitionFunc: func(ctx context.Context, name string) error {
+				return nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.ManagePartitions(context.Background(), 2, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, vos.PartitionReport{
+			Created: []string{
+				"entry_" + currentMonth.AddDate(0, 1, 0).Format("2006_01"),
+				"entry_" + currentMonth.AddDate(0, 2, 0).Format("2006_01"),
+			},
+			Indexed:  []string{"entry_default"},
+			Detached: []string{"old"},
+		}, got)
+
+		assert.Len(t, mockedRepository.CreateEntryPartitionCalls(), 3)
+	})
+
+	t.Run("should not detach partitions without retention", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateEntryPartitionFunc: func(ctx context.Context, month time.Time) (string, error) {
+				return "", nil
+			},
+			IndexEntryPartitionsFunc: func(ctx context.Context) ([]string, error) {
+				return []string{}, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.ManagePartitions(context.Background(), 0, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, got.Created)
+		assert.Empty(t, got.Detached)
+		assert.Empty(t, mockedRepository.ListEntryPartitionsCalls())
+	})
+
+	t.Run("should stop when partitions can't be indexed", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateEntryPartitionFunc: func(ctx context.Context, month time.Time) (string, error) {
+				return "", nil
+			},
+			IndexEntryPartitionsFunc: func(ctx context.Context) ([]string, error) {
+				return nil, errors.New("index failed")
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.ManagePartitions(context.Background(), 0, 1)
+		assert.Error(t, err)
+		assert.Empty(t, mockedRepository.ListEntryPartitionsCalls())
+	})
+
+	t.Run("should reject negative windows", func(t *testing.T) {
+		usecase := NewAdminUseCase(&mocks.AdminRepositoryMock{}, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.ManagePartitions(context.Background(), -1, 0)
+		assert.ErrorIs(t, err, app.ErrInvalidPartitionWindow)
+	})
+}
+
+func TestAdminUseCase_ArchivePartitions(t *testing.T) {
+	partitions := []vos.EntryPartition{
+		{Name: "entry_2021_01", DetachedAt: time.Now(), ArchivedAt: time.Now()},
+		{Name: "entry_2021_02", DetachedAt: time.Now()},
+		{Name: "entry_2021_03"},
+	}
+
+	t.Run("should export and drop detached partitions", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "partitions")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			ListEntryPartitionsFunc: func(ctx context.Context) ([]vos.EntryPartition, error) {
+				return partitions, nil
+			},
+			ExportEntryPartitionFunc: func(ctx context.Context, name string, w io.Writer) error {
+				_, err := io.WriteString(w, "id,tx_id\n")
+				return err
+			},
+			DropEntryPartitionFunc: func(ctx context.Context, name string, archive string) error {
+				return nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.ArchivePartitions(context.Background(), dir)
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+
+		archive := filepath.Join(dir, "entry_2021_02.csv.gz")
+		assert.Equal(t, archive, got[0].Archive)
+		assert.True(t, got[0].Archived())
+
+		drops := mockedRepository.DropEntryPartitionCalls()
+		assert.Len(t, drops, 1)
+		assert.Equal(t, "entry_2021_02", drops[0].S1)
+		assert.Equal(t, archive, drops[0].S2)
+
+		file, err := os.Open(archive)
+		require.NoError(t, err)
+		defer file.Close()
+
+		zr, err := gzip.NewReader(file)
+		require.NoError(t, err)
+
+		content, err := ioutil.ReadAll(zr)
+		assert.NoError(t, err)
+		assert.Equal(t, "id,tx_id\n", string(content))
+	})
+
+	t.Run("should keep the partition when the export fails", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "partitions")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		exportErr := errors.New("connection lost")
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			ListEntryPartitionsFunc: func(ctx context.Context) ([]vos.EntryPartition, error) {
+				return partitions, nil
+			},
+			ExportEntryPartitionFunc: func(ctx context.Context, name string, w io.Writer) error {
+				return exportErr
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err = usecase.ArchivePartitions(context.Background(), dir)
+		assert.ErrorIs(t, err, exportErr)
+		assert.Empty(t, mockedRepository.DropEntryPartitionCalls())
+
+		files, err := ioutil.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
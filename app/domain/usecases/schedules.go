@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// CreateSchedule saves a schedule built by vos.NewSchedule, once its transaction is known to be valid.
+func (a *AdminUseCase) CreateSchedule(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.CreateSchedule")
+	defer segment.End()
+
+	if _, err := scheduledTransaction(schedule); err != nil {
+		return vos.Schedule{}, err
+	}
+
+	created, err := a.repository.CreateSchedule(ctx, schedule)
+	if err != nil {
+		return vos.Schedule{}, fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	return created, nil
+}
+
+func (a *AdminUseCase) ListSchedules(ctx context.Context, req vos.ScheduleListRequest) (vos.ScheduleListResponse, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ListSchedules")
+	defer segment.End()
+
+	schedules, nextPage, err := a.repository.ListSchedules(ctx, req)
+	if err != nil {
+		return vos.ScheduleListResponse{}, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	return vos.ScheduleListResponse{
+		Schedules: schedules,
+		NextPage:  nextPage,
+	}, nil
+}
+
+func (a *AdminUseCase) PauseSchedule(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.PauseSchedule")
+	defer segment.End()
+
+	return a.changeSchedule(ctx, id, (*vos.Schedule).Pause)
+}
+
+func (a *AdminUseCase) ResumeSchedule(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.ResumeSchedule")
+	defer segment.End()
+
+	return a.changeSchedule(ctx, id, func(schedule *vos.Schedule) error {
+		return schedule.Resume(time.Now())
+	})
+}
+
+func (a *AdminUseCase) CancelSchedule(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.CancelSchedule")
+	defer segment.End()
+
+	return a.changeSchedule(ctx, id, (*vos.Schedule).Cancel)
+}
+
+// changeSchedule applies change to the schedule and saves it. It fails with app.ErrScheduleConflict
+// if the schedule was changed in between, such as by the worker posting its transaction.
+func (a *AdminUseCase) changeSchedule(ctx context.Context, id uuid.UUID, change func(*vos.Schedule) error) (vos.Schedule, error) {
+	schedule, err := a.repository.GetSchedule(ctx, id)
+	if err != nil {
+		return vos.Schedule{}, fmt.Errorf("failed to get schedule %s: %w", id, err)
+	}
+
+	if err = change(&schedule); err != nil {
+		return vos.Schedule{}, err
+	}
+
+	schedule, err = a.repository.UpdateSchedule(ctx, schedule)
+	if err != nil {
+		return vos.Schedule{}, fmt.Errorf("failed to update schedule %s: %w", id, err)
+	}
+
+	return schedule, nil
+}
+
+// RunSchedules posts the transactions of the due schedules, up to limit schedules per run. Replicas
+// share the schedules, so only the one holding the schedules lock runs them, and the others return
+// an empty report. Occurrences missed while no replica ran are posted one after the other.
+//
+// Transactions have ids derived from their schedule and occurrence, so an occurrence posted by a run
+// that failed before saving its schedule hits the idempotency keys on the next run and is only saved.
+// Schedules whose transaction fails keep the error and are retried on every run until they are paused
+// or canceled.
+func (a *AdminUseCase) RunSchedules(ctx context.Context, limit int) (vos.ScheduleReport, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.RunSchedules")
+	defer segment.End()
+
+	if a.ledger == nil {
+		return vos.ScheduleReport{}, errors.New("schedules need a ledger to post their transactions")
+	}
+
+	unlock, locked, err := a.repository.LockSchedules(ctx)
+	if err != nil {
+		return vos.ScheduleReport{}, fmt.Errorf("failed to lock schedules: %w", err)
+	}
+
+	if !locked {
+		return vos.ScheduleReport{}, nil
+	}
+
+	defer unlock()
+
+	now := time.Now()
+
+	schedules, err := a.repository.ListDueSchedules(ctx, now, limit)
+	if err != nil {
+		return vos.ScheduleReport{}, fmt.Errorf("failed to list due schedules: %w", err)
+	}
+
+	var report vos.ScheduleReport
+
+	for _, schedule := range schedules {
+		posted, err := a.runSchedule(ctx, schedule, now)
+		report.Posted += posted
+
+		if err != nil {
+			report.Failed++
+			a.instrumentator.FailedSchedule(ctx, schedule, err)
+		}
+	}
+
+	a.instrumentator.RanSchedules(ctx, report)
+
+	return report, nil
+}
+
+// runSchedule posts the due occurrences of the schedule, saving it after each one.
+func (a *AdminUseCase) runSchedule(ctx context.Context, schedule vos.Schedule, now time.Time) (int, error) {
+	ctx = vos.WithBook(ctx, schedule.Book)
+	posted := 0
+
+	for schedule.Due(now) {
+		tx, err := scheduledTransaction(schedule)
+		if err == nil {
+			_, err = a.ledger.CreateTransaction(ctx, tx)
+		}
+
+		if err != nil && !errors.Is(err, app.ErrIdempotencyKeyViolation) {
+			schedule.LastError = err.Error()
+			if _, saveErr := a.repository.UpdateSchedule(ctx, schedule); saveErr != nil {
+				return posted, fmt.Errorf("failed to save the error of schedule %s: %w", schedule.ID, saveErr)
+			}
+
+			return posted, err
+		}
+
+		if err == nil {
+			posted++
+		}
+
+		schedule.Advance()
+
+		updated, err := a.repository.UpdateSchedule(ctx, schedule)
+		if err != nil {
+			return posted, fmt.Errorf("failed to update schedule %s: %w", schedule.ID, err)
+		}
+
+		schedule = updated
+	}
+
+	return posted, nil
+}
+
+// scheduledTransaction builds the transaction of the next occurrence of the schedule. Its id derives
+// from the schedule and occurrence, and the ids of its entries from its id.
+func scheduledTransaction(schedule vos.Schedule) (entities.Transaction, error) {
+	id := uuid.NewSHA1(schedule.ID, []byte(strconv.Itoa(schedule.Occurrence)))
+
+	entries := make([]entities.Entry, 0, len(schedule.Entries))
+	for i, e := range schedule.Entries {
+		entry, err := entities.NewEntry(uuid.NewSHA1(id, []byte(strconv.Itoa(i))), e.Operation, e.Account, e.Version, e.Amount, e.Metadata)
+		if err != nil {
+			return entities.Transaction{}, fmt.Errorf("invalid entry %d: %w", i, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entities.NewTransaction(id, schedule.Event, schedule.Company, schedule.NextRunAt, entries...)
+}
@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func newTestSchedule(start time.Time, recurrence vos.Recurrence) vos.Schedule {
+	return vos.Schedule{
+		ID:      uuid.New(),
+		Book:    vos.DefaultBook,
+		Company: "abc",
+		Event:   1,
+		Entries: []vos.ScheduledEntry{
+			{Operation: vos.DebitOperation, Account: "liability.abc.account1", Version: vos.NextAccountVersion, Amount: 100},
+			{Operation: vos.CreditOperation, Account: "liability.abc.account2", Version: vos.NextAccountVersion, Amount: 100},
+		},
+		StartAt:    start,
+		Recurrence: recurrence,
+		Status:     vos.ActiveSchedule,
+		NextRunAt:  start,
+		Revision:   1,
+	}
+}
+
+func TestAdminUseCase_CreateSchedule(t *testing.T) {
+	t.Run("should create the schedule", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(time.Hour), vos.Recurrence{})
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			CreateScheduleFunc: func(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+				return schedule, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.CreateSchedule(context.Background(), schedule)
+		assert.NoError(t, err)
+		assert.Equal(t, schedule, got)
+		assert.Len(t, mockedRepository.CreateScheduleCalls(), 1)
+	})
+
+	t.Run("should reject unbalanced transactions", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(time.Hour), vos.Recurrence{})
+		schedule.Entries[1].Amount = 50
+
+		mockedRepository := &mocks.AdminRepositoryMock{}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CreateSchedule(context.Background(), schedule)
+		assert.ErrorIs(t, err, app.ErrInvalidBalance)
+		assert.Empty(t, mockedRepository.CreateScheduleCalls())
+	})
+}
+
+func TestAdminUseCase_ChangeSchedule(t *testing.T) {
+	newRepository := func(schedule vos.Schedule) *mocks.AdminRepositoryMock {
+		return &mocks.AdminRepositoryMock{
+			GetScheduleFunc: func(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+				if id != schedule.ID {
+					return vos.Schedule{}, app.ErrScheduleNotFound
+				}
+
+				return schedule, nil
+			},
+			UpdateScheduleFunc: func(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+				schedule.Revision++
+				return schedule, nil
+			},
+		}
+	}
+
+	t.Run("should pause, resume and cancel the schedule", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(time.Hour), vos.Recurrence{})
+		usecase := NewAdminUseCase(newRepository(schedule), &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.PauseSchedule(context.Background(), schedule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vos.PausedSchedule, got.Status)
+		assert.Equal(t, 2, got.Revision)
+
+		schedule.Status = vos.PausedSchedule
+		usecase = NewAdminUseCase(newRepository(schedule), &instrumentators.LedgerInstrumentator{})
+
+		got, err = usecase.ResumeSchedule(context.Background(), schedule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vos.ActiveSchedule, got.Status)
+
+		got, err = usecase.CancelSchedule(context.Background(), schedule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, vos.CanceledSchedule, got.Status)
+	})
+
+	t.Run("should not change finished schedules", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(time.Hour), vos.Recurrence{})
+		schedule.Status = vos.CompletedSchedule
+
+		mockedRepository := newRepository(schedule)
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.CancelSchedule(context.Background(), schedule.ID)
+		assert.ErrorIs(t, err, app.ErrInvalidScheduleStatus)
+		assert.Empty(t, mockedRepository.UpdateScheduleCalls())
+	})
+
+	t.Run("should return not found for unknown schedules", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(time.Hour), vos.Recurrence{})
+		usecase := NewAdminUseCase(newRepository(schedule), &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.PauseSchedule(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, app.ErrScheduleNotFound)
+	})
+}
+
+func TestAdminUseCase_RunSchedules(t *testing.T) {
+	newRepository := func(locked bool, schedules ...vos.Schedule) *mocks.AdminRepositoryMock {
+		return &mocks.AdminRepositoryMock{
+			LockSchedulesFunc: func(ctx context.Context) (func(), bool, error) {
+				return func() {}, locked, nil
+			},
+			ListDueSchedulesFunc: func(ctx context.Context, now time.Time, limit int) ([]vos.Schedule, error) {
+				return schedules, nil
+			},
+			UpdateScheduleFunc: func(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+				schedule.Revision++
+				return schedule, nil
+			},
+		}
+	}
+
+	newLedger := func(err error) *mocks.UseCaseMock {
+		return &mocks.UseCaseMock{
+			CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+				return vos.TransactionResult{}, err
+			},
+		}
+	}
+
+	t.Run("should post the due occurrences and advance the schedules", func(t *testing.T) {
+		start := time.Now().Add(-36 * time.Hour)
+		schedule := newTestSchedule(start, vos.Recurrence{Frequency: vos.Daily, Interval: 1, Count: 5})
+
+		mockedRepository := newRepository(true, schedule)
+		ledger := newLedger(nil)
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(ledger)
+
+		got, err := usecase.RunSchedules(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, vos.ScheduleReport{Posted: 2}, got)
+
+		transactions := ledger.CreateTransactionCalls()
+		require.Len(t, transactions, 2)
+		assert.Equal(t, start, transactions[0].Transaction.CompetenceDate)
+		assert.Equal(t, start.AddDate(0, 0, 1), transactions[1].Transaction.CompetenceDate)
+		assert.NotEqual(t, transactions[0].Transaction.ID, transactions[1].Transaction.ID)
+		assert.Equal(t, vos.DefaultBook, vos.BookFromContext(transactions[0].ContextMoqParam))
+
+		updates := mockedRepository.UpdateScheduleCalls()
+		require.Len(t, updates, 2)
+		assert.Equal(t, 2, updates[1].Schedule.Occurrence)
+		assert.Equal(t, 2, updates[1].Schedule.Revision)
+		assert.Equal(t, start.AddDate(0, 0, 2), updates[1].Schedule.NextRunAt)
+	})
+
+	t.Run("should post the same transaction ids for the same occurrence", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(-time.Minute), vos.Recurrence{})
+
+		ledger := newLedger(nil)
+		usecase := NewAdminUseCase(newRepository(true, schedule), &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(ledger)
+
+		_, err := usecase.RunSchedules(context.Background(), 10)
+		require.NoError(t, err)
+		_, err = usecase.RunSchedules(context.Background(), 10)
+		require.NoError(t, err)
+
+		transactions := ledger.CreateTransactionCalls()
+		require.Len(t, transactions, 2)
+		assert.Equal(t, transactions[0].Transaction.ID, transactions[1].Transaction.ID)
+		assert.Equal(t, transactions[0].Transaction.Entries[0].ID, transactions[1].Transaction.Entries[0].ID)
+	})
+
+	t.Run("should advance occurrences that were already posted", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(-time.Minute), vos.Recurrence{})
+
+		mockedRepository := newRepository(true, schedule)
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(newLedger(app.ErrIdempotencyKeyViolation))
+
+		got, err := usecase.RunSchedules(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, vos.ScheduleReport{}, got)
+
+		updates := mockedRepository.UpdateScheduleCalls()
+		require.Len(t, updates, 1)
+		assert.Equal(t, vos.CompletedSchedule, updates[0].Schedule.Status)
+	})
+
+	t.Run("should keep the error of failed schedules", func(t *testing.T) {
+		schedule := newTestSchedule(time.Now().Add(-time.Minute), vos.Recurrence{})
+
+		mockedRepository := newRepository(true, schedule)
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(newLedger(app.ErrInvalidVersion))
+
+		got, err := usecase.RunSchedules(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, vos.ScheduleReport{Failed: 1}, got)
+
+		updates := mockedRepository.UpdateScheduleCalls()
+		require.Len(t, updates, 1)
+		assert.Equal(t, vos.ActiveSchedule, updates[0].Schedule.Status)
+		assert.Equal(t, 0, updates[0].Schedule.Occurrence)
+		assert.Contains(t, updates[0].Schedule.LastError, app.ErrInvalidVersion.Error())
+	})
+
+	t.Run("should not run without the lock", func(t *testing.T) {
+		mockedRepository := newRepository(false)
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(newLedger(nil))
+
+		got, err := usecase.RunSchedules(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, vos.ScheduleReport{}, got)
+		assert.Empty(t, mockedRepository.ListDueSchedulesCalls())
+	})
+
+	t.Run("should return lock errors", func(t *testing.T) {
+		lockErr := errors.New("connection refused")
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			LockSchedulesFunc: func(ctx context.Context) (func(), bool, error) {
+				return nil, false, lockErr
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+		usecase.SetLedger(newLedger(nil))
+
+		_, err := usecase.RunSchedules(context.Background(), 10)
+		assert.ErrorIs(t, err, lockErr)
+	})
+}
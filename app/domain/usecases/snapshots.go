@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func (a *AdminUseCase) RebuildSnapshots(ctx context.Context, account vos.Account) (int, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.RebuildSnapshots")
+	defer segment.End()
+
+	total, err := a.repository.RebuildSnapshots(ctx, account)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild snapshots of %s: %w", account.Value(), err)
+	}
+
+	a.instrumentator.MaintainedSnapshots(ctx, "rebuild", total)
+
+	return total, nil
+}
+
+func (a *AdminUseCase) PruneSnapshots(ctx context.Context, retention time.Duration) (int, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.PruneSnapshots")
+	defer segment.End()
+
+	if retention <= 0 {
+		return 0, app.ErrInvalidSnapshotRetention
+	}
+
+	total, err := a.repository.PruneSnapshots(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+
+	a.instrumentator.MaintainedSnapshots(ctx, "prune", total)
+
+	return total, nil
+}
+
+func (a *AdminUseCase) PrecomputeSnapshots(ctx context.Context, limit int) (int, error) {
+	ctx, segment := a.instrumentator.MonitorSegment(ctx, "AdminUseCase.PrecomputeSnapshots")
+	defer segment.End()
+
+	if limit <= 0 {
+		return 0, app.ErrInvalidSnapshotLimit
+	}
+
+	total, err := a.repository.PrecomputeSnapshots(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to precompute snapshots: %w", err)
+	}
+
+	a.instrumentator.MaintainedSnapshots(ctx, "precompute", total)
+
+	return total, nil
+}
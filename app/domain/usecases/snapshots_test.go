@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+)
+
+func TestAdminUseCase_RebuildSnapshots(t *testing.T) {
+	account, err := vos.NewAccount("liability.clients.*")
+	assert.NoError(t, err)
+
+	t.Run("should rebuild the snapshots of the account", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			RebuildSnapshotsFunc: func(ctx context.Context, account vos.Account) (int, error) {
+				return 3, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.RebuildSnapshots(context.Background(), account)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, got)
+
+		calls := mockedRepository.RebuildSnapshotsCalls()
+		assert.Len(t, calls, 1)
+		assert.Equal(t, account, calls[0].Account)
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		rebuildErr := errors.New("rebuild failed")
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			RebuildSnapshotsFunc: func(ctx context.Context, account vos.Account) (int, error) {
+				return 0, rebuildErr
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.RebuildSnapshots(context.Background(), account)
+		assert.ErrorIs(t, err, rebuildErr)
+	})
+}
+
+func TestAdminUseCase_PruneSnapshots(t *testing.T) {
+	t.Run("should prune snapshots not read within the retention", func(t *testing.T) {
+		retention := 24 * time.Hour
+
+		mockedRepository := &mocks.AdminRepositoryMock{
+			PruneSnapshotsFunc: func(ctx context.Context, unreadSince time.Time) (int, error) {
+				return 5, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		before := time.Now()
+		got, err := usecase.PruneSnapshots(context.Background(), retention)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, got)
+
+		calls := mockedRepository.PruneSnapshotsCalls()
+		assert.Len(t, calls, 1)
+		assert.WithinDuration(t, before.Add(-retention), calls[0].TimeMoqParam, time.Second)
+	})
+
+	t.Run("should reject a non positive retention", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.PruneSnapshots(context.Background(), 0)
+		assert.ErrorIs(t, err, app.ErrInvalidSnapshotRetention)
+		assert.Empty(t, mockedRepository.PruneSnapshotsCalls())
+	})
+}
+
+func TestAdminUseCase_PrecomputeSnapshots(t *testing.T) {
+	t.Run("should precompute the hottest snapshots", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{
+			PrecomputeSnapshotsFunc: func(ctx context.Context, limit int) (int, error) {
+				return 2, nil
+			},
+		}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		got, err := usecase.PrecomputeSnapshots(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, got)
+
+		calls := mockedRepository.PrecomputeSnapshotsCalls()
+		assert.Len(t, calls, 1)
+		assert.Equal(t, 10, calls[0].N)
+	})
+
+	t.Run("should reject a non positive limit", func(t *testing.T) {
+		mockedRepository := &mocks.AdminRepositoryMock{}
+		usecase := NewAdminUseCase(mockedRepository, &instrumentators.LedgerInstrumentator{})
+
+		_, err := usecase.PrecomputeSnapshots(context.Background(), 0)
+		assert.ErrorIs(t, err, app.ErrInvalidSnapshotLimit)
+		assert.Empty(t, mockedRepository.PrecomputeSnapshotsCalls())
+	})
+}
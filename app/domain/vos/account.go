@@ -12,10 +12,13 @@ import (
 // 'foo.', '.foo', 'foo..bar' are all invalid. Also, each label has a maximum size of 255 characters with
 // a maximum number of total labels of 65535.
 //
-// When the account represents a group, it has a more flexible syntax, allowing a wildcard '*' in a label,
-// which follows the behavior described in the Postgres docs (https://www.postgresql.org/docs/current/ltree.html).
+// When the account represents a group, its value is a Postgres lquery (https://www.postgresql.org/docs/current/ltree.html),
+// limited to the subset described in newQuery: wildcards ('*', '*{1,2}'), prefixes ('foo*'), alternatives
+// ('a|b', also written '(a|b)') and negations ('!a').
 //
-// The fist label if a given account is called 'class', and it can only be one of the following:
+// The fist label if a given account is called 'class', and the accounts receiving entries can only
+// have one of the classes of the chart of accounts (see Chart), which is checked when they are
+// posted. By default, they are:
 //  - liability
 //  - asset
 //  - revenue
@@ -30,9 +33,11 @@ import (
 //  - liability.available.96a131a8_c4ac_495e_8971_fcecdbdd003a.some_detail
 //  - liability.clients.available.96a131a8_c4ac_495e_8971_fcecdbdd003a.detail1.detail2
 //  - asset.*.treasury
+//  - liability.clients.available|blocked.*
 type Account struct {
 	accountType AccountType
 	value       string
+	search      AccountSearch
 }
 
 func (a Account) Value() string {
@@ -43,6 +48,25 @@ func (a Account) Type() AccountType {
 	return a.accountType
 }
 
+// Search returns the search that narrows the accounts matched by a, which is empty unless set by
+// WithSearch.
+func (a Account) Search() AccountSearch {
+	return a.search
+}
+
+// WithSearch narrows a to the accounts that also match search. As it may match several accounts, the
+// result is always synthetic.
+func (a Account) WithSearch(search AccountSearch) Account {
+	if search.IsEmpty() {
+		return a
+	}
+
+	a.accountType = Synthetic
+	a.search = search
+
+	return a
+}
+
 // AccountType indicates what the given account represents, being either analytic or a synthetic.
 type AccountType uint8
 
@@ -51,17 +75,6 @@ const (
 	Synthetic
 )
 
-// Available classes
-const (
-	asset            = "asset"
-	conciliateCredit = "conciliate_credit"
-	conciliateDebit  = "conciliate_debit"
-	equity           = "equity"
-	expense          = "expense"
-	liability        = "liability"
-	revenue          = "revenue"
-)
-
 // Symbols
 const (
 	lowerLetterStart = 'a'
@@ -75,6 +88,9 @@ const (
 	star             = '*'
 )
 
+// queryOperators are the symbols of the lquery syntax, which make an account synthetic.
+const queryOperators = "*|!(){},"
+
 // Limits
 const (
 	maxLabelSize  uint = 256
@@ -82,11 +98,9 @@ const (
 )
 
 type state struct {
-	totalComponents  uint
-	componentSize    uint
-	strategy         AccountType
-	needsLower       bool
-	componentHasStar bool
+	totalComponents uint
+	componentSize   uint
+	needsLower      bool
 }
 
 // NewAnalyticAccount creates a new valid Account, which can only be of analytic type.
@@ -104,7 +118,7 @@ func newAccount(account string, analyticOnly bool) (Account, error) {
 		return Account{}, app.ErrInvalidAccountStructure
 	}
 
-	st := &state{strategy: Analytic}
+	st := &state{}
 
 	var (
 		r   rune
@@ -122,13 +136,13 @@ func newAccount(account string, analyticOnly bool) (Account, error) {
 		case r == underscore:
 			st.componentSize += 1
 		case r == dot:
-			err = treatDot(account, st)
-		case r == star:
+			err = treatDot(st)
+		case strings.ContainsRune(queryOperators, r):
 			if analyticOnly {
-				err = app.ErrInvalidSingleAccountComponentCharacters
-				break
+				return Account{}, app.ErrInvalidSingleAccountComponentCharacters
 			}
-			err = treatStar(st)
+
+			return newQuery(account)
 		default:
 			err = app.ErrInvalidAccountComponentCharacters
 		}
@@ -142,59 +156,29 @@ func newAccount(account string, analyticOnly bool) (Account, error) {
 		return Account{}, app.ErrInvalidAccountComponentSize
 	}
 
-	if st.totalComponents == 0 && !st.componentHasStar {
-		switch account[:st.componentSize] {
-		case asset, conciliateCredit, conciliateDebit, equity, expense, revenue, liability:
-		default:
-			return Account{}, app.ErrAccountPathViolation
-		}
-	} else if st.totalComponents < 2 && st.strategy != Synthetic {
-		return Account{}, app.ErrInvalidAccountStructure
-	}
-
 	if st.needsLower {
 		account = lowerAccount(account)
 	}
 
 	return Account{
 		value:       account,
-		accountType: st.strategy,
+		accountType: Analytic,
 	}, nil
 }
 
-func treatDot(account string, st *state) error {
+func treatDot(st *state) error {
 	// Check if the current component is empty or greater than maximum.
 	if st.componentSize == 0 || st.componentSize > maxLabelSize {
 		return app.ErrInvalidAccountComponentSize
 	}
 
-	// Checks if the account has a valid class and if number of components is greater than maximum.
-	if st.totalComponents == 0 && !st.componentHasStar {
-		switch account[:st.componentSize] {
-		case asset, conciliateCredit, conciliateDebit, equity, expense, revenue, liability:
-		default:
-			return app.ErrAccountPathViolation
-		}
-	} else if st.totalComponents >= maxComponents {
+	// Checks if number of components is greater than maximum.
+	if st.totalComponents >= maxComponents {
 		return app.ErrInvalidAccountStructure
 	}
 
 	st.totalComponents += 1
 	st.componentSize = 0
-	st.componentHasStar = false
-
-	return nil
-}
-
-func treatStar(st *state) error {
-	// Checks is the current component already have a '*'.
-	if st.componentHasStar {
-		return app.ErrInvalidAccountStructure
-	}
-
-	st.strategy = Synthetic
-	st.componentSize += 1
-	st.componentHasStar = true
 
 	return nil
 }
@@ -9,35 +9,50 @@ import (
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
 )
 
+// AccountEntryRequest lists the entries of an analytic account, newest first by competence date and
+// version, or of every account matched by a synthetic one, newest first by competence date, creation
+// time and id, since versions aren't comparable across accounts.
 type AccountEntryRequest struct {
 	Account   Account
 	StartDate time.Time
 	EndDate   time.Time
 	Filter    AccountEntryFilter
 	Page      pagination.Page
+	// IncludeOpeningBalance adds the balance before StartDate, computed like the balances of the
+	// entries from the current balance of the account less the entries after it.
+	IncludeOpeningBalance bool
 }
 
 type AccountEntryFilter struct {
 	Companies []string
 	Events    []int32
 	Operation OperationType
+	Metadata  MetadataFilter
 }
 
-func NewEntryFilter(filter *proto.ListAccountEntriesRequest_Filter) AccountEntryFilter {
+func NewEntryFilter(filter *proto.ListAccountEntriesRequest_Filter) (AccountEntryFilter, error) {
 	if filter == nil {
-		return AccountEntryFilter{}
+		return AccountEntryFilter{}, nil
+	}
+
+	metadata, err := NewMetadataFilter(filter.MetadataContains, filter.MetadataKeys, filter.MetadataEquals)
+	if err != nil {
+		return AccountEntryFilter{}, err
 	}
 
 	return AccountEntryFilter{
 		Companies: filter.Companies,
 		Events:    filter.Events,
 		Operation: OperationType(proto.Operation_value[filter.Operation.String()]),
-	}
+		Metadata:  metadata,
+	}, nil
 }
 
 type AccountEntryResponse struct {
 	Entries  []AccountEntry
 	NextPage pagination.Cursor
+	// OpeningBalance is the balance before the start of the period, set when requested.
+	OpeningBalance *int
 }
 
 type AccountEntry struct {
@@ -48,4 +63,11 @@ type AccountEntry struct {
 	Event          int
 	CompetenceDate time.Time
 	Metadata       map[string]interface{}
+	Account        Account
+	CreatedAt      time.Time
+	TxID           uuid.UUID
+	Company        string
+	// Balance is the balance of the account right after the entry, in the order of the history. It's
+	// the current balance of the account less the entries after it.
+	Balance int
 }
@@ -0,0 +1,103 @@
+package vos
+
+import (
+	"strings"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+// AccountPrefix is a node of the account hierarchy: the first labels of some accounts, without
+// wildcards. The empty prefix is the root, whose children are the account classes.
+type AccountPrefix struct {
+	value string
+}
+
+// NewAccountPrefix validates the labels of prefix as the labels of an account, lowering them.
+func NewAccountPrefix(prefix string) (AccountPrefix, error) {
+	if prefix == "" {
+		return AccountPrefix{}, nil
+	}
+
+	if strings.ContainsAny(prefix, queryOperators) {
+		return AccountPrefix{}, app.ErrInvalidAccountComponentCharacters
+	}
+
+	query, err := NewAccount(prefix + ".*")
+	if err != nil {
+		return AccountPrefix{}, err
+	}
+
+	return AccountPrefix{value: strings.TrimSuffix(query.Value(), ".*")}, nil
+}
+
+func (p AccountPrefix) Value() string {
+	return p.value
+}
+
+// Depth is the number of labels of the prefix.
+func (p AccountPrefix) Depth() int {
+	if p.value == "" {
+		return 0
+	}
+
+	return strings.Count(p.value, string(dot)) + 1
+}
+
+// Child returns the prefix one label below p.
+func (p AccountPrefix) Child(label string) AccountPrefix {
+	if p.value == "" {
+		return AccountPrefix{value: label}
+	}
+
+	return AccountPrefix{value: p.value + string(dot) + label}
+}
+
+// Query returns the synthetic account of every account under the prefix, the prefix included.
+func (p AccountPrefix) Query() Account {
+	if p.value == "" {
+		return Account{value: string(star), accountType: Synthetic}
+	}
+
+	return Account{value: p.value + string(dot) + string(star), accountType: Synthetic}
+}
+
+// AccountListRequest lists the accounts matching Pattern, which is an analytic account or an
+// account query.
+type AccountListRequest struct {
+	Pattern Account
+	Page    pagination.Page
+}
+
+type AccountListResponse struct {
+	Accounts []AccountSummary
+	NextPage pagination.Cursor
+}
+
+// AccountSummary is an account that has entries, created when its first entry was.
+type AccountSummary struct {
+	Account   Account
+	CreatedAt time.Time
+}
+
+// AccountChildrenRequest lists the nodes one level below Prefix, along with the balance of the
+// accounts under each of them when IncludeBalances is set.
+type AccountChildrenRequest struct {
+	Prefix          AccountPrefix
+	IncludeBalances bool
+	Page            pagination.Page
+}
+
+type AccountChildrenResponse struct {
+	Children []AccountChild
+	NextPage pagination.Cursor
+}
+
+// AccountChild is a node of the account hierarchy, with the number of accounts under it (itself
+// included, when it is an account). Balance is only set when requested.
+type AccountChild struct {
+	Prefix   AccountPrefix
+	Accounts int
+	Balance  *AccountBalance
+}
@@ -0,0 +1,48 @@
+package vos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestNewAccountPrefix(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prefix        string
+		expected      string
+		expectedDepth int
+		expectedQuery string
+		expectedErr   error
+	}{
+		{name: "empty prefix is the root", prefix: "", expected: "", expectedDepth: 0, expectedQuery: "*"},
+		{name: "class", prefix: "liability", expected: "liability", expectedDepth: 1, expectedQuery: "liability.*"},
+		{name: "two labels", prefix: "liability.Clients", expected: "liability.clients", expectedDepth: 2, expectedQuery: "liability.clients.*"},
+		{name: "class out of the chart", prefix: "foo.bar", expected: "foo.bar", expectedDepth: 2, expectedQuery: "foo.bar.*"},
+		{name: "wildcard", prefix: "liability.*", expectedErr: app.ErrInvalidAccountComponentCharacters},
+		{name: "empty label", prefix: "liability..clients", expectedErr: app.ErrInvalidAccountComponentSize},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAccountPrefix(tt.prefix)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			assert.Equal(t, tt.expected, got.Value())
+			assert.Equal(t, tt.expectedDepth, got.Depth())
+			assert.Equal(t, tt.expectedQuery, got.Query().Value())
+			assert.Equal(t, Synthetic, got.Query().Type())
+		})
+	}
+}
+
+func TestAccountPrefix_Child(t *testing.T) {
+	root := AccountPrefix{}
+	assert.Equal(t, "asset", root.Child("asset").Value())
+	assert.Equal(t, "asset.bank", root.Child("asset").Child("bank").Value())
+}
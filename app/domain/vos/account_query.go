@@ -0,0 +1,228 @@
+package vos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// Query symbols
+const (
+	negation        = '!'
+	alternative     = '|'
+	groupStart      = '('
+	groupEnd        = ')'
+	quantifierStart = '{'
+	quantifierEnd   = '}'
+	quantifierSplit = ','
+)
+
+// unboundedLabels is the upper bound of the quantifiers without one, which is the Postgres limit.
+const unboundedLabels = maxComponents
+
+// queryLevel is a level of an lquery: either a wildcard, matching from low to high labels, or a
+// single label matching (or, when negated, not matching) one of the variants.
+type queryLevel struct {
+	wildcard bool
+	low      uint
+	high     uint
+	negated  bool
+	variants []queryVariant
+}
+
+// queryVariant matches a label equal to label or, for prefixes, starting with it.
+type queryVariant struct {
+	label  string
+	prefix bool
+}
+
+// newQuery parses a synthetic account, which is the subset of the lquery syntax whose levels are:
+//   - '*', any number of labels, or '*{n}', '*{n,}', '*{,m}' and '*{n,m}', from n to m labels;
+//   - 'foo', the label foo, or 'foo*', a label starting with foo;
+//   - 'foo|bar', either label, which may also be written '(foo|bar)';
+//   - '!foo|bar', a label that is neither.
+//
+// The value is written the way Postgres writes lquery values, since it keys the balance snapshots.
+func newQuery(account string) (Account, error) {
+	levels := strings.Split(account, string(dot))
+	if uint(len(levels)) > maxComponents {
+		return Account{}, app.ErrInvalidAccountStructure
+	}
+
+	values := make([]string, 0, len(levels))
+
+	for _, value := range levels {
+		level, err := parseQueryLevel(value)
+		if err != nil {
+			return Account{}, err
+		}
+
+		values = append(values, level.String())
+	}
+
+	return Account{
+		value:       strings.Join(values, string(dot)),
+		accountType: Synthetic,
+	}, nil
+}
+
+func parseQueryLevel(value string) (queryLevel, error) {
+	if value == "" {
+		return queryLevel{}, app.ErrInvalidAccountComponentSize
+	}
+
+	if value[0] == star {
+		low, high, err := parseQuantifier(value[1:])
+		if err != nil {
+			return queryLevel{}, err
+		}
+
+		return queryLevel{wildcard: true, low: low, high: high}, nil
+	}
+
+	var level queryLevel
+	if value[0] == negation {
+		level.negated = true
+		value = value[1:]
+	}
+
+	if strings.HasPrefix(value, string(groupStart)) {
+		if !strings.HasSuffix(value, string(groupEnd)) {
+			return queryLevel{}, app.ErrInvalidAccountStructure
+		}
+
+		value = value[1 : len(value)-1]
+	}
+
+	for _, label := range strings.Split(value, string(alternative)) {
+		variant := queryVariant{label: label}
+		if strings.HasSuffix(label, string(star)) {
+			variant.label = label[:len(label)-1]
+			variant.prefix = true
+		}
+
+		if err := checkQueryLabel(variant.label); err != nil {
+			return queryLevel{}, err
+		}
+
+		variant.label = strings.ToLower(variant.label)
+		level.variants = append(level.variants, variant)
+	}
+
+	return level, nil
+}
+
+// parseQuantifier parses the bounds that follow a wildcard, which are any number of labels when
+// there is none.
+func parseQuantifier(value string) (uint, uint, error) {
+	if value == "" {
+		return 0, unboundedLabels, nil
+	}
+
+	if len(value) < 3 || value[0] != quantifierStart || value[len(value)-1] != quantifierEnd {
+		return 0, 0, app.ErrInvalidAccountStructure
+	}
+
+	bounds := strings.Split(value[1:len(value)-1], string(quantifierSplit))
+	if len(bounds) > 2 || (len(bounds) == 1 && bounds[0] == "") {
+		return 0, 0, app.ErrInvalidAccountStructure
+	}
+
+	low, err := parseBound(bounds[0], 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	high := low
+	if len(bounds) == 2 {
+		if high, err = parseBound(bounds[1], unboundedLabels); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if low > high {
+		return 0, 0, app.ErrInvalidAccountStructure
+	}
+
+	return low, high, nil
+}
+
+func parseBound(value string, empty uint) (uint, error) {
+	if value == "" {
+		return empty, nil
+	}
+
+	if value[0] < digitStart || value[0] > digitEnd {
+		return 0, app.ErrInvalidAccountStructure
+	}
+
+	bound, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return 0, app.ErrInvalidAccountStructure
+	}
+
+	return uint(bound), nil
+}
+
+func checkQueryLabel(label string) error {
+	if label == "" || uint(len(label)) > maxLabelSize {
+		return app.ErrInvalidAccountComponentSize
+	}
+
+	for _, r := range label {
+		switch {
+		case r >= lowerLetterStart && r <= lowerLetterEnd,
+			r >= upperLetterStart && r <= upperLetterEnd,
+			r >= digitStart && r <= digitEnd,
+			r == underscore:
+		case strings.ContainsRune(queryOperators, r):
+			return app.ErrInvalidAccountStructure
+		default:
+			return app.ErrInvalidAccountComponentCharacters
+		}
+	}
+
+	return nil
+}
+
+// String writes the level as Postgres does, omitting the bounds of '*' and writing equal bounds once.
+func (l queryLevel) String() string {
+	var b strings.Builder
+
+	if l.wildcard {
+		b.WriteByte(star)
+
+		switch {
+		case l.low == l.high:
+			fmt.Fprintf(&b, "{%d}", l.low)
+		case l.low == 0 && l.high == unboundedLabels:
+		case l.low == 0:
+			fmt.Fprintf(&b, "{,%d}", l.high)
+		case l.high == unboundedLabels:
+			fmt.Fprintf(&b, "{%d,}", l.low)
+		default:
+			fmt.Fprintf(&b, "{%d,%d}", l.low, l.high)
+		}
+
+		return b.String()
+	}
+
+	if l.negated {
+		b.WriteByte(negation)
+	}
+
+	for i, variant := range l.variants {
+		if i > 0 {
+			b.WriteByte(alternative)
+		}
+
+		b.WriteString(variant.label)
+		if variant.prefix {
+			b.WriteByte(star)
+		}
+	}
+
+	return b.String()
+}
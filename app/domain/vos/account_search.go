@@ -0,0 +1,225 @@
+package vos
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// Search operators
+const (
+	searchAnd = '&'
+	searchOr  = '|'
+	searchNot = '!'
+)
+
+// maxSearchDepth bounds the nesting of negations and parentheses, below the limit of the Postgres
+// parser.
+const maxSearchDepth = 16
+
+// AccountSearch is the subset of the Postgres ltxtquery syntax that matches accounts by labels in any
+// position: words are labels, or label prefixes when followed by '*', combined by '!' (not), '&' (and)
+// and '|' (or), which bind in this order, and grouped by parentheses. For example, 'available & !abc*'
+// matches the accounts with an 'available' label and no label starting with 'abc'.
+type AccountSearch struct {
+	value string
+	expr  *searchExpr
+}
+
+// searchExpr is either a word or an operator applied to its operands.
+type searchExpr struct {
+	operator rune
+	word     queryVariant
+	operands []*searchExpr
+}
+
+// NewAccountSearch parses search, lowering its words. The empty search matches every account.
+func NewAccountSearch(search string) (AccountSearch, error) {
+	search = strings.ToLower(strings.TrimSpace(search))
+	if search == "" {
+		return AccountSearch{}, nil
+	}
+
+	p := &searchParser{input: search}
+
+	expr, err := p.or(0)
+	if err == nil && p.next() != 0 {
+		err = fmt.Errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	if err != nil {
+		return AccountSearch{}, fmt.Errorf("%w: %s", app.ErrInvalidAccountSearch, err)
+	}
+
+	return AccountSearch{value: search, expr: expr}, nil
+}
+
+func (s AccountSearch) Value() string {
+	return s.value
+}
+
+func (s AccountSearch) IsEmpty() bool {
+	return s.expr == nil
+}
+
+// Match reports whether the labels of the analytic account satisfy the search.
+func (s AccountSearch) Match(account string) bool {
+	if s.expr == nil {
+		return true
+	}
+
+	return s.expr.match(strings.Split(account, string(dot)))
+}
+
+func (e *searchExpr) match(labels []string) bool {
+	switch e.operator {
+	case searchNot:
+		return !e.operands[0].match(labels)
+	case searchAnd:
+		for _, operand := range e.operands {
+			if !operand.match(labels) {
+				return false
+			}
+		}
+
+		return true
+	case searchOr:
+		for _, operand := range e.operands {
+			if operand.match(labels) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, label := range labels {
+		if label == e.word.label || e.word.prefix && strings.HasPrefix(label, e.word.label) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type searchParser struct {
+	input string
+	pos   int
+}
+
+// next skips the spaces and returns the next symbol, or 0 at the end of the input.
+func (p *searchParser) next() rune {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+
+	if p.pos == len(p.input) {
+		return 0
+	}
+
+	return rune(p.input[p.pos])
+}
+
+func (p *searchParser) or(depth int) (*searchExpr, error) {
+	return p.binary(depth, searchOr, p.and)
+}
+
+func (p *searchParser) and(depth int) (*searchExpr, error) {
+	return p.binary(depth, searchAnd, p.not)
+}
+
+// binary parses the operands of operator, which are parsed by operand.
+func (p *searchParser) binary(depth int, operator rune, operand func(int) (*searchExpr, error)) (*searchExpr, error) {
+	expr, err := operand(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.next() != operator {
+		return expr, nil
+	}
+
+	expr = &searchExpr{operator: operator, operands: []*searchExpr{expr}}
+	for p.next() == operator {
+		p.pos++
+
+		next, err := operand(depth)
+		if err != nil {
+			return nil, err
+		}
+
+		expr.operands = append(expr.operands, next)
+	}
+
+	return expr, nil
+}
+
+func (p *searchParser) not(depth int) (*searchExpr, error) {
+	if depth > maxSearchDepth {
+		return nil, fmt.Errorf("more than %d nested operators", maxSearchDepth)
+	}
+
+	switch p.next() {
+	case searchNot:
+		p.pos++
+
+		operand, err := p.not(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return &searchExpr{operator: searchNot, operands: []*searchExpr{operand}}, nil
+	case groupStart:
+		p.pos++
+
+		expr, err := p.or(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.next() != groupEnd {
+			return nil, fmt.Errorf("missing %q", groupEnd)
+		}
+
+		p.pos++
+
+		return expr, nil
+	}
+
+	return p.word()
+}
+
+func (p *searchParser) word() (*searchExpr, error) {
+	p.next()
+
+	start := p.pos
+	for p.pos < len(p.input) && isLabelChar(p.input[p.pos]) {
+		p.pos++
+	}
+
+	word := queryVariant{label: p.input[start:p.pos]}
+	if word.label == "" {
+		if p.pos == len(p.input) {
+			return nil, fmt.Errorf("missing word at the end")
+		}
+
+		return nil, fmt.Errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	if uint(len(word.label)) > maxLabelSize {
+		return nil, fmt.Errorf("word %s is longer than a label", word.label)
+	}
+
+	if p.pos < len(p.input) && p.input[p.pos] == star {
+		word.prefix = true
+		p.pos++
+	}
+
+	return &searchExpr{word: word}, nil
+}
+
+// isLabelChar reports whether c can be part of a label, which is lowercase.
+func isLabelChar(c byte) bool {
+	return c >= lowerLetterStart && c <= lowerLetterEnd || c >= digitStart && c <= digitEnd || c == underscore
+}
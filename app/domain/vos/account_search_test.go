@@ -0,0 +1,82 @@
+package vos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestNewAccountSearch(t *testing.T) {
+	testCases := []struct {
+		search string
+		value  string
+		err    error
+	}{
+		{search: "", value: ""},
+		{search: " Available ", value: "available"},
+		{search: "available & !abc* | (blocked & x_1)", value: "available & !abc* | (blocked & x_1)"},
+		{search: "!!available", value: "!!available"},
+		{search: "available &", err: app.ErrInvalidAccountSearch},
+		{search: "available blocked", err: app.ErrInvalidAccountSearch},
+		{search: "(available", err: app.ErrInvalidAccountSearch},
+		{search: "available)", err: app.ErrInvalidAccountSearch},
+		{search: "avail*able", err: app.ErrInvalidAccountSearch},
+		{search: "available@", err: app.ErrInvalidAccountSearch},
+		{search: "((((((((((((((((((a))))))))))))))))))", err: app.ErrInvalidAccountSearch},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.search, func(t *testing.T) {
+			got, err := NewAccountSearch(tt.search)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.value, got.Value())
+		})
+	}
+}
+
+func TestAccountSearch_Match(t *testing.T) {
+	const account = "liability.clients.abc.available"
+
+	testCases := []struct {
+		search string
+		want   bool
+	}{
+		{search: "", want: true},
+		{search: "available", want: true},
+		{search: "blocked", want: false},
+		{search: "avail*", want: true},
+		{search: "available & !blocked", want: true},
+		{search: "available & blocked", want: false},
+		{search: "blocked | abc", want: true},
+		{search: "blocked | abc & available", want: true},
+		{search: "(blocked | abc) & !available", want: false},
+		{search: "!(blocked | xyz)", want: true},
+		{search: "liability & clients & ab*", want: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.search, func(t *testing.T) {
+			search, err := NewAccountSearch(tt.search)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, search.Match(account))
+		})
+	}
+}
+
+func TestAccount_WithSearch(t *testing.T) {
+	account, err := NewAccount("liability.clients.abc")
+	require.NoError(t, err)
+
+	assert.Equal(t, account, account.WithSearch(AccountSearch{}))
+
+	search, err := NewAccountSearch("abc")
+	require.NoError(t, err)
+
+	got := account.WithSearch(search)
+	assert.Equal(t, Synthetic, got.Type())
+	assert.Equal(t, "liability.clients.abc", got.Value())
+	assert.Equal(t, search, got.Search())
+}
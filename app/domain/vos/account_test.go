@@ -98,14 +98,104 @@ func TestNewAccount(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name:    "group suffix simple",
+			name:    "wildcard followed by a label",
 			account: "*asset.account",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountStructure,
+		},
+		{
+			name:    "alternatives",
+			account: "liability.clients.Available|blocked.*",
 			want: Account{
-				value:       "*asset.account",
+				value:       "liability.clients.available|blocked.*",
 				accountType: Synthetic,
 			},
 			wantErr: nil,
 		},
+		{
+			name:    "grouped alternatives",
+			account: "liability.clients.(available|blocked*).*",
+			want: Account{
+				value:       "liability.clients.available|blocked*.*",
+				accountType: Synthetic,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "negation",
+			account: "liability.!(clients|bank).*",
+			want: Account{
+				value:       "liability.!clients|bank.*",
+				accountType: Synthetic,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "quantifiers",
+			account: "liability.*{1}.*{0,}.*{,2}.*{2,}.*{1,3}.*{2,2}",
+			want: Account{
+				value:       "liability.*{1}.*.*{,2}.*{2,}.*{1,3}.*{2}",
+				accountType: Synthetic,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "alternative classes",
+			account: "liability|asset.*",
+			want: Account{
+				value:       "liability|asset.*",
+				accountType: Synthetic,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "alternative with a class out of the chart, which is only checked on posting",
+			account: "liability|assets.*",
+			want:    Account{value: "liability|assets.*", accountType: Synthetic},
+			wantErr: nil,
+		},
+		{
+			name:    "empty alternative",
+			account: "liability.clients|.*",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountComponentSize,
+		},
+		{
+			name:    "unbalanced group",
+			account: "liability.(clients|bank.*",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountStructure,
+		},
+		{
+			name:    "inverted quantifier",
+			account: "liability.*{3,1}",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountStructure,
+		},
+		{
+			name:    "quantifier above the limit",
+			account: "liability.*{70000}",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountStructure,
+		},
+		{
+			name:    "quantified label",
+			account: "liability.clients{1,2}",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountStructure,
+		},
+		{
+			name:    "negated wildcard",
+			account: "liability.!*",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountComponentSize,
+		},
+		{
+			name:    "case insensitive modifier",
+			account: "liability.clients@.*",
+			want:    Account{},
+			wantErr: app.ErrInvalidAccountComponentCharacters,
+		},
 		{
 			name:    "empty account",
 			account: "",
@@ -136,6 +226,13 @@ func TestNewAccount(t *testing.T) {
 			want:    Account{},
 			wantErr: app.ErrInvalidAccountComponentCharacters,
 		},
+		{
+			name:       "Analytic should fail with lquery operators",
+			account:    "asset.account|other",
+			singleOnly: true,
+			want:       Account{},
+			wantErr:    app.ErrInvalidSingleAccountComponentCharacters,
+		},
 		{
 			name:       "Analytic should only fail if any '*' is present",
 			account:    "*.account",
@@ -144,10 +241,10 @@ func TestNewAccount(t *testing.T) {
 			wantErr:    app.ErrInvalidSingleAccountComponentCharacters,
 		},
 		{
-			name:    "should fail with invalid class",
+			name:    "should parse a class out of the chart, which is only checked on posting",
 			account: "assets.account",
-			want:    Account{},
-			wantErr: app.ErrAccountPathViolation,
+			want:    Account{value: "assets.account", accountType: Analytic},
+			wantErr: nil,
 		},
 	}
 	for _, tt := range tests {
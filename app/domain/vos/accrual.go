@@ -0,0 +1,210 @@
+package vos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// defaultAccrualBasis is the number of days of the year the rates of the accrual rules are divided
+// by, unless a rule sets its own.
+const defaultAccrualBasis = 365
+
+// AccrualRulesDefinition is the accrual rules file: the rates accrued daily on the end-of-day balances
+// of the accounts matched by each rule, against its counter-account.
+//
+//	{
+//	  "rules": [{
+//	    "name": "savings_interest",
+//	    "accounts": "liability.clients.*.savings",
+//	    "rate": "0.12",
+//	    "basis": 365,
+//	    "event": 9,
+//	    "company": "ledger",
+//	    "counter_account": "expense.interest.savings"
+//	  }]
+//	}
+type AccrualRulesDefinition struct {
+	Rules []AccrualRuleDefinition `json:"rules"`
+}
+
+// AccrualRuleDefinition is a rule of the accrual rules file. Rate is a yearly rate written as a
+// decimal or a fraction, which may be negative for fees, divided by Basis days (365 by default).
+type AccrualRuleDefinition struct {
+	Name           string `json:"name"`
+	Book           string `json:"book"`
+	Accounts       string `json:"accounts"`
+	Rate           string `json:"rate"`
+	Basis          int    `json:"basis"`
+	Event          uint32 `json:"event"`
+	Company        string `json:"company"`
+	CounterAccount string `json:"counter_account"`
+}
+
+// AccrualRule is a validated rule.
+type AccrualRule struct {
+	Name           string
+	Book           string
+	Accounts       Account
+	Rate           *big.Rat
+	Basis          int
+	Event          uint32
+	Company        string
+	CounterAccount Account
+}
+
+// AccrualRules are the validated rules, in the order of their file.
+type AccrualRules struct {
+	rules []AccrualRule
+}
+
+// AccrualRun is the accrual of a rule for a day: the number of accounts it posted to and the sum of
+// their accruals, positive when credited.
+type AccrualRun struct {
+	Rule      string
+	Book      string
+	Date      time.Time
+	Accounts  int
+	Amount    int
+	CreatedAt time.Time
+}
+
+// AccrualRunRequest filters the runs of a rule, or of every rule when Rule is empty, between two
+// days, inclusive.
+type AccrualRunRequest struct {
+	Rule      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// AccrualBackRun is a range of days a rule is yet to accrue, requested by RunAccruals and accrued
+// by the accrual job a few days at a time.
+type AccrualBackRun struct {
+	Rule      string
+	StartDate time.Time
+	EndDate   time.Time
+	CreatedAt time.Time
+}
+
+// NewAccrualRules validates the definition. Rule names must be unique lowercase labels.
+func NewAccrualRules(def AccrualRulesDefinition) (*AccrualRules, error) {
+	rules := &AccrualRules{rules: make([]AccrualRule, 0, len(def.Rules))}
+	names := make(map[string]struct{}, len(def.Rules))
+
+	for _, ruleDef := range def.Rules {
+		if _, ok := names[ruleDef.Name]; ok {
+			return nil, fmt.Errorf("%w: rule %s is defined more than once", app.ErrInvalidAccrualRules, ruleDef.Name)
+		}
+
+		rule, err := newAccrualRule(ruleDef)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %q: %s", app.ErrInvalidAccrualRules, ruleDef.Name, err)
+		}
+
+		names[rule.Name] = struct{}{}
+		rules.rules = append(rules.rules, rule)
+	}
+
+	return rules, nil
+}
+
+func newAccrualRule(def AccrualRuleDefinition) (AccrualRule, error) {
+	if !validLabel(def.Name) {
+		return AccrualRule{}, fmt.Errorf("name must have only lowercase letters, digits and underscores")
+	}
+
+	book, err := NewBookName(def.Book)
+	if err != nil {
+		return AccrualRule{}, err
+	}
+
+	accounts, err := NewAccount(def.Accounts)
+	if err != nil {
+		return AccrualRule{}, fmt.Errorf("invalid accounts: %s", err)
+	}
+
+	rate, ok := new(big.Rat).SetString(def.Rate)
+	if !ok || rate.Sign() == 0 {
+		return AccrualRule{}, fmt.Errorf("rate must be a non zero number")
+	}
+
+	basis := def.Basis
+	if basis == 0 {
+		basis = defaultAccrualBasis
+	}
+
+	if basis < 0 {
+		return AccrualRule{}, fmt.Errorf("basis must be positive")
+	}
+
+	counterAccount, err := NewAnalyticAccount(def.CounterAccount)
+	if err != nil {
+		return AccrualRule{}, fmt.Errorf("invalid counter account: %s", err)
+	}
+
+	if def.Company == "" {
+		return AccrualRule{}, fmt.Errorf("company is required")
+	}
+
+	return AccrualRule{
+		Name:           def.Name,
+		Book:           book,
+		Accounts:       accounts,
+		Rate:           rate,
+		Basis:          basis,
+		Event:          def.Event,
+		Company:        def.Company,
+		CounterAccount: counterAccount,
+	}, nil
+}
+
+// LoadAccrualRules reads an accrual rules file, a json object with the AccrualRulesDefinition.
+func LoadAccrualRules(file string) (*AccrualRules, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accrual rules: %w", err)
+	}
+
+	var def AccrualRulesDefinition
+	if err = json.Unmarshal(content, &def); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode accrual rules: %s", app.ErrInvalidAccrualRules, err)
+	}
+
+	return NewAccrualRules(def)
+}
+
+// Rules returns the rules named name, or every rule when name is empty.
+func (r *AccrualRules) Rules(name string) ([]AccrualRule, error) {
+	if name == "" {
+		return r.rules, nil
+	}
+
+	for _, rule := range r.rules {
+		if rule.Name == name {
+			return []AccrualRule{rule}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", app.ErrAccrualRuleNotFound, name)
+}
+
+// Accrue returns the accrual of a day on an end-of-day balance, rounded half away from zero to cents.
+// It has the sign of the balance times the rate, so positive rates increase balances and negative
+// ones decrease them.
+func (r AccrualRule) Accrue(balance int) int {
+	accrual := new(big.Rat).SetInt64(int64(balance))
+	accrual.Mul(accrual, r.Rate)
+	accrual.Quo(accrual, new(big.Rat).SetInt64(int64(r.Basis)))
+
+	return int(roundRat(accrual, "round").Int64())
+}
+
+// AccrualDay returns the day of t, in UTC, which is how accrual days are stored.
+func AccrualDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,196 @@
+package vos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestNewAccrualRules(t *testing.T) {
+	rule := func(change func(*AccrualRuleDefinition)) AccrualRuleDefinition {
+		def := AccrualRuleDefinition{
+			Name:           "savings_interest",
+			Accounts:       "liability.clients.*.savings",
+			Rate:           "0.12",
+			Event:          9,
+			Company:        "ledger",
+			CounterAccount: "expense.interest.savings",
+		}
+		if change != nil {
+			change(&def)
+		}
+
+		return def
+	}
+
+	testCases := []struct {
+		name string
+		def  AccrualRulesDefinition
+		err  error
+	}{
+		{
+			name: "valid rules",
+			def: AccrualRulesDefinition{Rules: []AccrualRuleDefinition{
+				rule(nil),
+				rule(func(def *AccrualRuleDefinition) {
+					def.Name = "overdraft_fee"
+					def.Rate = "-1/100"
+					def.Basis = 360
+				}),
+			}},
+		},
+		{
+			name: "no rules",
+			def:  AccrualRulesDefinition{},
+		},
+		{
+			name: "duplicated name",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(nil), rule(nil)}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "invalid name",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.Name = "Savings Interest" })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "invalid book",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.Book = "Savings" })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "invalid accounts",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.Accounts = "liability..savings" })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "zero rate",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.Rate = "0" })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "invalid rate",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.Rate = "12%" })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "negative basis",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.Basis = -1 })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "synthetic counter account",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.CounterAccount = "expense.interest.*" })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+		{
+			name: "missing company",
+			def:  AccrualRulesDefinition{Rules: []AccrualRuleDefinition{rule(func(def *AccrualRuleDefinition) { def.Company = "" })}},
+			err:  app.ErrInvalidAccrualRules,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAccrualRules(tt.def)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestAccrualRules_Rules(t *testing.T) {
+	rules, err := NewAccrualRules(AccrualRulesDefinition{Rules: []AccrualRuleDefinition{
+		{Name: "a", Accounts: "liability.clients.*", Rate: "0.1", Company: "ledger", CounterAccount: "expense.interest.a"},
+		{Name: "b", Accounts: "liability.clients.*", Rate: "0.2", Company: "ledger", CounterAccount: "expense.interest.b"},
+	}})
+	require.NoError(t, err)
+
+	all, err := rules.Rules("")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "a", all[0].Name)
+	assert.Equal(t, DefaultBook, all[0].Book)
+	assert.Equal(t, defaultAccrualBasis, all[0].Basis)
+
+	named, err := rules.Rules("b")
+	require.NoError(t, err)
+	require.Len(t, named, 1)
+	assert.Equal(t, "b", named[0].Name)
+
+	_, err = rules.Rules("c")
+	assert.ErrorIs(t, err, app.ErrAccrualRuleNotFound)
+}
+
+func TestAccrualRule_Accrue(t *testing.T) {
+	newRule := func(rate string, basis int) AccrualRule {
+		rules, err := NewAccrualRules(AccrualRulesDefinition{Rules: []AccrualRuleDefinition{
+			{Name: "rule", Accounts: "liability.clients.*", Rate: rate, Basis: basis, Company: "ledger", CounterAccount: "expense.interest.clients"},
+		}})
+		require.NoError(t, err)
+
+		all, _ := rules.Rules("")
+		return all[0]
+	}
+
+	testCases := []struct {
+		name     string
+		rule     AccrualRule
+		balance  int
+		expected int
+	}{
+		{name: "yearly rate over 365 days", rule: newRule("0.365", 0), balance: 10000, expected: 10},
+		{name: "yearly rate over 360 days", rule: newRule("0.36", 360), balance: 10000, expected: 10},
+		{name: "rounds halves away from zero", rule: newRule("0.01", 1), balance: 50, expected: 1},
+		{name: "rounds down below the half", rule: newRule("0.01", 1), balance: 49, expected: 0},
+		{name: "negative balances accrue debits", rule: newRule("0.01", 1), balance: -250, expected: -3},
+		{name: "negative rates decrease balances", rule: newRule("-0.01", 1), balance: 1000, expected: -10},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Accrue(tt.balance))
+		})
+	}
+}
+
+func TestAccrualDay(t *testing.T) {
+	local := time.FixedZone("UTC-3", -3*60*60)
+
+	assert.Equal(t, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), AccrualDay(time.Date(2021, 2, 28, 22, 0, 0, 0, local)))
+	assert.Equal(t, time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC), AccrualDay(time.Date(2021, 2, 28, 23, 59, 59, 0, time.UTC)))
+}
+
+func TestLoadAccrualRules(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"rules": [{
+		"name": "savings_interest",
+		"accounts": "liability.clients.*.savings",
+		"rate": "0.12",
+		"event": 9,
+		"company": "ledger",
+		"counter_account": "expense.interest.savings"
+	}]}`), 0o600))
+
+	rules, err := LoadAccrualRules(valid)
+	require.NoError(t, err)
+
+	_, err = rules.Rules("savings_interest")
+	assert.NoError(t, err)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"rules": 1}`), 0o600))
+
+	_, err = LoadAccrualRules(invalid)
+	assert.ErrorIs(t, err, app.ErrInvalidAccrualRules)
+
+	_, err = LoadAccrualRules(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
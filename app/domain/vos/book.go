@@ -0,0 +1,58 @@
+package vos
+
+import (
+	"context"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// DefaultBook is the book of requests that don't name one. Entries created before books existed
+// were moved into it.
+const DefaultBook = "default"
+
+const maxBookNameSize = 63
+
+// Book is an isolated ledger: accounts, versions, balances and reports of a book never see the
+// entries of another one.
+type Book struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// NewBookName validates a book name, which has up to 63 lowercase letters, digits and underscores.
+// An empty name is the DefaultBook.
+func NewBookName(name string) (string, error) {
+	if name == "" {
+		return DefaultBook, nil
+	}
+
+	if len(name) > maxBookNameSize {
+		return "", app.ErrInvalidBook
+	}
+
+	for _, r := range name {
+		if (r < lowerLetterStart || r > lowerLetterEnd) && (r < digitStart || r > digitEnd) && r != underscore {
+			return "", app.ErrInvalidBook
+		}
+	}
+
+	return name, nil
+}
+
+type bookKey struct{}
+
+// WithBook returns a copy of ctx whose ledger operations apply to the given book.
+func WithBook(ctx context.Context, book string) context.Context {
+	return context.WithValue(ctx, bookKey{}, book)
+}
+
+// BookFromContext returns the book set by WithBook, or the DefaultBook.
+func BookFromContext(ctx context.Context) string {
+	book, _ := ctx.Value(bookKey{}).(string)
+	if book == "" {
+		return DefaultBook
+	}
+
+	return book
+}
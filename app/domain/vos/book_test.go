@@ -0,0 +1,42 @@
+package vos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestNewBookName(t *testing.T) {
+	testCases := []struct {
+		name        string
+		book        string
+		expected    string
+		expectedErr error
+	}{
+		{name: "empty name is the default book", book: "", expected: DefaultBook},
+		{name: "valid name", book: "acquiring_2021", expected: "acquiring_2021"},
+		{name: "uppercase letters", book: "Banking", expectedErr: app.ErrInvalidBook},
+		{name: "dots", book: "banking.internal", expectedErr: app.ErrInvalidBook},
+		{name: "too long", book: strings.Repeat("a", 64), expectedErr: app.ErrInvalidBook},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBookName(tt.book)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestBookFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, DefaultBook, BookFromContext(ctx))
+
+	ctx = WithBook(ctx, "banking")
+	assert.Equal(t, "banking", BookFromContext(ctx))
+}
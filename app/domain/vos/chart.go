@@ -0,0 +1,240 @@
+package vos
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// ChartDefinition is the chart of accounts as written in its file: the classes accepted as the first
+// label of accounts, the patterns that labels must follow and the accounts that can't receive
+// entries.
+//
+//	{
+//	  "classes": [{"name": "liability", "min_depth": 3}, {"name": "asset", "min_depth": 3, "max_depth": 5}],
+//	  "rules": [{"prefix": "liability.clients", "depth": 4, "pattern": "[0-9a-f]{8}(_[0-9a-f]{4}){3}_[0-9a-f]{12}"}],
+//	  "forbidden": ["liability.suspense"]
+//	}
+type ChartDefinition struct {
+	Classes   []ChartClass `json:"classes"`
+	Rules     []ChartRule  `json:"rules"`
+	Forbidden []string     `json:"forbidden"`
+}
+
+// ChartClass limits the number of labels of the analytic accounts of a class. A zero MaxDepth
+// means no limit.
+type ChartClass struct {
+	Name     string `json:"name"`
+	MinDepth int    `json:"min_depth"`
+	MaxDepth int    `json:"max_depth"`
+}
+
+// ChartRule requires the label at Depth (counting from 1) of the accounts under Prefix to match
+// Pattern as a whole.
+type ChartRule struct {
+	Prefix  string `json:"prefix"`
+	Depth   int    `json:"depth"`
+	Pattern string `json:"pattern"`
+}
+
+// Chart is a validated chart of accounts.
+type Chart struct {
+	classes   map[string]ChartClass
+	rules     []chartRule
+	forbidden []string
+}
+
+type chartRule struct {
+	prefix  string
+	depth   int
+	pattern *regexp.Regexp
+}
+
+// defaultMinDepth is the depth of the analytic accounts of the default chart, which has the classes
+// the ledger started with.
+const defaultMinDepth = 3
+
+var defaultClasses = []string{
+	"asset",
+	"conciliate_credit",
+	"conciliate_debit",
+	"equity",
+	"expense",
+	"liability",
+	"revenue",
+}
+
+// DefaultChart returns the chart used when no chart file is configured.
+func DefaultChart() *Chart {
+	classes := make([]ChartClass, 0, len(defaultClasses))
+	for _, class := range defaultClasses {
+		classes = append(classes, ChartClass{Name: class, MinDepth: defaultMinDepth})
+	}
+
+	chart, _ := NewChart(ChartDefinition{Classes: classes})
+
+	return chart
+}
+
+// NewChart validates the definition. Prefixes are given as account labels, which are lowered.
+func NewChart(def ChartDefinition) (*Chart, error) {
+	if len(def.Classes) == 0 {
+		return nil, fmt.Errorf("%w: no classes", app.ErrInvalidChart)
+	}
+
+	chart := &Chart{classes: make(map[string]ChartClass, len(def.Classes))}
+
+	for _, class := range def.Classes {
+		if !validLabel(class.Name) {
+			return nil, fmt.Errorf("%w: invalid class %q", app.ErrInvalidChart, class.Name)
+		}
+
+		if class.MinDepth < 1 || (class.MaxDepth != 0 && class.MaxDepth < class.MinDepth) {
+			return nil, fmt.Errorf("%w: invalid depth limits of class %s", app.ErrInvalidChart, class.Name)
+		}
+
+		chart.classes[class.Name] = class
+	}
+
+	for _, rule := range def.Rules {
+		prefix, err := chart.prefix(rule.Prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		if rule.Depth <= strings.Count(prefix, string(dot))+1 {
+			return nil, fmt.Errorf("%w: rule depth of %s must be below the prefix", app.ErrInvalidChart, prefix)
+		}
+
+		pattern, err := regexp.Compile("^(?:" + rule.Pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid pattern of %s: %s", app.ErrInvalidChart, prefix, err)
+		}
+
+		chart.rules = append(chart.rules, chartRule{prefix: prefix, depth: rule.Depth, pattern: pattern})
+	}
+
+	for _, forbidden := range def.Forbidden {
+		prefix, err := chart.prefix(forbidden)
+		if err != nil {
+			return nil, err
+		}
+
+		chart.forbidden = append(chart.forbidden, prefix)
+	}
+
+	return chart, nil
+}
+
+// LoadChart reads a chart file, a json object with the ChartDefinition.
+func LoadChart(file string) (*Chart, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart of accounts: %w", err)
+	}
+
+	var def ChartDefinition
+	if err = json.Unmarshal(content, &def); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode chart: %s", app.ErrInvalidChart, err)
+	}
+
+	return NewChart(def)
+}
+
+// Validate checks that an account receiving entries has a class of the chart, within its depth
+// limits, and follows the rules and forbidden accounts. Accounts are only checked when entries are
+// posted, so the accounts already in the ledger can still be read after the chart changes.
+func (c *Chart) Validate(account Account) error {
+	labels := strings.Split(account.Value(), string(dot))
+
+	if !c.hasClass(labels[0]) {
+		return app.ErrAccountPathViolation
+	}
+
+	if err := c.checkDepth(account.Value(), len(labels)); err != nil {
+		return err
+	}
+
+	for _, forbidden := range c.forbidden {
+		if hasPrefix(labels, forbidden) {
+			return fmt.Errorf("%w: %s", app.ErrForbiddenAccount, account.Value())
+		}
+	}
+
+	for _, rule := range c.rules {
+		if len(labels) < rule.depth || !hasPrefix(labels, rule.prefix) {
+			continue
+		}
+
+		if !rule.pattern.MatchString(labels[rule.depth-1]) {
+			return fmt.Errorf("%w: label %d of %s must match %s", app.ErrAccountLabelViolation, rule.depth, account.Value(), rule.pattern)
+		}
+	}
+
+	return nil
+}
+
+func (c *Chart) hasClass(class string) bool {
+	_, ok := c.classes[class]
+
+	return ok
+}
+
+// checkDepth checks the number of labels of an analytic account against the limits of its class.
+func (c *Chart) checkDepth(account string, depth int) error {
+	class := c.classes[strings.SplitN(account, string(dot), 2)[0]]
+	if depth < class.MinDepth || (class.MaxDepth != 0 && depth > class.MaxDepth) {
+		return app.ErrInvalidAccountStructure
+	}
+
+	return nil
+}
+
+// prefix validates the labels of a rule or forbidden prefix, whose class must be in the chart.
+func (c *Chart) prefix(value string) (string, error) {
+	labels := strings.Split(strings.ToLower(value), string(dot))
+	for _, label := range labels {
+		if !validLabel(label) {
+			return "", fmt.Errorf("%w: invalid prefix %q", app.ErrInvalidChart, value)
+		}
+	}
+
+	if !c.hasClass(labels[0]) {
+		return "", fmt.Errorf("%w: unknown class of %q", app.ErrInvalidChart, value)
+	}
+
+	return strings.Join(labels, string(dot)), nil
+}
+
+func hasPrefix(labels []string, prefix string) bool {
+	prefixLabels := strings.Split(prefix, string(dot))
+	if len(prefixLabels) > len(labels) {
+		return false
+	}
+
+	for i, label := range prefixLabels {
+		if labels[i] != label {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validLabel(label string) bool {
+	if label == "" || uint(len(label)) > maxLabelSize {
+		return false
+	}
+
+	for _, r := range label {
+		if (r < lowerLetterStart || r > lowerLetterEnd) && (r < digitStart || r > digitEnd) && r != underscore {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,122 @@
+package vos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+const testUUIDPattern = "[0-9a-f]{8}(_[0-9a-f]{4}){3}_[0-9a-f]{12}"
+
+func TestNewChart(t *testing.T) {
+	liability := ChartClass{Name: "liability", MinDepth: 3}
+
+	testCases := []struct {
+		name string
+		def  ChartDefinition
+		err  error
+	}{
+		{
+			name: "valid chart",
+			def: ChartDefinition{
+				Classes:   []ChartClass{liability, {Name: "asset", MinDepth: 2, MaxDepth: 4}},
+				Rules:     []ChartRule{{Prefix: "Liability.Clients", Depth: 4, Pattern: testUUIDPattern}},
+				Forbidden: []string{"liability.suspense"},
+			},
+		},
+		{name: "no classes", def: ChartDefinition{}, err: app.ErrInvalidChart},
+		{name: "invalid class", def: ChartDefinition{Classes: []ChartClass{{Name: "Liability", MinDepth: 3}}}, err: app.ErrInvalidChart},
+		{name: "no minimum depth", def: ChartDefinition{Classes: []ChartClass{{Name: "liability"}}}, err: app.ErrInvalidChart},
+		{name: "maximum below minimum", def: ChartDefinition{Classes: []ChartClass{{Name: "liability", MinDepth: 3, MaxDepth: 2}}}, err: app.ErrInvalidChart},
+		{
+			name: "rule of unknown class",
+			def:  ChartDefinition{Classes: []ChartClass{liability}, Rules: []ChartRule{{Prefix: "asset.bank", Depth: 3, Pattern: ".*"}}},
+			err:  app.ErrInvalidChart,
+		},
+		{
+			name: "rule depth within the prefix",
+			def:  ChartDefinition{Classes: []ChartClass{liability}, Rules: []ChartRule{{Prefix: "liability.clients", Depth: 2, Pattern: ".*"}}},
+			err:  app.ErrInvalidChart,
+		},
+		{
+			name: "invalid pattern",
+			def:  ChartDefinition{Classes: []ChartClass{liability}, Rules: []ChartRule{{Prefix: "liability.clients", Depth: 3, Pattern: "("}}},
+			err:  app.ErrInvalidChart,
+		},
+		{
+			name: "invalid forbidden prefix",
+			def:  ChartDefinition{Classes: []ChartClass{liability}, Forbidden: []string{"liability..x"}},
+			err:  app.ErrInvalidChart,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewChart(tt.def)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestChart_Validate(t *testing.T) {
+	chart, err := NewChart(ChartDefinition{
+		Classes:   []ChartClass{{Name: "liability", MinDepth: 3}, {Name: "passivo", MinDepth: 2, MaxDepth: 3}},
+		Rules:     []ChartRule{{Prefix: "liability.clients", Depth: 4, Pattern: testUUIDPattern}},
+		Forbidden: []string{"liability.suspense"},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		account string
+		err     error
+	}{
+		{account: "passivo.clients"},
+		{account: "passivo.clients.abc"},
+		{account: "passivo.clients.abc.def", err: app.ErrInvalidAccountStructure},
+		{account: "liability.clients", err: app.ErrInvalidAccountStructure},
+		{account: "asset.bank.main", err: app.ErrAccountPathViolation},
+		{account: "liability.clients.available.96a131a8_c4ac_495e_8971_fcecdbdd003a"},
+		{account: "liability.clients.available.96a131a8_c4ac_495e_8971_fcecdbdd003a.detail"},
+		{account: "liability.clients.available.abc", err: app.ErrAccountLabelViolation},
+		{account: "liability.clients.available"},
+		{account: "liability.suspense.abc", err: app.ErrForbiddenAccount},
+		{account: "liability.suspended.abc"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.account, func(t *testing.T) {
+			// Accounts out of the chart are still parsed, so the ones already in the ledger can be read.
+			account, err := NewAnalyticAccount(tt.account)
+			require.NoError(t, err)
+
+			assert.ErrorIs(t, chart.Validate(account), tt.err)
+		})
+	}
+
+	account, err := NewAnalyticAccount("assets.account.abc")
+	require.NoError(t, err)
+	assert.ErrorIs(t, DefaultChart().Validate(account), app.ErrAccountPathViolation)
+
+	account, err = NewAnalyticAccount("asset.account")
+	require.NoError(t, err)
+	assert.ErrorIs(t, DefaultChart().Validate(account), app.ErrInvalidAccountStructure)
+}
+
+func TestLoadChart(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "chart.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"classes": [{"name": "passivo", "min_depth": 2}], "forbidden": ["passivo.x"]}`), 0o600))
+
+	chart, err := LoadChart(file)
+	require.NoError(t, err)
+	assert.True(t, chart.hasClass("passivo"))
+	assert.False(t, chart.hasClass("liability"))
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"classes": `), 0o600))
+	_, err = LoadChart(file)
+	assert.ErrorIs(t, err, app.ErrInvalidChart)
+}
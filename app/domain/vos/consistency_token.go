@@ -0,0 +1,26 @@
+package vos
+
+import "context"
+
+// ConsistencyToken identifies the point of the ledger history just after a transaction. Reads
+// that carry it see that transaction, even when served by a read replica. Tokens are opaque to
+// clients, and an empty token means any point is good enough.
+type ConsistencyToken string
+
+type consistencyTokenKey struct{}
+
+// WithConsistencyToken returns a copy of ctx whose reads must observe the given token.
+func WithConsistencyToken(ctx context.Context, token ConsistencyToken) context.Context {
+	if token == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, consistencyTokenKey{}, token)
+}
+
+// ConsistencyTokenFromContext returns the token set by WithConsistencyToken, if any.
+func ConsistencyTokenFromContext(ctx context.Context) ConsistencyToken {
+	token, _ := ctx.Value(consistencyTokenKey{}).(ConsistencyToken)
+
+	return token
+}
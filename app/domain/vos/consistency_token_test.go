@@ -0,0 +1,16 @@
+package vos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsistencyToken(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ConsistencyToken(""), ConsistencyTokenFromContext(ctx))
+
+	ctx = WithConsistencyToken(ctx, "0/16B3748")
+	assert.Equal(t, ConsistencyToken("0/16B3748"), ConsistencyTokenFromContext(ctx))
+}
@@ -0,0 +1,149 @@
+package vos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+// EventSchema is a version of the JSON Schema that the metadata of the entries of an event must
+// follow. Versions are never changed, and the latest one is in force.
+type EventSchema struct {
+	Event     uint32
+	Version   int
+	Schema    json.RawMessage
+	CreatedAt time.Time
+}
+
+// MetadataSchema is a compiled EventSchema.
+type MetadataSchema struct {
+	schema *gojsonschema.Schema
+}
+
+// NewMetadataSchema compiles a JSON Schema object. Only local references ('#/definitions/...') are
+// allowed, so that schemas never make the ledger fetch documents.
+func NewMetadataSchema(schema json.RawMessage) (*MetadataSchema, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(schema, &document); err != nil || document == nil {
+		return nil, fmt.Errorf("%w: schema must be a JSON object", app.ErrInvalidMetadataSchema)
+	}
+
+	if ref, ok := remoteReference(document); ok {
+		return nil, fmt.Errorf("%w: reference %s is not local", app.ErrInvalidMetadataSchema, ref)
+	}
+
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(document))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", app.ErrInvalidMetadataSchema, err)
+	}
+
+	return &MetadataSchema{schema: compiled}, nil
+}
+
+// Validate returns the violations of metadata, each one prefixed by the path of its field.
+func (s *MetadataSchema) Validate(metadata json.RawMessage) []string {
+	result, err := s.schema.Validate(gojsonschema.NewBytesLoader(metadata))
+	if err != nil {
+		return []string{fmt.Sprintf("metadata is not valid JSON: %s", err)}
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, violation := range result.Errors() {
+		violations = append(violations, violation.String())
+	}
+
+	return violations
+}
+
+// remoteReference finds a $ref that doesn't point inside the document.
+func remoteReference(node interface{}) (string, bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, value := range n {
+			if ref, ok := value.(string); ok && key == "$ref" && !strings.HasPrefix(ref, "#") {
+				return ref, true
+			}
+
+			if ref, ok := remoteReference(value); ok {
+				return ref, true
+			}
+		}
+	case []interface{}:
+		for _, value := range n {
+			if ref, ok := remoteReference(value); ok {
+				return ref, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// MetadataViolation lists how the metadata of an entry breaks the schema of its event.
+type MetadataViolation struct {
+	EntryID uuid.UUID
+	TxID    uuid.UUID
+	Book    string
+	Account string
+	Errors  []string
+}
+
+// MetadataViolationError is an app.ErrMetadataSchemaViolation with the violations of each entry of
+// a transaction.
+type MetadataViolationError struct {
+	Event      uint32
+	Version    int
+	Violations []MetadataViolation
+}
+
+func (err MetadataViolationError) Error() string {
+	return fmt.Sprintf("%s: %d entries break version %d of the schema of event %d", app.ErrMetadataSchemaViolation, len(err.Violations), err.Version, err.Event)
+}
+
+func (err MetadataViolationError) Unwrap() error {
+	return app.ErrMetadataSchemaViolation
+}
+
+// EntryMetadata is the metadata of an entry, as checked by the metadata validation.
+type EntryMetadata struct {
+	ID        uuid.UUID
+	TxID      uuid.UUID
+	Book      string
+	Account   string
+	Metadata  json.RawMessage
+	CreatedAt time.Time
+}
+
+// EventMetadataRequest pages through the entries of an event created since Since, oldest first.
+type EventMetadataRequest struct {
+	Event uint32
+	Since time.Time
+	Page  pagination.Page
+}
+
+// MetadataValidationRequest checks the entries of an event created since Since against a version
+// of its schema, which is the latest when zero. Each call checks a page of entries, up to Page.Size,
+// starting at Page.Cursor.
+type MetadataValidationRequest struct {
+	Event   uint32
+	Version int
+	Since   time.Time
+	Page    pagination.Page
+}
+
+// MetadataValidationReport is the result of checking a page of existing entries against a schema,
+// which doesn't change them. NextPage continues the validation, and is nil after the last entry.
+type MetadataValidationReport struct {
+	Event      uint32
+	Version    int
+	Checked    int
+	Violations []MetadataViolation
+	NextPage   pagination.Cursor
+}
@@ -0,0 +1,64 @@
+package vos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestNewMetadataSchema(t *testing.T) {
+	testCases := []struct {
+		name   string
+		schema string
+		err    error
+	}{
+		{name: "object schema", schema: `{"type": "object", "properties": {"order_id": {"type": "integer"}}}`},
+		{name: "local reference", schema: `{"definitions": {"id": {"type": "string"}}, "properties": {"id": {"$ref": "#/definitions/id"}}}`},
+		{name: "empty schema", schema: `{}`},
+		{name: "not an object", schema: `["type"]`, err: app.ErrInvalidMetadataSchema},
+		{name: "null", schema: `null`, err: app.ErrInvalidMetadataSchema},
+		{name: "not json", schema: `{"type":`, err: app.ErrInvalidMetadataSchema},
+		{name: "unknown type", schema: `{"type": "decimal"}`, err: app.ErrInvalidMetadataSchema},
+		{name: "remote reference", schema: `{"properties": {"id": {"$ref": "http://example.com/id.json"}}}`, err: app.ErrInvalidMetadataSchema},
+		{name: "nested remote reference", schema: `{"allOf": [{"$ref": "other.json#/id"}]}`, err: app.ErrInvalidMetadataSchema},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMetadataSchema(json.RawMessage(tt.schema))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestMetadataSchema_Validate(t *testing.T) {
+	schema, err := NewMetadataSchema(json.RawMessage(`{
+		"type": "object",
+		"required": ["order_id"],
+		"properties": {
+			"order_id": {"type": "integer"},
+			"channel": {"enum": ["app", "web"]}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, schema.Validate(json.RawMessage(`{"order_id": 1, "channel": "app"}`)))
+	assert.Len(t, schema.Validate(json.RawMessage(`{}`)), 1)
+	assert.Len(t, schema.Validate(json.RawMessage(`{"order_id": "1", "channel": "pos"}`)), 2)
+	assert.Len(t, schema.Validate(json.RawMessage(`{"order_id":`)), 1)
+
+	violations := schema.Validate(json.RawMessage(`{"order_id": "1"}`))
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "order_id")
+}
+
+func TestMetadataViolationError(t *testing.T) {
+	err := MetadataViolationError{Event: 1, Version: 2, Violations: []MetadataViolation{{Account: "liability.abc"}}}
+
+	assert.ErrorIs(t, err, app.ErrMetadataSchemaViolation)
+	assert.Contains(t, err.Error(), "version 2")
+}
@@ -0,0 +1,10 @@
+package vos
+
+// DependencyStatus is the result of checking a dependency of the ledger, which is healthy when Err
+// is nil. Optional dependencies don't make the ledger unavailable when they fail, since it can work
+// around them.
+type DependencyStatus struct {
+	Name     string
+	Optional bool
+	Err      error
+}
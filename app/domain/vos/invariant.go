@@ -0,0 +1,62 @@
+package vos
+
+import (
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+// InvariantCheck identifies one of the ledger invariants verified by the invariant checker.
+type InvariantCheck string
+
+const (
+	// TransactionBalanceCheck verifies that the entries of every transaction sum to zero.
+	TransactionBalanceCheck InvariantCheck = "transaction_balance"
+	// AccountVersionCheck verifies that account_version matches the greatest entry version of the account.
+	AccountVersionCheck InvariantCheck = "account_version"
+	// VersionGapCheck verifies that the versions of an account are sequential, with no gaps or repetitions.
+	VersionGapCheck InvariantCheck = "version_gap"
+	// BalanceSnapshotCheck verifies that account_balance snapshots match a full recomputation.
+	BalanceSnapshotCheck InvariantCheck = "balance_snapshot"
+)
+
+// InvariantChecks lists every check, in the order they are executed.
+var InvariantChecks = []InvariantCheck{
+	TransactionBalanceCheck,
+	AccountVersionCheck,
+	VersionGapCheck,
+	BalanceSnapshotCheck,
+}
+
+func (c InvariantCheck) String() string {
+	return string(c)
+}
+
+// InvariantViolation describes a broken invariant. Subject is the transaction id or the
+// account (or account query, for snapshots) of Book where the violation was found.
+type InvariantViolation struct {
+	ID         int64
+	Check      InvariantCheck
+	Book       string
+	Subject    string
+	Detail     string
+	DetectedAt time.Time
+}
+
+// InvariantReport is the result of a single run of the invariant checker, covering the
+// entries created in the (From, To] window.
+type InvariantReport struct {
+	From       time.Time
+	To         time.Time
+	Violations []InvariantViolation
+}
+
+type InvariantViolationRequest struct {
+	Check InvariantCheck
+	Page  pagination.Page
+}
+
+type InvariantViolationResponse struct {
+	Violations []InvariantViolation
+	NextPage   pagination.Cursor
+}
@@ -0,0 +1,189 @@
+package vos
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// MetadataFilter selects entries by their metadata. Every condition must hold: the metadata contains
+// Contains (with the jsonb @> semantics), has every path of Keys and holds the values of Equals.
+type MetadataFilter struct {
+	Contains map[string]interface{}
+	Keys     []MetadataPath
+	Equals   []MetadataMatch
+}
+
+// MetadataPath is a path of nested keys of the metadata.
+type MetadataPath []string
+
+// MetadataMatch is a path that must hold a string value.
+type MetadataMatch struct {
+	Path  MetadataPath
+	Value string
+}
+
+// NewMetadataFilter parses contains as a JSON object and the paths of keys and equals, whose nested
+// keys are separated by dots. Equals are sorted by path, so the same filter always builds the same
+// query.
+func NewMetadataFilter(contains string, keys []string, equals map[string]string) (MetadataFilter, error) {
+	var filter MetadataFilter
+
+	if contains != "" {
+		if err := json.Unmarshal([]byte(contains), &filter.Contains); err != nil || filter.Contains == nil {
+			return MetadataFilter{}, fmt.Errorf("%w: %s", app.ErrInvalidMetadataFilter, contains)
+		}
+	}
+
+	for _, key := range keys {
+		path, err := NewMetadataPath(key)
+		if err != nil {
+			return MetadataFilter{}, err
+		}
+
+		filter.Keys = append(filter.Keys, path)
+	}
+
+	for key, value := range equals {
+		path, err := NewMetadataPath(key)
+		if err != nil {
+			return MetadataFilter{}, err
+		}
+
+		filter.Equals = append(filter.Equals, MetadataMatch{Path: path, Value: value})
+	}
+
+	sort.Slice(filter.Equals, func(i, j int) bool {
+		return filter.Equals[i].Path.String() < filter.Equals[j].Path.String()
+	})
+
+	return filter, nil
+}
+
+func NewMetadataPath(path string) (MetadataPath, error) {
+	keys := strings.Split(path, string(dot))
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("%w: %s", app.ErrInvalidMetadataPath, path)
+		}
+	}
+
+	return keys, nil
+}
+
+func (p MetadataPath) String() string {
+	return strings.Join(p, string(dot))
+}
+
+// JSONPath returns the strict jsonpath of p, which doesn't unwrap arrays along the way.
+func (p MetadataPath) JSONPath() string {
+	var b strings.Builder
+	b.WriteString("strict $")
+
+	for _, key := range p {
+		// JSON strings are valid jsonpath strings, which quote keys with any character.
+		quoted, _ := json.Marshal(key)
+		b.WriteByte('.')
+		b.Write(quoted)
+	}
+
+	return b.String()
+}
+
+// Document returns the object that contains the value at the path, so the match can be checked as
+// a containment.
+func (m MetadataMatch) Document() map[string]interface{} {
+	var value interface{} = m.Value
+	for i := len(m.Path) - 1; i > 0; i-- {
+		value = map[string]interface{}{m.Path[i]: value}
+	}
+
+	return map[string]interface{}{m.Path[0]: value}
+}
+
+func (f MetadataFilter) IsEmpty() bool {
+	return f.Contains == nil && len(f.Keys) == 0 && len(f.Equals) == 0
+}
+
+// Match reports whether metadata, decoded from JSON, passes the filter.
+func (f MetadataFilter) Match(metadata map[string]interface{}) bool {
+	if f.Contains != nil && !containsJSON(metadata, f.Contains) {
+		return false
+	}
+
+	for _, path := range f.Keys {
+		if _, ok := lookupJSON(metadata, path); !ok {
+			return false
+		}
+	}
+
+	for _, match := range f.Equals {
+		if value, ok := lookupJSON(metadata, match.Path); !ok || value != match.Value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsJSON follows the jsonb containment: objects contain the keys of sub with contained values,
+// arrays contain a containing element for each element of sub and scalars must be equal.
+func containsJSON(doc, sub interface{}) bool {
+	switch s := sub.(type) {
+	case map[string]interface{}:
+		d, ok := doc.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, value := range s {
+			if v, found := d[key]; !found || !containsJSON(v, value) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		d, ok := doc.([]interface{})
+		if !ok {
+			return false
+		}
+
+		for _, value := range s {
+			found := false
+			for _, v := range d {
+				if containsJSON(v, value) {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return doc == sub
+	}
+}
+
+func lookupJSON(doc map[string]interface{}, path MetadataPath) (interface{}, bool) {
+	var value interface{} = doc
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,99 @@
+package vos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestNewMetadataFilter(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contains    string
+		keys        []string
+		equals      map[string]string
+		expected    MetadataFilter
+		expectedErr error
+	}{
+		{name: "empty filter", expected: MetadataFilter{}},
+		{
+			name:     "every condition",
+			contains: `{"order": {"id": 123}}`,
+			keys:     []string{"external_id"},
+			equals:   map[string]string{"order.status": "paid", "channel": "app"},
+			expected: MetadataFilter{
+				Contains: map[string]interface{}{"order": map[string]interface{}{"id": float64(123)}},
+				Keys:     []MetadataPath{{"external_id"}},
+				Equals: []MetadataMatch{
+					{Path: MetadataPath{"channel"}, Value: "app"},
+					{Path: MetadataPath{"order", "status"}, Value: "paid"},
+				},
+			},
+		},
+		{name: "contains is an array", contains: `[1]`, expectedErr: app.ErrInvalidMetadataFilter},
+		{name: "contains is null", contains: `null`, expectedErr: app.ErrInvalidMetadataFilter},
+		{name: "contains is not json", contains: `{order`, expectedErr: app.ErrInvalidMetadataFilter},
+		{name: "empty key", keys: []string{""}, expectedErr: app.ErrInvalidMetadataPath},
+		{name: "empty nested key", equals: map[string]string{"order.": "x"}, expectedErr: app.ErrInvalidMetadataPath},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMetadataFilter(tt.contains, tt.keys, tt.equals)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestMetadataFilter_Match(t *testing.T) {
+	var metadata map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"external_id": "abc",
+		"order": {"id": 123, "status": "paid", "tags": ["a", "b"]},
+		"items": [{"sku": "x"}]
+	}`), &metadata))
+
+	testCases := []struct {
+		name     string
+		contains string
+		keys     []string
+		equals   map[string]string
+		expected bool
+	}{
+		{name: "empty filter", expected: true},
+		{name: "contains nested value", contains: `{"order": {"id": 123}}`, expected: true},
+		{name: "contains array element", contains: `{"order": {"tags": ["b"]}}`, expected: true},
+		{name: "contains object in array", contains: `{"items": [{"sku": "x"}]}`, expected: true},
+		{name: "contains different type", contains: `{"order": {"id": "123"}}`, expected: false},
+		{name: "has nested key", keys: []string{"order.status"}, expected: true},
+		{name: "key inside array", keys: []string{"items.sku"}, expected: false},
+		{name: "missing key", keys: []string{"order_id"}, expected: false},
+		{name: "equal value", equals: map[string]string{"external_id": "abc", "order.status": "paid"}, expected: true},
+		{name: "different value", equals: map[string]string{"order.status": "open"}, expected: false},
+		{name: "equality is not containment", equals: map[string]string{"order.tags": "a"}, expected: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewMetadataFilter(tt.contains, tt.keys, tt.equals)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, filter.Match(metadata))
+		})
+	}
+}
+
+func TestMetadataPath_JSONPath(t *testing.T) {
+	path, err := NewMetadataPath(`order.id"x`)
+	require.NoError(t, err)
+	assert.Equal(t, `strict $."order"."id\"x"`, path.JSONPath())
+}
+
+func TestMetadataMatch_Document(t *testing.T) {
+	match := MetadataMatch{Path: MetadataPath{"order", "id"}, Value: "123"}
+	assert.Equal(t, map[string]interface{}{"order": map[string]interface{}{"id": "123"}}, match.Document())
+}
@@ -0,0 +1,30 @@
+package vos
+
+import "time"
+
+// EntryPartition is a monthly partition of the entry table, holding the entries whose competence
+// date is in [From, To). Detached partitions are no longer part of the entry table, and archived
+// partitions were exported to Archive and dropped.
+type EntryPartition struct {
+	Name       string
+	From       time.Time
+	To         time.Time
+	DetachedAt time.Time
+	ArchivedAt time.Time
+	Archive    string
+}
+
+func (p EntryPartition) Detached() bool {
+	return !p.DetachedAt.IsZero()
+}
+
+func (p EntryPartition) Archived() bool {
+	return !p.ArchivedAt.IsZero()
+}
+
+// PartitionReport has the partitions created, indexed and detached by a partition management run.
+type PartitionReport struct {
+	Created  []string
+	Indexed  []string
+	Detached []string
+}
@@ -0,0 +1,292 @@
+package vos
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// amountVariable is the amount of the posted event, which every expression can use.
+const amountVariable = "amount"
+
+// remainderExpr is the amount expression of the entry that balances the others.
+const remainderExpr = "remainder"
+
+// amountExpr is a parsed amount expression: numbers, the amount of the event and its numeric
+// parameters combined by '+', '-', '*', '/' and parentheses, and the functions min, max, round,
+// floor and ceil. It is computed exactly, and only its result is rounded to cents.
+type amountExpr struct {
+	operator byte
+	function string
+	number   *big.Rat
+	variable string
+	operands []*amountExpr
+}
+
+// amountFunctions has the number of arguments of each function, -1 meaning two or more.
+var amountFunctions = map[string]int{
+	"min":   -1,
+	"max":   -1,
+	"round": 1,
+	"floor": 1,
+	"ceil":  1,
+}
+
+func parseAmountExpr(expr string) (*amountExpr, error) {
+	p := &exprParser{input: expr}
+
+	parsed, err := p.sum()
+	if err == nil && p.next() != 0 {
+		err = fmt.Errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %s", expr, err)
+	}
+
+	return parsed, nil
+}
+
+// variables returns the parameters used by the expression.
+func (e *amountExpr) variables() []string {
+	if e.variable != "" {
+		return []string{e.variable}
+	}
+
+	var variables []string
+	for _, operand := range e.operands {
+		variables = append(variables, operand.variables()...)
+	}
+
+	return variables
+}
+
+func (e *amountExpr) eval(values map[string]*big.Rat) (*big.Rat, error) {
+	if e.number != nil {
+		return e.number, nil
+	}
+
+	if e.variable != "" {
+		return values[e.variable], nil
+	}
+
+	operands := make([]*big.Rat, 0, len(e.operands))
+	for _, operand := range e.operands {
+		value, err := operand.eval(values)
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, value)
+	}
+
+	result := new(big.Rat)
+
+	switch e.function {
+	case "min", "max":
+		result.Set(operands[0])
+		for _, operand := range operands[1:] {
+			if cmp := operand.Cmp(result); cmp < 0 && e.function == "min" || cmp > 0 && e.function == "max" {
+				result.Set(operand)
+			}
+		}
+
+		return result, nil
+	case "round", "floor", "ceil":
+		return result.SetInt(roundRat(operands[0], e.function)), nil
+	}
+
+	switch e.operator {
+	case '+':
+		return result.Add(operands[0], operands[1]), nil
+	case '-':
+		if len(operands) == 1 {
+			return result.Neg(operands[0]), nil
+		}
+
+		return result.Sub(operands[0], operands[1]), nil
+	case '*':
+		return result.Mul(operands[0], operands[1]), nil
+	default:
+		if operands[1].Sign() == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+
+		return result.Quo(operands[0], operands[1]), nil
+	}
+}
+
+// roundRat rounds value to an integer: halves away from zero by round, down by floor and up by ceil.
+func roundRat(value *big.Rat, mode string) *big.Int {
+	quo, rem := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	switch mode {
+	case "floor":
+		if value.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		}
+	case "ceil":
+		if value.Sign() > 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	default:
+		if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+			quo.Add(quo, big.NewInt(int64(value.Sign())))
+		}
+	}
+
+	return quo
+}
+
+type exprParser struct {
+	input string
+	pos   int
+}
+
+// next skips the spaces and returns the next symbol, or 0 at the end of the input.
+func (p *exprParser) next() byte {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+
+	if p.pos == len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *exprParser) sum() (*amountExpr, error) {
+	return p.binary("+-", p.product)
+}
+
+func (p *exprParser) product() (*amountExpr, error) {
+	return p.binary("*/", p.unary)
+}
+
+// binary parses the left-associative operations of operators, whose operands are parsed by operand.
+func (p *exprParser) binary(operators string, operand func() (*amountExpr, error)) (*amountExpr, error) {
+	expr, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for c := p.next(); c != 0 && strings.IndexByte(operators, c) >= 0; c = p.next() {
+		p.pos++
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		expr = &amountExpr{operator: c, operands: []*amountExpr{expr, right}}
+	}
+
+	return expr, nil
+}
+
+func (p *exprParser) unary() (*amountExpr, error) {
+	switch c := p.next(); {
+	case c == '-':
+		p.pos++
+
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &amountExpr{operator: '-', operands: []*amountExpr{operand}}, nil
+	case c == '(':
+		p.pos++
+
+		expr, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.next() != ')' {
+			return nil, fmt.Errorf("missing ')'")
+		}
+
+		p.pos++
+
+		return expr, nil
+	case c >= digitStart && c <= digitEnd || c == '.':
+		return p.number()
+	case isLabelChar(c):
+		return p.identifier()
+	case c == 0:
+		return nil, fmt.Errorf("missing operand at the end")
+	default:
+		return nil, fmt.Errorf("unexpected %q", p.input[p.pos:])
+	}
+}
+
+func (p *exprParser) number() (*amountExpr, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] >= digitStart && p.input[p.pos] <= digitEnd || p.input[p.pos] == '.') {
+		p.pos++
+	}
+
+	number, ok := new(big.Rat).SetString(p.input[start:p.pos])
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", p.input[start:p.pos])
+	}
+
+	return &amountExpr{number: number}, nil
+}
+
+func (p *exprParser) identifier() (*amountExpr, error) {
+	start := p.pos
+	for p.pos < len(p.input) && isLabelChar(p.input[p.pos]) {
+		p.pos++
+	}
+
+	name := p.input[start:p.pos]
+
+	if p.next() != '(' {
+		if name == remainderExpr {
+			return nil, fmt.Errorf("%s must be the whole amount", remainderExpr)
+		}
+
+		return &amountExpr{variable: name}, nil
+	}
+
+	arity, ok := amountFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+
+	p.pos++
+
+	expr := &amountExpr{function: name}
+	for {
+		arg, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+
+		expr.operands = append(expr.operands, arg)
+
+		if p.next() != ',' {
+			break
+		}
+
+		p.pos++
+	}
+
+	if p.next() != ')' {
+		return nil, fmt.Errorf("missing ')' after the arguments of %s", name)
+	}
+
+	p.pos++
+
+	if arity > 0 && len(expr.operands) != arity || arity < 0 && len(expr.operands) < 2 {
+		return nil, fmt.Errorf("wrong number of arguments of %s", name)
+	}
+
+	return expr, nil
+}
@@ -0,0 +1,314 @@
+package vos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// PostingTemplatesDefinition is the posting templates file: the entries that each event expands
+// into, with placeholders in their accounts and expressions as their amounts.
+//
+//	{
+//	  "templates": [{
+//	    "event": 7,
+//	    "entries": [
+//	      {"operation": "debit", "account": "asset.banks.{bank}", "amount": "amount"},
+//	      {"operation": "credit", "account": "revenue.fees.pix", "amount": "max(amount * 0.5 / 100, min_fee)"},
+//	      {"operation": "credit", "account": "liability.clients.{client}.available", "amount": "remainder"}
+//	    ]
+//	  }]
+//	}
+type PostingTemplatesDefinition struct {
+	Templates []PostingTemplateDefinition `json:"templates"`
+}
+
+// PostingTemplateDefinition has the entries of an event.
+type PostingTemplateDefinition struct {
+	Event   uint32                   `json:"event"`
+	Entries []PostingEntryDefinition `json:"entries"`
+}
+
+// PostingEntryDefinition is an entry of a template. Account labels between braces are replaced by
+// the parameter of the same name, which must be a single label. Amount is an expression of the
+// amount of the event and its numeric parameters, rounded to cents (see amountExpr), or 'remainder'
+// for the one entry that balances the others, which absorbs the rounding of the splits.
+type PostingEntryDefinition struct {
+	Operation string `json:"operation"`
+	Account   string `json:"account"`
+	Amount    string `json:"amount"`
+}
+
+// PostingTemplates are the validated templates, by event.
+type PostingTemplates struct {
+	templates map[uint32]*PostingTemplate
+}
+
+// PostingTemplate expands an event into entries.
+type PostingTemplate struct {
+	event   uint32
+	entries []postingEntry
+}
+
+type postingEntry struct {
+	operation OperationType
+	account   string
+	amount    *amountExpr
+}
+
+// EventPosting is an event to be expanded into a transaction by its template.
+type EventPosting struct {
+	ID             uuid.UUID
+	Event          uint32
+	Company        string
+	CompetenceDate time.Time
+	Amount         int
+	Params         map[string]string
+	Metadata       json.RawMessage
+}
+
+// Posting is an entry expanded from a template.
+type Posting struct {
+	Operation OperationType
+	Account   string
+	Amount    int
+}
+
+var postingOperations = map[string]OperationType{
+	"debit":  DebitOperation,
+	"credit": CreditOperation,
+}
+
+// NewPostingTemplates validates the definition. Events may have a single template.
+func NewPostingTemplates(def PostingTemplatesDefinition) (*PostingTemplates, error) {
+	templates := &PostingTemplates{templates: make(map[uint32]*PostingTemplate, len(def.Templates))}
+
+	for _, templateDef := range def.Templates {
+		if _, ok := templates.templates[templateDef.Event]; ok {
+			return nil, fmt.Errorf("%w: event %d has more than one template", app.ErrInvalidPostingTemplates, templateDef.Event)
+		}
+
+		template, err := newPostingTemplate(templateDef)
+		if err != nil {
+			return nil, fmt.Errorf("%w: template of event %d: %s", app.ErrInvalidPostingTemplates, templateDef.Event, err)
+		}
+
+		templates.templates[templateDef.Event] = template
+	}
+
+	return templates, nil
+}
+
+func newPostingTemplate(def PostingTemplateDefinition) (*PostingTemplate, error) {
+	if len(def.Entries) < 2 {
+		return nil, fmt.Errorf("less than 2 entries")
+	}
+
+	template := &PostingTemplate{event: def.Event}
+	remainders := 0
+
+	for i, entryDef := range def.Entries {
+		operation, ok := postingOperations[entryDef.Operation]
+		if !ok {
+			return nil, fmt.Errorf("entry %d: invalid operation %q", i, entryDef.Operation)
+		}
+
+		if err := checkAccountTemplate(entryDef.Account); err != nil {
+			return nil, fmt.Errorf("entry %d: %s", i, err)
+		}
+
+		entry := postingEntry{operation: operation, account: entryDef.Account}
+
+		if strings.TrimSpace(entryDef.Amount) == remainderExpr {
+			remainders++
+		} else {
+			amount, err := parseAmountExpr(entryDef.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %s", i, err)
+			}
+
+			entry.amount = amount
+		}
+
+		template.entries = append(template.entries, entry)
+	}
+
+	if remainders > 1 {
+		return nil, fmt.Errorf("more than one %s entry", remainderExpr)
+	}
+
+	return template, nil
+}
+
+// checkAccountTemplate requires every label of the account to be a label or a placeholder.
+func checkAccountTemplate(account string) error {
+	for _, label := range strings.Split(account, string(dot)) {
+		if placeholder, ok := accountPlaceholder(label); ok {
+			label = placeholder
+		}
+
+		if !validLabel(label) {
+			return fmt.Errorf("invalid account %q", account)
+		}
+	}
+
+	return nil
+}
+
+// accountPlaceholder returns the parameter named by a '{name}' label.
+func accountPlaceholder(label string) (string, bool) {
+	if strings.HasPrefix(label, "{") && strings.HasSuffix(label, "}") {
+		return label[1 : len(label)-1], true
+	}
+
+	return "", false
+}
+
+// LoadPostingTemplates reads a posting templates file, a json object with the
+// PostingTemplatesDefinition.
+func LoadPostingTemplates(file string) (*PostingTemplates, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read posting templates: %w", err)
+	}
+
+	var def PostingTemplatesDefinition
+	if err = json.Unmarshal(content, &def); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode posting templates: %s", app.ErrInvalidPostingTemplates, err)
+	}
+
+	return NewPostingTemplates(def)
+}
+
+// Template returns the template of the event.
+func (t *PostingTemplates) Template(event uint32) (*PostingTemplate, error) {
+	template, ok := t.templates[event]
+	if !ok {
+		return nil, fmt.Errorf("%w: event %d", app.ErrPostingTemplateNotFound, event)
+	}
+
+	return template, nil
+}
+
+// Expand computes the entries of the template for the amount and parameters of the event, in the
+// order of the template. Entries whose amount is zero are left out.
+func (t *PostingTemplate) Expand(amount int, params map[string]string) ([]Posting, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", app.ErrInvalidPostingParams)
+	}
+
+	values := map[string]*big.Rat{amountVariable: new(big.Rat).SetInt64(int64(amount))}
+
+	postings := make([]Posting, len(t.entries))
+	remainder := -1
+	balance := 0
+
+	for i, entry := range t.entries {
+		account, err := expandAccount(entry.account, params)
+		if err != nil {
+			return nil, err
+		}
+
+		postings[i] = Posting{Operation: entry.operation, Account: account}
+
+		if entry.amount == nil {
+			remainder = i
+			continue
+		}
+
+		if postings[i].Amount, err = evalAmount(entry.amount, values, params); err != nil {
+			return nil, err
+		}
+
+		if entry.operation == DebitOperation {
+			balance += postings[i].Amount
+		} else {
+			balance -= postings[i].Amount
+		}
+	}
+
+	if remainder >= 0 {
+		postings[remainder].Amount = balance
+		if postings[remainder].Operation == DebitOperation {
+			postings[remainder].Amount = -balance
+		}
+
+		if postings[remainder].Amount < 0 {
+			return nil, fmt.Errorf("%w: the remainder of %s is negative", app.ErrInvalidPostingParams, postings[remainder].Account)
+		}
+	}
+
+	expanded := make([]Posting, 0, len(postings))
+	for _, posting := range postings {
+		if posting.Amount > 0 {
+			expanded = append(expanded, posting)
+		}
+	}
+
+	return expanded, nil
+}
+
+// expandAccount replaces the placeholders of the account by their parameters, which are lowered.
+func expandAccount(account string, params map[string]string) (string, error) {
+	labels := strings.Split(account, string(dot))
+	for i, label := range labels {
+		name, ok := accountPlaceholder(label)
+		if !ok {
+			continue
+		}
+
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("%w: missing parameter %s", app.ErrInvalidPostingParams, name)
+		}
+
+		value = strings.ToLower(value)
+		if !validLabel(value) {
+			return "", fmt.Errorf("%w: parameter %s must be an account label", app.ErrInvalidPostingParams, name)
+		}
+
+		labels[i] = value
+	}
+
+	return strings.Join(labels, string(dot)), nil
+}
+
+// evalAmount computes the amount expression, reading its numeric parameters, and rounds it to cents.
+func evalAmount(expr *amountExpr, values map[string]*big.Rat, params map[string]string) (int, error) {
+	for _, name := range expr.variables() {
+		if _, ok := values[name]; ok {
+			continue
+		}
+
+		param, ok := params[name]
+		if !ok {
+			return 0, fmt.Errorf("%w: missing parameter %s", app.ErrInvalidPostingParams, name)
+		}
+
+		value, ok := new(big.Rat).SetString(param)
+		if !ok {
+			return 0, fmt.Errorf("%w: parameter %s must be a number", app.ErrInvalidPostingParams, name)
+		}
+
+		values[name] = value
+	}
+
+	value, err := expr.eval(values)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", app.ErrInvalidPostingParams, err)
+	}
+
+	amount := roundRat(value, "round")
+	if amount.Sign() < 0 || !amount.IsInt64() {
+		return 0, fmt.Errorf("%w: amount %s is out of range", app.ErrInvalidPostingParams, value.FloatString(2))
+	}
+
+	return int(amount.Int64()), nil
+}
@@ -0,0 +1,309 @@
+package vos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestNewPostingTemplates(t *testing.T) {
+	entries := func(amounts ...string) []PostingEntryDefinition {
+		defs := make([]PostingEntryDefinition, 0, len(amounts))
+		for i, amount := range amounts {
+			operation := "debit"
+			if i > 0 {
+				operation = "credit"
+			}
+
+			defs = append(defs, PostingEntryDefinition{Operation: operation, Account: "liability.clients.{client}", Amount: amount})
+		}
+
+		return defs
+	}
+
+	testCases := []struct {
+		name string
+		def  PostingTemplatesDefinition
+		err  error
+	}{
+		{
+			name: "valid templates",
+			def: PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{
+				{Event: 1, Entries: entries("amount", "round(amount * 2.5 / 100)", "remainder")},
+				{Event: 2, Entries: entries("amount + fee", "max(fee, 10)", "amount")},
+			}},
+		},
+		{
+			name: "no templates",
+			def:  PostingTemplatesDefinition{},
+		},
+		{
+			name: "duplicated event",
+			def: PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{
+				{Event: 1, Entries: entries("amount", "amount")},
+				{Event: 1, Entries: entries("amount", "amount")},
+			}},
+			err: app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "single entry",
+			def:  PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: entries("amount")}}},
+			err:  app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "two remainders",
+			def:  PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: entries("remainder", "remainder")}}},
+			err:  app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "invalid operation",
+			def: PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: []PostingEntryDefinition{
+				{Operation: "transfer", Account: "liability.a.b", Amount: "amount"},
+				{Operation: "credit", Account: "liability.a.c", Amount: "amount"},
+			}}}},
+			err: app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "invalid account",
+			def: PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: []PostingEntryDefinition{
+				{Operation: "debit", Account: "liability.{client.b", Amount: "amount"},
+				{Operation: "credit", Account: "liability.a.c", Amount: "amount"},
+			}}}},
+			err: app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "invalid amount",
+			def:  PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: entries("amount *", "amount")}}},
+			err:  app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "unknown function",
+			def:  PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: entries("abs(amount)", "amount")}}},
+			err:  app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "wrong number of arguments",
+			def:  PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: entries("round(amount, 2)", "min(amount)")}}},
+			err:  app.ErrInvalidPostingTemplates,
+		},
+		{
+			name: "remainder within an expression",
+			def:  PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{{Event: 1, Entries: entries("remainder + 1", "amount")}}},
+			err:  app.ErrInvalidPostingTemplates,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPostingTemplates(tt.def)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPostingTemplate_Expand(t *testing.T) {
+	templates, err := NewPostingTemplates(PostingTemplatesDefinition{Templates: []PostingTemplateDefinition{
+		{
+			Event: 1,
+			Entries: []PostingEntryDefinition{
+				{Operation: "debit", Account: "asset.banks.{bank}", Amount: "amount"},
+				{Operation: "credit", Account: "revenue.fees.pix", Amount: "max(amount * 0.5 / 100, min_fee)"},
+				{Operation: "credit", Account: "liability.clients.{client}.available", Amount: "remainder"},
+			},
+		},
+		{
+			Event: 2,
+			Entries: []PostingEntryDefinition{
+				{Operation: "debit", Account: "liability.clients.{client}.available", Amount: "amount"},
+				{Operation: "credit", Account: "liability.partners.a.available", Amount: "amount / 3"},
+				{Operation: "credit", Account: "liability.partners.b.available", Amount: "amount / 3"},
+				{Operation: "credit", Account: "liability.partners.c.available", Amount: "remainder"},
+			},
+		},
+		{
+			Event: 3,
+			Entries: []PostingEntryDefinition{
+				{Operation: "debit", Account: "liability.clients.{client}.available", Amount: "amount - floor(amount * rate)"},
+				{Operation: "credit", Account: "revenue.fees.transfer", Amount: "ceil(amount * rate) * (2 - 1) / 1"},
+				{Operation: "credit", Account: "liability.clients.{to}.available", Amount: "remainder"},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		event  uint32
+		amount int
+		params map[string]string
+		want   []Posting
+		err    error
+	}{
+		{
+			name:   "fee above the minimum",
+			event:  1,
+			amount: 10001,
+			params: map[string]string{"bank": "itau", "client": "ABC", "min_fee": "10"},
+			want: []Posting{
+				{Operation: DebitOperation, Account: "asset.banks.itau", Amount: 10001},
+				{Operation: CreditOperation, Account: "revenue.fees.pix", Amount: 50},
+				{Operation: CreditOperation, Account: "liability.clients.abc.available", Amount: 9951},
+			},
+		},
+		{
+			name:   "minimum fee",
+			event:  1,
+			amount: 1000,
+			params: map[string]string{"bank": "itau", "client": "abc", "min_fee": "10"},
+			want: []Posting{
+				{Operation: DebitOperation, Account: "asset.banks.itau", Amount: 1000},
+				{Operation: CreditOperation, Account: "revenue.fees.pix", Amount: 10},
+				{Operation: CreditOperation, Account: "liability.clients.abc.available", Amount: 990},
+			},
+		},
+		{
+			name:   "remainder takes the rounding of the splits",
+			event:  2,
+			amount: 100,
+			params: map[string]string{"client": "abc"},
+			want: []Posting{
+				{Operation: DebitOperation, Account: "liability.clients.abc.available", Amount: 100},
+				{Operation: CreditOperation, Account: "liability.partners.a.available", Amount: 33},
+				{Operation: CreditOperation, Account: "liability.partners.b.available", Amount: 33},
+				{Operation: CreditOperation, Account: "liability.partners.c.available", Amount: 34},
+			},
+		},
+		{
+			name:   "zero amounts are left out",
+			event:  3,
+			amount: 100,
+			params: map[string]string{"client": "abc", "to": "xyz", "rate": "0"},
+			want: []Posting{
+				{Operation: DebitOperation, Account: "liability.clients.abc.available", Amount: 100},
+				{Operation: CreditOperation, Account: "liability.clients.xyz.available", Amount: 100},
+			},
+		},
+		{
+			name:   "missing account parameter",
+			event:  1,
+			amount: 100,
+			params: map[string]string{"bank": "itau", "min_fee": "10"},
+			err:    app.ErrInvalidPostingParams,
+		},
+		{
+			name:   "account parameter with many labels",
+			event:  1,
+			amount: 100,
+			params: map[string]string{"bank": "itau", "client": "abc.blocked", "min_fee": "10"},
+			err:    app.ErrInvalidPostingParams,
+		},
+		{
+			name:   "missing numeric parameter",
+			event:  1,
+			amount: 100,
+			params: map[string]string{"bank": "itau", "client": "abc"},
+			err:    app.ErrInvalidPostingParams,
+		},
+		{
+			name:   "parameter is not a number",
+			event:  1,
+			amount: 100,
+			params: map[string]string{"bank": "itau", "client": "abc", "min_fee": "ten"},
+			err:    app.ErrInvalidPostingParams,
+		},
+		{
+			name:   "negative remainder",
+			event:  1,
+			amount: 5,
+			params: map[string]string{"bank": "itau", "client": "abc", "min_fee": "10"},
+			err:    app.ErrInvalidPostingParams,
+		},
+		{
+			name:   "negative amount",
+			event:  3,
+			amount: 100,
+			params: map[string]string{"client": "abc", "to": "xyz", "rate": "-1"},
+			err:    app.ErrInvalidPostingParams,
+		},
+		{
+			name:   "zero amount",
+			event:  2,
+			amount: 0,
+			params: map[string]string{"client": "abc"},
+			err:    app.ErrInvalidPostingParams,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := templates.Template(tt.event)
+			require.NoError(t, err)
+
+			got, err := template.Expand(tt.amount, tt.params)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err = templates.Template(4)
+	assert.ErrorIs(t, err, app.ErrPostingTemplateNotFound)
+}
+
+func TestRoundRat(t *testing.T) {
+	testCases := []struct {
+		value string
+		round int64
+		floor int64
+		ceil  int64
+	}{
+		{value: "2", round: 2, floor: 2, ceil: 2},
+		{value: "2.5", round: 3, floor: 2, ceil: 3},
+		{value: "2.49", round: 2, floor: 2, ceil: 3},
+		{value: "-2.5", round: -3, floor: -3, ceil: -2},
+		{value: "-2.4", round: -2, floor: -3, ceil: -2},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.value, func(t *testing.T) {
+			expr, err := parseAmountExpr(tt.value)
+			require.NoError(t, err)
+
+			value, err := expr.eval(nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.round, roundRat(value, "round").Int64())
+			assert.Equal(t, tt.floor, roundRat(value, "floor").Int64())
+			assert.Equal(t, tt.ceil, roundRat(value, "ceil").Int64())
+		})
+	}
+}
+
+func TestLoadPostingTemplates(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"templates": [{"event": 1, "entries": [
+		{"operation": "debit", "account": "liability.clients.{client}", "amount": "amount"},
+		{"operation": "credit", "account": "revenue.fees.pix", "amount": "amount"}
+	]}]}`), 0o600))
+
+	templates, err := LoadPostingTemplates(valid)
+	require.NoError(t, err)
+
+	_, err = templates.Template(1)
+	assert.NoError(t, err)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"templates": 1}`), 0o600))
+
+	_, err = LoadPostingTemplates(invalid)
+	assert.ErrorIs(t, err, app.ErrInvalidPostingTemplates)
+
+	_, err = LoadPostingTemplates(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
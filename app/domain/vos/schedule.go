@@ -0,0 +1,324 @@
+package vos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+// ScheduleStatus is the lifecycle of a schedule. Canceled and completed schedules never run again.
+type ScheduleStatus string
+
+const (
+	ActiveSchedule    ScheduleStatus = "active"
+	PausedSchedule    ScheduleStatus = "paused"
+	CanceledSchedule  ScheduleStatus = "canceled"
+	CompletedSchedule ScheduleStatus = "completed"
+)
+
+func (s ScheduleStatus) String() string {
+	return string(s)
+}
+
+// Frequency is the unit of a Recurrence.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+var frequencies = map[Frequency]struct{}{Daily: {}, Weekly: {}, Monthly: {}, Yearly: {}}
+
+// untilLayouts are the forms of the UNTIL of a recurrence rule: a UTC date-time or a date.
+var untilLayouts = []string{"20060102T150405Z", "20060102"}
+
+// Recurrence is a subset of the iCalendar RRULE: FREQ (DAILY, WEEKLY, MONTHLY or YEARLY), INTERVAL,
+// and at most one of COUNT and UNTIL. The zero Recurrence has a single occurrence.
+type Recurrence struct {
+	Frequency Frequency
+	Interval  int
+	Count     int
+	Until     time.Time
+}
+
+// ParseRecurrence parses a rule such as 'FREQ=MONTHLY;INTERVAL=1;COUNT=12'. An empty rule is the zero
+// Recurrence.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return Recurrence{}, nil
+	}
+
+	recurrence := Recurrence{Interval: 1}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value := part, ""
+		if i := strings.Index(part, "="); i >= 0 {
+			key, value = part[:i], part[i+1:]
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			recurrence.Frequency = Frequency(strings.ToUpper(value))
+			if _, ok := frequencies[recurrence.Frequency]; !ok {
+				return Recurrence{}, fmt.Errorf("%w: invalid FREQ %q", app.ErrInvalidRecurrence, value)
+			}
+		case "INTERVAL":
+			if recurrence.Interval, err = strconv.Atoi(value); err != nil || recurrence.Interval <= 0 {
+				return Recurrence{}, fmt.Errorf("%w: INTERVAL must be a positive number", app.ErrInvalidRecurrence)
+			}
+		case "COUNT":
+			if recurrence.Count, err = strconv.Atoi(value); err != nil || recurrence.Count <= 0 {
+				return Recurrence{}, fmt.Errorf("%w: COUNT must be a positive number", app.ErrInvalidRecurrence)
+			}
+		case "UNTIL":
+			if recurrence.Until, err = parseUntil(value); err != nil {
+				return Recurrence{}, fmt.Errorf("%w: UNTIL must be like 20060102T150405Z", app.ErrInvalidRecurrence)
+			}
+		default:
+			return Recurrence{}, fmt.Errorf("%w: unsupported part %q", app.ErrInvalidRecurrence, part)
+		}
+	}
+
+	if recurrence.Frequency == "" {
+		return Recurrence{}, fmt.Errorf("%w: FREQ is required", app.ErrInvalidRecurrence)
+	}
+
+	if recurrence.Count > 0 && !recurrence.Until.IsZero() {
+		return Recurrence{}, fmt.Errorf("%w: COUNT and UNTIL can't be both set", app.ErrInvalidRecurrence)
+	}
+
+	return recurrence, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	var err error
+	for _, layout := range untilLayouts {
+		var until time.Time
+		if until, err = time.Parse(layout, value); err == nil {
+			return until, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// String returns the rule of the recurrence, which is empty for the zero Recurrence.
+func (r Recurrence) String() string {
+	if r.Frequency == "" {
+		return ""
+	}
+
+	rule := fmt.Sprintf("FREQ=%s;INTERVAL=%d", r.Frequency, r.Interval)
+
+	if r.Count > 0 {
+		rule += fmt.Sprintf(";COUNT=%d", r.Count)
+	}
+
+	if !r.Until.IsZero() {
+		rule += ";UNTIL=" + r.Until.UTC().Format(untilLayouts[0])
+	}
+
+	return rule
+}
+
+// Occurrence returns the nth occurrence (starting at 0) of a recurrence that starts at start, and
+// whether the recurrence has it. Occurrences are computed from the start rather than from the previous
+// one, so monthly occurrences on the 31st fall on the last day of shorter months and come back to the
+// 31st afterwards.
+func (r Recurrence) Occurrence(start time.Time, n int) (time.Time, bool) {
+	if r.Frequency == "" {
+		if n > 0 {
+			return time.Time{}, false
+		}
+
+		return start, true
+	}
+
+	if r.Count > 0 && n >= r.Count {
+		return time.Time{}, false
+	}
+
+	var occurrence time.Time
+	switch r.Frequency {
+	case Daily:
+		occurrence = start.AddDate(0, 0, n*r.Interval)
+	case Weekly:
+		occurrence = start.AddDate(0, 0, 7*n*r.Interval)
+	case Monthly:
+		occurrence = addMonths(start, n*r.Interval)
+	case Yearly:
+		occurrence = addMonths(start, 12*n*r.Interval)
+	}
+
+	if !r.Until.IsZero() && occurrence.After(r.Until) {
+		return time.Time{}, false
+	}
+
+	return occurrence, true
+}
+
+// addMonths adds months to t, keeping its day unless the resulting month is shorter.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return first.AddDate(0, 0, day-1)
+}
+
+// ScheduledEntry is an entry of the transactions of a schedule. Their ids derive from the schedule and
+// occurrence, and they post to the next version of their accounts or ignore it.
+type ScheduledEntry struct {
+	Operation OperationType   `json:"operation"`
+	Account   string          `json:"account"`
+	Version   Version         `json:"version"`
+	Amount    int             `json:"amount"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+}
+
+// Schedule posts a transaction on each occurrence of its recurrence, with the date of the occurrence
+// as its competence date. Occurrence is the index of the next occurrence, due at NextRunAt, which is
+// zero once the schedule finishes. Revision increases on every change, so concurrent changes are
+// detected.
+type Schedule struct {
+	ID         uuid.UUID
+	Book       string
+	Company    string
+	Event      uint32
+	Entries    []ScheduledEntry
+	StartAt    time.Time
+	Recurrence Recurrence
+	Status     ScheduleStatus
+	Occurrence int
+	NextRunAt  time.Time
+	LastError  string
+	Revision   int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewSchedule validates an active schedule whose first occurrence is start, which must be after now.
+// Occurrences are computed in UTC.
+func NewSchedule(id uuid.UUID, book, company string, event uint32, entries []ScheduledEntry, start time.Time, recurrence Recurrence, now time.Time) (Schedule, error) {
+	if id == uuid.Nil {
+		return Schedule{}, fmt.Errorf("%w: id is required", app.ErrInvalidSchedule)
+	}
+
+	if !start.After(now) {
+		return Schedule{}, fmt.Errorf("%w: start must be in the future", app.ErrInvalidSchedule)
+	}
+
+	if !recurrence.Until.IsZero() && recurrence.Until.Before(start) {
+		return Schedule{}, fmt.Errorf("%w: recurrence ends before the start", app.ErrInvalidSchedule)
+	}
+
+	for i, entry := range entries {
+		if entry.Version != NextAccountVersion && entry.Version != IgnoreAccountVersion {
+			return Schedule{}, fmt.Errorf("%w: entry %d must post to the next version or ignore it", app.ErrInvalidSchedule, i)
+		}
+	}
+
+	start = start.UTC()
+
+	return Schedule{
+		ID:         id,
+		Book:       book,
+		Company:    company,
+		Event:      event,
+		Entries:    entries,
+		StartAt:    start,
+		Recurrence: recurrence,
+		Status:     ActiveSchedule,
+		NextRunAt:  start,
+	}, nil
+}
+
+// Due returns whether the next occurrence must be posted.
+func (s Schedule) Due(now time.Time) bool {
+	return s.Status == ActiveSchedule && !s.NextRunAt.IsZero() && !s.NextRunAt.After(now)
+}
+
+// Advance moves the schedule to its next occurrence, completing it after the last one.
+func (s *Schedule) Advance() {
+	s.Occurrence++
+	s.LastError = ""
+
+	next, ok := s.Recurrence.Occurrence(s.StartAt, s.Occurrence)
+	if !ok {
+		s.Status = CompletedSchedule
+		s.NextRunAt = time.Time{}
+		return
+	}
+
+	s.NextRunAt = next
+}
+
+// Pause stops an active schedule from running.
+func (s *Schedule) Pause() error {
+	if s.Status != ActiveSchedule {
+		return fmt.Errorf("%w: can't pause a %s schedule", app.ErrInvalidScheduleStatus, s.Status)
+	}
+
+	s.Status = PausedSchedule
+
+	return nil
+}
+
+// Resume activates a paused schedule. The occurrences missed while it was paused are skipped.
+func (s *Schedule) Resume(now time.Time) error {
+	if s.Status != PausedSchedule {
+		return fmt.Errorf("%w: can't resume a %s schedule", app.ErrInvalidScheduleStatus, s.Status)
+	}
+
+	s.Status = ActiveSchedule
+
+	for s.Due(now) {
+		s.Advance()
+	}
+
+	return nil
+}
+
+// Cancel finishes an active or paused schedule.
+func (s *Schedule) Cancel() error {
+	if s.Status != ActiveSchedule && s.Status != PausedSchedule {
+		return fmt.Errorf("%w: can't cancel a %s schedule", app.ErrInvalidScheduleStatus, s.Status)
+	}
+
+	s.Status = CanceledSchedule
+	s.NextRunAt = time.Time{}
+
+	return nil
+}
+
+// ScheduleReport is the result of a run of the schedules: how many transactions were posted and how
+// many schedules failed to post theirs.
+type ScheduleReport struct {
+	Posted int
+	Failed int
+}
+
+type ScheduleListRequest struct {
+	Status ScheduleStatus
+	Page   pagination.Page
+}
+
+type ScheduleListResponse struct {
+	Schedules []Schedule
+	NextPage  pagination.Cursor
+}
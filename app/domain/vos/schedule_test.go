@@ -0,0 +1,214 @@
+package vos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rule        string
+		expected    Recurrence
+		expectedErr error
+	}{
+		{name: "empty rule is a single occurrence", rule: ""},
+		{name: "frequency only", rule: "FREQ=DAILY", expected: Recurrence{Frequency: Daily, Interval: 1}},
+		{name: "rrule prefix", rule: "RRULE:FREQ=WEEKLY;INTERVAL=2", expected: Recurrence{Frequency: Weekly, Interval: 2}},
+		{name: "count", rule: "FREQ=MONTHLY;COUNT=12", expected: Recurrence{Frequency: Monthly, Interval: 1, Count: 12}},
+		{
+			name:     "until date-time",
+			rule:     "FREQ=YEARLY;UNTIL=20301231T235959Z",
+			expected: Recurrence{Frequency: Yearly, Interval: 1, Until: time.Date(2030, 12, 31, 23, 59, 59, 0, time.UTC)},
+		},
+		{
+			name:     "until date",
+			rule:     "freq=daily;until=20301231",
+			expected: Recurrence{Frequency: Daily, Interval: 1, Until: time.Date(2030, 12, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		{name: "missing frequency", rule: "COUNT=3", expectedErr: app.ErrInvalidRecurrence},
+		{name: "unknown frequency", rule: "FREQ=HOURLY", expectedErr: app.ErrInvalidRecurrence},
+		{name: "non positive interval", rule: "FREQ=DAILY;INTERVAL=0", expectedErr: app.ErrInvalidRecurrence},
+		{name: "invalid count", rule: "FREQ=DAILY;COUNT=x", expectedErr: app.ErrInvalidRecurrence},
+		{name: "invalid until", rule: "FREQ=DAILY;UNTIL=2030-12-31", expectedErr: app.ErrInvalidRecurrence},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=3;UNTIL=20301231", expectedErr: app.ErrInvalidRecurrence},
+		{name: "unsupported part", rule: "FREQ=WEEKLY;BYDAY=MO", expectedErr: app.ErrInvalidRecurrence},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecurrence(tt.rule)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRecurrence_String(t *testing.T) {
+	for _, rule := range []string{"", "FREQ=DAILY;INTERVAL=1", "FREQ=MONTHLY;INTERVAL=3;COUNT=4", "FREQ=YEARLY;INTERVAL=1;UNTIL=20301231T000000Z"} {
+		recurrence, err := ParseRecurrence(rule)
+		require.NoError(t, err)
+		assert.Equal(t, rule, recurrence.String())
+	}
+}
+
+func TestRecurrence_Occurrence(t *testing.T) {
+	start := time.Date(2021, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		recurrence Recurrence
+		n          int
+		expected   time.Time
+		expectedOk bool
+	}{
+		{name: "single occurrence", n: 0, expected: start, expectedOk: true},
+		{name: "single occurrence has no second", n: 1},
+		{name: "daily", recurrence: Recurrence{Frequency: Daily, Interval: 2}, n: 3, expected: start.AddDate(0, 0, 6), expectedOk: true},
+		{name: "weekly", recurrence: Recurrence{Frequency: Weekly, Interval: 1}, n: 2, expected: start.AddDate(0, 0, 14), expectedOk: true},
+		{
+			name:       "monthly clamps to the end of shorter months",
+			recurrence: Recurrence{Frequency: Monthly, Interval: 1},
+			n:          1,
+			expected:   time.Date(2021, 2, 28, 10, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "monthly comes back to the day of the start",
+			recurrence: Recurrence{Frequency: Monthly, Interval: 1},
+			n:          2,
+			expected:   time.Date(2021, 3, 31, 10, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "yearly",
+			recurrence: Recurrence{Frequency: Yearly, Interval: 1},
+			n:          3,
+			expected:   time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{name: "past the count", recurrence: Recurrence{Frequency: Daily, Interval: 1, Count: 2}, n: 2},
+		{name: "past until", recurrence: Recurrence{Frequency: Daily, Interval: 1, Until: start.AddDate(0, 0, 1)}, n: 2},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.recurrence.Occurrence(start, tt.n)
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestNewSchedule(t *testing.T) {
+	now := time.Now()
+	start := now.Add(time.Hour)
+	entries := []ScheduledEntry{
+		{Operation: DebitOperation, Account: "liability.abc.account1", Version: NextAccountVersion, Amount: 100},
+		{Operation: CreditOperation, Account: "liability.abc.account2", Version: IgnoreAccountVersion, Amount: 100},
+	}
+
+	t.Run("should create an active schedule", func(t *testing.T) {
+		id := uuid.New()
+
+		got, err := NewSchedule(id, DefaultBook, "abc", 1, entries, start, Recurrence{}, now)
+		require.NoError(t, err)
+		assert.Equal(t, id, got.ID)
+		assert.Equal(t, ActiveSchedule, got.Status)
+		assert.Equal(t, start.UTC(), got.StartAt)
+		assert.Equal(t, got.StartAt, got.NextRunAt)
+		assert.Equal(t, 0, got.Occurrence)
+	})
+
+	testCases := []struct {
+		name       string
+		id         uuid.UUID
+		start      time.Time
+		recurrence Recurrence
+		entries    []ScheduledEntry
+	}{
+		{name: "nil id", id: uuid.Nil, start: start, entries: entries},
+		{name: "start in the past", id: uuid.New(), start: now.Add(-time.Hour), entries: entries},
+		{name: "until before the start", id: uuid.New(), start: start, recurrence: Recurrence{Frequency: Daily, Interval: 1, Until: now}, entries: entries},
+		{
+			name:    "expected version",
+			id:      uuid.New(),
+			start:   start,
+			entries: []ScheduledEntry{{Operation: DebitOperation, Account: "liability.abc.account1", Version: 3, Amount: 100}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			_, err := NewSchedule(tt.id, DefaultBook, "abc", 1, tt.entries, tt.start, tt.recurrence, now)
+			assert.ErrorIs(t, err, app.ErrInvalidSchedule)
+		})
+	}
+}
+
+func TestSchedule_Transitions(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newSchedule := func() Schedule {
+		return Schedule{
+			ID:         uuid.New(),
+			StartAt:    start,
+			Recurrence: Recurrence{Frequency: Daily, Interval: 1, Count: 3},
+			Status:     ActiveSchedule,
+			NextRunAt:  start,
+		}
+	}
+
+	t.Run("should advance to the next occurrence and complete after the last one", func(t *testing.T) {
+		schedule := newSchedule()
+		schedule.LastError = "failed"
+
+		assert.True(t, schedule.Due(start))
+		assert.False(t, schedule.Due(start.Add(-time.Second)))
+
+		schedule.Advance()
+		assert.Equal(t, 1, schedule.Occurrence)
+		assert.Equal(t, start.AddDate(0, 0, 1), schedule.NextRunAt)
+		assert.Empty(t, schedule.LastError)
+
+		schedule.Advance()
+		schedule.Advance()
+		assert.Equal(t, CompletedSchedule, schedule.Status)
+		assert.True(t, schedule.NextRunAt.IsZero())
+		assert.False(t, schedule.Due(start.AddDate(1, 0, 0)))
+	})
+
+	t.Run("should pause and resume skipping the missed occurrences", func(t *testing.T) {
+		schedule := newSchedule()
+
+		require.NoError(t, schedule.Pause())
+		assert.Equal(t, PausedSchedule, schedule.Status)
+		assert.False(t, schedule.Due(start))
+		assert.ErrorIs(t, schedule.Pause(), app.ErrInvalidScheduleStatus)
+
+		require.NoError(t, schedule.Resume(start.AddDate(0, 0, 1).Add(time.Hour)))
+		assert.Equal(t, ActiveSchedule, schedule.Status)
+		assert.Equal(t, 2, schedule.Occurrence)
+		assert.Equal(t, start.AddDate(0, 0, 2), schedule.NextRunAt)
+		assert.ErrorIs(t, schedule.Resume(start), app.ErrInvalidScheduleStatus)
+	})
+
+	t.Run("should cancel active and paused schedules only", func(t *testing.T) {
+		schedule := newSchedule()
+		require.NoError(t, schedule.Cancel())
+		assert.Equal(t, CanceledSchedule, schedule.Status)
+		assert.True(t, schedule.NextRunAt.IsZero())
+		assert.ErrorIs(t, schedule.Cancel(), app.ErrInvalidScheduleStatus)
+		assert.ErrorIs(t, schedule.Resume(start), app.ErrInvalidScheduleStatus)
+
+		schedule = newSchedule()
+		require.NoError(t, schedule.Pause())
+		assert.NoError(t, schedule.Cancel())
+	})
+}
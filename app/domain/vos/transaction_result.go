@@ -0,0 +1,57 @@
+package vos
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransactionResult is what saving a transaction produced: the versions its entries were given and,
+// when requested by WithResultingBalances, the balances of its accounts right after it. Both come from
+// the database transaction that saved it, so they aren't affected by later transactions. PostedAt is
+// when that database transaction started, the creation time of the entries, not when it committed.
+type TransactionResult struct {
+	ConsistencyToken ConsistencyToken
+	PostedAt         time.Time
+	Entries          []PostedEntry
+	Balances         []AccountBalance
+}
+
+// PostedEntry is an entry of a saved transaction, with the version it was given.
+type PostedEntry struct {
+	ID      uuid.UUID
+	Account Account
+	Version Version
+}
+
+type resultingBalancesKey struct{}
+
+// WithResultingBalances returns a copy of ctx whose saved transactions report the balances of their
+// accounts.
+func WithResultingBalances(ctx context.Context) context.Context {
+	return context.WithValue(ctx, resultingBalancesKey{}, true)
+}
+
+// ResultingBalancesFromContext returns whether WithResultingBalances was set.
+func ResultingBalancesFromContext(ctx context.Context) bool {
+	include, _ := ctx.Value(resultingBalancesKey{}).(bool)
+
+	return include
+}
+
+type simulationKey struct{}
+
+// WithSimulation returns a copy of ctx whose transactions are simulated: they go through every check
+// of a saved transaction and report its result, including the balances of its accounts, but are
+// never saved.
+func WithSimulation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, simulationKey{}, true)
+}
+
+// SimulationFromContext returns whether WithSimulation was set.
+func SimulationFromContext(ctx context.Context) bool {
+	simulated, _ := ctx.Value(simulationKey{}).(bool)
+
+	return simulated
+}
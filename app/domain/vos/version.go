@@ -1,5 +1,11 @@
 package vos
 
+import (
+	"fmt"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
 type Version int64
 
 const (
@@ -10,3 +16,18 @@ const (
 func (v Version) AsInt64() int64 {
 	return int64(v)
 }
+
+// VersionConflictError is an app.ErrInvalidVersion that knows the version the account is at, so
+// clients can retry the transaction without querying the account again.
+type VersionConflictError struct {
+	Account        string
+	CurrentVersion Version
+}
+
+func (err VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: account %s is at version %d", app.ErrInvalidVersion, err.Account, err.CurrentVersion)
+}
+
+func (err VersionConflictError) Unwrap() error {
+	return app.ErrInvalidVersion
+}
@@ -4,9 +4,18 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
 )
 
 func TestVersion_CheckConstants(t *testing.T) {
 	assert.Equal(t, IgnoreAccountVersion, Version(-1))
 	assert.Equal(t, NextAccountVersion, Version(0))
 }
+
+func TestVersionConflictError(t *testing.T) {
+	err := VersionConflictError{Account: "liability.clients.available", CurrentVersion: 3}
+
+	assert.ErrorIs(t, err, app.ErrInvalidVersion)
+	assert.Equal(t, "invalid version: account liability.clients.available is at version 3", err.Error())
+}
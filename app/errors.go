@@ -13,16 +13,120 @@ const (
 	ErrInvalidAccountStructure                 = DomainError("account does not meet minimum or maximum supported sizes")
 	ErrInvalidAccountComponentSize             = DomainError("account component cannot be empty and must be less than 256 characters")
 	ErrInvalidSingleAccountComponentCharacters = DomainError("only alphanumeric and underscore characters are supported")
-	ErrInvalidAccountComponentCharacters       = DomainError("only alphanumeric, underscore and star (*) characters are supported")
+	ErrInvalidAccountComponentCharacters       = DomainError("only alphanumeric, underscore and lquery operator characters are supported")
 	ErrAccountPathViolation                    = DomainError("invalid depth value")
 	ErrInvalidSyntheticReportStructure         = DomainError("invalid synthetic report structure")
 	ErrInvalidPageSize                         = DomainError("invalid page size")
 	ErrInvalidPageCursor                       = DomainError("invalid page cursor")
 	ErrInvalidAccountType                      = DomainError("invalid account type")
+	ErrInvalidInvariantCheck                   = DomainError("invalid invariant check")
+	ErrInvariantCheckInProgress                = DomainError("invariant check already in progress")
+	ErrInvalidSnapshotRetention                = DomainError("snapshot retention must be greater than zero")
+	ErrInvalidSnapshotLimit                    = DomainError("snapshot limit must be greater than zero")
+	ErrInvalidBalanceStrategy                  = DomainError("invalid balance strategy")
+	ErrStaleBalances                           = DomainError("running balances are stale until an eager replica rebuilds them")
+	ErrInvalidPartitionWindow                  = DomainError("partition months cannot be negative")
+	ErrPartitionNotFound                       = DomainError("partition not found")
+	ErrInvalidAuthConfig                       = DomainError("invalid auth config")
+	ErrUnauthenticated                         = DomainError("unauthenticated")
+	ErrPermissionDenied                        = DomainError("permission denied")
+	ErrInvalidBook                             = DomainError("book must have up to 63 lowercase letters, digits and underscores")
+	ErrBookNotFound                            = DomainError("book not found")
+	ErrBookAlreadyExists                       = DomainError("book already exists")
+	ErrInvalidTracer                           = DomainError("invalid tracer")
+	ErrInvalidMetadataFilter                   = DomainError("metadata filter must be a JSON object")
+	ErrInvalidMetadataPath                     = DomainError("metadata path keys cannot be empty")
+	ErrInvalidChart                            = DomainError("invalid chart of accounts")
+	ErrAccountLabelViolation                   = DomainError("account label does not match the chart of accounts")
+	ErrForbiddenAccount                        = DomainError("account is forbidden by the chart of accounts")
+	ErrInvalidAccountSearch                    = DomainError("invalid account search")
+	ErrInvalidMetadataSchema                   = DomainError("invalid metadata schema")
+	ErrMetadataSchemaViolation                 = DomainError("entry metadata does not match the schema of the event")
+	ErrEventNotFound                           = DomainError("event not found")
+	ErrEventSchemaNotFound                     = DomainError("event schema not found")
+	ErrInvalidPostingTemplates                 = DomainError("invalid posting templates")
+	ErrPostingTemplateNotFound                 = DomainError("event has no posting template")
+	ErrInvalidPostingParams                    = DomainError("invalid posting parameters")
+	ErrInvalidRecurrence                       = DomainError("invalid recurrence rule")
+	ErrInvalidSchedule                         = DomainError("invalid schedule")
+	ErrScheduleNotFound                        = DomainError("schedule not found")
+	ErrInvalidScheduleStatus                   = DomainError("invalid schedule status")
+	ErrScheduleConflict                        = DomainError("schedule was changed concurrently")
+	ErrInvalidAccrualRules                     = DomainError("invalid accrual rules")
+	ErrAccrualRuleNotFound                     = DomainError("accrual rule not found")
+	ErrInvalidAccrualDates                     = DomainError("invalid accrual dates")
 )
 
+// errorCodes are the stable codes of the domain errors, which clients should rely on instead of the
+// messages.
+var errorCodes = map[DomainError]string{
+	ErrInvalidTransactionID:                    "INVALID_TRANSACTION_ID",
+	ErrInvalidEntryID:                          "INVALID_ENTRY_ID",
+	ErrInvalidOperation:                        "INVALID_OPERATION",
+	ErrInvalidAmount:                           "INVALID_AMOUNT",
+	ErrInvalidEntriesNumber:                    "INVALID_ENTRIES_NUMBER",
+	ErrInvalidBalance:                          "INVALID_BALANCE",
+	ErrIdempotencyKeyViolation:                 "IDEMPOTENCY_KEY_VIOLATION",
+	ErrInvalidVersion:                          "INVALID_VERSION",
+	ErrAccountNotFound:                         "ACCOUNT_NOT_FOUND",
+	ErrInvalidAccountStructure:                 "INVALID_ACCOUNT_STRUCTURE",
+	ErrInvalidAccountComponentSize:             "INVALID_ACCOUNT_COMPONENT_SIZE",
+	ErrInvalidSingleAccountComponentCharacters: "INVALID_SINGLE_ACCOUNT_COMPONENT_CHARACTERS",
+	ErrInvalidAccountComponentCharacters:       "INVALID_ACCOUNT_COMPONENT_CHARACTERS",
+	ErrAccountPathViolation:                    "ACCOUNT_PATH_VIOLATION",
+	ErrInvalidSyntheticReportStructure:         "INVALID_SYNTHETIC_REPORT_STRUCTURE",
+	ErrInvalidPageSize:                         "INVALID_PAGE_SIZE",
+	ErrInvalidPageCursor:                       "INVALID_PAGE_CURSOR",
+	ErrInvalidAccountType:                      "INVALID_ACCOUNT_TYPE",
+	ErrInvalidInvariantCheck:                   "INVALID_INVARIANT_CHECK",
+	ErrInvariantCheckInProgress:                "INVARIANT_CHECK_IN_PROGRESS",
+	ErrInvalidSnapshotRetention:                "INVALID_SNAPSHOT_RETENTION",
+	ErrInvalidSnapshotLimit:                    "INVALID_SNAPSHOT_LIMIT",
+	ErrInvalidBalanceStrategy:                  "INVALID_BALANCE_STRATEGY",
+	ErrStaleBalances:                           "STALE_BALANCES",
+	ErrInvalidPartitionWindow:                  "INVALID_PARTITION_WINDOW",
+	ErrPartitionNotFound:                       "PARTITION_NOT_FOUND",
+	ErrInvalidAuthConfig:                       "INVALID_AUTH_CONFIG",
+	ErrUnauthenticated:                         "UNAUTHENTICATED",
+	ErrPermissionDenied:                        "PERMISSION_DENIED",
+	ErrInvalidBook:                             "INVALID_BOOK",
+	ErrBookNotFound:                            "BOOK_NOT_FOUND",
+	ErrBookAlreadyExists:                       "BOOK_ALREADY_EXISTS",
+	ErrInvalidTracer:                           "INVALID_TRACER",
+	ErrInvalidMetadataFilter:                   "INVALID_METADATA_FILTER",
+	ErrInvalidMetadataPath:                     "INVALID_METADATA_PATH",
+	ErrInvalidChart:                            "INVALID_CHART",
+	ErrAccountLabelViolation:                   "ACCOUNT_LABEL_VIOLATION",
+	ErrForbiddenAccount:                        "FORBIDDEN_ACCOUNT",
+	ErrInvalidAccountSearch:                    "INVALID_ACCOUNT_SEARCH",
+	ErrInvalidMetadataSchema:                   "INVALID_METADATA_SCHEMA",
+	ErrMetadataSchemaViolation:                 "METADATA_SCHEMA_VIOLATION",
+	ErrEventNotFound:                           "EVENT_NOT_FOUND",
+	ErrEventSchemaNotFound:                     "EVENT_SCHEMA_NOT_FOUND",
+	ErrInvalidPostingTemplates:                 "INVALID_POSTING_TEMPLATES",
+	ErrPostingTemplateNotFound:                 "POSTING_TEMPLATE_NOT_FOUND",
+	ErrInvalidPostingParams:                    "INVALID_POSTING_PARAMS",
+	ErrInvalidRecurrence:                       "INVALID_RECURRENCE",
+	ErrInvalidSchedule:                         "INVALID_SCHEDULE",
+	ErrScheduleNotFound:                        "SCHEDULE_NOT_FOUND",
+	ErrInvalidScheduleStatus:                   "INVALID_SCHEDULE_STATUS",
+	ErrScheduleConflict:                        "SCHEDULE_CONFLICT",
+	ErrInvalidAccrualRules:                     "INVALID_ACCRUAL_RULES",
+	ErrAccrualRuleNotFound:                     "ACCRUAL_RULE_NOT_FOUND",
+	ErrInvalidAccrualDates:                     "INVALID_ACCRUAL_DATES",
+}
+
 type DomainError string
 
 func (err DomainError) Error() string {
 	return string(err)
 }
+
+// Code returns the stable code of the error, which is UNKNOWN for errors not declared in this package.
+func (err DomainError) Code() string {
+	if code, ok := errorCodes[err]; ok {
+		return code
+	}
+
+	return "UNKNOWN"
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func (r *LedgerRepository) CreateBook(_ context.Context, name string) (vos.Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.books[name]; ok {
+		return vos.Book{}, app.ErrBookAlreadyExists
+	}
+
+	b := newBook()
+	r.books[name] = b
+
+	return vos.Book{Name: name, CreatedAt: b.createdAt}, nil
+}
+
+func (r *LedgerRepository) ListBooks(_ context.Context) ([]vos.Book, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	books := make([]vos.Book, 0, len(r.books))
+	for name, b := range r.books {
+		books = append(books, vos.Book{Name: name, CreatedAt: b.createdAt})
+	}
+
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].Name < books[j].Name
+	})
+
+	return books, nil
+}
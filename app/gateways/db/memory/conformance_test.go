@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/tests/conformance"
+)
+
+func TestLedgerRepository_Conformance(t *testing.T) {
+	conformance.TestRepository(t, func(t *testing.T) domain.Repository {
+		return NewLedgerRepository()
+	})
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// CreateTransaction returns an empty consistency token, since every read sees the committed transactions.
+func (r *LedgerRepository) CreateTransaction(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.books[vos.BookFromContext(ctx)]
+	if !ok {
+		return vos.TransactionResult{}, app.ErrBookNotFound
+	}
+
+	var (
+		now      = time.Now()
+		versions = make(map[string]vos.Version)
+		ids      = make(map[uuid.UUID]struct{})
+		created  = make([]entry, 0, len(transaction.Entries))
+		result   = vos.TransactionResult{
+			PostedAt: now,
+			Entries:  make([]vos.PostedEntry, 0, len(transaction.Entries)),
+		}
+	)
+
+	// Entries are checked in order and nothing is stored until all of them are valid, as the
+	// postgres insert is a single statement.
+	for _, e := range transaction.Entries {
+		version, err := b.nextVersion(versions, e.Account.Value(), e.Version)
+		if err != nil {
+			return vos.TransactionResult{}, err
+		}
+
+		if _, ok := r.ids[e.ID]; ok {
+			return vos.TransactionResult{}, app.ErrIdempotencyKeyViolation
+		}
+		if _, ok := ids[e.ID]; ok {
+			return vos.TransactionResult{}, app.ErrIdempotencyKeyViolation
+		}
+		ids[e.ID] = struct{}{}
+
+		created = append(created, entry{
+			id:             e.ID,
+			txID:           transaction.ID,
+			sequence:       r.transactions,
+			event:          transaction.Event,
+			operation:      e.Operation,
+			version:        version,
+			amount:         e.Amount,
+			createdAt:      now,
+			competenceDate: transaction.CompetenceDate,
+			account:        e.Account.Value(),
+			company:        transaction.Company,
+			metadata:       e.Metadata,
+		})
+
+		result.Entries = append(result.Entries, vos.PostedEntry{ID: e.ID, Account: e.Account, Version: version})
+	}
+
+	// Like the rolled back postgres transaction, a simulation reports its result but stores nothing.
+	if vos.SimulationFromContext(ctx) {
+		result.Balances = r.simulatedBalances(b, transaction, created)
+		return result, nil
+	}
+
+	for account, version := range versions {
+		b.versions[account] = version
+	}
+
+	for id := range ids {
+		r.ids[id] = struct{}{}
+	}
+
+	for _, e := range created {
+		b.accounts[e.account] = append(b.accounts[e.account], len(r.entries))
+		r.entries = append(r.entries, e)
+	}
+
+	r.transactions++
+
+	// Entries are sorted by account, so each account is reported once, in the order of its entries.
+	if vos.ResultingBalancesFromContext(ctx) {
+		for _, e := range transaction.Entries {
+			if n := len(result.Balances); n > 0 && result.Balances[n-1].Account.Value() == e.Account.Value() {
+				continue
+			}
+
+			balance, _ := r.analyticBalance(b, e.Account)
+			result.Balances = append(result.Balances, balance)
+		}
+	}
+
+	return result, nil
+}
+
+// simulatedBalances reports the balances the accounts of the transaction would have after its
+// created entries, in the order of its entries. As the entries of the transaction would be the latest
+// of their accounts, the version of an account is the greatest version of its entries.
+func (r *LedgerRepository) simulatedBalances(b *book, transaction entities.Transaction, created []entry) []vos.AccountBalance {
+	var balances []vos.AccountBalance
+
+	for i, e := range created {
+		n := len(balances)
+		if n == 0 || balances[n-1].Account.Value() != e.account {
+			balance, _ := r.analyticBalance(b, transaction.Entries[i].Account)
+			balance.Account = transaction.Entries[i].Account
+			balance.CurrentVersion = e.version
+			balances = append(balances, balance)
+			n++
+		}
+
+		balances[n-1].Balance += e.balance()
+		if e.version > balances[n-1].CurrentVersion {
+			balances[n-1].CurrentVersion = e.version
+		}
+	}
+
+	return balances
+}
+
+// nextVersion follows the account_version trigger: NextAccountVersion takes the next version, an
+// explicit version must be the next one, and the first versioned entry of an account is always
+// version 1. Negative versions (IgnoreAccountVersion) are stored as they are.
+func (b *book) nextVersion(pending map[string]vos.Version, account string, version vos.Version) (vos.Version, error) {
+	if version < vos.NextAccountVersion {
+		return version, nil
+	}
+
+	current, ok := pending[account]
+	if !ok {
+		current, ok = b.versions[account]
+	}
+
+	switch {
+	case !ok:
+		version = 1
+	case version == vos.NextAccountVersion:
+		version = current + 1
+	case version != current+1:
+		return 0, vos.VersionConflictError{Account: account, CurrentVersion: current}
+	}
+
+	pending[account] = version
+
+	return version, nil
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func TestLedgerRepository_CreateTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should increment the account version", func(t *testing.T) {
+		r := NewLedgerRepository()
+
+		createTransaction(t, ctx, r,
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.NextAccountVersion, 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100),
+		)
+		createTransaction(t, ctx, r,
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.Version(2), 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100),
+		)
+
+		assert.Equal(t, vos.Version(2), r.books[vos.DefaultBook].versions["liability.abc.account1"])
+		assert.NotContains(t, r.books[vos.DefaultBook].versions, "liability.abc.account2")
+	})
+
+	t.Run("should use version 1 for a new account", func(t *testing.T) {
+		r := NewLedgerRepository()
+
+		createTransaction(t, ctx, r,
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.Version(10), 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100),
+		)
+
+		assert.Equal(t, vos.Version(1), r.books[vos.DefaultBook].versions["liability.abc.account1"])
+	})
+
+	t.Run("should fail with an invalid version and keep nothing", func(t *testing.T) {
+		r := NewLedgerRepository()
+
+		createTransaction(t, ctx, r,
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.NextAccountVersion, 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.NextAccountVersion, 100),
+		)
+
+		tx, err := entities.NewTransaction(uuid.New(), 1, "abc", time.Now(),
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.NextAccountVersion, 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.Version(5), 100),
+		)
+		assert.NoError(t, err)
+
+		_, err = r.CreateTransaction(ctx, tx)
+		assert.ErrorIs(t, err, app.ErrInvalidVersion)
+		assert.Len(t, r.entries, 2)
+		assert.Equal(t, vos.Version(1), r.books[vos.DefaultBook].versions["liability.abc.account1"])
+	})
+
+	t.Run("should fail when the idempotency key is reused", func(t *testing.T) {
+		r := NewLedgerRepository()
+
+		tx := createTransaction(t, ctx, r,
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.IgnoreAccountVersion, 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100),
+		)
+
+		_, err := r.CreateTransaction(ctx, tx)
+		assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation)
+		assert.Len(t, r.entries, 2)
+	})
+
+	t.Run("should fail when the idempotency key is reused with another competence date", func(t *testing.T) {
+		r := NewLedgerRepository()
+
+		tx := createTransaction(t, ctx, r,
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.IgnoreAccountVersion, 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100),
+		)
+
+		retry, err := entities.NewTransaction(uuid.New(), tx.Event, tx.Company, tx.CompetenceDate.AddDate(0, -1, 0), tx.Entries...)
+		assert.NoError(t, err)
+
+		_, err = r.CreateTransaction(ctx, retry)
+		assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation)
+		assert.Len(t, r.entries, 2)
+	})
+
+	t.Run("should fail when the book doesn't exist", func(t *testing.T) {
+		r := NewLedgerRepository()
+
+		tx, err := entities.NewTransaction(uuid.New(), 1, "abc", time.Now(),
+			createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.NextAccountVersion, 100),
+			createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.NextAccountVersion, 100),
+		)
+		assert.NoError(t, err)
+
+		_, err = r.CreateTransaction(vos.WithBook(ctx, "unknown"), tx)
+		assert.ErrorIs(t, err, app.ErrBookNotFound)
+		assert.Empty(t, r.entries)
+	})
+}
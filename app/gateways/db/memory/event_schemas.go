@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// CreateEventSchema adds a version to the schema of the event. Unlike postgres, any event is known.
+func (r *LedgerRepository) CreateEventSchema(_ context.Context, event uint32, schema json.RawMessage) (vos.EventSchema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	eventSchema := vos.EventSchema{
+		Event:     event,
+		Version:   len(r.schemas[event]) + 1,
+		Schema:    schema,
+		CreatedAt: time.Now(),
+	}
+	r.schemas[event] = append(r.schemas[event], eventSchema)
+
+	return eventSchema, nil
+}
+
+func (r *LedgerRepository) GetEventSchema(_ context.Context, event uint32) (vos.EventSchema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := r.schemas[event]
+	if len(versions) == 0 {
+		return vos.EventSchema{}, app.ErrEventSchemaNotFound
+	}
+
+	return versions[len(versions)-1], nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// GetAnalyticAccountBalance returns the balance of the account with the greatest version of its most
+// recent transaction, as get_analytic_account_balance does.
+func (r *LedgerRepository) GetAnalyticAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	balance, ok := r.analyticBalance(r.book(ctx), account)
+	if !ok {
+		return vos.AccountBalance{}, app.ErrAccountNotFound
+	}
+
+	return balance, nil
+}
+
+// analyticBalance is GetAnalyticAccountBalance for callers holding the lock.
+func (r *LedgerRepository) analyticBalance(b *book, account vos.Account) (vos.AccountBalance, bool) {
+	indexes, ok := b.accounts[account.Value()]
+	if !ok {
+		return vos.AccountBalance{}, false
+	}
+	var (
+		balance  int
+		version  vos.Version
+		sequence = -1
+	)
+
+	for _, i := range indexes {
+		e := r.entries[i]
+		balance += e.balance()
+
+		if e.sequence > sequence || (e.sequence == sequence && e.version > version) {
+			sequence = e.sequence
+			version = e.version
+		}
+	}
+
+	return vos.NewAnalyticAccountBalance(account, version, balance), true
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func TestLedgerRepository_GetAnalyticAccountBalance(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository()
+
+	account, err := vos.NewAccount("liability.abc.account1")
+	assert.NoError(t, err)
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, account.Value(), vos.NextAccountVersion, 150),
+		createEntry(t, vos.DebitOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 150),
+	)
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, account.Value(), vos.NextAccountVersion, 50),
+		createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 50),
+	)
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, account)
+	assert.NoError(t, err)
+	assert.Equal(t, vos.NewAnalyticAccountBalance(account, vos.Version(2), 100), balance)
+
+	missing, err := vos.NewAccount("liability.abc.account3")
+	assert.NoError(t, err)
+
+	_, err = r.GetAnalyticAccountBalance(ctx, missing)
+	assert.ErrorIs(t, err, app.ErrAccountNotFound)
+}
@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func (r *LedgerRepository) GetSyntheticAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		balance int
+		found   bool
+	)
+
+	for acc, indexes := range r.book(ctx).accounts {
+		if !matchQuery(account, acc) {
+			continue
+		}
+
+		found = true
+		for _, i := range indexes {
+			balance += r.entries[i].balance()
+		}
+	}
+
+	if !found {
+		return vos.AccountBalance{}, app.ErrAccountNotFound
+	}
+
+	return vos.NewSyntheticAccountBalance(account, balance), nil
+}
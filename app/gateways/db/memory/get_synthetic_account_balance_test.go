@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func TestLedgerRepository_GetSyntheticAccountBalance(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository()
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, "liability.abc.account1", vos.IgnoreAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 50),
+		createEntry(t, vos.DebitOperation, "liability.xyz.account1", vos.IgnoreAccountVersion, 150),
+	)
+
+	testCases := []struct {
+		name    string
+		query   string
+		balance int
+		err     error
+	}{
+		{
+			name:    "should sum the accounts below the query",
+			query:   "liability.abc.*",
+			balance: 150,
+		},
+		{
+			name:    "should sum the accounts matching the label prefix",
+			query:   "liability.*.account1",
+			balance: -50,
+		},
+		{
+			name:    "should sum every account",
+			query:   "liability.*",
+			balance: 0,
+		},
+		{
+			name:  "should return not found when nothing matches",
+			query: "liability.def.*",
+			err:   app.ErrAccountNotFound,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := vos.NewAccount(tt.query)
+			assert.NoError(t, err)
+
+			got, err := r.GetSyntheticAccountBalance(ctx, account)
+			assert.ErrorIs(t, err, tt.err)
+
+			if tt.err == nil {
+				assert.Equal(t, vos.NewSyntheticAccountBalance(account, tt.balance), got)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var errInvalidReportLevel = errors.New("report level is greater than the account depth")
+
+// GetSyntheticReport groups the entries created in [startTime, endTime) by the first level labels of
+// their accounts. Results are sorted by account.
+func (r *LedgerRepository) GetSyntheticReport(ctx context.Context, query vos.Account, level int, startTime time.Time, endTime time.Time) (*vos.SyntheticReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type sums struct {
+		credit int64
+		debit  int64
+	}
+
+	groups := make(map[string]*sums)
+
+	for acc, indexes := range r.book(ctx).accounts {
+		if !matchQuery(query, acc) {
+			continue
+		}
+
+		labels := strings.Split(acc, ".")
+
+		for _, i := range indexes {
+			e := r.entries[i]
+			if e.createdAt.Before(startTime) || !e.createdAt.Before(endTime) {
+				continue
+			}
+
+			if level > len(labels) {
+				return nil, fmt.Errorf("%w: %d for %s", errInvalidReportLevel, level, acc)
+			}
+
+			path := strings.Join(labels[:level], ".")
+
+			group, ok := groups[path]
+			if !ok {
+				group = &sums{}
+				groups[path] = group
+			}
+
+			if e.operation == vos.CreditOperation {
+				group.credit += int64(e.amount)
+			} else {
+				group.debit += int64(e.amount)
+			}
+		}
+	}
+
+	if len(groups) == 0 {
+		return &vos.SyntheticReport{}, nil
+	}
+
+	paths := make([]string, 0, len(groups))
+	for path := range groups {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var (
+		results     = make([]vos.AccountResult, 0, len(paths))
+		totalCredit int64
+		totalDebit  int64
+	)
+
+	for _, path := range paths {
+		account, err := vos.NewAnalyticAccount(path)
+		if err != nil {
+			return nil, err
+		}
+
+		group := groups[path]
+		results = append(results, vos.AccountResult{
+			Account: account,
+			Credit:  group.credit,
+			Debit:   group.debit,
+		})
+
+		totalCredit += group.credit
+		totalDebit += group.debit
+	}
+
+	return vos.NewSyntheticReport(totalCredit, totalDebit, results)
+}
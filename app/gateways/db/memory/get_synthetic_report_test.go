@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func TestLedgerRepository_GetSyntheticReport(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository()
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, "liability.abc.account1", vos.IgnoreAccountVersion, 100),
+		createEntry(t, vos.DebitOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 40),
+		createEntry(t, vos.DebitOperation, "liability.xyz.account1", vos.IgnoreAccountVersion, 60),
+	)
+
+	start := time.Now().Add(-time.Hour)
+	end := time.Now().Add(time.Hour)
+
+	t.Run("should group the entries by level", func(t *testing.T) {
+		query, err := vos.NewAccount("liability.*")
+		assert.NoError(t, err)
+
+		got, err := r.GetSyntheticReport(ctx, query, 3, start, end)
+		assert.NoError(t, err)
+
+		accounts := make([]string, 0, len(got.Results))
+		for _, result := range got.Results {
+			accounts = append(accounts, result.Account.Value())
+		}
+
+		assert.Equal(t, int64(100), got.TotalCredit)
+		assert.Equal(t, int64(100), got.TotalDebit)
+		assert.Equal(t, []string{"liability.abc.account1", "liability.abc.account2", "liability.xyz.account1"}, accounts)
+	})
+
+	t.Run("should return an empty report outside the period", func(t *testing.T) {
+		query, err := vos.NewAccount("liability.*")
+		assert.NoError(t, err)
+
+		got, err := r.GetSyntheticReport(ctx, query, 3, end, end.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, &vos.SyntheticReport{}, got)
+	})
+
+	t.Run("should fail when the level is greater than the account depth", func(t *testing.T) {
+		query, err := vos.NewAccount("liability.*")
+		assert.NoError(t, err)
+
+		_, err = r.GetSyntheticReport(ctx, query, 4, start, end)
+		assert.ErrorIs(t, err, errInvalidReportLevel)
+	})
+}
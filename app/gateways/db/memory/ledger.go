@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var _ domain.Repository = &LedgerRepository{}
+
+// LedgerRepository is a domain.Repository that keeps the ledger in memory. It follows the contract of
+// the postgres repository, so use cases can be exercised without a database, but nothing survives
+// a restart.
+type LedgerRepository struct {
+	mu sync.RWMutex
+
+	entries []entry
+	books   map[string]*book
+	schemas map[uint32][]vos.EventSchema
+
+	// ids holds the ids of the created entries, the idempotency keys of the ledger, whatever their
+	// competence date, like the postgres entry_id table.
+	ids map[uuid.UUID]struct{}
+
+	// transactions counts the created transactions, so entries of the same transaction share a
+	// sequence number just like they share created_at in postgres.
+	transactions int
+}
+
+type entry struct {
+	id             uuid.UUID
+	txID           uuid.UUID
+	sequence       int
+	event          uint32
+	operation      vos.OperationType
+	version        vos.Version
+	amount         int
+	createdAt      time.Time
+	competenceDate time.Time
+	account        string
+	company        string
+	metadata       json.RawMessage
+}
+
+// book indexes the entries and keeps the account versions of a book.
+type book struct {
+	createdAt time.Time
+	accounts  map[string][]int
+	versions  map[string]vos.Version
+}
+
+// NewLedgerRepository creates a repository with the default book.
+func NewLedgerRepository() *LedgerRepository {
+	return &LedgerRepository{
+		entries: make([]entry, 0),
+		books:   map[string]*book{vos.DefaultBook: newBook()},
+		schemas: make(map[uint32][]vos.EventSchema),
+		ids:     make(map[uuid.UUID]struct{}),
+	}
+}
+
+func newBook() *book {
+	return &book{
+		createdAt: time.Now(),
+		accounts:  make(map[string][]int),
+		versions:  make(map[string]vos.Version),
+	}
+}
+
+// book returns the book of ctx, which is empty when the book doesn't exist.
+func (r *LedgerRepository) book(ctx context.Context) *book {
+	if b, ok := r.books[vos.BookFromContext(ctx)]; ok {
+		return b
+	}
+
+	return newBook()
+}
+
+func (e entry) balance() int {
+	if e.operation == vos.CreditOperation {
+		return e.amount
+	}
+
+	return -e.amount
+}
@@ -0,0 +1,269 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	pag "github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+// listAccountEntriesCursor and listSyntheticEntriesCursor have the same format of the postgres cursors,
+// so page tokens are interchangeable.
+type listAccountEntriesCursor struct {
+	CompetenceDate time.Time `json:"competence_date"`
+	Version        int64     `json:"version"`
+}
+
+type listSyntheticEntriesCursor struct {
+	CompetenceDate time.Time `json:"competence_date"`
+	CreatedAt      time.Time `json:"created_at"`
+	ID             uuid.UUID `json:"id"`
+}
+
+func (r *LedgerRepository) ListAccountEntries(ctx context.Context, req vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
+	synthetic := req.Account.Type() == vos.Synthetic
+
+	var (
+		cursor          *listAccountEntriesCursor
+		syntheticCursor *listSyntheticEntriesCursor
+	)
+	if req.Page.Cursor != nil {
+		var err error
+		if synthetic {
+			syntheticCursor = &listSyntheticEntriesCursor{}
+			err = req.Page.Extract(syntheticCursor)
+		} else {
+			cursor = &listAccountEntriesCursor{}
+			err = req.Page.Extract(cursor)
+		}
+
+		if err != nil {
+			return vos.AccountEntryResponse{}, fmt.Errorf("failed to extract cursor: %w", err)
+		}
+	}
+
+	r.mu.RLock()
+	history := make([]entry, 0)
+	for account, indexes := range r.book(ctx).accounts {
+		if !matchEntryAccount(req.Account, account) {
+			continue
+		}
+
+		for _, i := range indexes {
+			history = append(history, r.entries[i])
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(history, func(i, j int) bool {
+		if !history[i].competenceDate.Equal(history[j].competenceDate) {
+			return history[i].competenceDate.After(history[j].competenceDate)
+		}
+
+		if synthetic {
+			return syntheticAfter(history[i], history[j])
+		}
+
+		if history[i].version != history[j].version {
+			return history[i].version > history[j].version
+		}
+
+		return bytes.Compare(history[i].id[:], history[j].id[:]) > 0
+	})
+
+	// Like in postgres, the balance after each entry sums every entry up to it, whatever the period,
+	// and the opening balance sums the entries before the period.
+	var (
+		balances = make([]int, len(history))
+		balance  = 0
+		response vos.AccountEntryResponse
+	)
+
+	if req.IncludeOpeningBalance {
+		response.OpeningBalance = new(int)
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].operation == vos.CreditOperation {
+			balance += history[i].amount
+		} else {
+			balance -= history[i].amount
+		}
+
+		balances[i] = balance
+
+		if response.OpeningBalance != nil && history[i].competenceDate.Before(req.StartDate) {
+			*response.OpeningBalance = balance
+		}
+	}
+
+	entries := make([]vos.AccountEntry, 0)
+	for i, e := range history {
+		if len(entries) > req.Page.Size {
+			break
+		}
+
+		if e.competenceDate.Before(req.StartDate) || !e.competenceDate.Before(req.EndDate) {
+			continue
+		}
+
+		if !matchEntryRequest(e, req, cursor) || (syntheticCursor != nil && syntheticCursor.before(e)) {
+			continue
+		}
+
+		metadata := make(map[string]interface{})
+		if len(e.metadata) > 0 {
+			if err := json.Unmarshal(e.metadata, &metadata); err != nil {
+				return vos.AccountEntryResponse{}, fmt.Errorf("failed to decode metadata: %w", err)
+			}
+		}
+
+		account, err := vos.NewAnalyticAccount(e.account)
+		if err != nil {
+			return vos.AccountEntryResponse{}, fmt.Errorf("failed to parse account %s: %w", e.account, err)
+		}
+
+		entries = append(entries, vos.AccountEntry{
+			ID:             e.id,
+			Version:        e.version,
+			Operation:      e.operation,
+			Amount:         e.amount,
+			Event:          int(e.event),
+			CompetenceDate: e.competenceDate,
+			Metadata:       metadata,
+			Account:        account,
+			CreatedAt:      e.createdAt,
+			TxID:           e.txID,
+			Company:        e.company,
+			Balance:        balances[i],
+		})
+	}
+
+	if len(entries) <= req.Page.Size {
+		response.Entries = entries
+
+		return response, nil
+	}
+
+	lastEntry := entries[len(entries)-1]
+	entries = entries[:len(entries)-1]
+
+	var (
+		next pag.Cursor
+		err  error
+	)
+	if synthetic {
+		next, err = pag.NewCursor(listSyntheticEntriesCursor{
+			CompetenceDate: lastEntry.CompetenceDate,
+			CreatedAt:      lastEntry.CreatedAt,
+			ID:             lastEntry.ID,
+		})
+	} else {
+		next, err = pag.NewCursor(listAccountEntriesCursor{
+			CompetenceDate: lastEntry.CompetenceDate,
+			Version:        lastEntry.Version.AsInt64(),
+		})
+	}
+	if err != nil {
+		return vos.AccountEntryResponse{}, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	response.Entries, response.NextPage = entries, next
+
+	return response, nil
+}
+
+// matchEntryAccount reports whether the entries of account are listed for the requested account,
+// which is either the same account or a synthetic account matching it.
+func matchEntryAccount(requested vos.Account, account string) bool {
+	if requested.Type() == vos.Synthetic {
+		return matchAccount(requested.Value(), account)
+	}
+
+	return requested.Value() == account
+}
+
+func matchEntryRequest(e entry, req vos.AccountEntryRequest, cursor *listAccountEntriesCursor) bool {
+	if len(req.Filter.Companies) > 0 && !containsString(req.Filter.Companies, e.company) {
+		return false
+	}
+
+	if len(req.Filter.Events) > 0 && !containsInt32(req.Filter.Events, int32(e.event)) {
+		return false
+	}
+
+	if req.Filter.Operation != vos.InvalidOperation && req.Filter.Operation != e.operation {
+		return false
+	}
+
+	if !req.Filter.Metadata.IsEmpty() {
+		metadata := make(map[string]interface{})
+		if len(e.metadata) > 0 && json.Unmarshal(e.metadata, &metadata) != nil {
+			return false
+		}
+
+		if !req.Filter.Metadata.Match(metadata) {
+			return false
+		}
+	}
+
+	if cursor != nil {
+		// (competence_date, version) <= (cursor.competence_date, cursor.version)
+		if e.competenceDate.After(cursor.CompetenceDate) {
+			return false
+		}
+
+		if e.competenceDate.Equal(cursor.CompetenceDate) && e.version.AsInt64() > cursor.Version {
+			return false
+		}
+	}
+
+	return true
+}
+
+// syntheticAfter reports whether a comes after b by (created_at, id), in the order of entries with
+// the same competence date.
+func syntheticAfter(a, b entry) bool {
+	if !a.createdAt.Equal(b.createdAt) {
+		return a.createdAt.After(b.createdAt)
+	}
+
+	return bytes.Compare(a.id[:], b.id[:]) > 0
+}
+
+// before reports whether e comes before the cursor, so it was already listed:
+// (competence_date, created_at, id) > (cursor.competence_date, cursor.created_at, cursor.id)
+func (c listSyntheticEntriesCursor) before(e entry) bool {
+	if !e.competenceDate.Equal(c.CompetenceDate) {
+		return e.competenceDate.After(c.CompetenceDate)
+	}
+
+	return syntheticAfter(e, entry{createdAt: c.CreatedAt, id: c.ID})
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsInt32(values []int32, value int32) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
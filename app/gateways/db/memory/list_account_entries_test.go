@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+func TestLedgerRepository_ListAccountEntries(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository()
+
+	account, err := vos.NewAccount("liability.abc.account1")
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		createTransaction(t, ctx, r,
+			createEntry(t, vos.CreditOperation, account.Value(), vos.NextAccountVersion, 100),
+			createEntry(t, vos.DebitOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100),
+		)
+	}
+
+	req := vos.AccountEntryRequest{
+		Account:   account,
+		StartDate: time.Now().Add(-time.Hour),
+		EndDate:   time.Now().Add(time.Hour),
+		Page:      pagination.Page{Size: 2},
+	}
+
+	resp, err := r.ListAccountEntries(ctx, req)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.NextPage)
+
+	firstPage := resp.Entries
+	assert.Len(t, firstPage, 2)
+	assert.Equal(t, vos.Version(3), firstPage[0].Version)
+	assert.Equal(t, vos.Version(2), firstPage[1].Version)
+	assert.Equal(t, map[string]interface{}{}, firstPage[0].Metadata)
+
+	req.Page.Cursor = resp.NextPage
+
+	resp, err = r.ListAccountEntries(ctx, req)
+	assert.NoError(t, err)
+	assert.Nil(t, resp.NextPage)
+
+	secondPage := resp.Entries
+	assert.Len(t, secondPage, 1)
+	assert.Equal(t, vos.Version(1), secondPage[0].Version)
+
+	req.Page = pagination.Page{Size: 10}
+	req.Filter.Operation = vos.DebitOperation
+
+	resp, err = r.ListAccountEntries(ctx, req)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Entries)
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	pag "github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+// listAccountsCursor has the same format of the postgres cursor, so page tokens are interchangeable.
+type listAccountsCursor struct {
+	Account string `json:"account"`
+}
+
+// ListAccounts sorts accounts as strings, which matches the ltree order since dots sort before every
+// label character.
+func (r *LedgerRepository) ListAccounts(ctx context.Context, req vos.AccountListRequest) ([]vos.AccountSummary, pag.Cursor, error) {
+	after, err := extractAccountsCursor(req.Page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.RLock()
+	b := r.book(ctx)
+	accounts := make([]vos.AccountSummary, 0)
+	for account, indexes := range b.accounts {
+		if account <= after || !matchAccount(req.Pattern.Value(), account) {
+			continue
+		}
+
+		analytic, accErr := vos.NewAnalyticAccount(account)
+		if accErr != nil {
+			r.mu.RUnlock()
+			return nil, nil, fmt.Errorf("failed to parse account %s: %w", account, accErr)
+		}
+
+		accounts = append(accounts, vos.AccountSummary{Account: analytic, CreatedAt: r.entries[indexes[0]].createdAt})
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Account.Value() < accounts[j].Account.Value()
+	})
+
+	if len(accounts) <= req.Page.Size {
+		return accounts, nil, nil
+	}
+
+	accounts = accounts[:req.Page.Size]
+
+	next, err := pag.NewCursor(listAccountsCursor{Account: accounts[len(accounts)-1].Account.Value()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	return accounts, next, nil
+}
+
+func (r *LedgerRepository) ListAccountChildren(ctx context.Context, req vos.AccountChildrenRequest) ([]vos.AccountChild, pag.Cursor, error) {
+	after, err := extractAccountsCursor(req.Page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	depth := req.Prefix.Depth()
+	counts := make(map[string]int)
+
+	r.mu.RLock()
+	for account := range r.book(ctx).accounts {
+		labels := strings.Split(account, ".")
+		if len(labels) <= depth || (depth > 0 && strings.Join(labels[:depth], ".") != req.Prefix.Value()) {
+			continue
+		}
+
+		counts[labels[depth]]++
+	}
+	r.mu.RUnlock()
+
+	children := make([]vos.AccountChild, 0, len(counts))
+	for label, count := range counts {
+		child := req.Prefix.Child(label)
+		if child.Value() <= after {
+			continue
+		}
+
+		children = append(children, vos.AccountChild{Prefix: child, Accounts: count})
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Prefix.Value() < children[j].Prefix.Value()
+	})
+
+	if len(children) <= req.Page.Size {
+		return children, nil, nil
+	}
+
+	children = children[:req.Page.Size]
+
+	next, err := pag.NewCursor(listAccountsCursor{Account: children[len(children)-1].Prefix.Value()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	return children, next, nil
+}
+
+// extractAccountsCursor returns the account after which the page starts, which is empty for the
+// first page.
+func extractAccountsCursor(page pag.Page) (string, error) {
+	if page.Cursor == nil {
+		return "", nil
+	}
+
+	var cursor listAccountsCursor
+	if err := page.Extract(&cursor); err != nil {
+		return "", fmt.Errorf("failed to extract cursor: %w", err)
+	}
+
+	return cursor.Account, nil
+}
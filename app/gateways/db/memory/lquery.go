@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// maxLabels is the upper bound of the wildcards without one.
+const maxLabels = 65535
+
+// matchAccount reports whether the analytic account matches the synthetic account, following the
+// lquery syntax accepted by vos.Account: a '*' label matches any number of labels (including none),
+// or from n to m labels when followed by '{n,m}', a label ending with '*' matches the labels starting
+// with its prefix, 'a|b' matches either label and '!a|b' matches a label that is neither.
+func matchAccount(query, account string) bool {
+	return matchLabels(strings.Split(query, "."), strings.Split(account, "."))
+}
+
+// matchQuery reports whether the analytic account matches the synthetic account and its search.
+func matchQuery(query vos.Account, account string) bool {
+	return matchAccount(query.Value(), account) && query.Search().Match(account)
+}
+
+func matchLabels(query, account []string) bool {
+	if len(query) == 0 {
+		return len(account) == 0
+	}
+
+	if strings.HasPrefix(query[0], "*") {
+		low, high := wildcardBounds(query[0][1:])
+		for i := low; i <= high && i <= len(account); i++ {
+			if matchLabels(query[1:], account[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(account) == 0 || !matchLevel(query[0], account[0]) {
+		return false
+	}
+
+	return matchLabels(query[1:], account[1:])
+}
+
+// wildcardBounds parses the '{n}', '{n,}', '{,m}' or '{n,m}' that follows a wildcard, which are
+// written by vos.Account.
+func wildcardBounds(quantifier string) (int, int) {
+	if quantifier == "" {
+		return 0, maxLabels
+	}
+
+	bounds := strings.Split(strings.Trim(quantifier, "{}"), ",")
+
+	low, _ := strconv.Atoi(bounds[0])
+	if len(bounds) == 1 {
+		return low, low
+	}
+
+	high := maxLabels
+	if bounds[1] != "" {
+		high, _ = strconv.Atoi(bounds[1])
+	}
+
+	return low, high
+}
+
+func matchLevel(query, label string) bool {
+	negated := strings.HasPrefix(query, "!")
+
+	for _, variant := range strings.Split(strings.TrimPrefix(query, "!"), "|") {
+		if matchLabel(variant, label) {
+			return !negated
+		}
+	}
+
+	return negated
+}
+
+func matchLabel(query, label string) bool {
+	if strings.HasSuffix(query, "*") {
+		return strings.HasPrefix(label, strings.TrimSuffix(query, "*"))
+	}
+
+	return query == label
+}
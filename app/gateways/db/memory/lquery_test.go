@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchAccount(t *testing.T) {
+	testCases := []struct {
+		query   string
+		account string
+		want    bool
+	}{
+		{query: "liability.abc.account1", account: "liability.abc.account1", want: true},
+		{query: "liability.abc.account1", account: "liability.abc.account2", want: false},
+		{query: "liability.*", account: "liability.abc.account1", want: true},
+		{query: "liability.abc.*", account: "liability.abc", want: true},
+		{query: "liability.*.account1", account: "liability.abc.def.account1", want: true},
+		{query: "liability.*.account1", account: "liability.abc.account2", want: false},
+		{query: "liability.ab*.account1", account: "liability.abc.account1", want: true},
+		{query: "liability.ab*.account1", account: "liability.xyz.account1", want: false},
+		{query: "assets.*", account: "liability.abc.account1", want: false},
+		{query: "liability.abc|def.*", account: "liability.def.account1", want: true},
+		{query: "liability.abc|def.*", account: "liability.xyz.account1", want: false},
+		{query: "liability.!abc|def.*", account: "liability.xyz.account1", want: true},
+		{query: "liability.!abc|de*.*", account: "liability.def.account1", want: false},
+		{query: "liability.*{1}.account1", account: "liability.abc.account1", want: true},
+		{query: "liability.*{1}.account1", account: "liability.abc.def.account1", want: false},
+		{query: "liability.*{2,}", account: "liability.abc", want: false},
+		{query: "liability.*{,1}.account1", account: "liability.account1", want: true},
+		{query: "liability.*{1,2}.account1", account: "liability.abc.def.account1", want: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query+" ~ "+tt.account, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchAccount(tt.query, tt.account))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func createEntry(t testing.TB, op vos.OperationType, account string, version vos.Version, amount int) entities.Entry {
+	t.Helper()
+
+	entry, err := entities.NewEntry(
+		uuid.New(),
+		op,
+		account,
+		version,
+		amount,
+		json.RawMessage(`{}`),
+	)
+	assert.NoError(t, err)
+
+	return entry
+}
+
+func createTransaction(t testing.TB, ctx context.Context, r *LedgerRepository, entries ...entities.Entry) entities.Transaction {
+	t.Helper()
+
+	tx, err := entities.NewTransaction(
+		uuid.New(),
+		uint32(1),
+		"abc",
+		time.Now(),
+		entries...,
+	)
+	assert.NoError(t, err)
+
+	_, err = r.CreateTransaction(ctx, tx)
+	assert.NoError(t, err)
+
+	return tx
+}
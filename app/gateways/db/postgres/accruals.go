@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+const (
+	accrualsCollection        = "accrual_run"
+	accrualBackRunsCollection = "accrual_backrun"
+)
+
+// The balance at the end of a day is the current balance of the account less its entries dated from
+// the next day on, which are few for recent days and live in the latest partitions. The current
+// balance is the running balance while it's up to date, or the balance snapshot plus the entries
+// created after it. Only accounts without either sum their whole history. Accounts are read from the
+// catalog.
+const listEndOfDayBalancesQuery = `
+select
+	account,
+	balance
+from (
+	select
+		a.account::text as account,
+		(coalesce(rb.balance, coalesce(s.balance, 0) + recent.balance) - later.balance)::bigint as balance
+	from
+		account a
+		left join account_running_balance rb on rb.book = a.book and rb.account = a.account and not (select stale from running_balance_status)
+		left join account_balance s on rb.account is null and s.book = a.book and s.account = a.account::text
+		cross join lateral (
+			select
+				coalesce(sum(case when e.operation = 1 then e.amount else -e.amount end), 0) as balance
+			from
+				entry e
+			where
+				rb.account is null
+				and e.book = a.book
+				and e.account = a.account
+				and (s.tx_date is null or e.created_at > s.tx_date)
+		) recent
+		cross join lateral (
+			select
+				coalesce(sum(case when e.operation = 1 then e.amount else -e.amount end), 0) as balance
+			from
+				entry e
+			where
+				e.book = a.book
+				and e.account = a.account
+				and e.competence_date >= $3
+		) later
+	where
+		a.book = $1
+		and a.account ~ $2::lquery
+) balances
+where
+	balance <> 0
+order by
+	account;
+`
+
+const createAccrualRunQuery = `
+insert into accrual_run (rule, date, book, accounts, amount)
+values ($1, $2, $3, $4, $5)
+returning created_at;
+`
+
+const listAccrualRunsQuery = `
+select
+	rule,
+	date,
+	book,
+	accounts,
+	amount,
+	created_at
+from
+	accrual_run
+where
+	($1 = '' or rule = $1)
+	and date between $2 and $3
+order by
+	date,
+	rule;
+`
+
+const getLastAccrualDayQuery = `
+select max(date) from accrual_run where rule = $1;
+`
+
+// Requesting a back-run again keeps the first request, returning it.
+const createAccrualBackRunQuery = `
+insert into accrual_backrun (rule, start_date, end_date)
+values ($1, $2, $3)
+on conflict (rule, start_date, end_date) do update set rule = excluded.rule
+returning created_at;
+`
+
+const listAccrualBackRunsQuery = `
+select
+	rule,
+	start_date,
+	end_date,
+	created_at
+from
+	accrual_backrun
+order by
+	created_at,
+	rule;
+`
+
+const deleteAccrualBackRunQuery = `
+delete from accrual_backrun where rule = $1 and start_date = $2 and end_date = $3;
+`
+
+// ListEndOfDayBalances returns the non zero balances of the analytic accounts matched by account, in
+// the book of the context, from their entries whose competence date is before end. It reads from
+// the primary, as accruals can't miss entries lagging on a replica.
+func (r LedgerRepository) ListEndOfDayBalances(ctx context.Context, account vos.Account, end time.Time) ([]vos.AccountBalance, error) {
+	const op = "Repository.ListEndOfDayBalances"
+
+	defer r.pb.MonitorDataSegment(ctx, collection, op, listEndOfDayBalancesQuery).End()
+
+	rows, err := r.db.Query(ctx, listEndOfDayBalancesQuery, vos.BookFromContext(ctx), account.Value(), end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	balances := make([]vos.AccountBalance, 0)
+
+	for rows.Next() {
+		var (
+			value   string
+			balance int
+		)
+
+		if err = rows.Scan(&value, &balance); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		analytic, err := vos.NewAnalyticAccount(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse account %s: %w", value, err)
+		}
+
+		balances = append(balances, vos.AccountBalance{Account: analytic, CurrentVersion: vos.IgnoreAccountVersion, Balance: balance})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	return balances, nil
+}
+
+// CreateAccrualRun saves the run of a rule for a day, failing with app.ErrIdempotencyKeyViolation if
+// the rule already accrued the day.
+func (r LedgerRepository) CreateAccrualRun(ctx context.Context, run vos.AccrualRun) (vos.AccrualRun, error) {
+	const operation = "Repository.CreateAccrualRun"
+
+	defer r.pb.MonitorDataSegment(ctx, accrualsCollection, operation, createAccrualRunQuery).End()
+
+	err := r.db.QueryRow(ctx, createAccrualRunQuery, run.Rule, run.Date, run.Book, run.Accounts, run.Amount).Scan(&run.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return vos.AccrualRun{}, app.ErrIdempotencyKeyViolation
+			case pgerrcode.ForeignKeyViolation:
+				return vos.AccrualRun{}, app.ErrBookNotFound
+			}
+		}
+
+		return vos.AccrualRun{}, fmt.Errorf("failed to create accrual run: %w", err)
+	}
+
+	return run, nil
+}
+
+// ListAccrualRuns lists the runs between the dates of the request, by date.
+func (r LedgerRepository) ListAccrualRuns(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualRun, error) {
+	const op = "Repository.ListAccrualRuns"
+
+	defer r.pb.MonitorDataSegment(ctx, accrualsCollection, op, listAccrualRunsQuery).End()
+
+	rows, err := r.db.Query(ctx, listAccrualRunsQuery, req.Rule, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	runs := make([]vos.AccrualRun, 0)
+
+	for rows.Next() {
+		var run vos.AccrualRun
+		if err = rows.Scan(&run.Rule, &run.Date, &run.Book, &run.Accounts, &run.Amount, &run.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	return runs, nil
+}
+
+// GetLastAccrualDay returns the last day the rule accrued, which is zero if it never did.
+func (r LedgerRepository) GetLastAccrualDay(ctx context.Context, rule string) (time.Time, error) {
+	const op = "Repository.GetLastAccrualDay"
+
+	defer r.pb.MonitorDataSegment(ctx, accrualsCollection, op, getLastAccrualDayQuery).End()
+
+	var last *time.Time
+	if err := r.db.QueryRow(ctx, getLastAccrualDayQuery, rule).Scan(&last); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last accrual day: %w", err)
+	}
+
+	if last == nil {
+		return time.Time{}, nil
+	}
+
+	return *last, nil
+}
+
+// CreateAccrualBackRun saves a back-run, returning the one saved before for the same range.
+func (r LedgerRepository) CreateAccrualBackRun(ctx context.Context, backRun vos.AccrualBackRun) (vos.AccrualBackRun, error) {
+	const operation = "Repository.CreateAccrualBackRun"
+
+	defer r.pb.MonitorDataSegment(ctx, accrualBackRunsCollection, operation, createAccrualBackRunQuery).End()
+
+	err := r.db.QueryRow(ctx, createAccrualBackRunQuery, backRun.Rule, backRun.StartDate, backRun.EndDate).Scan(&backRun.CreatedAt)
+	if err != nil {
+		return vos.AccrualBackRun{}, fmt.Errorf("failed to create accrual back-run: %w", err)
+	}
+
+	return backRun, nil
+}
+
+// ListAccrualBackRuns lists the pending back-runs, oldest first.
+func (r LedgerRepository) ListAccrualBackRuns(ctx context.Context) ([]vos.AccrualBackRun, error) {
+	const op = "Repository.ListAccrualBackRuns"
+
+	defer r.pb.MonitorDataSegment(ctx, accrualBackRunsCollection, op, listAccrualBackRunsQuery).End()
+
+	rows, err := r.db.Query(ctx, listAccrualBackRunsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	backRuns := make([]vos.AccrualBackRun, 0)
+
+	for rows.Next() {
+		var backRun vos.AccrualBackRun
+		if err = rows.Scan(&backRun.Rule, &backRun.StartDate, &backRun.EndDate, &backRun.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		backRuns = append(backRuns, backRun)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	return backRuns, nil
+}
+
+// DeleteAccrualBackRun removes a back-run, which is done or whose rule no longer exists.
+func (r LedgerRepository) DeleteAccrualBackRun(ctx context.Context, backRun vos.AccrualBackRun) error {
+	const operation = "Repository.DeleteAccrualBackRun"
+
+	defer r.pb.MonitorDataSegment(ctx, accrualBackRunsCollection, operation, deleteAccrualBackRunQuery).End()
+
+	if _, err := r.db.Exec(ctx, deleteAccrualBackRunQuery, backRun.Rule, backRun.StartDate, backRun.EndDate); err != nil {
+		return fmt.Errorf("failed to delete accrual back-run: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+)
+
+func TestLedgerRepository_ListEndOfDayBalances(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance", "account")
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, "liability.accrual.acc1", vos.NextAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, "liability.accrual.acc2", vos.NextAccountVersion, 100),
+	)
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, "liability.accrual.acc2", vos.NextAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, "liability.accrual.acc3", vos.NextAccountVersion, 100),
+	)
+
+	query, err := vos.NewAccount("liability.accrual.*")
+	require.NoError(t, err)
+
+	balances, err := r.ListEndOfDayBalances(ctx, query, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, "liability.accrual.acc1", balances[0].Account.Value())
+	assert.Equal(t, -100, balances[0].Balance)
+	assert.Equal(t, "liability.accrual.acc3", balances[1].Account.Value())
+	assert.Equal(t, 100, balances[1].Balance)
+
+	balances, err = r.ListEndOfDayBalances(ctx, query, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, balances)
+
+	balances, err = r.ListEndOfDayBalances(vos.WithBook(ctx, "other"), query, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, balances)
+
+	// A snapshot of acc1 is written, then entries are posted after it, one of them dated days later.
+	acc1, err := vos.NewAccount("liability.accrual.acc1")
+	require.NoError(t, err)
+
+	_, err = r.GetAnalyticAccountBalance(ctx, acc1)
+	require.NoError(t, err)
+
+	_, err = fetchSnapshot(ctx, pgDocker.DB, acc1)
+	require.NoError(t, err)
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, "liability.accrual.acc1", vos.NextAccountVersion, 10),
+		createEntry(t, vos.CreditOperation, "liability.accrual.acc3", vos.NextAccountVersion, 10),
+	)
+	createDatedTransaction(t, ctx, r, time.Now().AddDate(0, 0, 2),
+		createEntry(t, vos.DebitOperation, "liability.accrual.acc1", vos.NextAccountVersion, 50),
+		createEntry(t, vos.CreditOperation, "liability.accrual.acc3", vos.NextAccountVersion, 50),
+	)
+
+	balances, err = r.ListEndOfDayBalances(ctx, query, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, -110, balances[0].Balance)
+	assert.Equal(t, 110, balances[1].Balance)
+
+	balances, err = r.ListEndOfDayBalances(ctx, query, time.Now().AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, -160, balances[0].Balance)
+	assert.Equal(t, 160, balances[1].Balance)
+}
+
+func TestEagerLedgerRepository_ListEndOfDayBalances(t *testing.T) {
+	ctx := context.Background()
+	r := NewEagerLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance", "account_running_balance", "account")
+	require.NoError(t, r.PrepareBalances(ctx))
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, "liability.accrual.acc1", vos.NextAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, "liability.accrual.acc2", vos.NextAccountVersion, 100),
+	)
+	createDatedTransaction(t, ctx, r, time.Now().AddDate(0, 0, 2),
+		createEntry(t, vos.DebitOperation, "liability.accrual.acc1", vos.NextAccountVersion, 50),
+		createEntry(t, vos.CreditOperation, "liability.accrual.acc2", vos.NextAccountVersion, 50),
+	)
+
+	// The running balance holds the later entry, which is taken out.
+	var running int
+	err := pgDocker.DB.QueryRow(ctx, `select balance from account_running_balance where account = 'liability.accrual.acc2'`).Scan(&running)
+	require.NoError(t, err)
+	assert.Equal(t, 150, running)
+
+	query, err := vos.NewAccount("liability.accrual.*")
+	require.NoError(t, err)
+
+	balances, err := r.ListEndOfDayBalances(ctx, query, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, -100, balances[0].Balance)
+	assert.Equal(t, 100, balances[1].Balance)
+}
+
+func TestLedgerRepository_AccrualBackRuns(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "accrual_backrun")
+
+	day := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	backRuns, err := r.ListAccrualBackRuns(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, backRuns)
+
+	interest, err := r.CreateAccrualBackRun(ctx, vos.AccrualBackRun{Rule: "interest", StartDate: day, EndDate: day.AddDate(0, 0, 9)})
+	require.NoError(t, err)
+	assert.False(t, interest.CreatedAt.IsZero())
+
+	fees, err := r.CreateAccrualBackRun(ctx, vos.AccrualBackRun{Rule: "fees", StartDate: day, EndDate: day})
+	require.NoError(t, err)
+
+	// Requesting the same back-run again keeps the pending one.
+	again, err := r.CreateAccrualBackRun(ctx, vos.AccrualBackRun{Rule: "interest", StartDate: day, EndDate: day.AddDate(0, 0, 9)})
+	require.NoError(t, err)
+	assert.True(t, interest.CreatedAt.Equal(again.CreatedAt))
+
+	backRuns, err = r.ListAccrualBackRuns(ctx)
+	require.NoError(t, err)
+	require.Len(t, backRuns, 2)
+	assert.Equal(t, "interest", backRuns[0].Rule)
+	assert.True(t, day.Equal(backRuns[0].StartDate))
+	assert.True(t, day.AddDate(0, 0, 9).Equal(backRuns[0].EndDate))
+	assert.Equal(t, "fees", backRuns[1].Rule)
+
+	require.NoError(t, r.DeleteAccrualBackRun(ctx, fees))
+
+	backRuns, err = r.ListAccrualBackRuns(ctx)
+	require.NoError(t, err)
+	require.Len(t, backRuns, 1)
+	assert.Equal(t, "interest", backRuns[0].Rule)
+}
+
+func TestLedgerRepository_AccrualRuns(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "accrual_run")
+
+	day := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	last, err := r.GetLastAccrualDay(ctx, "interest")
+	require.NoError(t, err)
+	assert.True(t, last.IsZero())
+
+	for _, run := range []vos.AccrualRun{
+		{Rule: "interest", Book: vos.DefaultBook, Date: day, Accounts: 2, Amount: 15},
+		{Rule: "interest", Book: vos.DefaultBook, Date: day.AddDate(0, 0, 1), Accounts: 2, Amount: 16},
+		{Rule: "fees", Book: vos.DefaultBook, Date: day, Accounts: 1, Amount: -3},
+	} {
+		created, err := r.CreateAccrualRun(ctx, run)
+		require.NoError(t, err)
+		assert.False(t, created.CreatedAt.IsZero())
+	}
+
+	_, err = r.CreateAccrualRun(ctx, vos.AccrualRun{Rule: "interest", Book: vos.DefaultBook, Date: day})
+	assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation)
+
+	_, err = r.CreateAccrualRun(ctx, vos.AccrualRun{Rule: "other", Book: "unknown", Date: day})
+	assert.ErrorIs(t, err, app.ErrBookNotFound)
+
+	last, err = r.GetLastAccrualDay(ctx, "interest")
+	require.NoError(t, err)
+	assert.True(t, day.AddDate(0, 0, 1).Equal(last))
+
+	runs, err := r.ListAccrualRuns(ctx, vos.AccrualRunRequest{StartDate: day, EndDate: day.AddDate(0, 0, 1)})
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, "fees", runs[0].Rule)
+	assert.Equal(t, -3, runs[0].Amount)
+
+	runs, err = r.ListAccrualRuns(ctx, vos.AccrualRunRequest{Rule: "interest", StartDate: day.AddDate(0, 0, 1), EndDate: day.AddDate(0, 0, 1)})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, 16, runs[0].Amount)
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+const booksCollection = "book"
+
+const createBookQuery = `
+insert into book (name) values ($1) returning created_at;
+`
+
+const listBooksQuery = `
+select name, created_at from book order by name;
+`
+
+func (r LedgerRepository) CreateBook(ctx context.Context, name string) (vos.Book, error) {
+	const operation = "Repository.CreateBook"
+
+	defer r.pb.MonitorDataSegment(ctx, booksCollection, operation, createBookQuery).End()
+
+	book := vos.Book{Name: name}
+
+	err := r.db.QueryRow(ctx, createBookQuery, name).Scan(&book.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return vos.Book{}, app.ErrBookAlreadyExists
+		}
+
+		return vos.Book{}, fmt.Errorf("failed to create book: %w", err)
+	}
+
+	return book, nil
+}
+
+func (r LedgerRepository) ListBooks(ctx context.Context) ([]vos.Book, error) {
+	const operation = "Repository.ListBooks"
+
+	defer r.pb.MonitorDataSegment(ctx, booksCollection, operation, listBooksQuery).End()
+
+	rows, err := r.db.Query(ctx, listBooksQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list books: %w", err)
+	}
+
+	defer rows.Close()
+
+	books := make([]vos.Book, 0)
+
+	for rows.Next() {
+		var book vos.Book
+		if err = rows.Scan(&book.Name, &book.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		books = append(books, book)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", operation, err)
+	}
+
+	return books, nil
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+const invariantWatermarkName = "ledger"
+
+// invariantsLockKey is the advisory lock held while the invariants are checked.
+const invariantsLockKey int64 = 0x6c65646765720002
+
+// The end of the window comes from the database clock, the same one that sets entry created_at, so
+// skew between the application and the database can't move it past entries still in flight.
+const getInvariantWindowQuery = `
+select
+	(select created_at from invariant_watermark where name = $1),
+	now() - $2::interval;
+`
+
+const upsertInvariantWatermarkQuery = `
+insert into invariant_watermark (name, created_at)
+values ($1, $2)
+on conflict (name) do update set created_at = excluded.created_at;
+`
+
+const insertInvariantViolationQuery = `
+insert into invariant_violation (check_name, book, subject, detail)
+values ($1, $2, $3, $4);
+`
+
+// Entries of a transaction are inserted by a single statement, so they share the same created_at
+// and a transaction is never split between two windows.
+const checkTransactionBalanceQuery = `
+select
+	book,
+	tx_id::text,
+	format('transaction entries sum to %s', sum(case operation when 1 then amount else -amount end))
+from
+	entry
+where
+	created_at > $1
+	and created_at <= $2
+group by
+	book,
+	tx_id
+having
+	sum(case operation when 1 then amount else -amount end) <> 0;
+`
+
+const checkAccountVersionQuery = `
+with touched as (
+	select distinct book, account
+	from entry
+	where
+		created_at > $1
+		and created_at <= $2
+		and version > 0
+)
+select
+	t.book,
+	t.account::text,
+	format('account_version is %s but the greatest entry version is %s', coalesce(v.version, 0), max(e.version))
+from
+	touched t
+	join entry e on e.book = t.book and e.account = t.account
+	left join account_version v on v.book = t.book and v.account = t.account
+group by
+	t.book,
+	t.account,
+	v.version
+having
+	coalesce(v.version, 0) <> max(e.version);
+`
+
+// Carry-forward entries (event 0) stand for the versioned entries of archived partitions, whose
+// count is kept in their metadata.
+const checkVersionGapQuery = `
+with touched as (
+	select distinct book, account
+	from entry
+	where
+		created_at > $1
+		and created_at <= $2
+		and version > 0
+),
+versions as (
+	select
+		t.book,
+		t.account,
+		count(*) filter (where e.event <> 0) as entries,
+		count(distinct e.version) filter (where e.event <> 0) as distinct_versions,
+		coalesce(sum((e.metadata->'carry_forward'->>'versions')::int) filter (where e.event = 0), 0) as archived,
+		max(e.version) as max_version
+	from
+		touched t
+		join entry e on e.book = t.book and e.account = t.account and e.version > 0
+	group by
+		t.book,
+		t.account
+)
+select
+	book,
+	account::text,
+	format('%s entries (%s archived) with %s distinct versions up to version %s', entries + archived, archived, distinct_versions, max_version)
+from
+	versions
+where
+	entries + archived <> max_version
+	or distinct_versions <> entries;
+`
+
+// Snapshots hold the balance of every entry created up to tx_date, so only the ones that moved
+// inside the window need to be recomputed.
+const checkBalanceSnapshotQuery = `
+select
+	b.book,
+	b.account,
+	format('snapshot balance is %s but entries sum to %s', b.balance, coalesce(sum(case e.operation when 1 then e.amount else -e.amount end), 0))
+from
+	account_balance b
+	left join entry e on e.book = b.book and e.account ~ b.account::lquery and e.created_at <= b.tx_date
+where
+	b.tx_date > $1
+	and b.tx_date <= $2
+group by
+	b.book,
+	b.account,
+	b.balance
+having
+	b.balance <> coalesce(sum(case e.operation when 1 then e.amount else -e.amount end), 0);
+`
+
+var invariantCheckQueries = map[vos.InvariantCheck]string{
+	vos.TransactionBalanceCheck: checkTransactionBalanceQuery,
+	vos.AccountVersionCheck:     checkAccountVersionQuery,
+	vos.VersionGapCheck:         checkVersionGapQuery,
+	vos.BalanceSnapshotCheck:    checkBalanceSnapshotQuery,
+}
+
+// GetInvariantWindow returns the window of the next check, from the watermark of the last one, which
+// is zero before the first check, up to the current database time minus the safety window.
+func (r LedgerRepository) GetInvariantWindow(ctx context.Context, safety time.Duration) (time.Time, time.Time, error) {
+	const operation = "Repository.GetInvariantWindow"
+
+	defer r.pb.MonitorDataSegment(ctx, "invariant_watermark", operation, getInvariantWindowQuery).End()
+
+	var (
+		watermark *time.Time
+		to        time.Time
+	)
+
+	err := r.db.QueryRow(ctx, getInvariantWindowQuery, invariantWatermarkName, safety).Scan(&watermark, &to)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to get invariant window: %w", err)
+	}
+
+	if watermark == nil {
+		return time.Time{}, to, nil
+	}
+
+	return *watermark, to, nil
+}
+
+// LockInvariants tries to take the session advisory lock of the invariant checker, so concurrent runs
+// don't check the same window and save it twice. It works like LockSchedules.
+func (r LedgerRepository) LockInvariants(ctx context.Context) (func(), bool, error) {
+	return r.tryAdvisoryLock(ctx, invariantsLockKey)
+}
+
+func (r LedgerRepository) CheckInvariant(ctx context.Context, check vos.InvariantCheck, from, to time.Time) ([]vos.InvariantViolation, error) {
+	const operation = "Repository.CheckInvariant"
+
+	query, ok := invariantCheckQueries[check]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", app.ErrInvalidInvariantCheck, check)
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, collection, operation, query).End()
+
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute %s check: %w", check, err)
+	}
+
+	defer rows.Close()
+
+	violations := make([]vos.InvariantViolation, 0)
+
+	for rows.Next() {
+		violation := vos.InvariantViolation{Check: check}
+
+		if err = rows.Scan(&violation.Book, &violation.Subject, &violation.Detail); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		violations = append(violations, violation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", operation, err)
+	}
+
+	return violations, nil
+}
+
+func (r LedgerRepository) SaveInvariantReport(ctx context.Context, report vos.InvariantReport) error {
+	const operation = "Repository.SaveInvariantReport"
+
+	defer r.pb.MonitorDataSegment(ctx, "invariant_violation", operation, insertInvariantViolationQuery).End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	batch := &pgx.Batch{}
+	for _, violation := range report.Violations {
+		batch.Queue(insertInvariantViolationQuery, violation.Check.String(), violation.Book, violation.Subject, violation.Detail)
+	}
+	batch.Queue(upsertInvariantWatermarkQuery, invariantWatermarkName, report.To)
+
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to save invariant report: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit invariant report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+	"github.com/stone-co/the-amazing-ledger/app/tests/testdata"
+)
+
+func TestLedgerRepository_CheckInvariant(t *testing.T) {
+	acc1, err := vos.NewAnalyticAccount(testdata.GenerateAccountPath())
+	assert.NoError(t, err)
+
+	acc2, err := vos.NewAnalyticAccount(testdata.GenerateAccountPath())
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		check           vos.InvariantCheck
+		corrupt         func(t *testing.T, ctx context.Context, r *LedgerRepository)
+		expectedSubject func(tx uuid.UUID) string
+	}{
+		{
+			name:            "should find no violations on a consistent ledger",
+			check:           vos.TransactionBalanceCheck,
+			corrupt:         func(t *testing.T, ctx context.Context, r *LedgerRepository) {},
+			expectedSubject: nil,
+		},
+		{
+			name:  "should find unbalanced transactions",
+			check: vos.TransactionBalanceCheck,
+			corrupt: func(t *testing.T, ctx context.Context, r *LedgerRepository) {
+				_, err := pgDocker.DB.Exec(ctx, `update entry set amount = amount + 1 where account = $1`, acc1.Value())
+				require.NoError(t, err)
+			},
+			expectedSubject: func(tx uuid.UUID) string { return tx.String() },
+		},
+		{
+			name:  "should find account versions that do not match the entries",
+			check: vos.AccountVersionCheck,
+			corrupt: func(t *testing.T, ctx context.Context, r *LedgerRepository) {
+				_, err := pgDocker.DB.Exec(ctx, `update account_version set version = version + 1 where account = $1`, acc1.Value())
+				require.NoError(t, err)
+			},
+			expectedSubject: func(uuid.UUID) string { return acc1.Value() },
+		},
+		{
+			name:  "should find gaps in account versions",
+			check: vos.VersionGapCheck,
+			corrupt: func(t *testing.T, ctx context.Context, r *LedgerRepository) {
+				_, err := pgDocker.DB.Exec(ctx, `update entry set version = 5 where account = $1`, acc1.Value())
+				require.NoError(t, err)
+			},
+			expectedSubject: func(uuid.UUID) string { return acc1.Value() },
+		},
+		{
+			name:  "should find snapshots that do not match the entries",
+			check: vos.BalanceSnapshotCheck,
+			corrupt: func(t *testing.T, ctx context.Context, r *LedgerRepository) {
+				createTransaction(t, ctx, r,
+					createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 100),
+					createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 100),
+				)
+
+				_, err := r.GetAnalyticAccountBalance(ctx, acc1)
+				require.NoError(t, err)
+
+				_, err = pgDocker.DB.Exec(ctx, `update account_balance set balance = balance + 1 where account = $1`, acc1.Value())
+				require.NoError(t, err)
+			},
+			expectedSubject: func(uuid.UUID) string { return acc1.Value() },
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
+
+			tx := createTransaction(t, ctx, r,
+				createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 100),
+				createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 100),
+			)
+
+			tt.corrupt(t, ctx, r)
+
+			violations, err := r.CheckInvariant(ctx, tt.check, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+
+			if tt.expectedSubject == nil {
+				assert.Empty(t, violations)
+				return
+			}
+
+			assert.Len(t, violations, 1)
+			assert.Equal(t, tt.check, violations[0].Check)
+			assert.Equal(t, tt.expectedSubject(tx.ID), violations[0].Subject)
+			assert.NotEmpty(t, violations[0].Detail)
+		})
+	}
+}
+
+func TestLedgerRepository_SaveInvariantReport(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "invariant_violation", "invariant_watermark")
+
+	watermark, to, err := r.GetInvariantWindow(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, watermark.IsZero())
+	assert.True(t, to.Before(time.Now()))
+
+	report := vos.InvariantReport{
+		From: watermark,
+		To:   to,
+		Violations: []vos.InvariantViolation{
+			{Check: vos.TransactionBalanceCheck, Subject: uuid.New().String(), Detail: "transaction entries sum to 1"},
+			{Check: vos.VersionGapCheck, Subject: "liability.abc.account1", Detail: "2 entries with 2 distinct versions up to version 3"},
+			{Check: vos.VersionGapCheck, Subject: "liability.abc.account2", Detail: "2 entries with 1 distinct versions up to version 2"},
+		},
+	}
+
+	err = r.SaveInvariantReport(ctx, report)
+	assert.NoError(t, err)
+
+	watermark, _, err = r.GetInvariantWindow(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, report.To.Equal(watermark))
+
+	violations, cursor, err := r.ListInvariantViolations(ctx, vos.InvariantViolationRequest{
+		Check: vos.VersionGapCheck,
+		Page:  pagination.Page{Size: 1},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, cursor)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "liability.abc.account2", violations[0].Subject)
+
+	violations, cursor, err = r.ListInvariantViolations(ctx, vos.InvariantViolationRequest{
+		Check: vos.VersionGapCheck,
+		Page:  pagination.Page{Size: 1, Cursor: cursor},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, cursor)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "liability.abc.account1", violations[0].Subject)
+}
+
+func TestLedgerRepository_LockInvariants(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+	unlock, locked, err := r.LockInvariants(ctx)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	_, locked, err = r.LockInvariants(ctx)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	unlockSchedules, locked, err := r.LockSchedules(ctx)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	unlockSchedules()
+
+	unlock()
+
+	unlock, locked, err = r.LockInvariants(ctx)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	unlock()
+}
@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+	"github.com/stone-co/the-amazing-ledger/app/tests/conformance"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	for _, strategy := range []string{LazyBalanceStrategy, EagerBalanceStrategy} {
+		t.Run(strategy, func(t *testing.T) {
+			defer tests.TruncateTables(context.Background(), pgDocker.DB, "entry", "entry_id", "account_version", "account_balance", "account_running_balance", "account", "event_schema")
+
+			conformance.TestRepository(t, func(t *testing.T) domain.Repository {
+				r, err := NewRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}, strategy)
+				require.NoError(t, err)
+				require.NoError(t, r.PrepareBalances(context.Background()))
+
+				return r
+			})
+		})
+	}
+}
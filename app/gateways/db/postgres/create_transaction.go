@@ -2,30 +2,46 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
-	"github.com/stone-co/the-amazing-ledger/app/instrumentation/newrelic"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 )
 
 const (
-	numArgs           = 10
+	numArgs           = 11
 	numDefaultQueries = 5
 )
 
+// Entries written by the lazy strategy don't move running balances, so they mark them stale. The
+// update finds nothing to change once they are, and takes no lock.
 const createTransactionQuery = `
-insert into entry (id, tx_id, event, operation, version, amount, competence_date, account, company, metadata)
-values %s;`
+with stale as (
+	update running_balance_status set stale = true, updated_at = now() where not stale
+)
+insert into entry (id, tx_id, event, operation, version, amount, competence_date, account, company, metadata, book)
+values %s
+returning id, account, version, created_at;`
+
+// Reads the balance of an account of the transaction within its database transaction. It doesn't
+// write snapshots, which would lock the snapshot of the account until the transaction commits.
+const getResultingBalanceQuery = `
+select total_balance, version from get_analytic_account_balance_readonly($1, $2);
+`
 
-func (r LedgerRepository) CreateTransaction(ctx context.Context, transaction entities.Transaction) error {
+func (r LedgerRepository) CreateTransaction(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
 	const operation = "Repository.CreateTransaction"
 
 	query := r.qb.Build(len(transaction.Entries))
 	args := make([]interface{}, 0)
+	book := vos.BookFromContext(ctx)
 
 	for _, entry := range transaction.Entries {
 		args = append(
@@ -40,27 +56,145 @@ func (r LedgerRepository) CreateTransaction(ctx context.Context, transaction ent
 			entry.Account.Value(),
 			transaction.Company,
 			entry.Metadata,
+			book,
 		)
 	}
 
-	defer newrelic.NewDatastoreSegment(ctx, collection, operation, query).End()
+	defer r.pb.MonitorDataSegment(ctx, collection, operation, query).End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return vos.TransactionResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	result, err := insertEntries(ctx, tx, query, args)
+	if err != nil {
+		return vos.TransactionResult{}, createTransactionError(err)
+	}
+
+	simulated := vos.SimulationFromContext(ctx)
+
+	if simulated || vos.ResultingBalancesFromContext(ctx) {
+		if result.Balances, err = r.resultingBalances(ctx, tx, transaction); err != nil {
+			return vos.TransactionResult{}, err
+		}
+	}
+
+	// Simulations are rolled back by the deferred rollback. No constraint is deferred, so the commit
+	// couldn't have failed where the simulation succeeded.
+	if simulated {
+		return result, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return vos.TransactionResult{}, createTransactionError(err)
+	}
+
+	result.ConsistencyToken = r.reads.consistencyToken(ctx)
+
+	return result, nil
+}
 
-	_, err := r.db.Exec(ctx, query, args...)
+// insertEntries runs the insert query, returning the versions the entries were given. Every entry
+// shares created_at, the start of the database transaction.
+func insertEntries(ctx context.Context, tx pgx.Tx, query string, args []interface{}) (vos.TransactionResult, error) {
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if ok := errors.As(err, &pgErr); !ok {
-			return err
+		return vos.TransactionResult{}, err
+	}
+
+	defer rows.Close()
+
+	var result vos.TransactionResult
+	for rows.Next() {
+		var (
+			entry   vos.PostedEntry
+			account string
+		)
+
+		if err = rows.Scan(&entry.ID, &account, &entry.Version, &result.PostedAt); err != nil {
+			return vos.TransactionResult{}, err
 		}
 
-		switch pgErr.Code {
-		case pgerrcode.RaiseException:
-			return app.ErrInvalidVersion
-		case pgerrcode.UniqueViolation:
-			return app.ErrIdempotencyKeyViolation
-		default:
-			return err
+		if entry.Account, err = vos.NewAccount(account); err != nil {
+			return vos.TransactionResult{}, err
 		}
+
+		result.Entries = append(result.Entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return vos.TransactionResult{}, err
+	}
+
+	return result, nil
+}
+
+// resultingBalances reads the balance of each account of the transaction, which are sorted by account,
+// in the order of their entries.
+func (r LedgerRepository) resultingBalances(ctx context.Context, tx pgx.Tx, transaction entities.Transaction) ([]vos.AccountBalance, error) {
+	book := vos.BookFromContext(ctx)
+	balances := make([]vos.AccountBalance, 0, len(transaction.Entries))
+
+	for _, entry := range transaction.Entries {
+		if n := len(balances); n > 0 && balances[n-1].Account.Value() == entry.Account.Value() {
+			continue
+		}
+
+		var balance int
+		var version int64
+
+		if err := tx.QueryRow(ctx, r.resultingBalanceQuery, book, entry.Account.Value()).Scan(&balance, &version); err != nil {
+			// Only the running balances of the eager strategy can be missing, while they are stale.
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, app.ErrStaleBalances
+			}
+
+			return nil, fmt.Errorf("failed to get resulting balance: %w", err)
+		}
+
+		balances = append(balances, vos.NewAnalyticAccountBalance(entry.Account, vos.Version(version), balance))
+	}
+
+	return balances, nil
+}
+
+// createTransactionError maps the errors raised by the entry constraints and triggers.
+func createTransactionError(err error) error {
+	var pgErr *pgconn.PgError
+	if ok := errors.As(err, &pgErr); !ok {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == pgerrcode.RaiseException:
+		return versionConflict(pgErr)
+	case pgErr.Code == pgerrcode.UniqueViolation:
+		return app.ErrIdempotencyKeyViolation
+	case pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == "entry_book_fkey":
+		return app.ErrBookNotFound
+	case pgErr.Code == pgerrcode.ForeignKeyViolation, pgErr.Code == pgerrcode.NumericValueOutOfRange:
+		return app.ErrEventNotFound
+	default:
+		return err
+	}
+}
+
+// versionConflict reads the account and its current version from the detail of the exception raised
+// by invalid_account_version, falling back to a bare app.ErrInvalidVersion if the detail is missing.
+func versionConflict(pgErr *pgconn.PgError) error {
+	var detail struct {
+		Account        string      `json:"account"`
+		CurrentVersion vos.Version `json:"current_version"`
+	}
+
+	if err := json.Unmarshal([]byte(pgErr.Detail), &detail); err != nil || detail.Account == "" {
+		return app.ErrInvalidVersion
 	}
 
-	return nil
+	return vos.VersionConflictError{Account: detail.Account, CurrentVersion: detail.CurrentVersion}
 }
@@ -93,7 +93,7 @@ func TestLedgerRepository_CreateTransactionSuccess(t *testing.T) {
 			ctx := context.Background()
 			r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
 
-			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "account_version")
+			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version")
 
 			tt.repoSeed(t, ctx, r)
 
@@ -103,7 +103,7 @@ func TestLedgerRepository_CreateTransactionSuccess(t *testing.T) {
 			tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", time.Now(), entries...)
 			assert.NoError(t, err)
 
-			err = r.CreateTransaction(ctx, tx)
+			_, err = r.CreateTransaction(ctx, tx)
 			assert.NoError(t, err)
 
 			assertMetadata(t, ctx, pgDocker.DB, e1.ID, e1.Metadata)
@@ -194,7 +194,7 @@ func TestLedgerRepository_CreateTransactionFailure(t *testing.T) {
 			ctx := context.Background()
 			r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
 
-			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "account_version")
+			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version")
 
 			tt.repoSeed(t, ctx, r)
 
@@ -204,7 +204,7 @@ func TestLedgerRepository_CreateTransactionFailure(t *testing.T) {
 			tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", time.Now(), entries...)
 			assert.NoError(t, err)
 
-			err = r.CreateTransaction(ctx, tx)
+			_, err = r.CreateTransaction(ctx, tx)
 			assert.ErrorIs(t, err, tt.expectedErr)
 
 			assertAccountVersion(t, ctx, pgDocker.DB, e1.Account, tt.expectedAccountVersion)
@@ -213,6 +213,64 @@ func TestLedgerRepository_CreateTransactionFailure(t *testing.T) {
 	}
 }
 
+func TestLedgerRepository_CreateTransactionUnknownReferences(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version")
+
+	testCases := []struct {
+		name        string
+		book        string
+		event       uint32
+		expectedErr error
+	}{
+		{name: "return error when posting to an unknown book", book: "unknown", event: 1, expectedErr: app.ErrBookNotFound},
+		{name: "return error when posting with an unknown event", book: vos.DefaultBook, event: 999, expectedErr: app.ErrEventNotFound},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := entities.NewTransaction(uuid.New(), tt.event, "abc", time.Now(),
+				createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.NextAccountVersion, 100),
+				createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100),
+			)
+			require.NoError(t, err)
+
+			_, err = r.CreateTransaction(vos.WithBook(ctx, tt.book), tx)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestLedgerRepository_CreateTransactionIdempotencyAcrossPartitions(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version")
+
+	e1 := createEntry(t, vos.DebitOperation, "liability.abc.account1", vos.IgnoreAccountVersion, 100)
+	e2 := createEntry(t, vos.CreditOperation, "liability.abc.account2", vos.IgnoreAccountVersion, 100)
+
+	tx := createTransaction(t, ctx, r, e1, e2)
+
+	for _, competenceDate := range []time.Time{
+		tx.CompetenceDate.Add(time.Second),
+		tx.CompetenceDate.AddDate(0, -2, 0),
+		tx.CompetenceDate.AddDate(10, 0, 0),
+	} {
+		retry, err := entities.NewTransaction(uuid.New(), tx.Event, tx.Company, competenceDate, e1, e2)
+		require.NoError(t, err)
+
+		_, err = r.CreateTransaction(ctx, retry)
+		assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation, competenceDate)
+	}
+
+	var entries int
+	require.NoError(t, pgDocker.DB.QueryRow(ctx, "select count(*) from entry where id = $1", e1.ID).Scan(&entries))
+	assert.Equal(t, 1, entries)
+}
+
 func assertAccountVersion(t *testing.T, ctx context.Context, db *pgxpool.Pool, account vos.Account, want vos.Version) {
 	t.Helper()
 
@@ -0,0 +1,356 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/gateways/db/querybuilder"
+)
+
+// Balance strategies supported by NewRepository.
+const (
+	// LazyBalanceStrategy computes balances on read, keeping snapshots in account_balance.
+	LazyBalanceStrategy = "lazy"
+	// EagerBalanceStrategy keeps running balances in account_running_balance, updated on write.
+	EagerBalanceStrategy = "eager"
+)
+
+const runningBalanceCollection = "account_running_balance"
+
+// Running balances are updated by the same statement that inserts the entries, so they are always
+// consistent with the committed entries. Rows are upserted in account order to avoid deadlocks
+// between transactions that share accounts.
+const createTransactionEagerQuery = `
+with inserted as (
+	insert into entry (id, tx_id, event, operation, version, amount, competence_date, account, company, metadata, book)
+	values %s
+	returning id, book, account, operation, amount, version, created_at
+), running as (
+	insert into account_running_balance (balance, version, account, book)
+	select
+		coalesce(sum(amount) filter (where operation = 1), 0) -
+		coalesce(sum(amount) filter (where operation = 2), 0),
+		max(version),
+		account,
+		book
+	from
+		inserted
+	group by
+		book,
+		account
+	order by
+		account
+	on conflict (book, account) do update
+	set
+		balance = account_running_balance.balance + excluded.balance,
+		version = excluded.version
+)
+select id, account, version, created_at from inserted order by account;`
+
+// Running balances are only read while they are current. The status is a single row, so the left
+// join returns a row even for unknown accounts, telling stale balances apart from missing ones.
+const getRunningBalanceQuery = `
+select not s.stale, b.balance, b.version
+from running_balance_status s
+left join account_running_balance b on b.book = $1 and b.account = $2;
+`
+
+// Reads the running balance within the transaction that posted to it, returning no row while the
+// running balances are stale.
+const getResultingRunningBalanceQuery = `
+select balance, version
+from account_running_balance
+where book = $1 and account = $2 and not (select stale from running_balance_status);
+`
+
+const getSyntheticRunningBalanceQuery = `
+select not s.stale, sum(b.balance), count(b.account)
+from running_balance_status s
+left join account_running_balance b on b.book = $1 and b.account ~ $2
+group by s.stale;
+`
+
+const getSearchedRunningBalanceQuery = `
+select not s.stale, sum(b.balance), count(b.account)
+from running_balance_status s
+left join account_running_balance b on b.book = $1 and b.account ~ $2 and b.account @ $3::ltxtquery
+group by s.stale;
+`
+
+// The lock blocks concurrent writes (but not reads) while the balances are recomputed.
+const lockRunningBalanceQuery = `
+lock table account_running_balance in share row exclusive mode;
+`
+
+const lockRunningBalanceStatusQuery = `
+select stale from running_balance_status for update;
+`
+
+// The lock blocks the writes of both strategies (but not reads) while every running balance is
+// recomputed, so none of them is missed.
+const lockEntriesQuery = `
+lock table entry in share mode;
+`
+
+const clearRunningBalancesQuery = `
+delete from account_running_balance;
+`
+
+const rebuildAllRunningBalancesQuery = `
+insert into account_running_balance (balance, version, account, book)
+select
+	coalesce(sum(amount) filter (where operation = 1), 0) -
+	coalesce(sum(amount) filter (where operation = 2), 0),
+	(array_agg(version order by created_at desc, version desc))[1],
+	account,
+	book
+from
+	entry
+group by
+	book,
+	account;
+`
+
+const markRunningBalancesCurrentQuery = `
+update running_balance_status set stale = false, updated_at = now();
+`
+
+const rebuildRunningBalancesQuery = `
+insert into account_running_balance (balance, version, account, book)
+select
+	coalesce(sum(amount) filter (where operation = 1), 0) -
+	coalesce(sum(amount) filter (where operation = 2), 0),
+	(array_agg(version order by created_at desc, version desc))[1],
+	account,
+	book
+from
+	entry
+where
+	book = $1
+	and account ~ $2::lquery
+group by
+	book,
+	account
+on conflict (book, account) do update
+set
+	balance = excluded.balance,
+	version = excluded.version;
+`
+
+// Repository is implemented by every balance strategy.
+type Repository interface {
+	domain.Repository
+	domain.AdminRepository
+	domain.HealthChecker
+
+	// PrepareBalances gets the balances of the strategy ready to be served, and must be called before
+	// serving.
+	PrepareBalances(context.Context) error
+}
+
+var _ Repository = &EagerLedgerRepository{}
+
+// EagerLedgerRepository is a LedgerRepository that maintains per-account running balances on write,
+// so balance reads never scan entries nor write snapshots.
+type EagerLedgerRepository struct {
+	*LedgerRepository
+}
+
+func NewEagerLedgerRepository(db *pgxpool.Pool, pb *instrumentators.LedgerInstrumentator) *EagerLedgerRepository {
+	qb := querybuilder.New(createTransactionEagerQuery, numArgs)
+	qb.Init(numDefaultQueries)
+
+	return &EagerLedgerRepository{
+		LedgerRepository: &LedgerRepository{
+			db:    db,
+			pb:    pb,
+			qb:    qb,
+			reads: newReadRouter(db, nil, 0),
+
+			resultingBalanceQuery: getResultingRunningBalanceQuery,
+		},
+	}
+}
+
+// NewRepository creates the repository of the given balance strategy.
+func NewRepository(db *pgxpool.Pool, pb *instrumentators.LedgerInstrumentator, strategy string, opts ...RepositoryOption) (Repository, error) {
+	switch strategy {
+	case LazyBalanceStrategy:
+		r := NewLedgerRepository(db, pb)
+		for _, opt := range opts {
+			opt(r)
+		}
+
+		return r, nil
+	case EagerBalanceStrategy:
+		r := NewEagerLedgerRepository(db, pb)
+		for _, opt := range opts {
+			opt(r.LedgerRepository)
+		}
+
+		return r, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", app.ErrInvalidBalanceStrategy, strategy)
+	}
+}
+
+// PrepareBalances rebuilds every running balance if they were marked stale by writes of the lazy
+// strategy, blocking the writes until they are current again.
+func (r EagerLedgerRepository) PrepareBalances(ctx context.Context) error {
+	const operation = "Repository.PrepareBalances"
+
+	defer r.pb.MonitorDataSegment(ctx, runningBalanceCollection, operation, rebuildAllRunningBalancesQuery).End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var stale bool
+	if err = tx.QueryRow(ctx, lockRunningBalanceStatusQuery).Scan(&stale); err != nil {
+		return fmt.Errorf("failed to get running balance status: %w", err)
+	}
+
+	if !stale {
+		return tx.Commit(ctx)
+	}
+
+	for _, query := range []string{
+		lockEntriesQuery,
+		clearRunningBalancesQuery,
+		rebuildAllRunningBalancesQuery,
+		markRunningBalancesCurrentQuery,
+	} {
+		if _, err = tx.Exec(ctx, query); err != nil {
+			return fmt.Errorf("failed to rebuild running balances: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit running balances: %w", err)
+	}
+
+	return nil
+}
+
+func (r EagerLedgerRepository) GetAnalyticAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+	const operation = "Repository.GetAnalyticAccountBalance"
+
+	defer r.pb.MonitorDataSegment(ctx, runningBalanceCollection, operation, getRunningBalanceQuery).End()
+
+	var current bool
+	var balance *int
+	var version *int64
+
+	db, _ := r.reads.reader(ctx)
+
+	start := time.Now()
+	err := db.QueryRow(ctx, getRunningBalanceQuery, vos.BookFromContext(ctx), account.Value()).Scan(
+		&current,
+		&balance,
+		&version,
+	)
+	if err != nil {
+		return vos.AccountBalance{}, fmt.Errorf("failed to get account balance: %w", err)
+	}
+
+	if !current {
+		return vos.AccountBalance{}, app.ErrStaleBalances
+	}
+
+	if balance == nil {
+		return vos.AccountBalance{}, app.ErrAccountNotFound
+	}
+
+	r.pb.QueriedBalance(ctx, account, instrumentators.RunningBalanceSource, time.Since(start), 1)
+
+	return vos.NewAnalyticAccountBalance(
+		account,
+		vos.Version(*version),
+		*balance,
+	), nil
+}
+
+func (r EagerLedgerRepository) GetSyntheticAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+	const operation = "Repository.GetSyntheticAccountBalance"
+
+	query, args := getSyntheticRunningBalanceQuery, []interface{}{vos.BookFromContext(ctx), account.Value()}
+	if !account.Search().IsEmpty() {
+		query, args = getSearchedRunningBalanceQuery, append(args, account.Search().Value())
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, runningBalanceCollection, operation, query).End()
+
+	var current bool
+	var balance *int
+	var scannedRows int64
+
+	db, _ := r.reads.reader(ctx)
+
+	start := time.Now()
+	err := db.QueryRow(ctx, query, args...).Scan(&current, &balance, &scannedRows)
+	if err != nil {
+		return vos.AccountBalance{}, fmt.Errorf("failed to query aggregated balance: %w", err)
+	}
+
+	if !current {
+		return vos.AccountBalance{}, app.ErrStaleBalances
+	}
+
+	if balance == nil {
+		return vos.AccountBalance{}, app.ErrAccountNotFound
+	}
+
+	r.pb.QueriedBalance(ctx, account, instrumentators.RunningBalanceSource, time.Since(start), scannedRows)
+
+	return vos.NewSyntheticAccountBalance(account, *balance), nil
+}
+
+// RebuildSnapshots also recomputes the running balances of the accounts matching the given account,
+// repairing them without waiting for a restart. Stale running balances are only marked current by
+// PrepareBalances, which rebuilds all of them.
+func (r EagerLedgerRepository) RebuildSnapshots(ctx context.Context, account vos.Account) (int, error) {
+	const operation = "Repository.RebuildSnapshots"
+
+	total, err := r.LedgerRepository.RebuildSnapshots(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, runningBalanceCollection, operation, rebuildRunningBalancesQuery).End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, lockRunningBalanceQuery); err != nil {
+		return 0, fmt.Errorf("failed to lock running balances: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, rebuildRunningBalancesQuery, vos.BookFromContext(ctx), account.Value())
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild running balances: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit running balances: %w", err)
+	}
+
+	return total + int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,259 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+	"github.com/stone-co/the-amazing-ledger/app/tests/testdata"
+)
+
+func TestEagerLedgerRepository_Balances(t *testing.T) {
+	acc1, err := vos.NewAccount("liability.eager.acc1")
+	assert.NoError(t, err)
+
+	acc2, err := vos.NewAccount("liability.eager.acc2")
+	assert.NoError(t, err)
+
+	company, err := vos.NewAccount("asset.eager.company")
+	assert.NoError(t, err)
+
+	query, err := vos.NewAccount("liability.eager.*")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewEagerLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance", "account_running_balance")
+	require.NoError(t, r.PrepareBalances(ctx))
+
+	_, err = r.GetAnalyticAccountBalance(ctx, acc1)
+	assert.ErrorIs(t, err, app.ErrAccountNotFound)
+
+	_, err = r.GetSyntheticAccountBalance(ctx, query)
+	assert.ErrorIs(t, err, app.ErrAccountNotFound)
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, acc1.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 100),
+	)
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 50),
+		createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 20),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 30),
+	)
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, acc1)
+	assert.NoError(t, err)
+	assert.Equal(t, 80, balance.Balance)
+	assert.Equal(t, vos.Version(2), balance.CurrentVersion)
+
+	balance, err = r.GetAnalyticAccountBalance(ctx, company)
+	assert.NoError(t, err)
+	assert.Equal(t, -130, balance.Balance)
+
+	balance, err = r.GetSyntheticAccountBalance(ctx, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 130, balance.Balance)
+
+	_, err = fetchSnapshot(ctx, pgDocker.DB, acc1)
+	assert.Error(t, err, "eager reads should not write snapshots")
+}
+
+func TestEagerLedgerRepository_CreateTransactionFailure(t *testing.T) {
+	acc, err := vos.NewAnalyticAccount(testdata.GenerateAccountPath())
+	assert.NoError(t, err)
+
+	company, err := vos.NewAccount("asset.eager.company")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewEagerLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_running_balance")
+	require.NoError(t, r.PrepareBalances(ctx))
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, acc.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 100),
+	)
+
+	tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", time.Now().Round(time.Microsecond),
+		createEntry(t, vos.CreditOperation, acc.Value(), vos.Version(5), 100),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 100),
+	)
+	assert.NoError(t, err)
+
+	_, err = r.CreateTransaction(ctx, tx)
+	assert.ErrorIs(t, err, app.ErrInvalidVersion)
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, acc)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, balance.Balance)
+}
+
+func TestEagerLedgerRepository_RebuildSnapshots(t *testing.T) {
+	acc1, err := vos.NewAccount("liability.eager.acc1")
+	assert.NoError(t, err)
+
+	company, err := vos.NewAccount("asset.eager.company")
+	assert.NoError(t, err)
+
+	query, err := vos.NewAccount("liability.eager.*")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewEagerLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance", "account_running_balance")
+	require.NoError(t, r.PrepareBalances(ctx))
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, acc1.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 100),
+	)
+
+	// A drifted running balance is repaired without touching the others.
+	_, err = pgDocker.DB.Exec(ctx, "update account_running_balance set balance = 0")
+	require.NoError(t, err)
+
+	_, err = r.RebuildSnapshots(ctx, query)
+	assert.NoError(t, err)
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, acc1)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, balance.Balance)
+	assert.Equal(t, vos.Version(1), balance.CurrentVersion)
+
+	balance, err = r.GetAnalyticAccountBalance(ctx, company)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, balance.Balance)
+}
+
+func TestEagerLedgerRepository_PrepareBalances(t *testing.T) {
+	acc1, err := vos.NewAccount("liability.eager.acc1")
+	assert.NoError(t, err)
+
+	company, err := vos.NewAccount("asset.eager.company")
+	assert.NoError(t, err)
+
+	query, err := vos.NewAccount("liability.eager.*")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewEagerLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance", "account_running_balance")
+	require.NoError(t, r.PrepareBalances(ctx))
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.CreditOperation, acc1.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 100),
+	)
+
+	// Entries written by the lazy strategy don't move running balances, so they can't be served.
+	createTransaction(t, ctx, NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}),
+		createEntry(t, vos.CreditOperation, acc1.Value(), vos.NextAccountVersion, 50),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 50),
+	)
+
+	_, err = r.GetAnalyticAccountBalance(ctx, acc1)
+	assert.ErrorIs(t, err, app.ErrStaleBalances)
+
+	_, err = r.GetSyntheticAccountBalance(ctx, query)
+	assert.ErrorIs(t, err, app.ErrStaleBalances)
+
+	tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", time.Now().Round(time.Microsecond),
+		createEntry(t, vos.CreditOperation, acc1.Value(), vos.NextAccountVersion, 10),
+		createEntry(t, vos.DebitOperation, company.Value(), vos.IgnoreAccountVersion, 10),
+	)
+	assert.NoError(t, err)
+
+	_, err = r.CreateTransaction(vos.WithResultingBalances(ctx), tx)
+	assert.ErrorIs(t, err, app.ErrStaleBalances)
+
+	require.NoError(t, r.PrepareBalances(ctx))
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, acc1)
+	assert.NoError(t, err)
+	assert.Equal(t, 150, balance.Balance)
+	assert.Equal(t, vos.Version(2), balance.CurrentVersion)
+
+	balance, err = r.GetSyntheticAccountBalance(ctx, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 150, balance.Balance)
+}
+
+func TestNewRepository(t *testing.T) {
+	r, err := NewRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}, LazyBalanceStrategy)
+	assert.NoError(t, err)
+	assert.IsType(t, &LedgerRepository{}, r)
+
+	r, err = NewRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}, EagerBalanceStrategy)
+	assert.NoError(t, err)
+	assert.IsType(t, &EagerLedgerRepository{}, r)
+
+	_, err = NewRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}, "unknown")
+	assert.ErrorIs(t, err, app.ErrInvalidBalanceStrategy)
+}
+
+// BenchmarkBalanceStrategies runs the perftests ledger scenario (client accounts transferring to a
+// single company account) with a balance read after every transaction, for both strategies.
+func BenchmarkBalanceStrategies(b *testing.B) {
+	const totalClientAccounts = 100
+
+	company, err := vos.NewAccount("asset.aaa.bbb.sa")
+	require.NoError(b, err)
+
+	clients := make([]vos.Account, totalClientAccounts)
+	for i := range clients {
+		clients[i], err = vos.NewAnalyticAccount(testdata.GenerateAccountPath())
+		require.NoError(b, err)
+	}
+
+	query, err := vos.NewAccount("liability.clients.available.*")
+	require.NoError(b, err)
+
+	strategies := []struct {
+		name string
+		repo Repository
+	}{
+		{name: LazyBalanceStrategy, repo: NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})},
+		{name: EagerBalanceStrategy, repo: NewEagerLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})},
+	}
+
+	for _, s := range strategies {
+		for _, read := range []struct {
+			name string
+			get  func(context.Context, vos.Account) (vos.AccountBalance, error)
+			acc  func(i int) vos.Account
+		}{
+			{name: "analytic", get: s.repo.GetAnalyticAccountBalance, acc: func(i int) vos.Account { return clients[i%len(clients)] }},
+			{name: "synthetic", get: s.repo.GetSyntheticAccountBalance, acc: func(int) vos.Account { return query }},
+		} {
+			b.Run(fmt.Sprintf("%s/%s", s.name, read.name), func(b *testing.B) {
+				ctx := context.Background()
+				defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance", "account_running_balance")
+				require.NoError(b, s.repo.PrepareBalances(ctx))
+
+				for i := 0; i < b.N; i++ {
+					client := clients[i%len(clients)]
+
+					createTransaction(b, ctx, s.repo,
+						createEntry(b, vos.DebitOperation, client.Value(), vos.IgnoreAccountVersion, 20000),
+						createEntry(b, vos.CreditOperation, company.Value(), vos.IgnoreAccountVersion, 20000),
+					)
+
+					_, err := read.get(ctx, read.acc(i))
+					require.NoError(b, err)
+				}
+			})
+		}
+	}
+}
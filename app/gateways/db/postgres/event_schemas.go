@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	pag "github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+const eventSchemasCollection = "event_schema"
+
+const createEventSchemaQuery = `
+insert into event_schema (event, version, schema)
+select $1, coalesce(max(version), 0) + 1, $2
+from event_schema
+where event = $1
+returning version, created_at;
+`
+
+const getEventSchemaQuery = `
+select version, schema, created_at
+from event_schema
+where event = $1
+order by version desc
+limit 1;
+`
+
+const listEventSchemasQuery = `
+select version, schema, created_at
+from event_schema
+where event = $1
+order by version;
+`
+
+const (
+	_eventMetadataQueryPrefix = `
+select
+	id,
+	tx_id,
+	book,
+	account,
+	metadata,
+	created_at
+from
+	entry
+where
+	event = $2
+	and created_at >= $3
+`
+
+	_eventMetadataQueryPagination = `
+	and (created_at, id) >= ($4, $5)
+`
+
+	_eventMetadataQuerySuffix = `
+order by
+	created_at,
+	id
+limit $1;
+`
+)
+
+type listEventMetadataCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (r LedgerRepository) CreateEventSchema(ctx context.Context, event uint32, schema json.RawMessage) (vos.EventSchema, error) {
+	const operation = "Repository.CreateEventSchema"
+
+	defer r.pb.MonitorDataSegment(ctx, eventSchemasCollection, operation, createEventSchemaQuery).End()
+
+	eventSchema := vos.EventSchema{Event: event, Schema: schema}
+
+	err := r.db.QueryRow(ctx, createEventSchemaQuery, event, schema).Scan(&eventSchema.Version, &eventSchema.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == pgerrcode.ForeignKeyViolation || pgErr.Code == pgerrcode.NumericValueOutOfRange) {
+			return vos.EventSchema{}, app.ErrEventNotFound
+		}
+
+		return vos.EventSchema{}, fmt.Errorf("failed to create event schema: %w", err)
+	}
+
+	return eventSchema, nil
+}
+
+// GetEventSchema reads the schema in force from the primary, so new versions apply as soon as the
+// use case cache expires.
+func (r LedgerRepository) GetEventSchema(ctx context.Context, event uint32) (vos.EventSchema, error) {
+	const operation = "Repository.GetEventSchema"
+
+	defer r.pb.MonitorDataSegment(ctx, eventSchemasCollection, operation, getEventSchemaQuery).End()
+
+	eventSchema := vos.EventSchema{Event: event}
+
+	err := r.db.QueryRow(ctx, getEventSchemaQuery, event).Scan(&eventSchema.Version, &eventSchema.Schema, &eventSchema.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vos.EventSchema{}, app.ErrEventSchemaNotFound
+		}
+
+		return vos.EventSchema{}, fmt.Errorf("failed to get event schema: %w", err)
+	}
+
+	return eventSchema, nil
+}
+
+func (r LedgerRepository) ListEventSchemas(ctx context.Context, event uint32) ([]vos.EventSchema, error) {
+	const operation = "Repository.ListEventSchemas"
+
+	defer r.pb.MonitorDataSegment(ctx, eventSchemasCollection, operation, listEventSchemasQuery).End()
+
+	rows, err := r.db.Query(ctx, listEventSchemasQuery, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event schemas: %w", err)
+	}
+
+	defer rows.Close()
+
+	schemas := make([]vos.EventSchema, 0)
+
+	for rows.Next() {
+		eventSchema := vos.EventSchema{Event: event}
+		if err = rows.Scan(&eventSchema.Version, &eventSchema.Schema, &eventSchema.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		schemas = append(schemas, eventSchema)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", operation, err)
+	}
+
+	return schemas, nil
+}
+
+func (r LedgerRepository) ListEventMetadata(ctx context.Context, req vos.EventMetadataRequest) ([]vos.EntryMetadata, pag.Cursor, error) {
+	const op = "Repository.ListEventMetadata"
+
+	query, args, err := generateListEventMetadataQuery(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate %s query: %w", op, err)
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, "entry", op, query).End()
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := make([]vos.EntryMetadata, 0)
+
+	for rows.Next() {
+		var entry vos.EntryMetadata
+
+		if err = rows.Scan(
+			&entry.ID,
+			&entry.TxID,
+			&entry.Book,
+			&entry.Account,
+			&entry.Metadata,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	if len(entries) <= req.Page.Size {
+		return entries, nil, nil
+	}
+
+	next := entries[len(entries)-1]
+	entries = entries[:len(entries)-1]
+
+	cursor, err := pag.NewCursor(listEventMetadataCursor{CreatedAt: next.CreatedAt, ID: next.ID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	return entries, cursor, nil
+}
+
+func generateListEventMetadataQuery(req vos.EventMetadataRequest) (string, []interface{}, error) {
+	var (
+		query = _eventMetadataQueryPrefix
+		args  = []interface{}{req.Page.Size + 1, req.Event, req.Since}
+	)
+
+	if req.Page.Cursor != nil {
+		var cursor listEventMetadataCursor
+		err := req.Page.Extract(&cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		query += _eventMetadataQueryPagination
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += _eventMetadataQuerySuffix
+
+	return query, args, nil
+}
@@ -4,35 +4,62 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 
 	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
-	"github.com/stone-co/the-amazing-ledger/app/instrumentation/newrelic"
 )
 
 const getAccountBalanceQuery = `
 select
 	total_balance,
-	version
+	version,
+	snapshot_hit,
+	scanned_entries
 from
-	get_analytic_account_balance($1)
+	get_analytic_account_balance($1, $2)
+;
+`
+
+// Replicas are read-only, so they use the variant that doesn't write snapshots.
+const getAccountBalanceReadOnlyQuery = `
+select
+	total_balance,
+	version,
+	snapshot_hit,
+	scanned_entries
+from
+	get_analytic_account_balance_readonly($1, $2)
 ;
 `
 
 func (r LedgerRepository) GetAnalyticAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
 	const operation = "Repository.GetAnalyticAccountBalance"
 
-	defer newrelic.NewDatastoreSegment(ctx, collection, operation, getAccountBalanceQuery).End()
+	db, replica := r.reads.reader(ctx)
+
+	query := getAccountBalanceQuery
+	if replica {
+		query = getAccountBalanceReadOnlyQuery
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, collection, operation, query).End()
 
 	var balance int
 	var version int64
+	var snapshotHit bool
+	var scannedEntries int64
 
-	err := r.db.QueryRow(ctx, getAccountBalanceQuery, account.Value()).Scan(
+	start := time.Now()
+	err := db.QueryRow(ctx, query, vos.BookFromContext(ctx), account.Value()).Scan(
 		&balance,
 		&version,
+		&snapshotHit,
+		&scannedEntries,
 	)
 
 	if err != nil {
@@ -48,9 +75,21 @@ func (r LedgerRepository) GetAnalyticAccountBalance(ctx context.Context, account
 		return vos.AccountBalance{}, fmt.Errorf("failed to get account balance: %w", pgErr)
 	}
 
+	r.pb.QueriedBalance(ctx, account, balanceSource(snapshotHit), time.Since(start), scannedEntries)
+
 	return vos.NewAnalyticAccountBalance(
 		account,
 		vos.Version(version),
 		balance,
 	), nil
 }
+
+// balanceSource tells whether a balance function started from a snapshot or recomputed the balance
+// from every entry.
+func balanceSource(snapshotHit bool) string {
+	if snapshotHit {
+		return instrumentators.SnapshotBalanceSource
+	}
+
+	return instrumentators.RecomputedBalanceSource
+}
@@ -113,7 +113,7 @@ func TestLedgerRepository_GetAccountBalanceSuccess(t *testing.T) {
 
 			tt.repoSeed(t, ctx, r)
 
-			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "account_version", "account_balance")
+			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
 
 			balance, err := r.GetAnalyticAccountBalance(ctx, acc1)
 			assert.NoError(t, err)
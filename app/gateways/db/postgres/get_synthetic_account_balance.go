@@ -4,27 +4,60 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 
 	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
-	"github.com/stone-co/the-amazing-ledger/app/instrumentation/newrelic"
 )
 
 const queryAggregatedBalanceQuery = `
-select get_synthetic_account_balance($1);
+select total_balance, snapshot_hit, scanned_entries from get_synthetic_account_balance($1, $2);
+`
+
+// Replicas are read-only, so they use the variant that doesn't write snapshots.
+const queryAggregatedBalanceReadOnlyQuery = `
+select total_balance, snapshot_hit, scanned_entries from get_synthetic_account_balance_readonly($1, $2);
+`
+
+// Snapshots are keyed by the account alone, so searched accounts are always summed from the entries.
+const querySearchedBalanceQuery = `
+select
+	coalesce(sum(amount) filter (where operation = 1), 0)::bigint -
+	coalesce(sum(amount) filter (where operation = 2), 0)::bigint,
+	count(*)
+from
+	entry
+where
+	book = $1
+	and account ~ $2::lquery
+	and account @ $3::ltxtquery;
 `
 
 func (r LedgerRepository) GetSyntheticAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
 	const operation = "Repository.GetSyntheticAccountBalance"
 
-	defer newrelic.NewDatastoreSegment(ctx, collection, operation, queryAggregatedBalanceQuery).End()
+	if !account.Search().IsEmpty() {
+		return r.getSearchedAccountBalance(ctx, account)
+	}
+
+	db, replica := r.reads.reader(ctx)
+
+	query := queryAggregatedBalanceQuery
+	if replica {
+		query = queryAggregatedBalanceReadOnlyQuery
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, collection, operation, query).End()
 
 	var balance int
+	var snapshotHit bool
+	var scannedEntries int64
 
-	err := r.db.QueryRow(ctx, queryAggregatedBalanceQuery, account.Value()).Scan(&balance)
+	start := time.Now()
+	err := db.QueryRow(ctx, query, vos.BookFromContext(ctx), account.Value()).Scan(&balance, &snapshotHit, &scannedEntries)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if !errors.As(err, &pgErr) {
@@ -38,5 +71,32 @@ func (r LedgerRepository) GetSyntheticAccountBalance(ctx context.Context, accoun
 		return vos.AccountBalance{}, fmt.Errorf("failed to query aggregated balance: %w", pgErr)
 	}
 
+	r.pb.QueriedBalance(ctx, account, balanceSource(snapshotHit), time.Since(start), scannedEntries)
+
+	return vos.NewSyntheticAccountBalance(account, balance), nil
+}
+
+func (r LedgerRepository) getSearchedAccountBalance(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+	const operation = "Repository.GetSyntheticAccountBalance"
+
+	defer r.pb.MonitorDataSegment(ctx, collection, operation, querySearchedBalanceQuery).End()
+
+	db, _ := r.reads.reader(ctx)
+
+	var balance int
+	var scannedEntries int64
+
+	start := time.Now()
+	err := db.QueryRow(ctx, querySearchedBalanceQuery, vos.BookFromContext(ctx), account.Value(), account.Search().Value()).Scan(&balance, &scannedEntries)
+	if err != nil {
+		return vos.AccountBalance{}, fmt.Errorf("failed to query searched balance: %w", err)
+	}
+
+	if scannedEntries == 0 {
+		return vos.AccountBalance{}, app.ErrAccountNotFound
+	}
+
+	r.pb.QueriedBalance(ctx, account, balanceSource(false), time.Since(start), scannedEntries)
+
 	return vos.NewSyntheticAccountBalance(account, balance), nil
 }
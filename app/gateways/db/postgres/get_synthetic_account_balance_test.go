@@ -114,7 +114,7 @@ func TestLedgerRepository_QueryAggregatedBalanceSuccess(t *testing.T) {
 			r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
 			tt.repoSeed(t, ctx, r)
 
-			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "account_version", "account_balance")
+			defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
 
 			balance, err := r.GetSyntheticAccountBalance(ctx, query)
 			assert.NoError(t, err)
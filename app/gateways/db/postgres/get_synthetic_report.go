@@ -21,18 +21,25 @@ from
 	entry 
 where 
 	account ~ $2
+%s
+and 
+	book = $5
 and 
 	created_at >= $3 and created_at < $4 
 group by 1;
 `
 
+const syntheticReportSearchFilter = `and account @ $6::ltxtquery`
+
 func (r *LedgerRepository) GetSyntheticReport(ctx context.Context, query vos.Account, level int, startTime time.Time, endTime time.Time) (*vos.SyntheticReport, error) {
 	const operation = "Repository.GetSyntheticReport"
 
-	sqlQuery, params := buildQueryAndParams(query, level, startTime, endTime)
+	sqlQuery, params := buildQueryAndParams(vos.BookFromContext(ctx), query, level, startTime, endTime)
 
 	defer r.pb.MonitorDataSegment(ctx, collection, operation, sqlQuery).End()
-	rows, errQuery := r.db.Query(
+
+	db, _ := r.reads.reader(ctx)
+	rows, errQuery := db.Query(
 		ctx,
 		sqlQuery,
 		params...,
@@ -100,12 +107,21 @@ func (r *LedgerRepository) GetSyntheticReport(ctx context.Context, query vos.Acc
 	return syntheticReport, nil
 }
 
-func buildQueryAndParams(query vos.Account, level int, startTime time.Time, endTime time.Time) (string, []interface{}) {
+func buildQueryAndParams(book string, query vos.Account, level int, startTime time.Time, endTime time.Time) (string, []interface{}) {
+	var searchFilter string
+	if !query.Search().IsEmpty() {
+		searchFilter = syntheticReportSearchFilter
+	}
+
 	sqlQuery := syntheticReportQuery
-	sqlQuery = fmt.Sprintf(sqlQuery, vos.CreditOperation, vos.DebitOperation)
+	sqlQuery = fmt.Sprintf(sqlQuery, vos.CreditOperation, vos.DebitOperation, searchFilter)
 
 	params := make([]interface{}, 0)
-	params = append(params, strconv.Itoa(level), query.Value(), startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	params = append(params, strconv.Itoa(level), query.Value(), startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), book)
+
+	if searchFilter != "" {
+		params = append(params, query.Search().Value())
+	}
 
 	return sqlQuery, params
 }
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var _ domain.HealthChecker = &LedgerRepository{}
+
+var (
+	errDirtySchema      = errors.New("schema is dirty after a failed migration")
+	errSchemaBehind     = errors.New("schema is behind the embedded migrations")
+	errReplicaLagging   = errors.New("replica is lagging")
+	errInvalidMigration = errors.New("invalid migration file name")
+)
+
+const getSchemaVersionQuery = `
+select version, dirty from schema_migrations;
+`
+
+// CheckHealth checks that the primary is reachable, that its schema is at least at the version of
+// the embedded migrations and, when replicas are configured, that they are within the lag allowed.
+// Replicas are optional, as the reads fall back to the primary when they lag.
+func (r LedgerRepository) CheckHealth(ctx context.Context) []vos.DependencyStatus {
+	statuses := []vos.DependencyStatus{
+		{Name: "postgres", Err: r.db.Ping(ctx)},
+		{Name: "migrations", Err: r.checkSchemaVersion(ctx)},
+	}
+
+	return append(statuses, r.reads.checkReplicas(ctx)...)
+}
+
+func (r LedgerRepository) checkSchemaVersion(ctx context.Context) error {
+	want, err := latestMigration()
+	if err != nil {
+		return err
+	}
+
+	var (
+		version uint
+		dirty   bool
+	)
+
+	if err = r.db.QueryRow(ctx, getSchemaVersionQuery).Scan(&version, &dirty); err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%w: version %d", errDirtySchema, version)
+	}
+
+	// Migrations must stay compatible with the previous release, so a schema migrated by a newer
+	// server is still served by the older ones during a rolling deploy.
+	if version < want {
+		return fmt.Errorf("%w: version %d, want %d", errSchemaBehind, version, want)
+	}
+
+	return nil
+}
+
+// latestMigration returns the version of the last embedded migration, the prefix of its file name.
+func latestMigration() (uint, error) {
+	files, err := fs.ReadDir(migrations, "migrations")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var latest uint
+
+	for _, file := range files {
+		prefix := strings.SplitN(file.Name(), "_", 2)[0]
+
+		version, parseErr := strconv.ParseUint(prefix, 10, 64)
+		if parseErr != nil {
+			return 0, fmt.Errorf("%w: %s", errInvalidMigration, file.Name())
+		}
+
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+
+	return latest, nil
+}
+
+func (rr *readRouter) checkReplicas(ctx context.Context) []vos.DependencyStatus {
+	statuses := make([]vos.DependencyStatus, 0, len(rr.replicas))
+
+	for i, rep := range rr.replicas {
+		status := vos.DependencyStatus{
+			Name:     fmt.Sprintf("replica_%d", i),
+			Optional: true,
+		}
+
+		// The replica is queried without the lock, so a slow one doesn't stall the reads checking it.
+		lsn, lag, err := rep.status(ctx)
+		if err == nil {
+			rep.mu.Lock()
+			rep.store(lsn, lag)
+			rep.mu.Unlock()
+		}
+
+		switch {
+		case err != nil:
+			status.Err = err
+		case lag > rr.maxLag:
+			status.Err = fmt.Errorf("%w: %s behind the primary", errReplicaLagging, lag)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
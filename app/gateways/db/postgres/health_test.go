@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+func TestLedgerRepository_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report a migrated database as healthy", func(t *testing.T) {
+		r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+		statuses := r.CheckHealth(ctx)
+		require.Len(t, statuses, 2)
+
+		for _, status := range statuses {
+			assert.NoError(t, status.Err, status.Name)
+		}
+	})
+
+	t.Run("should only report a schema behind the embedded migrations", func(t *testing.T) {
+		r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+		want, err := latestMigration()
+		require.NoError(t, err)
+
+		defer func() {
+			_, err = pgDocker.DB.Exec(ctx, `update schema_migrations set version = $1`, want)
+			require.NoError(t, err)
+		}()
+
+		// A newer server migrated the schema.
+		_, err = pgDocker.DB.Exec(ctx, `update schema_migrations set version = $1`, want+1)
+		require.NoError(t, err)
+		assert.NoError(t, r.checkSchemaVersion(ctx))
+
+		_, err = pgDocker.DB.Exec(ctx, `update schema_migrations set version = $1`, want-1)
+		require.NoError(t, err)
+		assert.ErrorIs(t, r.checkSchemaVersion(ctx), errSchemaBehind)
+	})
+
+	t.Run("should check the lag of the replicas", func(t *testing.T) {
+		r, err := NewRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}, LazyBalanceStrategy, WithReplicas(nil, 0))
+		require.NoError(t, err)
+		assert.Len(t, r.CheckHealth(ctx), 2)
+
+		// The primary is never behind itself, so it can stand in for a replica.
+		r, err = NewRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}, LazyBalanceStrategy, WithReplicas([]*pgxpool.Pool{pgDocker.DB}, 0))
+		require.NoError(t, err)
+
+		statuses := r.CheckHealth(ctx)
+		require.Len(t, statuses, 3)
+		assert.Equal(t, vos.DependencyStatus{Name: "replica_0", Optional: true}, statuses[2])
+	})
+}
+
+func TestLatestMigration(t *testing.T) {
+	latest, err := latestMigration()
+	require.NoError(t, err)
+	assert.Equal(t, uint(20), latest)
+}
@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"context"
+
 	"github.com/jackc/pgx/v4/pgxpool"
 
 	"github.com/stone-co/the-amazing-ledger/app/domain"
@@ -12,12 +14,21 @@ const (
 	collection = "entry"
 )
 
-var _ domain.Repository = &LedgerRepository{}
+var (
+	_ domain.Repository      = &LedgerRepository{}
+	_ domain.AdminRepository = &LedgerRepository{}
+)
 
 type LedgerRepository struct {
 	db *pgxpool.Pool
 	pb *instrumentators.LedgerInstrumentator
 	qb querybuilder.QueryBuilder
+
+	// resultingBalanceQuery reads the balance of an account within the transaction that posted to it.
+	resultingBalanceQuery string
+
+	// reads picks the pool of read-only calls, which is db unless replicas are configured.
+	reads *readRouter
 }
 
 func NewLedgerRepository(db *pgxpool.Pool, pb *instrumentators.LedgerInstrumentator) *LedgerRepository {
@@ -25,8 +36,16 @@ func NewLedgerRepository(db *pgxpool.Pool, pb *instrumentators.LedgerInstrumenta
 	qb.Init(numDefaultQueries)
 
 	return &LedgerRepository{
-		db: db,
-		pb: pb,
-		qb: qb,
+		db:    db,
+		pb:    pb,
+		qb:    qb,
+		reads: newReadRouter(db, nil, 0),
+
+		resultingBalanceQuery: getResultingBalanceQuery,
 	}
 }
+
+// PrepareBalances has nothing to prepare, as lazy balances are computed from the entries on read.
+func (r LedgerRepository) PrepareBalances(context.Context) error {
+	return nil
+}
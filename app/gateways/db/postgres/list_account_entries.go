@@ -2,16 +2,24 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
-	"github.com/stone-co/the-amazing-ledger/app/instrumentation/newrelic"
 	pag "github.com/stone-co/the-amazing-ledger/app/pagination"
 )
 
 const (
-	_accountEntriesQueryPrefix = `
+	// Every filter, including the cursor, is part of the query, so only the entries of the page are
+	// read. The balances of the entries are read apart.
+	_accountEntriesSelect = `
 select
 	id,
 	version,
@@ -19,15 +27,30 @@ select
 	amount,
 	event,
 	competence_date,
-	metadata
+	metadata,
+	account,
+	created_at,
+	tx_id,
+	company
 from
 	entry
 where
-	account = $1
+`
+
+	_accountEntriesPeriodFilter = `
+	and book = $5
 	and competence_date >= $2
 	and competence_date < $3
 `
 
+	_accountEntriesQueryPrefix = _accountEntriesSelect + `
+	account = $1
+` + _accountEntriesPeriodFilter
+
+	_syntheticEntriesQueryPrefix = _accountEntriesSelect + `
+	account ~ $1::lquery
+` + _accountEntriesPeriodFilter
+
 	_accountEntriesCompanyFilter = `
 	and company = $%d
 `
@@ -48,6 +71,15 @@ where
 	and operation = $%d
 `
 
+	// The metadata filters are served by the gin index on metadata, which supports both operators.
+	_accountEntriesMetadataContainsFilter = `
+	and metadata @> $%d::jsonb
+`
+
+	_accountEntriesMetadataKeyFilter = `
+	and metadata @? $%d::jsonpath
+`
+
 	_accountEntriesQueryPagination = `
 	and (competence_date, version) <= ($%d, $%d)
 `
@@ -55,8 +87,97 @@ where
 	_accountEntriesQuerySuffix = `
 order by
 	competence_date desc,
-	version desc
+	version desc,
+	id desc
+limit $4;
+`
+
+	_syntheticEntriesQueryPagination = `
+	and (competence_date, created_at, id) <= ($%d, $%d, $%d)
+`
+
+	_syntheticEntriesQuerySuffix = `
+order by
+	competence_date desc,
+	created_at desc,
+	id desc
 limit $4;
+`
+
+	// The balance after an entry is the current balance of the account less the entries after it in
+	// the history, whatever their competence date. So only the entries from the oldest one of the page
+	// on are summed, which are few for recent pages, besides the current balance, which starts from
+	// the balance snapshot.
+	_accountEntriesLaterQuery = `
+select
+	id,
+	later
+from
+	(
+		select
+			id,
+			coalesce(sum(case when operation = 1 then amount else -amount end) over later, 0) as later
+		from
+			entry
+		where
+			account = $1
+			and book = $2
+			and competence_date >= $3
+			and (competence_date, version, id) >= ($3, $4, $5)
+		window later as (order by competence_date desc, version desc, id desc rows between unbounded preceding and 1 preceding)
+	) as entries
+where
+	id = any($6);
+`
+
+	_syntheticEntriesLaterQuery = `
+select
+	id,
+	later
+from
+	(
+		select
+			id,
+			coalesce(sum(case when operation = 1 then amount else -amount end) over later, 0) as later
+		from
+			entry
+		where
+			account ~ $1::lquery
+			and book = $2
+			and competence_date >= $3
+			and (competence_date, created_at, id) >= ($3, $4, $5)
+		window later as (order by competence_date desc, created_at desc, id desc rows between unbounded preceding and 1 preceding)
+	) as entries
+where
+	id = any($6);
+`
+
+	_accountEntriesCurrentBalanceQuery = `
+select total_balance from get_analytic_account_balance_readonly($1, $2);
+`
+
+	_syntheticEntriesCurrentBalanceQuery = `
+select total_balance from get_synthetic_account_balance_readonly($1, $2);
+`
+
+	_openingBalanceLaterSelect = `
+select
+	coalesce(sum(case when operation = 1 then amount else -amount end), 0)
+from
+	entry
+where
+`
+
+	_openingBalanceLaterQuery = _openingBalanceLaterSelect + `
+	account = $1
+	and book = $2
+	and competence_date >= $3;
+`
+
+	_syntheticOpeningBalanceLaterQuery = _openingBalanceLaterSelect + `
+	account ~ $1::lquery
+	and book = $2
+	and competence_date >= $3;
 `
 )
 
@@ -65,19 +186,99 @@ type listAccountEntriesCursor struct {
 	Version        int64     `json:"version"`
 }
 
-func (r LedgerRepository) ListAccountEntries(ctx context.Context, req vos.AccountEntryRequest) ([]vos.AccountEntry, pag.Cursor, error) {
+// listSyntheticEntriesCursor pages the entries of synthetic accounts, whose versions belong to
+// different accounts.
+type listSyntheticEntriesCursor struct {
+	CompetenceDate time.Time `json:"competence_date"`
+	CreatedAt      time.Time `json:"created_at"`
+	ID             uuid.UUID `json:"id"`
+}
+
+func (r LedgerRepository) ListAccountEntries(ctx context.Context, req vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
 	const op = "Repository.ListAccountEntries"
 
-	query, args, err := generateListAccountEntriesQuery(req)
+	book := vos.BookFromContext(ctx)
+
+	query, args, err := generateListAccountEntriesQuery(book, req)
+	if err != nil {
+		return vos.AccountEntryResponse{}, fmt.Errorf("failed to generate %s query: %w", op, err)
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, collection, op, query).End()
+
+	db, _ := r.reads.reader(ctx)
+
+	// The entries, their balances and the opening balance are read from the same snapshot of the
+	// ledger.
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return vos.AccountEntryResponse{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	entries, err := listAccountEntries(ctx, tx, req, query, args)
+	if err != nil {
+		return vos.AccountEntryResponse{}, err
+	}
+
+	if len(entries) == 0 && !req.IncludeOpeningBalance {
+		return vos.AccountEntryResponse{Entries: entries}, nil
+	}
+
+	current, err := currentBalance(ctx, tx, book, req.Account)
+	if err != nil {
+		return vos.AccountEntryResponse{}, err
+	}
+
+	if len(entries) > 0 {
+		if err = setEntryBalances(ctx, tx, book, req.Account, current, entries); err != nil {
+			return vos.AccountEntryResponse{}, err
+		}
+	}
+
+	var response vos.AccountEntryResponse
+
+	if req.IncludeOpeningBalance {
+		opening, err := openingBalance(ctx, tx, book, req.Account, req.StartDate, current)
+		if err != nil {
+			return vos.AccountEntryResponse{}, err
+		}
+
+		response.OpeningBalance = &opening
+	}
+
+	response.Entries, response.NextPage, err = pageAccountEntries(req, entries)
+	if err != nil {
+		return vos.AccountEntryResponse{}, err
+	}
+
+	return response, nil
+}
+
+// pageAccountEntries splits the extra entry read past the page, which starts the next page.
+func pageAccountEntries(req vos.AccountEntryRequest, entries []vos.AccountEntry) ([]vos.AccountEntry, pag.Cursor, error) {
+	if len(entries) <= req.Page.Size {
+		return entries, nil, nil
+	}
+
+	lastEntry := entries[len(entries)-1]
+	entries = entries[:len(entries)-1]
+
+	cursor, err := newAccountEntriesCursor(req.Account, lastEntry)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate %s query: %w", op, err)
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
 	}
 
-	defer newrelic.NewDatastoreSegment(ctx, collection, op, query).End()
+	return entries, cursor, nil
+}
 
-	rows, err := r.db.Query(ctx, query, args...)
+func listAccountEntries(ctx context.Context, tx pgx.Tx, req vos.AccountEntryRequest, query string, args []interface{}) ([]vos.AccountEntry, error) {
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
 	defer rows.Close()
@@ -85,7 +286,10 @@ func (r LedgerRepository) ListAccountEntries(ctx context.Context, req vos.Accoun
 	entries := make([]vos.AccountEntry, 0)
 
 	for rows.Next() {
-		var entry vos.AccountEntry
+		var (
+			entry   vos.AccountEntry
+			account string
+		)
 
 		if err = rows.Scan(
 			&entry.ID,
@@ -95,42 +299,147 @@ func (r LedgerRepository) ListAccountEntries(ctx context.Context, req vos.Accoun
 			&entry.Event,
 			&entry.CompetenceDate,
 			&entry.Metadata,
+			&account,
+			&entry.CreatedAt,
+			&entry.TxID,
+			&entry.Company,
 		); err != nil {
-			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		entry.Account = req.Account
+		if req.Account.Type() == vos.Synthetic {
+			if entry.Account, err = vos.NewAnalyticAccount(account); err != nil {
+				return nil, fmt.Errorf("failed to parse account %s: %w", account, err)
+			}
 		}
 
 		entries = append(entries, entry)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, nil, fmt.Errorf("%s rows have error: %w", op, err)
+		return nil, fmt.Errorf("rows have error: %w", err)
 	}
 
-	if len(entries) <= req.Page.Size {
-		return entries, nil, nil
+	return entries, nil
+}
+
+// currentBalance returns the balance of account, which is zero when it has no entries.
+func currentBalance(ctx context.Context, tx pgx.Tx, book string, account vos.Account) (int, error) {
+	query := _accountEntriesCurrentBalanceQuery
+	if account.Type() == vos.Synthetic {
+		query = _syntheticEntriesCurrentBalanceQuery
 	}
 
-	lastEntry := entries[len(entries)-1]
-	entries = entries[:len(entries)-1]
+	var current int
+	if err := tx.QueryRow(ctx, query, book, account.Value()).Scan(&current); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.NoDataFound {
+			return 0, nil
+		}
 
-	cursor, err := pag.NewCursor(listAccountEntriesCursor{
-		CompetenceDate: lastEntry.CompetenceDate,
-		Version:        lastEntry.Version.AsInt64(),
-	})
+		return 0, fmt.Errorf("failed to get current balance: %w", err)
+	}
+
+	return current, nil
+}
+
+// openingBalance returns the balance of account before date: the current balance less the entries
+// from date on, so only those are summed, instead of the whole history before date.
+func openingBalance(ctx context.Context, tx pgx.Tx, book string, account vos.Account, date time.Time, current int) (int, error) {
+	query := _openingBalanceLaterQuery
+	if account.Type() == vos.Synthetic {
+		query = _syntheticOpeningBalanceLaterQuery
+	}
+
+	var later int
+	if err := tx.QueryRow(ctx, query, account.Value(), book, date).Scan(&later); err != nil {
+		return 0, fmt.Errorf("failed to get opening balance: %w", err)
+	}
+
+	return current - later, nil
+}
+
+// setEntryBalances sets the balance of the account right after each entry, the entries being sorted
+// newest first, from the current balance of the account.
+func setEntryBalances(ctx context.Context, tx pgx.Tx, book string, account vos.Account, current int, entries []vos.AccountEntry) error {
+	var (
+		oldest     = entries[len(entries)-1]
+		ids        = make([]uuid.UUID, 0, len(entries))
+		laterQuery = _accountEntriesLaterQuery
+		laterArgs  = []interface{}{account.Value(), book, oldest.CompetenceDate, oldest.Version.AsInt64(), oldest.ID}
+	)
+
+	if account.Type() == vos.Synthetic {
+		laterQuery = _syntheticEntriesLaterQuery
+		laterArgs = []interface{}{account.Value(), book, oldest.CompetenceDate, oldest.CreatedAt, oldest.ID}
+	}
+
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	rows, err := tx.Query(ctx, laterQuery, append(laterArgs, ids)...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+		return fmt.Errorf("failed to get entry balances: %w", err)
 	}
 
-	return entries, cursor, nil
+	defer rows.Close()
+
+	balances := make(map[uuid.UUID]int, len(entries))
+
+	for rows.Next() {
+		var (
+			id    uuid.UUID
+			later int
+		)
+
+		if err = rows.Scan(&id, &later); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		balances[id] = current - later
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("entry balances rows have error: %w", err)
+	}
+
+	for i := range entries {
+		entries[i].Balance = balances[entries[i].ID]
+	}
+
+	return nil
+}
+
+// newAccountEntriesCursor returns the cursor of the page that starts at entry.
+func newAccountEntriesCursor(account vos.Account, entry vos.AccountEntry) (pag.Cursor, error) {
+	if account.Type() == vos.Synthetic {
+		return pag.NewCursor(listSyntheticEntriesCursor{
+			CompetenceDate: entry.CompetenceDate,
+			CreatedAt:      entry.CreatedAt,
+			ID:             entry.ID,
+		})
+	}
+
+	return pag.NewCursor(listAccountEntriesCursor{
+		CompetenceDate: entry.CompetenceDate,
+		Version:        entry.Version.AsInt64(),
+	})
 }
 
-func generateListAccountEntriesQuery(req vos.AccountEntryRequest) (string, []interface{}, error) {
+func generateListAccountEntriesQuery(book string, req vos.AccountEntryRequest) (string, []interface{}, error) {
 	var (
+		synthetic = req.Account.Type() == vos.Synthetic
 		query     = _accountEntriesQueryPrefix
-		totalArgs = 4
-		args      = []interface{}{req.Account.Value(), req.StartDate, req.EndDate, req.Page.Size + 1}
+		totalArgs = 5
+		args      = []interface{}{req.Account.Value(), req.StartDate, req.EndDate, req.Page.Size + 1, book}
 	)
 
+	if synthetic {
+		query = _syntheticEntriesQueryPrefix
+	}
+
 	switch len(req.Filter.Companies) {
 	case 0:
 		break
@@ -163,6 +472,49 @@ func generateListAccountEntriesQuery(req vos.AccountEntryRequest) (string, []int
 		totalArgs += 1
 	}
 
+	// Every condition is a separate clause, since merging the objects would turn two values for the
+	// same path into an array.
+	documents := make([]interface{}, 0, len(req.Filter.Metadata.Equals)+1)
+	if req.Filter.Metadata.Contains != nil {
+		documents = append(documents, req.Filter.Metadata.Contains)
+	}
+
+	for _, match := range req.Filter.Metadata.Equals {
+		documents = append(documents, match.Document())
+	}
+
+	for _, document := range documents {
+		value, err := json.Marshal(document)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to encode metadata filter: %w", err)
+		}
+
+		query += fmt.Sprintf(_accountEntriesMetadataContainsFilter, totalArgs+1)
+		args = append(args, string(value))
+		totalArgs += 1
+	}
+
+	for _, path := range req.Filter.Metadata.Keys {
+		query += fmt.Sprintf(_accountEntriesMetadataKeyFilter, totalArgs+1)
+		args = append(args, path.JSONPath())
+		totalArgs += 1
+	}
+
+	if synthetic {
+		if req.Page.Cursor != nil {
+			var cursor listSyntheticEntriesCursor
+			err := req.Page.Extract(&cursor)
+			if err != nil {
+				return "", nil, err
+			}
+
+			query += fmt.Sprintf(_syntheticEntriesQueryPagination, totalArgs+1, totalArgs+2, totalArgs+3)
+			args = append(args, cursor.CompetenceDate, cursor.CreatedAt, cursor.ID)
+		}
+
+		return query + _syntheticEntriesQuerySuffix, args, nil
+	}
+
 	if req.Page.Cursor != nil {
 		var cursor listAccountEntriesCursor
 		err := req.Page.Extract(&cursor)
@@ -50,7 +50,7 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix + _accountEntriesQuerySuffix,
-			expectedArgs:  []interface{}{account.Value(), start, end, size + 1},
+			expectedArgs:  []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook},
 			expectedErr:   nil,
 		},
 		{
@@ -72,9 +72,9 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix +
-				fmt.Sprintf(_accountEntriesQueryPagination, 5, 6) +
+				fmt.Sprintf(_accountEntriesQueryPagination, 6, 7) +
 				_accountEntriesQuerySuffix,
-			expectedArgs: []interface{}{account.Value(), start, end, size + 1, end, version.AsInt64()},
+			expectedArgs: []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook, end, version.AsInt64()},
 			expectedErr:  nil,
 		},
 		{
@@ -94,9 +94,9 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix +
-				fmt.Sprintf(_accountEntriesCompanyFilter, 5) +
+				fmt.Sprintf(_accountEntriesCompanyFilter, 6) +
 				_accountEntriesQuerySuffix,
-			expectedArgs: []interface{}{account.Value(), start, end, size + 1, "company_1"},
+			expectedArgs: []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook, "company_1"},
 			expectedErr:  nil,
 		},
 		{
@@ -116,9 +116,9 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix +
-				fmt.Sprintf(_accountEntriesCompaniesFilter, 5) +
+				fmt.Sprintf(_accountEntriesCompaniesFilter, 6) +
 				_accountEntriesQuerySuffix,
-			expectedArgs: []interface{}{account.Value(), start, end, size + 1, []string{"company_1", "company_2"}},
+			expectedArgs: []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook, []string{"company_1", "company_2"}},
 			expectedErr:  nil,
 		},
 		{
@@ -138,9 +138,9 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix +
-				fmt.Sprintf(_accountEntriesEventFilter, 5) +
+				fmt.Sprintf(_accountEntriesEventFilter, 6) +
 				_accountEntriesQuerySuffix,
-			expectedArgs: []interface{}{account.Value(), start, end, size + 1, int32(1)},
+			expectedArgs: []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook, int32(1)},
 			expectedErr:  nil,
 		},
 		{
@@ -160,9 +160,9 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix +
-				fmt.Sprintf(_accountEntriesEventsFilter, 5) +
+				fmt.Sprintf(_accountEntriesEventsFilter, 6) +
 				_accountEntriesQuerySuffix,
-			expectedArgs: []interface{}{account.Value(), start, end, size + 1, []int32{1, 2}},
+			expectedArgs: []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook, []int32{1, 2}},
 			expectedErr:  nil,
 		},
 		{
@@ -182,9 +182,9 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix +
-				fmt.Sprintf(_accountEntriesOperationFilter, 5) +
+				fmt.Sprintf(_accountEntriesOperationFilter, 6) +
 				_accountEntriesQuerySuffix,
-			expectedArgs: []interface{}{account.Value(), start, end, size + 1, vos.CreditOperation},
+			expectedArgs: []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook, vos.CreditOperation},
 			expectedErr:  nil,
 		},
 		{
@@ -213,18 +213,76 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix +
-				fmt.Sprintf(_accountEntriesCompaniesFilter, 5) +
-				fmt.Sprintf(_accountEntriesEventFilter, 6) +
-				fmt.Sprintf(_accountEntriesOperationFilter, 7) +
-				fmt.Sprintf(_accountEntriesQueryPagination, 8, 9) +
+				fmt.Sprintf(_accountEntriesCompaniesFilter, 6) +
+				fmt.Sprintf(_accountEntriesEventFilter, 7) +
+				fmt.Sprintf(_accountEntriesOperationFilter, 8) +
+				fmt.Sprintf(_accountEntriesQueryPagination, 9, 10) +
 				_accountEntriesQuerySuffix,
 			expectedArgs: []interface{}{
-				account.Value(), start, end, size + 1,
+				account.Value(), start, end, size + 1, vos.DefaultBook,
 				[]string{"company_1", "company_2"}, int32(1), vos.CreditOperation,
 				end, version.AsInt64(),
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "valid - metadata filters",
+			req: func() vos.AccountEntryRequest {
+				metadata, _ := vos.NewMetadataFilter(`{"order": {"id": 123}}`, []string{"external_id"}, map[string]string{"order.status": "paid"})
+
+				return vos.AccountEntryRequest{
+					Account:   account,
+					StartDate: start,
+					EndDate:   end,
+					Filter:    vos.AccountEntryFilter{Metadata: metadata},
+					Page: pagination.Page{
+						Size:   size,
+						Cursor: nil,
+					},
+				}
+			},
+			expectedQuery: _accountEntriesQueryPrefix +
+				fmt.Sprintf(_accountEntriesMetadataContainsFilter, 6) +
+				fmt.Sprintf(_accountEntriesMetadataContainsFilter, 7) +
+				fmt.Sprintf(_accountEntriesMetadataKeyFilter, 8) +
+				_accountEntriesQuerySuffix,
+			expectedArgs: []interface{}{
+				account.Value(), start, end, size + 1, vos.DefaultBook,
+				`{"order":{"id":123}}`, `{"order":{"status":"paid"}}`, `strict $."external_id"`,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "valid - synthetic account - with pagination",
+			req: func() vos.AccountEntryRequest {
+				synthetic, _ := vos.NewAccount("liability.test.*")
+				cursor, _ := pagination.NewCursor(listSyntheticEntriesCursor{
+					CompetenceDate: end,
+					CreatedAt:      start,
+					ID:             uuid.Nil,
+				})
+
+				return vos.AccountEntryRequest{
+					Account:   synthetic,
+					StartDate: start,
+					EndDate:   end,
+					Filter:    vos.AccountEntryFilter{Operation: vos.CreditOperation},
+					Page: pagination.Page{
+						Size:   size,
+						Cursor: cursor,
+					},
+				}
+			},
+			expectedQuery: _syntheticEntriesQueryPrefix +
+				fmt.Sprintf(_accountEntriesOperationFilter, 6) +
+				fmt.Sprintf(_syntheticEntriesQueryPagination, 7, 8, 9) +
+				_syntheticEntriesQuerySuffix,
+			expectedArgs: []interface{}{
+				"liability.test.*", start, end, size + 1, vos.DefaultBook,
+				vos.CreditOperation, end, start, uuid.Nil,
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "invalid page	token",
 			req: func() vos.AccountEntryRequest {
@@ -261,13 +319,13 @@ func Test_generateListAccountEntriesQuery(t *testing.T) {
 				}
 			},
 			expectedQuery: _accountEntriesQueryPrefix + _accountEntriesQuerySuffix,
-			expectedArgs:  []interface{}{account.Value(), start, end, size + 1},
+			expectedArgs:  []interface{}{account.Value(), start, end, size + 1, vos.DefaultBook},
 			expectedErr:   nil,
 		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1, err := generateListAccountEntriesQuery(tt.req())
+			got, got1, err := generateListAccountEntriesQuery(vos.DefaultBook, tt.req())
 			assert.ErrorIs(t, err, tt.expectedErr)
 			assert.Equal(t, tt.expectedQuery, got)
 			assert.EqualValues(t, tt.expectedArgs, got1)
@@ -459,7 +517,7 @@ func TestLedgerRepository_ListAccountEntries(t *testing.T) {
 				)
 				assert.NoError(t, err)
 
-				err = r.CreateTransaction(ctx, tx1)
+				_, err = r.CreateTransaction(ctx, tx1)
 				assert.NoError(t, err)
 
 				e1 = createEntry(t, vos.DebitOperation, account1, vos.Version(2), amount)
@@ -510,7 +568,7 @@ func TestLedgerRepository_ListAccountEntries(t *testing.T) {
 				)
 				assert.NoError(t, err)
 
-				err = r.CreateTransaction(ctx, tx1)
+				_, err = r.CreateTransaction(ctx, tx1)
 				assert.NoError(t, err)
 
 				e1 = createEntry(t, vos.DebitOperation, account1, vos.Version(2), amount)
@@ -562,7 +620,7 @@ func TestLedgerRepository_ListAccountEntries(t *testing.T) {
 				)
 				assert.NoError(t, err)
 
-				err = r.CreateTransaction(ctx, tx1)
+				_, err = r.CreateTransaction(ctx, tx1)
 				assert.NoError(t, err)
 
 				e1 = createEntry(t, vos.DebitOperation, account1, vos.Version(2), amount)
@@ -613,7 +671,7 @@ func TestLedgerRepository_ListAccountEntries(t *testing.T) {
 				)
 				assert.NoError(t, err)
 
-				err = r.CreateTransaction(ctx, tx1)
+				_, err = r.CreateTransaction(ctx, tx1)
 				assert.NoError(t, err)
 
 				e1 = createEntry(t, vos.DebitOperation, account1, vos.Version(2), amount)
@@ -704,9 +762,15 @@ func TestLedgerRepository_ListAccountEntries(t *testing.T) {
 
 			req := tt.setupRequest(t, txs)
 
-			resp, cur, err := r.ListAccountEntries(ctx, req)
+			resp, err := r.ListAccountEntries(ctx, req)
+			for i := range resp.Entries {
+				// Creation times come from the database, and balances are checked by the conformance tests.
+				resp.Entries[i].CreatedAt = time.Time{}
+				resp.Entries[i].Balance = 0
+			}
+
 			want := tt.want(t, txs)
-			got := w{entries: resp, cursor: cur}
+			got := w{entries: resp.Entries, cursor: resp.NextPage}
 			assert.NoError(t, err)
 			assert.Equal(t, want, got)
 		})
@@ -734,6 +798,9 @@ func accountEntriesFromTransaction(t *testing.T, tx entities.Transaction, accoun
 			Event:          int(tx.Event),
 			CompetenceDate: tx.CompetenceDate.Round(time.Microsecond),
 			Metadata:       mt,
+			Account:        et.Account,
+			TxID:           tx.ID,
+			Company:        tx.Company,
 		})
 	}
 
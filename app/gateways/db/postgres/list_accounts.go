@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	pag "github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+const accountsCollection = "account"
+
+const (
+	_listAccountsQuery = `
+select
+	account,
+	created_at
+from
+	account
+where
+	book = $1
+	and account ~ $2::lquery
+	and ($4::ltree is null or account > $4::ltree)
+order by
+	account
+limit $3;
+`
+
+	_listAccountChildrenQuery = `
+select
+	subpath(account, 0, $3 + 1) as child,
+	count(*)
+from
+	account
+where
+	book = $1
+	and account <@ $2::ltree
+	and nlevel(account) > $3
+	and ($5::ltree is null or subpath(account, 0, $3 + 1) > $5::ltree)
+group by
+	child
+order by
+	child
+limit $4;
+`
+)
+
+type listAccountsCursor struct {
+	Account string `json:"account"`
+}
+
+func (r LedgerRepository) ListAccounts(ctx context.Context, req vos.AccountListRequest) ([]vos.AccountSummary, pag.Cursor, error) {
+	const op = "Repository.ListAccounts"
+
+	after, err := extractAccountsCursor(req.Page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, accountsCollection, op, _listAccountsQuery).End()
+
+	db, _ := r.reads.reader(ctx)
+
+	rows, err := db.Query(ctx, _listAccountsQuery, vos.BookFromContext(ctx), req.Pattern.Value(), req.Page.Size+1, after)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	accounts := make([]vos.AccountSummary, 0)
+
+	for rows.Next() {
+		var (
+			account   string
+			createdAt time.Time
+		)
+
+		if err = rows.Scan(&account, &createdAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		analytic, accErr := vos.NewAnalyticAccount(account)
+		if accErr != nil {
+			return nil, nil, fmt.Errorf("failed to parse account %s: %w", account, accErr)
+		}
+
+		accounts = append(accounts, vos.AccountSummary{Account: analytic, CreatedAt: createdAt})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	if len(accounts) <= req.Page.Size {
+		return accounts, nil, nil
+	}
+
+	accounts = accounts[:len(accounts)-1]
+
+	cursor, err := pag.NewCursor(listAccountsCursor{Account: accounts[len(accounts)-1].Account.Value()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	return accounts, cursor, nil
+}
+
+func (r LedgerRepository) ListAccountChildren(ctx context.Context, req vos.AccountChildrenRequest) ([]vos.AccountChild, pag.Cursor, error) {
+	const op = "Repository.ListAccountChildren"
+
+	after, err := extractAccountsCursor(req.Page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, accountsCollection, op, _listAccountChildrenQuery).End()
+
+	db, _ := r.reads.reader(ctx)
+
+	rows, err := db.Query(ctx, _listAccountChildrenQuery,
+		vos.BookFromContext(ctx),
+		req.Prefix.Value(),
+		req.Prefix.Depth(),
+		req.Page.Size+1,
+		after,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	children := make([]vos.AccountChild, 0)
+
+	for rows.Next() {
+		var (
+			child    string
+			accounts int
+		)
+
+		if err = rows.Scan(&child, &accounts); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		prefix, prefixErr := vos.NewAccountPrefix(child)
+		if prefixErr != nil {
+			return nil, nil, fmt.Errorf("failed to parse prefix %s: %w", child, prefixErr)
+		}
+
+		children = append(children, vos.AccountChild{Prefix: prefix, Accounts: accounts})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	if len(children) <= req.Page.Size {
+		return children, nil, nil
+	}
+
+	children = children[:len(children)-1]
+
+	cursor, err := pag.NewCursor(listAccountsCursor{Account: children[len(children)-1].Prefix.Value()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	return children, cursor, nil
+}
+
+// extractAccountsCursor returns the account after which the page starts, which is nil for the
+// first page.
+func extractAccountsCursor(page pag.Page) (*string, error) {
+	if page.Cursor == nil {
+		return nil, nil
+	}
+
+	var cursor listAccountsCursor
+	if err := page.Extract(&cursor); err != nil {
+		return nil, err
+	}
+
+	return &cursor.Account, nil
+}
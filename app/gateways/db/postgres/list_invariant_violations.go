@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	pag "github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+const (
+	_invariantViolationsQueryPrefix = `
+select
+	id,
+	check_name,
+	book,
+	subject,
+	detail,
+	detected_at
+from
+	invariant_violation
+where
+	true
+`
+
+	_invariantViolationsCheckFilter = `
+	and check_name = $%d
+`
+
+	_invariantViolationsQueryPagination = `
+	and id <= $%d
+`
+
+	_invariantViolationsQuerySuffix = `
+order by
+	id desc
+limit $1;
+`
+)
+
+type listInvariantViolationsCursor struct {
+	ID int64 `json:"id"`
+}
+
+func (r LedgerRepository) ListInvariantViolations(ctx context.Context, req vos.InvariantViolationRequest) ([]vos.InvariantViolation, pag.Cursor, error) {
+	const op = "Repository.ListInvariantViolations"
+
+	query, args, err := generateListInvariantViolationsQuery(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate %s query: %w", op, err)
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, "invariant_violation", op, query).End()
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	violations := make([]vos.InvariantViolation, 0)
+
+	for rows.Next() {
+		var violation vos.InvariantViolation
+
+		if err = rows.Scan(
+			&violation.ID,
+			&violation.Check,
+			&violation.Book,
+			&violation.Subject,
+			&violation.Detail,
+			&violation.DetectedAt,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		violations = append(violations, violation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	if len(violations) <= req.Page.Size {
+		return violations, nil, nil
+	}
+
+	lastViolation := violations[len(violations)-1]
+	violations = violations[:len(violations)-1]
+
+	cursor, err := pag.NewCursor(listInvariantViolationsCursor{ID: lastViolation.ID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	return violations, cursor, nil
+}
+
+func generateListInvariantViolationsQuery(req vos.InvariantViolationRequest) (string, []interface{}, error) {
+	var (
+		query     = _invariantViolationsQueryPrefix
+		totalArgs = 1
+		args      = []interface{}{req.Page.Size + 1}
+	)
+
+	if req.Check != "" {
+		query += fmt.Sprintf(_invariantViolationsCheckFilter, totalArgs+1)
+		args = append(args, req.Check.String())
+		totalArgs += 1
+	}
+
+	if req.Page.Cursor != nil {
+		var cursor listInvariantViolationsCursor
+		err := req.Page.Extract(&cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		query += fmt.Sprintf(_invariantViolationsQueryPagination, totalArgs+1)
+		args = append(args, cursor.ID)
+	}
+	query += _invariantViolationsQuerySuffix
+
+	return query, args, nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+const tryAdvisoryLockQuery = `
+select pg_try_advisory_lock($1);
+`
+
+const advisoryUnlockQuery = `
+select pg_advisory_unlock($1);
+`
+
+// tryAdvisoryLock tries to take the session advisory lock of key on a connection of its own, returning
+// whether it was taken and the function that releases it. The lock is also released if the connection
+// is lost, so a replica that dies while holding it doesn't keep it locked.
+func (r LedgerRepository) tryAdvisoryLock(ctx context.Context, key int64) (func(), bool, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	var locked bool
+	if err = conn.QueryRow(ctx, tryAdvisoryLockQuery, key).Scan(&locked); err != nil || !locked {
+		conn.Release()
+		return nil, false, err
+	}
+
+	unlock := func() {
+		// A connection that can't unlock is closed rather than given back to the pool still holding the lock.
+		if _, err := conn.Exec(context.Background(), advisoryUnlockQuery, key); err != nil {
+			_ = conn.Conn().Close(context.Background())
+		}
+
+		conn.Release()
+	}
+
+	return unlock, true, nil
+}
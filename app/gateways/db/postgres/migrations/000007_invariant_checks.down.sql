@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists invariant_violation;
+drop table if exists invariant_watermark;
+
+commit;
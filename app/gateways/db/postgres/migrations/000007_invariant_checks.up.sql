@@ -0,0 +1,21 @@
+begin;
+
+create table if not exists invariant_watermark
+(
+    name       text        primary key,
+    created_at timestamptz not null
+);
+
+create table if not exists invariant_violation
+(
+    id          bigserial   primary key,
+    check_name  text        not null,
+    subject     text        not null,
+    detail      text        not null,
+    detected_at timestamptz not null default now()
+);
+
+create index if not exists idx_invariant_violation_check
+    on invariant_violation using btree (check_name, id);
+
+commit;
@@ -0,0 +1,200 @@
+begin;
+
+alter table account_balance
+    add column if not exists read_at    timestamptz not null default now(),
+    add column if not exists read_count bigint      not null default 0;
+
+create index if not exists idx_account_balance_read_at
+    on account_balance using btree (read_at);
+
+-- Marks a snapshot as read. Reads are counted at most once a minute, so read_count tells for
+-- how long a snapshot has been in use instead of growing (and writing) on every single read.
+create or replace procedure _touch_account_balance(_account text)
+    language sql
+as
+$$
+    update account_balance
+    set
+        read_at = now(),
+        read_count = read_count + 1
+    where
+        account = _account
+        and read_at < now() - interval '1 minute';
+$$;
+
+--
+-- Analytic account
+--
+
+drop function if exists get_analytic_account_balance(ltree);
+
+create or replace function get_analytic_account_balance(
+    in _account ltree, in _touch boolean default true,
+    out total_balance bigint, out version int
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+
+    _partial_balance    bigint;
+    _partial_date       timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            partial_balance,
+            partial_date,
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance,
+            version
+        from
+            _get_analytic_account_balance(_account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        -- Only recent balance exists, so return it without creating snapshot
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select
+        _existing_balance + partial_balance,
+        partial_date,
+
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version
+    into
+        _partial_balance,
+        _partial_date,
+
+        total_balance,
+        version
+    from
+        _get_analytic_account_balance_since(_account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_account => _account::text);
+    end if;
+
+    -- No new entries exists
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+--
+-- Synthetic account
+--
+
+drop function if exists get_synthetic_account_balance(lquery);
+
+create or replace function get_synthetic_account_balance(
+    in _account lquery, in _touch boolean default true,
+    out total_balance bigint
+)
+    returns bigint
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+    _partial_balance  bigint;
+    _partial_date     timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where account = _account::text;
+
+    if (_existing_balance is null) then
+        select partial_balance,
+               partial_date,
+               coalesce(partial_balance, 0) + recent_balance
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance
+        from
+            _get_synthetic_account_balance(_account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select _existing_balance + partial_balance,
+           partial_date,
+           _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0)
+    into
+        _partial_balance,
+        _partial_date,
+        total_balance
+    from
+        _get_synthetic_account_balance_since(_account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_account => _account::text);
+    end if;
+
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+commit;
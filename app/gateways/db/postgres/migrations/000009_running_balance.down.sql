@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists running_balance_status;
+drop table if exists account_running_balance;
+
+commit;
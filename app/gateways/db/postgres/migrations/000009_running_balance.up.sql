@@ -0,0 +1,40 @@
+begin;
+
+-- Running balances are only maintained by the eager balance strategy, which updates them in the
+-- same statement that inserts the entries. The version is the greatest version of the last write,
+-- the same one returned by get_analytic_account_balance.
+create table if not exists account_running_balance
+(
+    balance bigint not null,
+    version int    not null,
+    account ltree  primary key
+) with (fillfactor = 70);
+
+create index if not exists idx_account_running_balance_gist
+    on account_running_balance using gist (account gist_ltree_ops(siglen=32));
+
+insert into account_running_balance (balance, version, account)
+select
+    coalesce(sum(amount) filter (where operation = 1), 0) -
+    coalesce(sum(amount) filter (where operation = 2), 0),
+    (array_agg(version order by created_at desc, version desc))[1],
+    account
+from
+    entry
+group by
+    account
+on conflict (account) do nothing;
+
+-- The lazy strategy doesn't maintain running balances, so its writes mark them stale. Eager replicas
+-- don't serve stale running balances, and rebuild them when they start. The single row starts current,
+-- as the running balances were just computed from every entry.
+create table if not exists running_balance_status
+(
+    id         boolean     primary key default true check (id),
+    stale      boolean     not null,
+    updated_at timestamptz not null default now()
+);
+
+insert into running_balance_status (stale) values (false) on conflict do nothing;
+
+commit;
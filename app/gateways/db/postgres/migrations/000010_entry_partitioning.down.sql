@@ -0,0 +1,55 @@
+begin;
+
+alter table entry rename to entry_partitioned;
+
+create table entry
+(
+    id              uuid primary key,
+    tx_id           uuid        not null,
+    event           smallint    not null references event(id),
+    operation       smallint    not null check (operation = 1 or operation = 2),
+    version         int         not null,
+    amount          bigint      not null,
+    created_at      timestamptz not null default now(),
+    competence_date timestamptz not null,
+    account         ltree       not null,
+    company         text        not null,
+    metadata        jsonb       not null default '{}'
+);
+
+-- Entries of archived partitions are kept as their carry-forward entries.
+insert into entry (id, tx_id, event, operation, version, amount, created_at, competence_date, account, company, metadata)
+select id, tx_id, event, operation, version, amount, created_at, competence_date, account, company, metadata
+from entry_partitioned;
+
+drop table entry_partitioned;
+
+-- Detached partitions are left untouched, as they are no longer part of the entry table.
+
+drop function if exists register_entry_id;
+drop table if exists entry_id;
+drop function if exists detach_entry_partition;
+drop function if exists create_entry_partition;
+drop table if exists entry_partition;
+
+create index if not exists idx_entry_account_gist
+    on entry using gist (account gist_ltree_ops(siglen=32));
+create index if not exists idx_entry_tx
+    on entry using btree (tx_id);
+create index if not exists idx_entry_company
+    on entry using btree (company);
+create index if not exists idx_entry_event
+    on entry using btree (event);
+create index if not exists idx_created_at
+    on entry using brin (created_at) with (pages_per_range = 32);
+create index if not exists idx_entry_competence_date
+    on entry using btree (competence_date);
+
+create trigger tg_update_account_version
+    before insert
+    on entry
+    for each row
+    when (new.version >= 0)
+execute procedure update_account_version();
+
+commit;
@@ -0,0 +1,198 @@
+begin;
+
+-- Carry-forward entries replace the entries of archived partitions, keeping balances unchanged.
+insert into event (id, name) values (0, 'carry_forward') on conflict do nothing;
+
+create table if not exists entry_partition
+(
+    name        text        primary key,
+    range_start timestamptz not null,
+    range_end   timestamptz not null,
+    detached_at timestamptz,
+    archived_at timestamptz,
+    archive     text
+);
+
+--
+-- Partitioned entry table
+--
+
+alter table entry rename to entry_unpartitioned;
+
+create table entry
+(
+    id              uuid        not null,
+    tx_id           uuid        not null,
+    event           smallint    not null references event(id),
+    operation       smallint    not null check (operation = 1 or operation = 2),
+    version         int         not null,
+    amount          bigint      not null,
+    created_at      timestamptz not null default now(),
+    competence_date timestamptz not null,
+    account         ltree       not null,
+    company         text        not null,
+    metadata        jsonb       not null default '{}'
+) partition by range (competence_date);
+
+-- Entries out of the range of the monthly partitions, including carry-forward entries of archived months.
+create table entry_default partition of entry default;
+
+-- Creates the monthly partition that holds the given date (in UTC), moving its entries out of the
+-- default partition. Returns null when the partition was already created.
+create or replace function create_entry_partition(_date timestamptz)
+    returns text
+    language plpgsql
+as
+$$
+declare
+    _month timestamp := date_trunc('month', _date at time zone 'UTC');
+    _start timestamptz := _month at time zone 'UTC';
+    _end   timestamptz := (_month + interval '1 month') at time zone 'UTC';
+    _name  text := 'entry_' || to_char(_month, 'YYYY_MM');
+begin
+    if exists (select 1 from entry_partition where name = _name) then
+        return null;
+    end if;
+
+    execute format('create table %I (like entry including defaults including constraints)', _name);
+
+    execute format(
+        'with moved as (delete from entry_default where competence_date >= $1 and competence_date < $2 returning *) '
+        'insert into %I select * from moved',
+        _name
+    ) using _start, _end;
+
+    execute format('alter table entry attach partition %I for values from (%L) to (%L)', _name, _start, _end);
+
+    insert into entry_partition (name, range_start, range_end)
+    values (_name, _start, _end);
+
+    return _name;
+end;
+$$;
+
+-- Detaches a monthly partition, replacing its entries by one carry-forward entry per account and
+-- company. Snapshots that may include only part of the detached entries are discarded, since the
+-- carry-forward entries are created at the creation date of the last detached entry.
+create or replace function detach_entry_partition(_name text)
+    returns void
+    language plpgsql
+as
+$$
+declare
+    _last_created_at timestamptz;
+begin
+    if not exists (select 1 from entry_partition where name = _name and detached_at is null) then
+        raise no_data_found;
+    end if;
+
+    execute format('alter table entry detach partition %I', _name);
+
+    execute format(
+        'insert into entry (id, tx_id, event, operation, version, amount, created_at, competence_date, account, company, metadata) '
+        'select '
+        '    gen_random_uuid(), $1, 0, '
+        '    case when sub.balance >= 0 then 1 else 2 end, sub.version, abs(sub.balance), '
+        '    sub.created_at, sub.competence_date, sub.account, sub.company, '
+        '    jsonb_build_object(''carry_forward'', jsonb_build_object(''partition'', $2::text, ''entries'', sub.entries, ''versions'', sub.versions)) '
+        'from ( '
+        '    select '
+        '        account, company, '
+        '        coalesce(sum(amount) filter (where operation = 1), 0) - '
+        '        coalesce(sum(amount) filter (where operation = 2), 0) as balance, '
+        '        max(version) as version, '
+        '        max(created_at) as created_at, '
+        '        max(competence_date) as competence_date, '
+        '        count(*) as entries, '
+        '        count(*) filter (where version > 0) as versions '
+        '    from %I '
+        '    group by account, company '
+        ') sub',
+        _name
+    ) using gen_random_uuid(), _name;
+
+    execute format('select max(created_at) from %I', _name) into _last_created_at;
+
+    delete from account_balance where tx_date < _last_created_at;
+
+    update entry_partition set detached_at = now() where name = _name;
+end;
+$$;
+
+do
+$$
+declare
+    _month timestamptz;
+begin
+    for _month in
+        select generate_series(
+            date_trunc('month', coalesce((select min(competence_date) from entry_unpartitioned), now())),
+            now() + interval '2 months',
+            interval '1 month'
+        )
+    loop
+        perform create_entry_partition(_month);
+    end loop;
+end;
+$$;
+
+insert into entry (id, tx_id, event, operation, version, amount, created_at, competence_date, account, company, metadata)
+select id, tx_id, event, operation, version, amount, created_at, competence_date, account, company, metadata
+from entry_unpartitioned;
+
+drop table entry_unpartitioned;
+
+-- Unique constraints of partitioned tables must include the partition key, so the primary key can't
+-- keep the idempotency key (id) unique by itself. The entry_id registry does, across every partition.
+alter table entry add constraint entry_pkey primary key (id, competence_date);
+
+create table if not exists entry_id
+(
+    id uuid primary key
+);
+
+insert into entry_id (id)
+select id from entry;
+
+create index if not exists idx_entry_account_gist
+    on entry using gist (account gist_ltree_ops(siglen=32));
+create index if not exists idx_entry_tx
+    on entry using btree (tx_id);
+create index if not exists idx_entry_company
+    on entry using btree (company);
+create index if not exists idx_entry_event
+    on entry using btree (event);
+create index if not exists idx_created_at
+    on entry using brin (created_at) with (pages_per_range = 32);
+create index if not exists idx_entry_competence_date
+    on entry using btree (competence_date);
+
+-- Registers the id of every inserted entry, in the same transaction, failing with a unique violation
+-- when it was already posted, whatever its competence date. Ids are kept after their partition is
+-- archived, so archived entries can't be posted again either.
+create or replace function register_entry_id()
+    returns trigger
+    language plpgsql
+as
+$$
+begin
+    insert into entry_id (id) values (new.id);
+    return new;
+end;
+$$;
+
+create trigger tg_register_entry_id
+    before insert
+    on entry
+    for each row
+execute procedure register_entry_id();
+
+-- Carry-forward entries keep the version of the detached entries without moving account_version.
+create trigger tg_update_account_version
+    before insert
+    on entry
+    for each row
+    when (new.version >= 0 and new.event <> 0)
+execute procedure update_account_version();
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop function if exists get_analytic_account_balance_readonly(ltree);
+drop function if exists get_synthetic_account_balance_readonly(lquery);
+
+commit;
@@ -0,0 +1,99 @@
+begin;
+
+-- Read-only variants of the balance functions, used on read replicas where snapshots can't be
+-- written. They read the existing snapshot, if any, and sum the entries created after it.
+
+create or replace function get_analytic_account_balance_readonly(
+    in _account ltree,
+    out total_balance bigint, out version int
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version
+        into
+            total_balance,
+            version
+        from
+            _get_analytic_account_balance(_account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version
+    into
+        total_balance,
+        version
+    from
+        _get_analytic_account_balance_since(_account, _existing_date);
+end;
+$$ stable;
+
+create or replace function get_synthetic_account_balance_readonly(
+    in _account lquery, out total_balance bigint
+)
+    returns bigint
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where account = _account::text;
+
+    if (_existing_balance is null) then
+        select coalesce(partial_balance, 0) + recent_balance
+        into
+            total_balance
+        from
+            _get_synthetic_account_balance(_account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0)
+    into
+        total_balance
+    from
+        _get_synthetic_account_balance_since(_account, _existing_date);
+end;
+$$ stable;
+
+commit;
@@ -0,0 +1,523 @@
+begin;
+
+-- Only the default book can be kept, as every other book shares its accounts.
+delete from entry where book <> 'default';
+delete from account_version where book <> 'default';
+delete from account_balance where book <> 'default';
+delete from account_running_balance where book <> 'default';
+delete from invariant_violation where book <> 'default';
+
+drop function if exists get_analytic_account_balance_readonly(text, ltree);
+drop function if exists get_synthetic_account_balance_readonly(text, lquery);
+drop function if exists get_analytic_account_balance(text, ltree, boolean);
+drop function if exists get_synthetic_account_balance(text, lquery, boolean);
+drop function if exists _get_analytic_account_balance(text, ltree);
+drop function if exists _get_analytic_account_balance_since(text, ltree, timestamptz);
+drop function if exists _get_synthetic_account_balance(text, lquery);
+drop function if exists _get_synthetic_account_balance_since(text, lquery, timestamptz);
+drop procedure if exists _update_account_balance(text, text, bigint, timestamptz);
+drop procedure if exists _insert_account_balance(text, text, bigint, timestamptz);
+drop procedure if exists _touch_account_balance(text, text);
+
+create or replace function update_account_version()
+    returns trigger
+    language plpgsql
+as
+$$
+begin
+    if new.version = 0 then
+        update account_version set version = version + 1 where account = new.account returning version into new.version;
+    else
+        update account_version set version = new.version where account = new.account;
+    end if;
+
+    if not found then
+        insert into account_version(version, account) values (1, new.account);
+        new.version = 1;
+    end if;
+
+    return new;
+end;
+$$;
+
+create or replace procedure _update_account_balance(
+    _account text, _balance bigint, _dt timestamptz
+)
+    language sql
+as
+$$
+    update account_balance
+    set
+        balance = _balance,
+        tx_date = _dt
+    where account = _account;
+$$;
+
+create or replace procedure _insert_account_balance(
+    _account text, _balance bigint, _dt timestamptz
+)
+    language sql
+as
+$$
+    insert into account_balance (balance, tx_date, account)
+    values (_balance, _dt, _account);
+$$;
+
+create or replace procedure _touch_account_balance(_account text)
+    language sql
+as
+$$
+    update account_balance
+    set
+        read_at = now(),
+        read_count = read_count + 1
+    where
+        account = _account
+        and read_at < now() - interval '1 minute';
+$$;
+
+create or replace function _get_analytic_account_balance(_account ltree)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            recent_version  int
+        )
+    language sql
+as
+$$
+    select
+        sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+        max(created_at)  filter (where sub.row_number = 2) as partial_date,
+
+        sum(sub.balance) filter (where sub.row_number = 1) as recent_balance,
+        max(version)     filter (where sub.row_number = 1) as recent_version
+    from (
+        select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            max(version) as version,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+        from entry
+        where account = _account
+        group by created_at
+        order by created_at desc
+    ) sub
+$$ stable rows 1;
+
+create or replace function _get_analytic_account_balance_since(_account ltree, _dt timestamptz)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            recent_version  int
+        )
+    language sql
+as
+$$
+    select
+        sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+        max(created_at)  filter (where sub.row_number = 2) as partial_date,
+
+        sum(sub.balance) filter (where sub.row_number = 1) as recent_balance,
+        max(version)     filter (where sub.row_number = 1) as recent_version
+    from (
+        select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            max(version) as version,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+        from entry
+        where
+            account = _account
+            and created_at > _dt
+        group by created_at
+        order by created_at desc
+    ) sub
+$$ stable rows 1;
+
+create or replace function get_analytic_account_balance(
+    in _account ltree, in _touch boolean default true,
+    out total_balance bigint, out version int
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+
+    _partial_balance    bigint;
+    _partial_date       timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            partial_balance,
+            partial_date,
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance,
+            version
+        from
+            _get_analytic_account_balance(_account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        -- Only recent balance exists, so return it without creating snapshot
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select
+        _existing_balance + partial_balance,
+        partial_date,
+
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version
+    into
+        _partial_balance,
+        _partial_date,
+
+        total_balance,
+        version
+    from
+        _get_analytic_account_balance_since(_account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_account => _account::text);
+    end if;
+
+    -- No new entries exists
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+create or replace function get_analytic_account_balance_readonly(
+    in _account ltree,
+    out total_balance bigint, out version int
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version
+        into
+            total_balance,
+            version
+        from
+            _get_analytic_account_balance(_account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version
+    into
+        total_balance,
+        version
+    from
+        _get_analytic_account_balance_since(_account, _existing_date);
+end;
+$$ stable;
+
+create or replace function _get_synthetic_account_balance(_account lquery)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint
+        )
+    language sql
+as
+$$
+select sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+       max(created_at)  filter (where sub.row_number = 2) as partial_date,
+       sum(sub.balance) filter (where sub.row_number = 1) as recent_balance
+from (
+         select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+         from entry
+         where account ~ _account
+         group by created_at
+         order by created_at desc
+     ) sub
+$$ stable
+   rows 1
+;
+
+create or replace function _get_synthetic_account_balance_since(_account lquery, _dt timestamptz)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint
+        )
+    language sql
+as
+$$
+select sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+       max(created_at) filter (where sub.row_number = 2)  as partial_date,
+       sum(sub.balance) filter (where sub.row_number = 1) as recent_credit
+from (
+         select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+         from entry
+         where account ~ _account
+           and created_at > _dt
+         group by created_at
+         order by created_at desc
+     ) sub
+$$ stable
+   rows 1
+;
+
+create or replace function get_synthetic_account_balance(
+    in _account lquery, in _touch boolean default true,
+    out total_balance bigint
+)
+    returns bigint
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+    _partial_balance  bigint;
+    _partial_date     timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where account = _account::text;
+
+    if (_existing_balance is null) then
+        select partial_balance,
+               partial_date,
+               coalesce(partial_balance, 0) + recent_balance
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance
+        from
+            _get_synthetic_account_balance(_account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select _existing_balance + partial_balance,
+           partial_date,
+           _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0)
+    into
+        _partial_balance,
+        _partial_date,
+        total_balance
+    from
+        _get_synthetic_account_balance_since(_account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_account => _account::text);
+    end if;
+
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+create or replace function get_synthetic_account_balance_readonly(
+    in _account lquery, out total_balance bigint
+)
+    returns bigint
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where account = _account::text;
+
+    if (_existing_balance is null) then
+        select coalesce(partial_balance, 0) + recent_balance
+        into
+            total_balance
+        from
+            _get_synthetic_account_balance(_account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0)
+    into
+        total_balance
+    from
+        _get_synthetic_account_balance_since(_account, _existing_date);
+end;
+$$ stable;
+
+create or replace function detach_entry_partition(_name text)
+    returns void
+    language plpgsql
+as
+$$
+declare
+    _last_created_at timestamptz;
+begin
+    if not exists (select 1 from entry_partition where name = _name and detached_at is null) then
+        raise no_data_found;
+    end if;
+
+    execute format('alter table entry detach partition %I', _name);
+
+    execute format(
+        'insert into entry (id, tx_id, event, operation, version, amount, created_at, competence_date, account, company, metadata) '
+        'select '
+        '    gen_random_uuid(), $1, 0, '
+        '    case when sub.balance >= 0 then 1 else 2 end, sub.version, abs(sub.balance), '
+        '    sub.created_at, sub.competence_date, sub.account, sub.company, '
+        '    jsonb_build_object(''carry_forward'', jsonb_build_object(''partition'', $2::text, ''entries'', sub.entries, ''versions'', sub.versions)) '
+        'from ( '
+        '    select '
+        '        account, company, '
+        '        coalesce(sum(amount) filter (where operation = 1), 0) - '
+        '        coalesce(sum(amount) filter (where operation = 2), 0) as balance, '
+        '        max(version) as version, '
+        '        max(created_at) as created_at, '
+        '        max(competence_date) as competence_date, '
+        '        count(*) as entries, '
+        '        count(*) filter (where version > 0) as versions '
+        '    from %I '
+        '    group by account, company '
+        ') sub',
+        _name
+    ) using gen_random_uuid(), _name;
+
+    execute format('select max(created_at) from %I', _name) into _last_created_at;
+
+    delete from account_balance where tx_date < _last_created_at;
+
+    update entry_partition set detached_at = now() where name = _name;
+end;
+$$;
+
+alter table invariant_violation drop column if exists book;
+
+alter table account_running_balance
+    drop constraint account_running_balance_pkey,
+    add primary key (account);
+alter table account_running_balance drop column if exists book;
+
+alter table account_balance
+    drop constraint account_balance_pkey,
+    add primary key (account);
+alter table account_balance drop column if exists book;
+
+alter table account_version
+    drop constraint account_version_pkey,
+    add primary key (account);
+alter table account_version drop column if exists book;
+
+alter table entry drop constraint if exists entry_book_fkey;
+alter table entry drop column if exists book;
+
+drop table if exists book;
+
+commit;
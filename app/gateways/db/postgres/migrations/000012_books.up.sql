@@ -0,0 +1,572 @@
+begin;
+
+-- Books are isolated ledgers. Every table keyed by account is keyed by book and account, and the
+-- existing rows are moved into the default book, which is also the default value of the columns.
+create table if not exists book
+(
+    name       text        primary key,
+    created_at timestamptz not null default now()
+);
+
+insert into book (name) values ('default') on conflict do nothing;
+
+alter table entry
+    add column if not exists book text not null default 'default';
+
+alter table entry
+    add constraint entry_book_fkey foreign key (book) references book (name);
+
+alter table account_version
+    add column if not exists book text not null default 'default';
+
+alter table account_version
+    drop constraint account_version_pkey,
+    add primary key (book, account);
+
+alter table account_balance
+    add column if not exists book text not null default 'default';
+
+alter table account_balance
+    drop constraint account_balance_pkey,
+    add primary key (book, account);
+
+alter table account_running_balance
+    add column if not exists book text not null default 'default';
+
+alter table account_running_balance
+    drop constraint account_running_balance_pkey,
+    add primary key (book, account);
+
+alter table invariant_violation
+    add column if not exists book text not null default 'default';
+
+--
+-- Account versions
+--
+
+create or replace function update_account_version()
+    returns trigger
+    language plpgsql
+as
+$$
+begin
+    if new.version = 0 then
+        update account_version set version = version + 1 where book = new.book and account = new.account returning version into new.version;
+    else
+        update account_version set version = new.version where book = new.book and account = new.account;
+    end if;
+
+    if not found then
+        insert into account_version(version, account, book) values (1, new.account, new.book);
+        new.version = 1;
+    end if;
+
+    return new;
+end;
+$$;
+
+--
+-- Snapshots
+--
+
+drop function if exists get_analytic_account_balance_readonly(ltree);
+drop function if exists get_synthetic_account_balance_readonly(lquery);
+drop function if exists get_analytic_account_balance(ltree, boolean);
+drop function if exists get_synthetic_account_balance(lquery, boolean);
+drop function if exists _get_analytic_account_balance(ltree);
+drop function if exists _get_analytic_account_balance_since(ltree, timestamptz);
+drop function if exists _get_synthetic_account_balance(lquery);
+drop function if exists _get_synthetic_account_balance_since(lquery, timestamptz);
+drop procedure if exists _update_account_balance(text, bigint, timestamptz);
+drop procedure if exists _insert_account_balance(text, bigint, timestamptz);
+drop procedure if exists _touch_account_balance(text);
+
+create or replace procedure _update_account_balance(
+    _book text, _account text, _balance bigint, _dt timestamptz
+)
+    language sql
+as
+$$
+    update account_balance
+    set
+        balance = _balance,
+        tx_date = _dt
+    where
+        book = _book
+        and account = _account;
+$$;
+
+create or replace procedure _insert_account_balance(
+    _book text, _account text, _balance bigint, _dt timestamptz
+)
+    language sql
+as
+$$
+    insert into account_balance (balance, tx_date, account, book)
+    values (_balance, _dt, _account, _book);
+$$;
+
+create or replace procedure _touch_account_balance(_book text, _account text)
+    language sql
+as
+$$
+    update account_balance
+    set
+        read_at = now(),
+        read_count = read_count + 1
+    where
+        book = _book
+        and account = _account
+        and read_at < now() - interval '1 minute';
+$$;
+
+--
+-- Analytic account
+--
+
+create or replace function _get_analytic_account_balance(_book text, _account ltree)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            recent_version  int
+        )
+    language sql
+as
+$$
+    select
+        sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+        max(created_at)  filter (where sub.row_number = 2) as partial_date,
+
+        sum(sub.balance) filter (where sub.row_number = 1) as recent_balance,
+        max(version)     filter (where sub.row_number = 1) as recent_version
+    from (
+        select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            max(version) as version,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+        from entry
+        where
+            book = _book
+            and account = _account
+        group by created_at
+        order by created_at desc
+    ) sub
+$$ stable rows 1;
+
+create or replace function _get_analytic_account_balance_since(_book text, _account ltree, _dt timestamptz)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            recent_version  int
+        )
+    language sql
+as
+$$
+    select
+        sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+        max(created_at)  filter (where sub.row_number = 2) as partial_date,
+
+        sum(sub.balance) filter (where sub.row_number = 1) as recent_balance,
+        max(version)     filter (where sub.row_number = 1) as recent_version
+    from (
+        select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            max(version) as version,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+        from entry
+        where
+            book = _book
+            and account = _account
+            and created_at > _dt
+        group by created_at
+        order by created_at desc
+    ) sub
+$$ stable rows 1;
+
+create or replace function get_analytic_account_balance(
+    in _book text, in _account ltree, in _touch boolean default true,
+    out total_balance bigint, out version int
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+
+    _partial_balance    bigint;
+    _partial_date       timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        book = _book
+        and account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            partial_balance,
+            partial_date,
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance,
+            version
+        from
+            _get_analytic_account_balance(_book, _account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        -- Only recent balance exists, so return it without creating snapshot
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _book => _book,
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select
+        _existing_balance + partial_balance,
+        partial_date,
+
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version
+    into
+        _partial_balance,
+        _partial_date,
+
+        total_balance,
+        version
+    from
+        _get_analytic_account_balance_since(_book, _account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_book => _book, _account => _account::text);
+    end if;
+
+    -- No new entries exists
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _book => _book,
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+create or replace function get_analytic_account_balance_readonly(
+    in _book text, in _account ltree,
+    out total_balance bigint, out version int
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        book = _book
+        and account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version
+        into
+            total_balance,
+            version
+        from
+            _get_analytic_account_balance(_book, _account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version
+    into
+        total_balance,
+        version
+    from
+        _get_analytic_account_balance_since(_book, _account, _existing_date);
+end;
+$$ stable;
+
+--
+-- Synthetic account
+--
+
+create or replace function _get_synthetic_account_balance(_book text, _account lquery)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint
+        )
+    language sql
+as
+$$
+select sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+       max(created_at)  filter (where sub.row_number = 2) as partial_date,
+       sum(sub.balance) filter (where sub.row_number = 1) as recent_balance
+from (
+         select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+         from entry
+         where book = _book
+           and account ~ _account
+         group by created_at
+         order by created_at desc
+     ) sub
+$$ stable
+   rows 1
+;
+
+create or replace function _get_synthetic_account_balance_since(_book text, _account lquery, _dt timestamptz)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint
+        )
+    language sql
+as
+$$
+select sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+       max(created_at) filter (where sub.row_number = 2)  as partial_date,
+       sum(sub.balance) filter (where sub.row_number = 1) as recent_credit
+from (
+         select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+         from entry
+         where book = _book
+           and account ~ _account
+           and created_at > _dt
+         group by created_at
+         order by created_at desc
+     ) sub
+$$ stable
+   rows 1
+;
+
+create or replace function get_synthetic_account_balance(
+    in _book text, in _account lquery, in _touch boolean default true,
+    out total_balance bigint
+)
+    returns bigint
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+    _partial_balance  bigint;
+    _partial_date     timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where book = _book
+      and account = _account::text;
+
+    if (_existing_balance is null) then
+        select partial_balance,
+               partial_date,
+               coalesce(partial_balance, 0) + recent_balance
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance
+        from
+            _get_synthetic_account_balance(_book, _account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _book => _book,
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select _existing_balance + partial_balance,
+           partial_date,
+           _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0)
+    into
+        _partial_balance,
+        _partial_date,
+        total_balance
+    from
+        _get_synthetic_account_balance_since(_book, _account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_book => _book, _account => _account::text);
+    end if;
+
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _book => _book,
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+create or replace function get_synthetic_account_balance_readonly(
+    in _book text, in _account lquery, out total_balance bigint
+)
+    returns bigint
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where book = _book
+      and account = _account::text;
+
+    if (_existing_balance is null) then
+        select coalesce(partial_balance, 0) + recent_balance
+        into
+            total_balance
+        from
+            _get_synthetic_account_balance(_book, _account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0)
+    into
+        total_balance
+    from
+        _get_synthetic_account_balance_since(_book, _account, _existing_date);
+end;
+$$ stable;
+
+--
+-- Partitions
+--
+
+-- Carry-forward entries are created per book, account and company.
+create or replace function detach_entry_partition(_name text)
+    returns void
+    language plpgsql
+as
+$$
+declare
+    _last_created_at timestamptz;
+begin
+    if not exists (select 1 from entry_partition where name = _name and detached_at is null) then
+        raise no_data_found;
+    end if;
+
+    execute format('alter table entry detach partition %I', _name);
+
+    execute format(
+        'insert into entry (id, tx_id, event, operation, version, amount, created_at, competence_date, account, company, metadata, book) '
+        'select '
+        '    gen_random_uuid(), $1, 0, '
+        '    case when sub.balance >= 0 then 1 else 2 end, sub.version, abs(sub.balance), '
+        '    sub.created_at, sub.competence_date, sub.account, sub.company, '
+        '    jsonb_build_object(''carry_forward'', jsonb_build_object(''partition'', $2::text, ''entries'', sub.entries, ''versions'', sub.versions)), '
+        '    sub.book '
+        'from ( '
+        '    select '
+        '        book, account, company, '
+        '        coalesce(sum(amount) filter (where operation = 1), 0) - '
+        '        coalesce(sum(amount) filter (where operation = 2), 0) as balance, '
+        '        max(version) as version, '
+        '        max(created_at) as created_at, '
+        '        max(competence_date) as competence_date, '
+        '        count(*) as entries, '
+        '        count(*) filter (where version > 0) as versions '
+        '    from %I '
+        '    group by book, account, company '
+        ') sub',
+        _name
+    ) using gen_random_uuid(), _name;
+
+    execute format('select max(created_at) from %I', _name) into _last_created_at;
+
+    delete from account_balance where tx_date < _last_created_at;
+
+    update entry_partition set detached_at = now() where name = _name;
+end;
+$$;
+
+commit;
@@ -0,0 +1,462 @@
+begin;
+
+-- The balance functions report whether they started from a snapshot and how many entries they read
+-- besides it, so the ledger can expose how effective the snapshots are. Their result types change,
+-- so they are dropped and created again.
+drop function if exists get_analytic_account_balance_readonly(text, ltree);
+drop function if exists get_synthetic_account_balance_readonly(text, lquery);
+drop function if exists get_analytic_account_balance(text, ltree, boolean);
+drop function if exists get_synthetic_account_balance(text, lquery, boolean);
+drop function if exists _get_analytic_account_balance(text, ltree);
+drop function if exists _get_analytic_account_balance_since(text, ltree, timestamptz);
+drop function if exists _get_synthetic_account_balance(text, lquery);
+drop function if exists _get_synthetic_account_balance_since(text, lquery, timestamptz);
+
+--
+-- Analytic account
+--
+
+create or replace function _get_analytic_account_balance(_book text, _account ltree)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            recent_version  int,
+            entry_count     bigint
+        )
+    language sql
+as
+$$
+    select
+        sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+        max(created_at)  filter (where sub.row_number = 2) as partial_date,
+
+        sum(sub.balance) filter (where sub.row_number = 1) as recent_balance,
+        max(version)     filter (where sub.row_number = 1) as recent_version,
+        coalesce(sum(sub.entries), 0)                     as entry_count
+    from (
+        select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            max(version) as version,
+            count(*) as entries,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+        from entry
+        where
+            book = _book
+            and account = _account
+        group by created_at
+        order by created_at desc
+    ) sub
+$$ stable rows 1;
+
+create or replace function _get_analytic_account_balance_since(_book text, _account ltree, _dt timestamptz)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            recent_version  int,
+            entry_count     bigint
+        )
+    language sql
+as
+$$
+    select
+        sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+        max(created_at)  filter (where sub.row_number = 2) as partial_date,
+
+        sum(sub.balance) filter (where sub.row_number = 1) as recent_balance,
+        max(version)     filter (where sub.row_number = 1) as recent_version,
+        coalesce(sum(sub.entries), 0)                     as entry_count
+    from (
+        select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            max(version) as version,
+            count(*) as entries,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+        from entry
+        where
+            book = _book
+            and account = _account
+            and created_at > _dt
+        group by created_at
+        order by created_at desc
+    ) sub
+$$ stable rows 1;
+
+create or replace function get_analytic_account_balance(
+    in _book text, in _account ltree, in _touch boolean default true,
+    out total_balance bigint, out version int,
+    out snapshot_hit boolean, out scanned_entries bigint
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+
+    _partial_balance    bigint;
+    _partial_date       timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        book = _book
+        and account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            partial_balance,
+            partial_date,
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version,
+            false,
+            entry_count
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance,
+            version,
+            snapshot_hit,
+            scanned_entries
+        from
+            _get_analytic_account_balance(_book, _account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        -- Only recent balance exists, so return it without creating snapshot
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _book => _book,
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select
+        _existing_balance + partial_balance,
+        partial_date,
+
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version,
+        true,
+        entry_count
+    into
+        _partial_balance,
+        _partial_date,
+
+        total_balance,
+        version,
+        snapshot_hit,
+        scanned_entries
+    from
+        _get_analytic_account_balance_since(_book, _account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_book => _book, _account => _account::text);
+    end if;
+
+    -- No new entries exists
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _book => _book,
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+create or replace function get_analytic_account_balance_readonly(
+    in _book text, in _account ltree,
+    out total_balance bigint, out version int,
+    out snapshot_hit boolean, out scanned_entries bigint
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance   bigint;
+    _existing_date      timestamptz;
+begin
+    select
+        balance,
+        tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from
+        account_balance
+    where
+        book = _book
+        and account = _account::text;
+
+    if (_existing_balance is null) then
+        select
+            coalesce(partial_balance, 0) + recent_balance,
+            recent_version,
+            false,
+            entry_count
+        into
+            total_balance,
+            version,
+            snapshot_hit,
+            scanned_entries
+        from
+            _get_analytic_account_balance(_book, _account);
+
+        -- No entries found for the given account
+        if (version is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select
+        _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+        recent_version,
+        true,
+        entry_count
+    into
+        total_balance,
+        version,
+        snapshot_hit,
+        scanned_entries
+    from
+        _get_analytic_account_balance_since(_book, _account, _existing_date);
+end;
+$$ stable;
+
+--
+-- Synthetic account
+--
+
+create or replace function _get_synthetic_account_balance(_book text, _account lquery)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            entry_count     bigint
+        )
+    language sql
+as
+$$
+select sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+       max(created_at)  filter (where sub.row_number = 2) as partial_date,
+       sum(sub.balance) filter (where sub.row_number = 1) as recent_balance,
+       coalesce(sum(sub.entries), 0)                     as entry_count
+from (
+         select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            count(*) as entries,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+         from entry
+         where book = _book
+           and account ~ _account
+         group by created_at
+         order by created_at desc
+     ) sub
+$$ stable
+   rows 1
+;
+
+create or replace function _get_synthetic_account_balance_since(_book text, _account lquery, _dt timestamptz)
+    returns table
+        (
+            partial_balance bigint,
+            partial_date    timestamptz,
+            recent_balance  bigint,
+            entry_count     bigint
+        )
+    language sql
+as
+$$
+select sum(sub.balance) filter (where sub.row_number > 1) as partial_balance,
+       max(created_at) filter (where sub.row_number = 2)  as partial_date,
+       sum(sub.balance) filter (where sub.row_number = 1) as recent_credit,
+       coalesce(sum(sub.entries), 0)                     as entry_count
+from (
+         select
+            coalesce(sum(amount) filter (where operation = 1), 0) -
+            coalesce(sum(amount) filter (where operation = 2), 0) as balance,
+            count(*) as entries,
+            created_at,
+            row_number() over (order by created_at desc) as row_number
+         from entry
+         where book = _book
+           and account ~ _account
+           and created_at > _dt
+         group by created_at
+         order by created_at desc
+     ) sub
+$$ stable
+   rows 1
+;
+
+create or replace function get_synthetic_account_balance(
+    in _book text, in _account lquery, in _touch boolean default true,
+    out total_balance bigint,
+    out snapshot_hit boolean, out scanned_entries bigint
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+    _partial_balance  bigint;
+    _partial_date     timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where book = _book
+      and account = _account::text;
+
+    if (_existing_balance is null) then
+        select partial_balance,
+               partial_date,
+               coalesce(partial_balance, 0) + recent_balance,
+               false,
+               entry_count
+        into
+            _partial_balance,
+            _partial_date,
+            total_balance,
+            snapshot_hit,
+            scanned_entries
+        from
+            _get_synthetic_account_balance(_book, _account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        elsif (_partial_balance is null) then
+            return;
+        end if;
+
+        call _insert_account_balance(
+            _book => _book,
+            _account => _account::text,
+            _balance => _partial_balance,
+            _dt => _partial_date
+        );
+
+        return;
+    end if;
+
+    select _existing_balance + partial_balance,
+           partial_date,
+           _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+           true,
+           entry_count
+    into
+        _partial_balance,
+        _partial_date,
+        total_balance,
+        snapshot_hit,
+        scanned_entries
+    from
+        _get_synthetic_account_balance_since(_book, _account, _existing_date);
+
+    if (_touch) then
+        call _touch_account_balance(_book => _book, _account => _account::text);
+    end if;
+
+    if (_partial_date is null) then
+        return;
+    end if;
+
+    call _update_account_balance(
+        _book => _book,
+        _account => _account::text,
+        _balance => _partial_balance,
+        _dt => _partial_date
+    );
+end;
+$$ volatile;
+
+create or replace function get_synthetic_account_balance_readonly(
+    in _book text, in _account lquery,
+    out total_balance bigint,
+    out snapshot_hit boolean, out scanned_entries bigint
+)
+    returns record
+    language plpgsql
+as
+$$
+declare
+    _existing_balance bigint;
+    _existing_date    timestamptz;
+begin
+    select balance,
+           tx_date
+    into
+        _existing_balance,
+        _existing_date
+    from account_balance
+    where book = _book
+      and account = _account::text;
+
+    if (_existing_balance is null) then
+        select coalesce(partial_balance, 0) + recent_balance,
+               false,
+               entry_count
+        into
+            total_balance,
+            snapshot_hit,
+            scanned_entries
+        from
+            _get_synthetic_account_balance(_book, _account);
+
+        if (total_balance is null) then
+            raise no_data_found;
+        end if;
+
+        return;
+    end if;
+
+    select _existing_balance + coalesce(partial_balance, 0) + coalesce(recent_balance, 0),
+           true,
+           entry_count
+    into
+        total_balance,
+        snapshot_hit,
+        scanned_entries
+    from
+        _get_synthetic_account_balance_since(_book, _account, _existing_date);
+end;
+$$ stable;
+
+commit;
@@ -0,0 +1,13 @@
+begin;
+
+create or replace function invalid_account_version()
+    returns trigger
+    language plpgsql
+as
+$$
+begin
+    raise exception 'invalid account version (from % to %)', old.version, new.version;
+end;
+$$;
+
+commit;
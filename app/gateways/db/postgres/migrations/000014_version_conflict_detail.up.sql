@@ -0,0 +1,15 @@
+begin;
+
+-- The detail carries the version the account is at, so the ledger can report it on conflicts.
+create or replace function invalid_account_version()
+    returns trigger
+    language plpgsql
+as
+$$
+begin
+    raise exception 'invalid account version (from % to %)', old.version, new.version
+        using detail = json_build_object('account', old.account::text, 'current_version', old.version)::text;
+end;
+$$;
+
+commit;
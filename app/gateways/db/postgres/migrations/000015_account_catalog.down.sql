@@ -0,0 +1,7 @@
+begin;
+
+drop trigger if exists tg_register_account on entry;
+drop function if exists register_account();
+drop table if exists account;
+
+commit;
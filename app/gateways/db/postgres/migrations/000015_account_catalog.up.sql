@@ -0,0 +1,46 @@
+begin;
+
+-- The accounts of each book, registered by their first entry, so they can be listed and browsed
+-- without scanning the entries.
+create table if not exists account
+(
+    book       text        not null references book (name),
+    account    ltree       not null,
+    created_at timestamptz not null default now(),
+    primary key (book, account)
+);
+
+create index if not exists idx_account_account
+    on account using gist (account gist_ltree_ops(siglen=32));
+
+insert into account (book, account, created_at)
+select
+    book,
+    account,
+    min(created_at)
+from
+    entry
+group by
+    book,
+    account
+on conflict do nothing;
+
+create or replace function register_account()
+    returns trigger
+    language plpgsql
+as
+$$
+begin
+    insert into account (book, account, created_at) values (new.book, new.account, new.created_at) on conflict do nothing;
+
+    return new;
+end;
+$$;
+
+create trigger tg_register_account
+    after insert
+    on entry
+    for each row
+execute procedure register_account();
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+-- Serves the metadata filters of ListAccountEntries, which use the containment (@>) and jsonpath
+-- existence (@?) operators. Building it on the whole entry table at once would lock the writes for
+-- as long as it takes, so only the parent index is created here, invalid until each partition has
+-- its own: the partition management job builds them concurrently and attaches them. Partitions
+-- created afterwards get theirs when attached.
+create index if not exists idx_entry_metadata
+    on only entry using gin (metadata);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop index if exists idx_entry_event_created_at;
+drop table if exists event_schema;
+
+commit;
@@ -0,0 +1,21 @@
+begin;
+
+-- Versions of the JSON Schema that the metadata of the entries of an event must follow. The latest
+-- version is in force, and versions are never changed.
+create table if not exists event_schema
+(
+    event      smallint    not null references event(id),
+    version    int         not null,
+    schema     jsonb       not null,
+    created_at timestamptz not null default now(),
+
+    primary key (event, version)
+);
+
+-- Serves the dry-run validation of the metadata of an event, which pages through its entries. Like
+-- idx_entry_metadata, only the parent index is created here, and the partition management job builds
+-- the index of each partition concurrently and attaches it.
+create index if not exists idx_entry_event_created_at
+    on only entry using btree (event, created_at, id);
+
+commit;
@@ -0,0 +1,31 @@
+begin;
+
+-- Transactions posted on the occurrences of a recurrence rule. occurrence is the index of the next
+-- occurrence, due at next_run_at, which is null once the schedule finishes. revision increases on
+-- every update, so concurrent updates are detected.
+create table if not exists schedule
+(
+    id          uuid        primary key,
+    book        text        not null references book (name),
+    company     text        not null,
+    event       smallint    not null references event (id),
+    entries     jsonb       not null,
+    start_at    timestamptz not null,
+    recurrence  text        not null default '',
+    status      text        not null,
+    occurrence  int         not null default 0,
+    next_run_at timestamptz,
+    last_error  text        not null default '',
+    revision    int         not null default 1,
+    created_at  timestamptz not null default now(),
+    updated_at  timestamptz not null default now()
+);
+
+-- Serves the worker, which only looks for the due active schedules.
+create index if not exists idx_schedule_next_run_at
+    on schedule using btree (next_run_at) where status = 'active';
+
+create index if not exists idx_schedule_created_at
+    on schedule using btree (created_at, id);
+
+commit;
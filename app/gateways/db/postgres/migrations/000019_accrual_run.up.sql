@@ -0,0 +1,16 @@
+begin;
+
+-- The days accrued by each accrual rule. A day is saved once the accruals of every account it matched
+-- are posted, so a rule accrues a day only once.
+create table if not exists accrual_run
+(
+    rule       text        not null,
+    date       date        not null,
+    book       text        not null references book (name),
+    accounts   int         not null,
+    amount     bigint      not null,
+    created_at timestamptz not null default now(),
+    primary key (rule, date)
+);
+
+commit;
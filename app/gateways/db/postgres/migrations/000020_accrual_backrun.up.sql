@@ -0,0 +1,14 @@
+begin;
+
+-- The back-runs requested by RunAccruals, accrued a few days at a time by the accrual job. A back-run
+-- is deleted once every day of its range is accrued.
+create table if not exists accrual_backrun
+(
+    rule       text        not null,
+    start_date date        not null,
+    end_date   date        not null,
+    created_at timestamptz not null default now(),
+    primary key (rule, start_date, end_date)
+);
+
+commit;
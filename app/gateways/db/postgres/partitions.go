@@ -0,0 +1,336 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+const partitionsCollection = "entry_partition"
+
+const createEntryPartitionQuery = `
+select create_entry_partition($1);
+`
+
+const listEntryPartitionsQuery = `
+select
+	name,
+	range_start,
+	range_end,
+	detached_at,
+	archived_at,
+	coalesce(archive, '')
+from
+	entry_partition
+order by
+	range_start;
+`
+
+const detachEntryPartitionQuery = `
+select detach_entry_partition($1);
+`
+
+const exportEntryPartitionQuery = `
+copy %s to stdout with (format csv, header);
+`
+
+const archiveEntryPartitionQuery = `
+update entry_partition
+set
+	archived_at = now(),
+	archive = $2
+where
+	name = $1
+	and detached_at is not null
+	and archived_at is null;
+`
+
+const dropEntryPartitionQuery = `
+drop table %s;
+`
+
+// indexEntryPartitionsLockKey keeps replicas from building the same partition index concurrently.
+const indexEntryPartitionsLockKey int64 = 0x6c65646765720003
+
+// entryPartitionIndex is an index created only on the entry table, whose partitions get theirs
+// built concurrently by IndexEntryPartitions.
+type entryPartitionIndex struct {
+	parent     string
+	suffix     string
+	definition string
+}
+
+var entryPartitionIndexes = []entryPartitionIndex{
+	{parent: "idx_entry_metadata", suffix: "_metadata_idx", definition: "using gin (metadata)"},
+	{parent: "idx_entry_event_created_at", suffix: "_event_created_at_idx", definition: "using btree (event, created_at, id)"},
+}
+
+// listUnindexedEntryPartitionsQuery lists the partitions without an index attached to the given
+// parent index.
+const listUnindexedEntryPartitionsQuery = `
+select
+	c.relname
+from
+	pg_inherits p
+	join pg_class c on c.oid = p.inhrelid
+where
+	p.inhparent = 'entry'::regclass
+	and not exists (
+		select 1
+		from pg_inherits pi
+		join pg_index i on i.indexrelid = pi.inhrelid
+		where pi.inhparent = $1::regclass and i.indrelid = c.oid
+	)
+order by
+	c.relname;
+`
+
+// A concurrent build that failed leaves an invalid index behind, which is dropped before building it
+// again.
+const (
+	dropEntryPartitionIndexQuery   = `drop index concurrently if exists %s;`
+	createEntryPartitionIndexQuery = `create index concurrently %s on %s %s;`
+	attachEntryPartitionIndexQuery = `alter index %s attach partition %s;`
+)
+
+func (r LedgerRepository) CreateEntryPartition(ctx context.Context, month time.Time) (string, error) {
+	const operation = "Repository.CreateEntryPartition"
+
+	defer r.pb.MonitorDataSegment(ctx, partitionsCollection, operation, createEntryPartitionQuery).End()
+
+	var name *string
+
+	err := r.db.QueryRow(ctx, createEntryPartitionQuery, month).Scan(&name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create entry partition: %w", err)
+	}
+
+	if name == nil {
+		return "", nil
+	}
+
+	return *name, nil
+}
+
+func (r LedgerRepository) ListEntryPartitions(ctx context.Context) ([]vos.EntryPartition, error) {
+	const operation = "Repository.ListEntryPartitions"
+
+	defer r.pb.MonitorDataSegment(ctx, partitionsCollection, operation, listEntryPartitionsQuery).End()
+
+	rows, err := r.db.Query(ctx, listEntryPartitionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entry partitions: %w", err)
+	}
+
+	defer rows.Close()
+
+	partitions := make([]vos.EntryPartition, 0)
+
+	for rows.Next() {
+		var (
+			partition  vos.EntryPartition
+			detachedAt *time.Time
+			archivedAt *time.Time
+		)
+
+		err = rows.Scan(
+			&partition.Name,
+			&partition.From,
+			&partition.To,
+			&detachedAt,
+			&archivedAt,
+			&partition.Archive,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if detachedAt != nil {
+			partition.DetachedAt = *detachedAt
+		}
+
+		if archivedAt != nil {
+			partition.ArchivedAt = *archivedAt
+		}
+
+		partitions = append(partitions, partition)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", operation, err)
+	}
+
+	return partitions, nil
+}
+
+func (r LedgerRepository) DetachEntryPartition(ctx context.Context, name string) error {
+	const operation = "Repository.DetachEntryPartition"
+
+	defer r.pb.MonitorDataSegment(ctx, partitionsCollection, operation, detachEntryPartitionQuery).End()
+
+	_, err := r.db.Exec(ctx, detachEntryPartitionQuery, name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.NoDataFound {
+			return fmt.Errorf("%w: %s", app.ErrPartitionNotFound, name)
+		}
+
+		return fmt.Errorf("failed to detach entry partition %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// IndexEntryPartitions builds the indexes of entryPartitionIndexes that the partitions lack, without
+// blocking writes, and attaches them to their parent index, which becomes valid once every partition
+// has one. It returns the partitions indexed, none when another replica holds the lock.
+func (r LedgerRepository) IndexEntryPartitions(ctx context.Context) ([]string, error) {
+	const operation = "Repository.IndexEntryPartitions"
+
+	defer r.pb.MonitorDataSegment(ctx, partitionsCollection, operation, listUnindexedEntryPartitionsQuery).End()
+
+	unlock, locked, err := r.tryAdvisoryLock(ctx, indexEntryPartitionsLockKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock partition indexes: %w", err)
+	}
+
+	if !locked {
+		return []string{}, nil
+	}
+
+	defer unlock()
+
+	var (
+		indexed = make([]string, 0)
+		seen    = make(map[string]struct{})
+	)
+
+	for _, index := range entryPartitionIndexes {
+		partitions, err := r.listUnindexedEntryPartitions(ctx, index.parent)
+		if err != nil {
+			return indexed, err
+		}
+
+		// Concurrent builds can't run within a transaction, so each statement runs by itself.
+		for _, partition := range partitions {
+			name := pgx.Identifier{partition + index.suffix}.Sanitize()
+
+			queries := []string{
+				fmt.Sprintf(dropEntryPartitionIndexQuery, name),
+				fmt.Sprintf(createEntryPartitionIndexQuery, name, pgx.Identifier{partition}.Sanitize(), index.definition),
+				fmt.Sprintf(attachEntryPartitionIndexQuery, pgx.Identifier{index.parent}.Sanitize(), name),
+			}
+
+			for _, query := range queries {
+				if _, err = r.db.Exec(ctx, query); err != nil {
+					return indexed, fmt.Errorf("failed to index entry partition %s: %w", partition, err)
+				}
+			}
+
+			if _, ok := seen[partition]; !ok {
+				seen[partition] = struct{}{}
+				indexed = append(indexed, partition)
+			}
+		}
+	}
+
+	return indexed, nil
+}
+
+// listUnindexedEntryPartitions lists the partitions without an index attached to parent.
+func (r LedgerRepository) listUnindexedEntryPartitions(ctx context.Context, parent string) ([]string, error) {
+	rows, err := r.db.Query(ctx, listUnindexedEntryPartitionsQuery, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unindexed entry partitions: %w", err)
+	}
+
+	defer rows.Close()
+
+	partitions := make([]string, 0)
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		partitions = append(partitions, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unindexed entry partitions rows have error: %w", err)
+	}
+
+	return partitions, nil
+}
+
+// ExportEntryPartition writes the entries of the given partition to w as csv, with a header.
+func (r LedgerRepository) ExportEntryPartition(ctx context.Context, name string, w io.Writer) error {
+	const operation = "Repository.ExportEntryPartition"
+
+	query := fmt.Sprintf(exportEntryPartitionQuery, pgx.Identifier{name}.Sanitize())
+
+	defer r.pb.MonitorDataSegment(ctx, partitionsCollection, operation, query).End()
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	defer conn.Release()
+
+	if _, err = conn.Conn().PgConn().CopyTo(ctx, w, query); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable {
+			return fmt.Errorf("%w: %s", app.ErrPartitionNotFound, name)
+		}
+
+		return fmt.Errorf("failed to export entry partition %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// DropEntryPartition drops a detached partition, recording where it was archived.
+func (r LedgerRepository) DropEntryPartition(ctx context.Context, name, archive string) error {
+	const operation = "Repository.DropEntryPartition"
+
+	defer r.pb.MonitorDataSegment(ctx, partitionsCollection, operation, archiveEntryPartitionQuery).End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, archiveEntryPartitionQuery, name, archive)
+	if err != nil {
+		return fmt.Errorf("failed to archive entry partition %s: %w", name, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", app.ErrPartitionNotFound, name)
+	}
+
+	if _, err = tx.Exec(ctx, fmt.Sprintf(dropEntryPartitionQuery, pgx.Identifier{name}.Sanitize())); err != nil {
+		return fmt.Errorf("failed to drop entry partition %s: %w", name, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit archived partition: %w", err)
+	}
+
+	return nil
+}
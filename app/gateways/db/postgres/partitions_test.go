@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+	"github.com/stone-co/the-amazing-ledger/app/tests/testdata"
+)
+
+func TestLedgerRepository_EntryPartitions(t *testing.T) {
+	acc1, err := vos.NewAnalyticAccount(testdata.GenerateAccountPath())
+	assert.NoError(t, err)
+
+	acc2, err := vos.NewAnalyticAccount(testdata.GenerateAccountPath())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
+
+	createTransactionAt := func(competenceDate time.Time, amount int) entities.Transaction {
+		tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", competenceDate,
+			createEntry(t, vos.CreditOperation, acc1.Value(), vos.NextAccountVersion, amount),
+			createEntry(t, vos.DebitOperation, acc2.Value(), vos.NextAccountVersion, amount),
+		)
+		require.NoError(t, err)
+		_, err = r.CreateTransaction(ctx, tx)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	archivedMonth := time.Date(2020, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	archivedTx := createTransactionAt(archivedMonth, 100)
+	createTransactionAt(archivedMonth.AddDate(0, 0, 1), 50)
+	createTransactionAt(time.Now(), 10)
+
+	// Entries out of the existing partitions are kept by the default partition until their partition is created.
+	name, err := r.CreateEntryPartition(ctx, archivedMonth)
+	assert.NoError(t, err)
+	assert.Equal(t, "entry_2020_01", name)
+
+	name, err = r.CreateEntryPartition(ctx, archivedMonth)
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+
+	var partitionEntries int
+	err = pgDocker.DB.QueryRow(ctx, `select count(*) from entry_2020_01`).Scan(&partitionEntries)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, partitionEntries)
+
+	before, err := r.GetAnalyticAccountBalance(ctx, acc1)
+	require.NoError(t, err)
+
+	err = r.DetachEntryPartition(ctx, "entry_2020_01")
+	assert.NoError(t, err)
+
+	err = r.DetachEntryPartition(ctx, "entry_2020_01")
+	assert.ErrorIs(t, err, app.ErrPartitionNotFound)
+
+	after, err := r.GetAnalyticAccountBalance(ctx, acc1)
+	assert.NoError(t, err)
+	assert.Equal(t, before.Balance, after.Balance)
+	assert.Equal(t, before.CurrentVersion, after.CurrentVersion)
+
+	createTransactionAt(time.Now(), 5)
+
+	for _, check := range vos.InvariantChecks {
+		violations, err := r.CheckInvariant(ctx, check, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, violations, check)
+	}
+
+	partitions, err := r.ListEntryPartitions(ctx)
+	assert.NoError(t, err)
+	require.NotEmpty(t, partitions)
+	assert.Equal(t, "entry_2020_01", partitions[0].Name)
+	assert.True(t, partitions[0].Detached())
+	assert.False(t, partitions[0].Archived())
+	assert.True(t, archivedMonth.AddDate(0, 0, -14).Equal(partitions[0].From))
+
+	var archive bytes.Buffer
+	err = r.ExportEntryPartition(ctx, "entry_2020_01", &archive)
+	assert.NoError(t, err)
+	assert.Contains(t, archive.String(), "id,tx_id,event")
+	assert.Contains(t, archive.String(), archivedTx.ID.String())
+
+	err = r.DropEntryPartition(ctx, "entry_2020_01", "/archive/entry_2020_01.csv.gz")
+	assert.NoError(t, err)
+
+	err = r.DropEntryPartition(ctx, "entry_2020_01", "/archive/entry_2020_01.csv.gz")
+	assert.ErrorIs(t, err, app.ErrPartitionNotFound)
+
+	partitions, err = r.ListEntryPartitions(ctx)
+	assert.NoError(t, err)
+	assert.True(t, partitions[0].Archived())
+	assert.Equal(t, "/archive/entry_2020_01.csv.gz", partitions[0].Archive)
+
+	err = r.ExportEntryPartition(ctx, "entry_2020_01", &archive)
+	assert.ErrorIs(t, err, app.ErrPartitionNotFound)
+}
+
+func TestLedgerRepository_IndexEntryPartitions(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+	_, err := r.IndexEntryPartitions(ctx)
+	require.NoError(t, err)
+
+	valid := func() bool {
+		var indisvalid bool
+		err := pgDocker.DB.QueryRow(ctx, `
+			select bool_and(indisvalid) from pg_index
+			where indexrelid in ('idx_entry_metadata'::regclass, 'idx_entry_event_created_at'::regclass)
+		`).Scan(&indisvalid)
+		require.NoError(t, err)
+
+		return indisvalid
+	}
+
+	// Once every partition has the index attached, the parent index is valid.
+	assert.True(t, valid())
+
+	indexed, err := r.IndexEntryPartitions(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, indexed)
+
+	// Partitions created afterwards get the index when attached.
+	_, err = r.CreateEntryPartition(ctx, time.Now().AddDate(10, 0, 0))
+	require.NoError(t, err)
+
+	indexed, err = r.IndexEntryPartitions(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, indexed)
+	assert.True(t, valid())
+
+	// Another replica building the indexes makes the call skip them.
+	unlock, locked, err := r.tryAdvisoryLock(ctx, indexEntryPartitionsLockKey)
+	require.NoError(t, err)
+	require.True(t, locked)
+	defer unlock()
+
+	indexed, err = r.IndexEntryPartitions(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, indexed)
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolConnectionsDesc = prometheus.NewDesc(
+		"ledger_db_pool_connections",
+		"Number of connections of the pool, by state.",
+		[]string{"pool", "state"}, nil,
+	)
+	poolMaxConnectionsDesc = prometheus.NewDesc(
+		"ledger_db_pool_max_connections",
+		"Maximum number of connections of the pool.",
+		[]string{"pool"}, nil,
+	)
+	poolAcquiresDesc = prometheus.NewDesc(
+		"ledger_db_pool_acquires_total",
+		"Number of connections acquired from the pool.",
+		[]string{"pool"}, nil,
+	)
+	poolEmptyAcquiresDesc = prometheus.NewDesc(
+		"ledger_db_pool_empty_acquires_total",
+		"Number of acquires that waited for a connection because the pool was empty.",
+		[]string{"pool"}, nil,
+	)
+	poolCanceledAcquiresDesc = prometheus.NewDesc(
+		"ledger_db_pool_canceled_acquires_total",
+		"Number of acquires canceled while waiting for a connection.",
+		[]string{"pool"}, nil,
+	)
+	poolAcquireDurationDesc = prometheus.NewDesc(
+		"ledger_db_pool_acquire_duration_seconds_total",
+		"Total time spent acquiring connections from the pool.",
+		[]string{"pool"}, nil,
+	)
+)
+
+var _ prometheus.Collector = &PoolCollector{}
+
+// PoolCollector exposes the saturation of a connection pool, read from its statistics on each
+// scrape.
+type PoolCollector struct {
+	name string
+	pool *pgxpool.Pool
+}
+
+// NewPoolCollector creates a collector of the pool, whose metrics are labeled by the given name.
+func NewPoolCollector(name string, pool *pgxpool.Pool) *PoolCollector {
+	return &PoolCollector{
+		name: name,
+		pool: pool,
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolConnectionsDesc
+	ch <- poolMaxConnectionsDesc
+	ch <- poolAcquiresDesc
+	ch <- poolEmptyAcquiresDesc
+	ch <- poolCanceledAcquiresDesc
+	ch <- poolAcquireDurationDesc
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()), c.name, "acquired")
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stat.IdleConns()), c.name, "idle")
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stat.ConstructingConns()), c.name, "constructing")
+	ch <- prometheus.MustNewConstMetric(poolMaxConnectionsDesc, prometheus.GaugeValue, float64(stat.MaxConns()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolAcquiresDesc, prometheus.CounterValue, float64(stat.AcquireCount()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquiresDesc, prometheus.CounterValue, float64(stat.EmptyAcquireCount()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquiresDesc, prometheus.CounterValue, float64(stat.CanceledAcquireCount()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolAcquireDurationDesc, prometheus.CounterValue, stat.AcquireDuration().Seconds(), c.name)
+}
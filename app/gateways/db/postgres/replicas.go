@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// replicaCheckInterval is how long the replay position and lag of a replica are trusted before
+// they are queried again.
+const replicaCheckInterval = time.Second
+
+// A server that is not in recovery is never behind itself, so a primary can also be used as a replica.
+const replicaStatusQuery = `
+select
+	case when pg_is_in_recovery() then pg_last_wal_replay_lsn() else pg_current_wal_lsn() end::text,
+	case
+		when not pg_is_in_recovery() or pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() then 0
+		else coalesce(extract(epoch from now() - pg_last_xact_replay_timestamp()), 0)
+	end::float8;
+`
+
+var errInvalidLSN = errors.New("invalid lsn")
+
+const currentLSNQuery = `
+select pg_current_wal_lsn()::text;
+`
+
+// primaryConsistencyToken isn't a WAL position, so the reads that pass it are served by the primary,
+// which has every committed transaction.
+const primaryConsistencyToken vos.ConsistencyToken = "primary"
+
+// RepositoryOption configures optional features of the repositories built by NewRepository.
+type RepositoryOption func(*LedgerRepository)
+
+// WithReplicas routes the read-only calls (balances, entries and reports) to the given read
+// replicas. Replicas lagging more than maxLag, or behind the consistency token of the call, are
+// skipped, falling back to the primary.
+func WithReplicas(replicas []*pgxpool.Pool, maxLag time.Duration) RepositoryOption {
+	return func(r *LedgerRepository) {
+		r.reads = newReadRouter(r.db, replicas, maxLag)
+	}
+}
+
+type readRouter struct {
+	primary  *pgxpool.Pool
+	replicas []*replica
+	maxLag   time.Duration
+	next     uint32
+}
+
+type replica struct {
+	pool *pgxpool.Pool
+
+	mu         sync.Mutex
+	refreshing bool
+	checkedAt  time.Time
+	lsn        uint64
+	lag        time.Duration
+}
+
+func newReadRouter(primary *pgxpool.Pool, pools []*pgxpool.Pool, maxLag time.Duration) *readRouter {
+	replicas := make([]*replica, 0, len(pools))
+	for _, pool := range pools {
+		replicas = append(replicas, &replica{pool: pool})
+	}
+
+	return &readRouter{
+		primary:  primary,
+		replicas: replicas,
+		maxLag:   maxLag,
+	}
+}
+
+func (rr *readRouter) enabled() bool {
+	return len(rr.replicas) > 0
+}
+
+// reader returns the pool for a read-only call, and whether it's a replica. Replicas are tried in
+// turns, so the load is spread between them.
+func (rr *readRouter) reader(ctx context.Context) (*pgxpool.Pool, bool) {
+	if !rr.enabled() {
+		return rr.primary, false
+	}
+
+	var want uint64
+
+	if token := vos.ConsistencyTokenFromContext(ctx); token != "" {
+		lsn, err := parseLSN(string(token))
+		if err != nil {
+			// A token no replica can be compared with is only safe on the primary.
+			return rr.primary, false
+		}
+
+		want = lsn
+	}
+
+	start := atomic.AddUint32(&rr.next, 1)
+
+	for i := range rr.replicas {
+		rep := rr.replicas[(int(start)+i)%len(rr.replicas)]
+		if rep.ready(ctx, want, rr.maxLag) {
+			return rep.pool, true
+		}
+	}
+
+	return rr.primary, false
+}
+
+// ready reports whether the replica is within maxLag and has replayed the want position. The
+// cached status is refreshed when it's too old or when it's behind want, as the replica may have
+// caught up since. The lock isn't held while the replica is queried, and a single call refreshes it
+// at a time, so a slow replica doesn't stall the reads checking it: they skip it until it answers.
+func (r *replica) ready(ctx context.Context, want uint64, maxLag time.Duration) bool {
+	r.mu.Lock()
+	refresh := !r.refreshing && (time.Since(r.checkedAt) > replicaCheckInterval || r.lsn < want)
+	if refresh {
+		r.refreshing = true
+	}
+	r.mu.Unlock()
+
+	if refresh {
+		lsn, lag, err := r.status(ctx)
+
+		r.mu.Lock()
+		r.refreshing = false
+		if err == nil {
+			r.store(lsn, lag)
+		}
+		r.mu.Unlock()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A status that couldn't be refreshed in time is not trusted.
+	return time.Since(r.checkedAt) <= replicaCheckInterval && r.lag <= maxLag && r.lsn >= want
+}
+
+// status queries the replay position and lag of the replica.
+func (r *replica) status(ctx context.Context) (uint64, time.Duration, error) {
+	var (
+		lsn string
+		lag float64
+	)
+
+	if err := r.pool.QueryRow(ctx, replicaStatusQuery).Scan(&lsn, &lag); err != nil {
+		return 0, 0, fmt.Errorf("failed to get replica status: %w", err)
+	}
+
+	position, err := parseLSN(lsn)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return position, time.Duration(lag * float64(time.Second)), nil
+}
+
+// store saves a status of the replica. The caller must hold r.mu.
+func (r *replica) store(lsn uint64, lag time.Duration) {
+	r.lsn = lsn
+	r.lag = lag
+	r.checkedAt = time.Now()
+}
+
+// consistencyToken returns the current WAL position of the primary, read after the transaction
+// committed, so it's past its commit record. A position read within the transaction would come
+// before the commit record, which a replica could be yet to replay. The transaction is committed
+// by then, so failing to read the position doesn't fail the call: the primaryConsistencyToken is
+// returned instead. Without replicas every read goes to the primary, so no token is needed.
+func (rr *readRouter) consistencyToken(ctx context.Context) vos.ConsistencyToken {
+	if !rr.enabled() {
+		return ""
+	}
+
+	var lsn string
+	if err := rr.primary.QueryRow(ctx, currentLSNQuery).Scan(&lsn); err != nil {
+		return primaryConsistencyToken
+	}
+
+	return vos.ConsistencyToken(lsn)
+}
+
+// parseLSN parses the textual pg_lsn format, two hexadecimal numbers separated by a slash.
+func parseLSN(lsn string) (uint64, error) {
+	parts := strings.Split(lsn, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("%w: %s", errInvalidLSN, lsn)
+	}
+
+	hi, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: %s", errInvalidLSN, lsn, err)
+	}
+
+	lo, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: %s", errInvalidLSN, lsn, err)
+	}
+
+	return hi<<32 | lo, nil
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+)
+
+func TestParseLSN(t *testing.T) {
+	lsn, err := parseLSN("16/B374D848")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0x16B374D848), lsn)
+
+	_, err = parseLSN("16B374D848")
+	assert.ErrorIs(t, err, errInvalidLSN)
+
+	_, err = parseLSN("16/XYZ")
+	assert.ErrorIs(t, err, errInvalidLSN)
+}
+
+func TestReadRouter_Reader(t *testing.T) {
+	ctx := context.Background()
+
+	// The primary reports its current position, so it can stand in for an up to date replica.
+	replica, err := pgxpool.Connect(ctx, pgDocker.DB.Config().ConnString())
+	require.NoError(t, err)
+	defer replica.Close()
+
+	rr := newReadRouter(pgDocker.DB, []*pgxpool.Pool{replica}, time.Second)
+
+	testCases := []struct {
+		name    string
+		token   vos.ConsistencyToken
+		maxLag  time.Duration
+		replica bool
+	}{
+		{
+			name:    "should read from the replica without a token",
+			maxLag:  time.Second,
+			replica: true,
+		},
+		{
+			name:    "should read from the replica when it replayed the token",
+			token:   "0/1",
+			maxLag:  time.Second,
+			replica: true,
+		},
+		{
+			name:   "should read from the primary when the replica is behind the token",
+			token:  "FFFFFFFF/0",
+			maxLag: time.Second,
+		},
+		{
+			name:   "should read from the primary when the token is invalid",
+			token:  "invalid",
+			maxLag: time.Second,
+		},
+		{
+			name:   "should read from the primary when the replica lags too much",
+			maxLag: -time.Second,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rr.maxLag = tt.maxLag
+
+			db, isReplica := rr.reader(vos.WithConsistencyToken(ctx, tt.token))
+			assert.Equal(t, tt.replica, isReplica)
+
+			if tt.replica {
+				assert.Same(t, replica, db)
+			} else {
+				assert.Same(t, pgDocker.DB, db)
+			}
+		})
+	}
+}
+
+func TestReplica_Ready(t *testing.T) {
+	ctx := context.Background()
+
+	pool, err := pgxpool.Connect(ctx, pgDocker.DB.Config().ConnString())
+	require.NoError(t, err)
+
+	rep := &replica{pool: pool}
+	assert.True(t, rep.ready(ctx, 0, time.Second))
+
+	// While another call refreshes the status, an outdated one is skipped without waiting for it.
+	rep.checkedAt = time.Now().Add(-time.Minute)
+	rep.refreshing = true
+	assert.False(t, rep.ready(ctx, 0, time.Second))
+
+	// A replica that can't be queried is skipped until it answers again.
+	pool.Close()
+	rep.refreshing = false
+	assert.False(t, rep.ready(ctx, 0, time.Second))
+	assert.False(t, rep.refreshing)
+}
+
+func TestReadRouter_ConsistencyToken(t *testing.T) {
+	ctx := context.Background()
+
+	rr := newReadRouter(pgDocker.DB, []*pgxpool.Pool{pgDocker.DB}, time.Second)
+
+	token := rr.consistencyToken(ctx)
+	_, err := parseLSN(string(token))
+	assert.NoError(t, err)
+
+	// A committed transaction whose position can't be read gets a token that only the primary serves.
+	closed, err := pgxpool.Connect(ctx, pgDocker.DB.Config().ConnString())
+	require.NoError(t, err)
+	closed.Close()
+
+	rr = newReadRouter(closed, []*pgxpool.Pool{pgDocker.DB}, time.Second)
+	assert.Equal(t, primaryConsistencyToken, rr.consistencyToken(ctx))
+
+	db, isReplica := rr.reader(vos.WithConsistencyToken(ctx, primaryConsistencyToken))
+	assert.False(t, isReplica)
+	assert.Same(t, closed, db)
+}
+
+func TestLedgerRepository_Replicas(t *testing.T) {
+	acc1, err := vos.NewAccount("liability.replica.acc1")
+	assert.NoError(t, err)
+
+	acc2, err := vos.NewAccount("liability.replica.acc2")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
+
+	replica, err := pgxpool.Connect(ctx, pgDocker.DB.Config().ConnString())
+	require.NoError(t, err)
+	defer replica.Close()
+
+	r, err := NewRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{}, LazyBalanceStrategy, WithReplicas([]*pgxpool.Pool{replica}, time.Second))
+	require.NoError(t, err)
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 100),
+	)
+
+	tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", time.Now(),
+		createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 100),
+	)
+	require.NoError(t, err)
+
+	result, err := r.CreateTransaction(ctx, tx)
+	require.NoError(t, err)
+
+	token := result.ConsistencyToken
+
+	_, err = parseLSN(string(token))
+	assert.NoError(t, err)
+
+	balance, err := r.GetAnalyticAccountBalance(vos.WithConsistencyToken(ctx, token), acc1)
+	assert.NoError(t, err)
+	assert.Equal(t, vos.NewAnalyticAccountBalance(acc1, vos.Version(2), -200), balance)
+
+	// Balances read from replicas don't write snapshots.
+	var snapshots int
+	err = pgDocker.DB.QueryRow(ctx, `select count(*) from account_balance`).Scan(&snapshots)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, snapshots)
+
+	primary := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+	tx, err = entities.NewTransaction(uuid.New(), uint32(1), "abc", time.Now(),
+		createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 100),
+	)
+	require.NoError(t, err)
+
+	result, err = primary.CreateTransaction(ctx, tx)
+	assert.NoError(t, err)
+	assert.Empty(t, result.ConsistencyToken, "repositories without replicas don't need tokens")
+}
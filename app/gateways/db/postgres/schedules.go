@@ -0,0 +1,356 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	pag "github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+const schedulesCollection = "schedule"
+
+// schedulesLockKey is the advisory lock held by the replica running the schedules.
+const schedulesLockKey int64 = 0x6c65646765720001
+
+const scheduleColumns = `
+	id,
+	book,
+	company,
+	event,
+	entries,
+	start_at,
+	recurrence,
+	status,
+	occurrence,
+	next_run_at,
+	last_error,
+	revision,
+	created_at,
+	updated_at
+`
+
+const createScheduleQuery = `
+insert into schedule (id, book, company, event, entries, start_at, recurrence, status, occurrence, next_run_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+returning revision, created_at, updated_at;
+`
+
+const getScheduleQuery = `
+select` + scheduleColumns + `
+from schedule
+where id = $1;
+`
+
+const listDueSchedulesQuery = `
+select` + scheduleColumns + `
+from schedule
+where status = 'active' and next_run_at <= $1
+order by next_run_at
+limit $2;
+`
+
+// Updates only apply to the revision that was read, so concurrent updates fail instead of overwriting
+// each other.
+const updateScheduleQuery = `
+update schedule
+set
+	status = $3,
+	occurrence = $4,
+	next_run_at = $5,
+	last_error = $6,
+	revision = revision + 1,
+	updated_at = now()
+where
+	id = $1
+	and revision = $2
+returning revision, updated_at;
+`
+
+const (
+	_schedulesQueryPrefix = `
+select` + scheduleColumns + `
+from
+	schedule
+where
+	true
+`
+
+	_schedulesStatusFilter = `
+	and status = $%d
+`
+
+	_schedulesQueryPagination = `
+	and (created_at, id) <= ($%d, $%d)
+`
+
+	_schedulesQuerySuffix = `
+order by
+	created_at desc,
+	id desc
+limit $1;
+`
+)
+
+type listSchedulesCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (r LedgerRepository) CreateSchedule(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+	const operation = "Repository.CreateSchedule"
+
+	entries, err := json.Marshal(schedule.Entries)
+	if err != nil {
+		return vos.Schedule{}, fmt.Errorf("failed to marshal schedule entries: %w", err)
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, schedulesCollection, operation, createScheduleQuery).End()
+
+	err = r.db.QueryRow(
+		ctx,
+		createScheduleQuery,
+		schedule.ID,
+		schedule.Book,
+		schedule.Company,
+		schedule.Event,
+		entries,
+		schedule.StartAt,
+		schedule.Recurrence.String(),
+		schedule.Status.String(),
+		schedule.Occurrence,
+		nullTime(schedule.NextRunAt),
+	).Scan(&schedule.Revision, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		return vos.Schedule{}, createScheduleError(err)
+	}
+
+	return schedule, nil
+}
+
+// createScheduleError maps the errors raised by the schedule constraints.
+func createScheduleError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	switch {
+	case pgErr.Code == pgerrcode.UniqueViolation:
+		return app.ErrIdempotencyKeyViolation
+	case pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == "schedule_book_fkey":
+		return app.ErrBookNotFound
+	case pgErr.Code == pgerrcode.ForeignKeyViolation, pgErr.Code == pgerrcode.NumericValueOutOfRange:
+		return app.ErrEventNotFound
+	default:
+		return fmt.Errorf("failed to create schedule: %w", err)
+	}
+}
+
+func (r LedgerRepository) GetSchedule(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+	const operation = "Repository.GetSchedule"
+
+	defer r.pb.MonitorDataSegment(ctx, schedulesCollection, operation, getScheduleQuery).End()
+
+	schedule, err := scanSchedule(r.db.QueryRow(ctx, getScheduleQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vos.Schedule{}, app.ErrScheduleNotFound
+		}
+
+		return vos.Schedule{}, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (r LedgerRepository) ListSchedules(ctx context.Context, req vos.ScheduleListRequest) ([]vos.Schedule, pag.Cursor, error) {
+	const op = "Repository.ListSchedules"
+
+	query, args, err := generateListSchedulesQuery(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate %s query: %w", op, err)
+	}
+
+	defer r.pb.MonitorDataSegment(ctx, schedulesCollection, op, query).End()
+
+	schedules, err := r.querySchedules(ctx, op, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(schedules) <= req.Page.Size {
+		return schedules, nil, nil
+	}
+
+	next := schedules[len(schedules)-1]
+	schedules = schedules[:len(schedules)-1]
+
+	cursor, err := pag.NewCursor(listSchedulesCursor{CreatedAt: next.CreatedAt, ID: next.ID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate next page token: %w", err)
+	}
+
+	return schedules, cursor, nil
+}
+
+func generateListSchedulesQuery(req vos.ScheduleListRequest) (string, []interface{}, error) {
+	var (
+		query     = _schedulesQueryPrefix
+		totalArgs = 1
+		args      = []interface{}{req.Page.Size + 1}
+	)
+
+	if req.Status != "" {
+		query += fmt.Sprintf(_schedulesStatusFilter, totalArgs+1)
+		args = append(args, req.Status.String())
+		totalArgs += 1
+	}
+
+	if req.Page.Cursor != nil {
+		var cursor listSchedulesCursor
+		err := req.Page.Extract(&cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		query += fmt.Sprintf(_schedulesQueryPagination, totalArgs+1, totalArgs+2)
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += _schedulesQuerySuffix
+
+	return query, args, nil
+}
+
+// ListDueSchedules lists the active schedules whose next occurrence is due at now, the most late first.
+func (r LedgerRepository) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]vos.Schedule, error) {
+	const op = "Repository.ListDueSchedules"
+
+	defer r.pb.MonitorDataSegment(ctx, schedulesCollection, op, listDueSchedulesQuery).End()
+
+	return r.querySchedules(ctx, op, listDueSchedulesQuery, now, limit)
+}
+
+func (r LedgerRepository) querySchedules(ctx context.Context, op, query string, args ...interface{}) ([]vos.Schedule, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	schedules := make([]vos.Schedule, 0)
+
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows have error: %w", op, err)
+	}
+
+	return schedules, nil
+}
+
+// UpdateSchedule saves the status, occurrence and last error of the schedule, failing with
+// app.ErrScheduleConflict if it was updated since it was read.
+func (r LedgerRepository) UpdateSchedule(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+	const operation = "Repository.UpdateSchedule"
+
+	defer r.pb.MonitorDataSegment(ctx, schedulesCollection, operation, updateScheduleQuery).End()
+
+	err := r.db.QueryRow(
+		ctx,
+		updateScheduleQuery,
+		schedule.ID,
+		schedule.Revision,
+		schedule.Status.String(),
+		schedule.Occurrence,
+		nullTime(schedule.NextRunAt),
+		schedule.LastError,
+	).Scan(&schedule.Revision, &schedule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vos.Schedule{}, app.ErrScheduleConflict
+		}
+
+		return vos.Schedule{}, fmt.Errorf("failed to update schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// LockSchedules tries to take the session advisory lock of the schedules, returning whether it was
+// taken and the function that releases it.
+func (r LedgerRepository) LockSchedules(ctx context.Context) (func(), bool, error) {
+	return r.tryAdvisoryLock(ctx, schedulesLockKey)
+}
+
+func scanSchedule(row pgx.Row) (vos.Schedule, error) {
+	var (
+		schedule   vos.Schedule
+		entries    []byte
+		recurrence string
+		status     string
+		nextRunAt  *time.Time
+		err        error
+	)
+
+	if err = row.Scan(
+		&schedule.ID,
+		&schedule.Book,
+		&schedule.Company,
+		&schedule.Event,
+		&entries,
+		&schedule.StartAt,
+		&recurrence,
+		&status,
+		&schedule.Occurrence,
+		&nextRunAt,
+		&schedule.LastError,
+		&schedule.Revision,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	); err != nil {
+		return vos.Schedule{}, err
+	}
+
+	if err = json.Unmarshal(entries, &schedule.Entries); err != nil {
+		return vos.Schedule{}, fmt.Errorf("failed to decode schedule entries: %w", err)
+	}
+
+	if schedule.Recurrence, err = vos.ParseRecurrence(recurrence); err != nil {
+		return vos.Schedule{}, err
+	}
+
+	schedule.Status = vos.ScheduleStatus(status)
+
+	if nextRunAt != nil {
+		schedule.NextRunAt = *nextRunAt
+	}
+
+	return schedule, nil
+}
+
+// nullTime stores zero times as nulls.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+)
+
+func newSchedule(t *testing.T, start time.Time) vos.Schedule {
+	recurrence, err := vos.ParseRecurrence("FREQ=DAILY;COUNT=3")
+	require.NoError(t, err)
+
+	entries := []vos.ScheduledEntry{
+		{Operation: vos.DebitOperation, Account: "liability.sched.acc1", Version: vos.NextAccountVersion, Amount: 100, Metadata: []byte(`{"rent":true}`)},
+		{Operation: vos.CreditOperation, Account: "liability.sched.acc2", Version: vos.IgnoreAccountVersion, Amount: 100},
+	}
+
+	schedule, err := vos.NewSchedule(uuid.New(), vos.DefaultBook, "abc", 1, entries, start, recurrence, start.Add(-time.Hour))
+	require.NoError(t, err)
+
+	return schedule
+}
+
+func TestLedgerRepository_Schedules(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "schedule")
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	due := newSchedule(t, now.Add(-time.Hour))
+	future := newSchedule(t, now.Add(time.Hour))
+
+	created, err := r.CreateSchedule(ctx, due)
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Revision)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	_, err = r.CreateSchedule(ctx, future)
+	require.NoError(t, err)
+
+	_, err = r.CreateSchedule(ctx, due)
+	assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation)
+
+	got, err := r.GetSchedule(ctx, due.ID)
+	require.NoError(t, err)
+	assert.Equal(t, due.Entries, got.Entries)
+	assert.Equal(t, due.Recurrence, got.Recurrence)
+	assert.True(t, due.NextRunAt.Equal(got.NextRunAt))
+
+	_, err = r.GetSchedule(ctx, uuid.New())
+	assert.ErrorIs(t, err, app.ErrScheduleNotFound)
+
+	dueSchedules, err := r.ListDueSchedules(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, dueSchedules, 1)
+	assert.Equal(t, due.ID, dueSchedules[0].ID)
+
+	got.Advance()
+	updated, err := r.UpdateSchedule(ctx, got)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Revision)
+
+	_, err = r.UpdateSchedule(ctx, got)
+	assert.ErrorIs(t, err, app.ErrScheduleConflict)
+
+	require.NoError(t, updated.Cancel())
+	_, err = r.UpdateSchedule(ctx, updated)
+	require.NoError(t, err)
+
+	canceled, err := r.GetSchedule(ctx, due.ID)
+	require.NoError(t, err)
+	assert.Equal(t, vos.CanceledSchedule, canceled.Status)
+	assert.True(t, canceled.NextRunAt.IsZero())
+
+	schedules, cursor, err := r.ListSchedules(ctx, vos.ScheduleListRequest{Status: vos.ActiveSchedule, Page: pagination.Page{Size: 10}})
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, future.ID, schedules[0].ID)
+
+	page, cursor, err := r.ListSchedules(ctx, vos.ScheduleListRequest{Page: pagination.Page{Size: 1}})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.NotNil(t, cursor)
+
+	next, cursor, err := r.ListSchedules(ctx, vos.ScheduleListRequest{Page: pagination.Page{Size: 1, Cursor: cursor}})
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+	assert.Nil(t, cursor)
+	assert.NotEqual(t, page[0].ID, next[0].ID)
+}
+
+func TestLedgerRepository_CreateScheduleErrors(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "schedule")
+
+	schedule := newSchedule(t, time.Now().Add(time.Hour))
+	schedule.Book = "unknown"
+
+	_, err := r.CreateSchedule(ctx, schedule)
+	assert.ErrorIs(t, err, app.ErrBookNotFound)
+
+	schedule = newSchedule(t, time.Now().Add(time.Hour))
+	schedule.Event = 99
+
+	_, err = r.CreateSchedule(ctx, schedule)
+	assert.ErrorIs(t, err, app.ErrEventNotFound)
+}
+
+func TestLedgerRepository_LockSchedules(t *testing.T) {
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+
+	unlock, locked, err := r.LockSchedules(ctx)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	_, locked, err = r.LockSchedules(ctx)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	unlock()
+
+	unlock, locked, err = r.LockSchedules(ctx)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	unlock()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/stone-co/the-amazing-ledger/app/domain"
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	"github.com/stone-co/the-amazing-ledger/app/tests"
@@ -33,7 +34,7 @@ func TestMain(m *testing.M) {
 	os.Exit(exitCode)
 }
 
-func createEntry(t *testing.T, op vos.OperationType, account string, version vos.Version, amount int) entities.Entry {
+func createEntry(t testing.TB, op vos.OperationType, account string, version vos.Version, amount int) entities.Entry {
 	t.Helper()
 
 	entry, err := entities.NewEntry(
@@ -49,19 +50,25 @@ func createEntry(t *testing.T, op vos.OperationType, account string, version vos
 	return entry
 }
 
-func createTransaction(t *testing.T, ctx context.Context, r *LedgerRepository, entries ...entities.Entry) entities.Transaction {
+func createTransaction(t testing.TB, ctx context.Context, r domain.Repository, entries ...entities.Entry) entities.Transaction {
+	t.Helper()
+
+	return createDatedTransaction(t, ctx, r, time.Now(), entries...)
+}
+
+func createDatedTransaction(t testing.TB, ctx context.Context, r domain.Repository, competenceDate time.Time, entries ...entities.Entry) entities.Transaction {
 	t.Helper()
 
 	tx, err := entities.NewTransaction(
 		uuid.New(),
 		uint32(1),
 		"abc",
-		time.Now().Round(time.Microsecond),
+		competenceDate.Round(time.Microsecond),
 		entries...,
 	)
 	assert.NoError(t, err)
 
-	err = r.CreateTransaction(ctx, tx)
+	_, err = r.CreateTransaction(ctx, tx)
 	assert.NoError(t, err)
 
 	return tx
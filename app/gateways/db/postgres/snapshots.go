@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+const snapshotsCollection = "account_balance"
+
+// Snapshot keys are either ltree paths (analytic accounts) or lquery patterns (synthetic accounts),
+// so only the analytic ones can be cast to ltree and matched against the pattern.
+const deleteSnapshotsQuery = `
+delete from account_balance
+where
+	book = $1
+	and (
+		account = $2
+		or case
+			when account ~ '^[a-z0-9_]+(\.[a-z0-9_]+)*$' then account::ltree ~ $2::lquery
+			else false
+		end
+	)
+returning account;
+`
+
+const rebuildAnalyticSnapshotQuery = `
+select version from get_analytic_account_balance($1, $2, false);
+`
+
+const rebuildSyntheticSnapshotQuery = `
+select total_balance from get_synthetic_account_balance($1, $2, false);
+`
+
+const countSnapshotsQuery = `
+select count(*) from account_balance where book = $1 and account = any($2);
+`
+
+const pruneSnapshotsQuery = `
+delete from account_balance where read_at < $1;
+`
+
+const listHotSyntheticSnapshotsQuery = `
+select
+	book,
+	account
+from
+	account_balance
+where
+	account !~ '^[a-z0-9_]+(\.[a-z0-9_]+)*$'
+order by
+	read_count desc,
+	read_at desc
+limit $1;
+`
+
+func (r LedgerRepository) RebuildSnapshots(ctx context.Context, account vos.Account) (int, error) {
+	const operation = "Repository.RebuildSnapshots"
+
+	defer r.pb.MonitorDataSegment(ctx, snapshotsCollection, operation, deleteSnapshotsQuery).End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	book := vos.BookFromContext(ctx)
+
+	rows, err := tx.Query(ctx, deleteSnapshotsQuery, book, account.Value())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete snapshots: %w", err)
+	}
+
+	keys := []string{account.Value()}
+
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if key != account.Value() {
+			keys = append(keys, key)
+		}
+	}
+
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s rows have error: %w", operation, err)
+	}
+
+	for _, key := range keys {
+		if err = rebuildSnapshot(ctx, tx, book, key); err != nil {
+			return 0, err
+		}
+	}
+
+	var total int
+	if err = tx.QueryRow(ctx, countSnapshotsQuery, book, keys).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count rebuilt snapshots: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit rebuilt snapshots: %w", err)
+	}
+
+	return total, nil
+}
+
+// rebuildSnapshot recomputes the balance of the given snapshot key without marking it as read, so
+// maintenance doesn't keep snapshots alive. Keys that no longer have entries are left deleted.
+func rebuildSnapshot(ctx context.Context, tx pgx.Tx, book, key string) error {
+	account, err := vos.NewAccount(key)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot key %s: %w", key, err)
+	}
+
+	query := rebuildSyntheticSnapshotQuery
+	if account.Type() == vos.Analytic {
+		query = rebuildAnalyticSnapshotQuery
+	}
+
+	var discard int64
+
+	err = tx.QueryRow(ctx, query, book, key).Scan(&discard)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.NoDataFound {
+			return nil
+		}
+
+		return fmt.Errorf("failed to rebuild snapshot of %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (r LedgerRepository) PruneSnapshots(ctx context.Context, unreadSince time.Time) (int, error) {
+	const operation = "Repository.PruneSnapshots"
+
+	defer r.pb.MonitorDataSegment(ctx, snapshotsCollection, operation, pruneSnapshotsQuery).End()
+
+	tag, err := r.db.Exec(ctx, pruneSnapshotsQuery, unreadSince)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r LedgerRepository) PrecomputeSnapshots(ctx context.Context, limit int) (int, error) {
+	const operation = "Repository.PrecomputeSnapshots"
+
+	defer r.pb.MonitorDataSegment(ctx, snapshotsCollection, operation, listHotSyntheticSnapshotsQuery).End()
+
+	rows, err := r.db.Query(ctx, listHotSyntheticSnapshotsQuery, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list hot snapshots: %w", err)
+	}
+
+	type snapshotKey struct {
+		book    string
+		account string
+	}
+
+	keys := make([]snapshotKey, 0, limit)
+
+	for rows.Next() {
+		var key snapshotKey
+		if err = rows.Scan(&key.book, &key.account); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s rows have error: %w", operation, err)
+	}
+
+	var total int
+
+	for _, key := range keys {
+		var discard int64
+
+		err = r.db.QueryRow(ctx, rebuildSyntheticSnapshotQuery, key.book, key.account).Scan(&discard)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.NoDataFound {
+				continue
+			}
+
+			return total, fmt.Errorf("failed to precompute snapshot of %s in %s: %w", key.account, key.book, err)
+		}
+
+		total++
+	}
+
+	return total, nil
+}
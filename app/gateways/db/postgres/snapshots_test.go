@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests"
+)
+
+func seedSnapshots(t *testing.T, ctx context.Context, r *LedgerRepository, acc1, acc2, query vos.Account) {
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 100),
+		createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 100),
+	)
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 50),
+		createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 50),
+	)
+
+	_, err := r.GetAnalyticAccountBalance(ctx, acc1)
+	require.NoError(t, err)
+
+	_, err = r.GetSyntheticAccountBalance(ctx, query)
+	require.NoError(t, err)
+}
+
+func TestLedgerRepository_RebuildSnapshots(t *testing.T) {
+	acc1, err := vos.NewAccount("liability.snap.acc1")
+	assert.NoError(t, err)
+
+	acc2, err := vos.NewAccount("liability.snap.acc2")
+	assert.NoError(t, err)
+
+	query, err := vos.NewAccount("liability.snap.*")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
+
+	seedSnapshots(t, ctx, r, acc1, acc2, query)
+
+	_, err = pgDocker.DB.Exec(ctx, `update account_balance set balance = balance + 1`)
+	require.NoError(t, err)
+
+	total, err := r.RebuildSnapshots(ctx, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	snap, err := fetchSnapshot(ctx, pgDocker.DB, acc1)
+	assert.NoError(t, err)
+	assert.Equal(t, -100, snap.balance)
+
+	querySnap, err := fetchQuerySnapshot(ctx, pgDocker.DB, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, querySnap.balance)
+
+	_, err = fetchSnapshot(ctx, pgDocker.DB, acc2)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestLedgerRepository_PruneSnapshots(t *testing.T) {
+	acc1, err := vos.NewAccount("liability.snap.acc1")
+	assert.NoError(t, err)
+
+	acc2, err := vos.NewAccount("liability.snap.acc2")
+	assert.NoError(t, err)
+
+	query, err := vos.NewAccount("liability.snap.*")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
+
+	seedSnapshots(t, ctx, r, acc1, acc2, query)
+
+	_, err = pgDocker.DB.Exec(ctx, `update account_balance set read_at = now() - interval '10 days' where account = $1`, query.Value())
+	require.NoError(t, err)
+
+	total, err := r.PruneSnapshots(ctx, time.Now().Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	_, err = fetchQuerySnapshot(ctx, pgDocker.DB, query)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = fetchSnapshot(ctx, pgDocker.DB, acc1)
+	assert.NoError(t, err)
+}
+
+func TestLedgerRepository_PrecomputeSnapshots(t *testing.T) {
+	acc1, err := vos.NewAccount("liability.snap.acc1")
+	assert.NoError(t, err)
+
+	acc2, err := vos.NewAccount("liability.snap.acc2")
+	assert.NoError(t, err)
+
+	query, err := vos.NewAccount("liability.snap.*")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	r := NewLedgerRepository(pgDocker.DB, &instrumentators.LedgerInstrumentator{})
+	defer tests.TruncateTables(ctx, pgDocker.DB, "entry", "entry_id", "account_version", "account_balance")
+
+	seedSnapshots(t, ctx, r, acc1, acc2, query)
+
+	before, err := fetchQuerySnapshot(ctx, pgDocker.DB, query)
+	require.NoError(t, err)
+
+	createTransaction(t, ctx, r,
+		createEntry(t, vos.DebitOperation, acc1.Value(), vos.NextAccountVersion, 10),
+		createEntry(t, vos.CreditOperation, acc2.Value(), vos.NextAccountVersion, 10),
+	)
+
+	total, err := r.PrecomputeSnapshots(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	after, err := fetchQuerySnapshot(ctx, pgDocker.DB, query)
+	assert.NoError(t, err)
+	assert.True(t, after.date.After(before.date))
+}
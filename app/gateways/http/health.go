@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/rs/zerolog/log"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+const (
+	servingStatus    = "SERVING"
+	notServingStatus = "NOT_SERVING"
+)
+
+type healthResponse struct {
+	Status       string               `json:"status"`
+	Dependencies []dependencyResponse `json:"dependencies,omitempty"`
+}
+
+type dependencyResponse struct {
+	Name     string `json:"name"`
+	Optional bool   `json:"optional,omitempty"`
+	Healthy  bool   `json:"healthy"`
+	Error    string `json:"error,omitempty"`
+}
+
+// LivenessHandler answers while the process is able to serve http requests, regardless of its
+// dependencies.
+func LivenessHandler(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeHealth(w, http.StatusOK, healthResponse{Status: servingStatus})
+}
+
+// ReadinessHandler answers with 503 (Service Unavailable) while the server shouldn't receive calls,
+// listing the status of its dependencies.
+func ReadinessHandler(ready func() (bool, []vos.DependencyStatus)) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		serving, statuses := ready()
+
+		response := healthResponse{
+			Status:       servingStatus,
+			Dependencies: make([]dependencyResponse, 0, len(statuses)),
+		}
+
+		for _, status := range statuses {
+			dependency := dependencyResponse{
+				Name:     status.Name,
+				Optional: status.Optional,
+				Healthy:  status.Err == nil,
+			}
+			if status.Err != nil {
+				dependency.Error = status.Err.Error()
+			}
+
+			response.Dependencies = append(response.Dependencies, dependency)
+		}
+
+		code := http.StatusOK
+		if !serving {
+			code = http.StatusServiceUnavailable
+			response.Status = notServingStatus
+		}
+
+		writeHealth(w, code, response)
+	}
+}
+
+func writeHealth(w http.ResponseWriter, code int, response healthResponse) {
+	b, err := json.Marshal(response)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to marshal health")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if _, err = w.Write(b); err != nil {
+		log.Error().Err(err).Msg("failed to write health body")
+	}
+}
@@ -2,13 +2,9 @@ package rpc
 
 import (
 	"context"
-	"errors"
 
 	"github.com/rs/zerolog"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
-	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
 )
@@ -17,22 +13,44 @@ func (a *API) GetAccountBalance(ctx context.Context, request *proto.GetAccountBa
 	accountName, err := vos.NewAccount(request.Account)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create account name")
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, errorStatus(err, fieldViolation("account", err.Error()))
 	}
 
+	accountName, err = withSearch(accountName, request.Search)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid search")
+		return nil, err
+	}
+
+	ctx, err = withBook(ctx, request.Book)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = vos.WithConsistencyToken(ctx, vos.ConsistencyToken(request.ConsistencyToken))
 	accountBalance, err := a.UseCase.GetAccountBalance(ctx, accountName)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get account balance")
-		if errors.Is(err, app.ErrAccountNotFound) {
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-
-		return nil, status.Error(codes.Internal, "internal server error")
+		return nil, errorStatus(err)
 	}
 
+	return accountBalanceToProto(accountBalance), nil
+}
+
+func accountBalanceToProto(balance vos.AccountBalance) *proto.GetAccountBalanceResponse {
 	return &proto.GetAccountBalanceResponse{
-		Account:        accountBalance.Account.Value(),
-		CurrentVersion: accountBalance.CurrentVersion.AsInt64(),
-		Balance:        int64(accountBalance.Balance),
-	}, nil
+		Account:        balance.Account.Value(),
+		CurrentVersion: balance.CurrentVersion.AsInt64(),
+		Balance:        int64(balance.Balance),
+	}
+}
+
+// withSearch narrows account to the accounts matching search, when given.
+func withSearch(account vos.Account, search string) (vos.Account, error) {
+	accountSearch, err := vos.NewAccountSearch(search)
+	if err != nil {
+		return vos.Account{}, errorStatus(err, fieldViolation("search", err.Error()))
+	}
+
+	return account.WithSearch(accountSearch), nil
 }
@@ -28,7 +28,7 @@ func TestAPI_GetAccountBalance_Analytic_Success(t *testing.T) {
 				return accountBalance, nil
 			},
 		}
-		api := NewAPI(mockedUsecase)
+		api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
 
 		request := &proto.GetAccountBalanceRequest{
 			Account: account.Value(),
@@ -56,7 +56,7 @@ func TestAPI_GetAccountBalance_Synthetic_Success(t *testing.T) {
 				return balance, nil
 			},
 		}
-		api := NewAPI(mockedUsecase)
+		api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
 
 		request := &proto.GetAccountBalanceRequest{
 			Account: "liability.stone.clients.*",
@@ -73,6 +73,31 @@ func TestAPI_GetAccountBalance_Synthetic_Success(t *testing.T) {
 	})
 }
 
+func TestAPI_GetAccountBalance_Search(t *testing.T) {
+	mockedUsecase := &mocks.UseCaseMock{
+		GetAccountBalanceFunc: func(ctx context.Context, account vos.Account) (vos.AccountBalance, error) {
+			return vos.NewSyntheticAccountBalance(account, 100), nil
+		},
+	}
+	api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
+
+	got, err := api.GetAccountBalance(context.Background(), &proto.GetAccountBalanceRequest{
+		Account: "liability.clients.(available|blocked).*",
+		Search:  "!abc*",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &proto.GetAccountBalanceResponse{
+		Account:        "liability.clients.available|blocked.*",
+		CurrentVersion: -1,
+		Balance:        100,
+	}, got)
+
+	account := mockedUsecase.GetAccountBalanceCalls()[0].Account
+	assert.Equal(t, vos.Synthetic, account.Type())
+	assert.Equal(t, "!abc*", account.Search().Value())
+}
+
 func TestAPI_GetAccountBalance_InvalidRequest(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -90,6 +115,16 @@ func TestAPI_GetAccountBalance_InvalidRequest(t *testing.T) {
 			expectedCode:    codes.InvalidArgument,
 			expectedMessage: app.ErrInvalidAccountComponentCharacters.Error(),
 		},
+		{
+			name:         "should return an error if search is invalid",
+			useCaseSetup: &mocks.UseCaseMock{},
+			request: &proto.GetAccountBalanceRequest{
+				Account: "liability.clients.*",
+				Search:  "available &",
+			},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: app.ErrInvalidAccountSearch.Error() + ": missing word at the end",
+		},
 		{
 			name: "should return an error if account does not exist",
 			useCaseSetup: &mocks.UseCaseMock{
@@ -107,7 +142,7 @@ func TestAPI_GetAccountBalance_InvalidRequest(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			api := NewAPI(tt.useCaseSetup)
+			api := NewAPI(tt.useCaseSetup, &mocks.AdminUseCaseMock{})
 
 			_, err := api.GetAccountBalance(context.Background(), tt.request)
 			respStatus, ok := status.FromError(err)
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func (a *API) RunAccruals(ctx context.Context, request *proto.RunAccrualsRequest) (*proto.RunAccrualsResponse, error) {
+	req, err := accrualRunRequest(request.Rule, request.StartDate, request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	backRuns, err := a.AdminUseCase.RunAccruals(ctx, req)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to run accruals")
+		return nil, accrualStatus(err)
+	}
+
+	protoBackRuns := make([]*proto.AccrualBackRun, 0, len(backRuns))
+	for _, backRun := range backRuns {
+		protoBackRuns = append(protoBackRuns, &proto.AccrualBackRun{
+			Rule:      backRun.Rule,
+			StartDate: timestamppb.New(backRun.StartDate),
+			EndDate:   timestamppb.New(backRun.EndDate),
+			CreatedAt: timestamppb.New(backRun.CreatedAt),
+		})
+	}
+
+	return &proto.RunAccrualsResponse{
+		BackRuns: protoBackRuns,
+	}, nil
+}
+
+func (a *API) ListAccrualRuns(ctx context.Context, request *proto.ListAccrualRunsRequest) (*proto.ListAccrualRunsResponse, error) {
+	req, err := accrualRunRequest(request.Rule, request.StartDate, request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := a.AdminUseCase.ListAccrualRuns(ctx, req)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list accrual runs")
+		return nil, accrualStatus(err)
+	}
+
+	return &proto.ListAccrualRunsResponse{
+		Runs: accrualRunsToProto(runs),
+	}, nil
+}
+
+func accrualRunRequest(rule string, start, end *timestamppb.Timestamp) (vos.AccrualRunRequest, error) {
+	if start == nil {
+		return vos.AccrualRunRequest{}, invalidArgument("start_date", "start_date must have a value")
+	}
+
+	if end == nil {
+		return vos.AccrualRunRequest{}, invalidArgument("end_date", "end_date must have a value")
+	}
+
+	return vos.AccrualRunRequest{
+		Rule:      rule,
+		StartDate: start.AsTime(),
+		EndDate:   end.AsTime(),
+	}, nil
+}
+
+// accrualStatus points invalid dates to the dates of the request and unknown rules to its rule.
+func accrualStatus(err error) error {
+	switch {
+	case errors.Is(err, app.ErrInvalidAccrualDates):
+		return errorStatus(err, fieldViolation("start_date", err.Error()), fieldViolation("end_date", err.Error()))
+	case errors.Is(err, app.ErrAccrualRuleNotFound):
+		return errorStatus(err, fieldViolation("rule", err.Error()))
+	default:
+		return errorStatus(err)
+	}
+}
+
+func accrualRunsToProto(runs []vos.AccrualRun) []*proto.AccrualRun {
+	protoRuns := make([]*proto.AccrualRun, 0, len(runs))
+	for _, run := range runs {
+		protoRuns = append(protoRuns, &proto.AccrualRun{
+			Rule:      run.Rule,
+			Book:      run.Book,
+			Date:      timestamppb.New(run.Date),
+			Accounts:  int32(run.Accounts),
+			Amount:    int64(run.Amount),
+			CreatedAt: timestamppb.New(run.CreatedAt),
+		})
+	}
+
+	return protoRuns
+}
@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_RunAccruals(t *testing.T) {
+	day := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should return the back-runs requested", func(t *testing.T) {
+		adminUseCase := &mocks.AdminUseCaseMock{
+			RunAccrualsFunc: func(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualBackRun, error) {
+				return []vos.AccrualBackRun{{Rule: req.Rule, StartDate: req.StartDate, EndDate: req.EndDate, CreatedAt: day}}, nil
+			},
+		}
+
+		api := NewAPI(&mocks.UseCaseMock{}, adminUseCase)
+
+		got, err := api.RunAccruals(context.Background(), &proto.RunAccrualsRequest{
+			Rule:      "savings_interest",
+			StartDate: timestamppb.New(day),
+			EndDate:   timestamppb.New(day.AddDate(0, 0, 1)),
+		})
+		require.NoError(t, err)
+		require.Len(t, got.BackRuns, 1)
+		assert.Equal(t, "savings_interest", got.BackRuns[0].Rule)
+		assert.Equal(t, day, got.BackRuns[0].StartDate.AsTime())
+		assert.Equal(t, day.AddDate(0, 0, 1), got.BackRuns[0].EndDate.AsTime())
+		assert.Equal(t, day, got.BackRuns[0].CreatedAt.AsTime())
+
+		calls := adminUseCase.RunAccrualsCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, day.AddDate(0, 0, 1), calls[0].AccrualRunRequest.EndDate)
+	})
+
+	testCases := []struct {
+		name          string
+		request       *proto.RunAccrualsRequest
+		useCaseErr    error
+		expectedCode  codes.Code
+		expectedField string
+	}{
+		{
+			name:          "should return invalid argument for a missing start date",
+			request:       &proto.RunAccrualsRequest{EndDate: timestamppb.New(day)},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "start_date",
+		},
+		{
+			name:          "should return invalid argument for a missing end date",
+			request:       &proto.RunAccrualsRequest{StartDate: timestamppb.New(day)},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "end_date",
+		},
+		{
+			name:          "should return invalid argument for invalid dates",
+			request:       &proto.RunAccrualsRequest{StartDate: timestamppb.New(day), EndDate: timestamppb.New(day)},
+			useCaseErr:    fmt.Errorf("%w: only days that have ended can be accrued", app.ErrInvalidAccrualDates),
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "start_date",
+		},
+		{
+			name:          "should return not found for an unknown rule",
+			request:       &proto.RunAccrualsRequest{Rule: "unknown", StartDate: timestamppb.New(day), EndDate: timestamppb.New(day)},
+			useCaseErr:    fmt.Errorf("%w: unknown", app.ErrAccrualRuleNotFound),
+			expectedCode:  codes.NotFound,
+			expectedField: "rule",
+		},
+		{
+			name:         "should return not found for an unknown event",
+			request:      &proto.RunAccrualsRequest{StartDate: timestamppb.New(day), EndDate: timestamppb.New(day)},
+			useCaseErr:   fmt.Errorf("failed to accrue: %w", app.ErrEventNotFound),
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			adminUseCase := &mocks.AdminUseCaseMock{
+				RunAccrualsFunc: func(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualBackRun, error) {
+					return nil, tt.useCaseErr
+				},
+			}
+
+			api := NewAPI(&mocks.UseCaseMock{}, adminUseCase)
+
+			_, err := api.RunAccruals(context.Background(), tt.request)
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.expectedCode, st.Code())
+
+			if tt.expectedField != "" {
+				_, badRequest := statusDetails(t, st)
+				require.NotNil(t, badRequest)
+				assert.Equal(t, tt.expectedField, badRequest.FieldViolations[0].Field)
+			}
+		})
+	}
+}
+
+func TestAPI_ListAccrualRuns(t *testing.T) {
+	day := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	adminUseCase := &mocks.AdminUseCaseMock{
+		ListAccrualRunsFunc: func(ctx context.Context, req vos.AccrualRunRequest) ([]vos.AccrualRun, error) {
+			return []vos.AccrualRun{{Rule: "a", Date: day}, {Rule: "b", Date: day}}, nil
+		},
+	}
+
+	api := NewAPI(&mocks.UseCaseMock{}, adminUseCase)
+
+	got, err := api.ListAccrualRuns(context.Background(), &proto.ListAccrualRunsRequest{
+		StartDate: timestamppb.New(day),
+		EndDate:   timestamppb.New(day),
+	})
+	require.NoError(t, err)
+	require.Len(t, got.Runs, 2)
+	assert.Equal(t, "b", got.Runs[1].Rule)
+
+	_, err = api.ListAccrualRuns(context.Background(), &proto.ListAccrualRunsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -5,14 +5,22 @@ import (
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
 )
 
-var _ proto.LedgerServiceServer = &API{}
+var (
+	_ proto.LedgerServiceServer = &API{}
+	_ proto.AdminServiceServer  = &API{}
+)
 
 type API struct {
-	UseCase domain.UseCase
+	UseCase      domain.UseCase
+	AdminUseCase domain.AdminUseCase
+
+	// health is the status reported by Check, which is always serving when nil.
+	health *Health
 }
 
-func NewAPI(useCase domain.UseCase) *API {
+func NewAPI(useCase domain.UseCase, adminUseCase domain.AdminUseCase) *API {
 	return &API{
-		UseCase: useCase,
+		UseCase:      useCase,
+		AdminUseCase: adminUseCase,
 	}
 }
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/gateways/rpc/auth"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+const (
+	adminServicePrefix  = "/ledger.AdminService/"
+	healthServicePrefix = "/ledger.Health/"
+	// grpcHealthServicePrefix is the standard grpc.health.v1 service.
+	grpcHealthServicePrefix = "/grpc.health.v1.Health/"
+)
+
+// authInterceptor authenticates every call but health checks, and authorizes it by the accounts and
+// companies of its request.
+func authInterceptor(authenticator auth.Authenticator, policy *auth.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) || strings.HasPrefix(info.FullMethod, grpcHealthServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		principal, err := authenticator.Authenticate(ctx)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to authenticate")
+			return nil, errorStatus(app.ErrUnauthenticated)
+		}
+
+		if err = policy.Authorize(principal, accessFor(info.FullMethod, req)); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("subject", principal.Subject).Msg("failed to authorize")
+			return nil, errorStatus(err)
+		}
+
+		ctx = context.WithValue(auth.WithPrincipal(ctx, principal), authorizerKey{}, func(access auth.Access) error {
+			return policy.Authorize(principal, access)
+		})
+
+		return handler(ctx, req)
+	}
+}
+
+type authorizerKey struct{}
+
+// authorize checks the access of the calls whose accounts are only known by their handler. Every
+// access is granted when authentication is disabled.
+func authorize(ctx context.Context, access auth.Access) error {
+	authorizer, ok := ctx.Value(authorizerKey{}).(func(auth.Access) error)
+	if !ok {
+		return nil
+	}
+
+	return authorizer(access)
+}
+
+// accessFor returns what the request needs, in the book of the request. Balances and reports aggregate every company, so they
+// are only bound to accounts. Unknown requests need admin access.
+func accessFor(method string, req interface{}) auth.Access {
+	if strings.HasPrefix(method, adminServicePrefix) {
+		return auth.Access{Action: auth.AdminAction}
+	}
+
+	switch r := req.(type) {
+	case *proto.CreateTransactionRequest:
+		accounts := make([]string, 0, len(r.Entries))
+		for _, entry := range r.Entries {
+			accounts = append(accounts, entry.Account)
+		}
+
+		return auth.Access{Action: auth.PostAction, Book: r.Book, Companies: []string{r.Company}, Accounts: accounts}
+	case *proto.PostEventRequest:
+		// The accounts come from the template, so they are authorized by the handler.
+		return auth.Access{Action: auth.PostAction, Book: r.Book, Companies: []string{r.Company}}
+	case *proto.GetAccountBalanceRequest:
+		return auth.Access{Action: auth.ReadAction, Book: r.Book, Accounts: []string{r.Account}}
+	case *proto.GetSyntheticReportRequest:
+		return auth.Access{Action: auth.ReadAction, Book: r.Book, Accounts: []string{r.Account}}
+	case *proto.ListAccountsRequest:
+		return auth.Access{Action: auth.ReadAction, Book: r.Book, Accounts: []string{r.Pattern}}
+	case *proto.ListAccountChildrenRequest:
+		return auth.Access{Action: auth.ReadAction, Book: r.Book, Accounts: []string{childrenQuery(r.Prefix)}}
+	case *proto.ListAccountEntriesRequest:
+		return auth.Access{
+			Action:        auth.ReadAction,
+			Book:          r.Book,
+			Companies:     r.GetFilter().GetCompanies(),
+			Accounts:      []string{r.Account},
+			CompanyScoped: true,
+		}
+	default:
+		return auth.Access{Action: auth.AdminAction}
+	}
+}
+
+// childrenQuery is the synthetic account of the accounts under prefix, which are the ones a
+// ListAccountChildren call reveals.
+func childrenQuery(prefix string) string {
+	if prefix == "" {
+		return auth.Wildcard
+	}
+
+	return prefix + ".*"
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// Chain tries each authenticator in order, using the first one that finds credentials in the call.
+type Chain []Authenticator
+
+func (c Chain) Authenticate(ctx context.Context) (Principal, error) {
+	for _, authenticator := range c {
+		principal, err := authenticator.Authenticate(ctx)
+		if errors.Is(err, errNoCredentials) {
+			continue
+		}
+
+		return principal, err
+	}
+
+	return Principal{}, fmt.Errorf("%w: %s", app.ErrUnauthenticated, errNoCredentials)
+}
+
+// AuthenticatorConfig holds what the authentication methods need.
+type AuthenticatorConfig struct {
+	Methods     []string
+	MTLS        bool
+	JWKSFile    string
+	JWTIssuer   string
+	JWTAudience string
+
+	// JWKSReloadInterval is how often the JWKS file is read again.
+	JWKSReloadInterval time.Duration
+}
+
+// NewAuthenticator chains the authenticators of the given methods. mTLS requires client
+// certificates to be verified by the server (MTLS). The JWKS file is reloaded until ctx is done.
+func NewAuthenticator(ctx context.Context, cfg AuthenticatorConfig) (Authenticator, error) {
+	chain := make(Chain, 0, len(cfg.Methods))
+
+	for _, method := range cfg.Methods {
+		switch method {
+		case MTLSMethod:
+			if !cfg.MTLS {
+				return nil, fmt.Errorf("%w: mtls requires a client CA", app.ErrInvalidAuthConfig)
+			}
+
+			chain = append(chain, MTLSAuthenticator{})
+		case JWTMethod:
+			authenticator, err := NewJWTAuthenticator(cfg.JWKSFile, cfg.JWTIssuer, cfg.JWTAudience)
+			if err != nil {
+				return nil, err
+			}
+
+			go authenticator.Run(ctx, cfg.JWKSReloadInterval)
+
+			chain = append(chain, authenticator)
+		default:
+			return nil, fmt.Errorf("%w: unknown method %s", app.ErrInvalidAuthConfig, method)
+		}
+	}
+
+	return chain, nil
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const internalBufferSize = 1024 * 1024
+
+// InternalListener is an in-process listener for the connection between the gateway and the rpc
+// server. Connections accepted from it skip the transport security and are recognized by
+// MTLSAuthenticator, which then trusts the subject forwarded by the gateway.
+type InternalListener struct {
+	*bufconn.Listener
+}
+
+func NewInternalListener() *InternalListener {
+	return &InternalListener{Listener: bufconn.Listen(internalBufferSize)}
+}
+
+func (l *InternalListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return internalConn{Conn: conn}, nil
+}
+
+// Dial connects to the listener, to be used as the gateway context dialer.
+func (l *InternalListener) Dial(_ context.Context, _ string) (net.Conn, error) {
+	return l.Listener.Dial()
+}
+
+type internalConn struct {
+	net.Conn
+}
+
+type internalAuthInfo struct {
+	credentials.CommonAuthInfo
+}
+
+func (internalAuthInfo) AuthType() string {
+	return "internal"
+}
+
+// serverCredentials uses the given transport security, except for internal connections.
+type serverCredentials struct {
+	credentials.TransportCredentials
+}
+
+// NewServerCredentials returns the rpc server credentials, with TLS when config is set.
+func NewServerCredentials(config *tls.Config) credentials.TransportCredentials {
+	if config == nil {
+		return serverCredentials{TransportCredentials: insecure.NewCredentials()}
+	}
+
+	return serverCredentials{TransportCredentials: credentials.NewTLS(config)}
+}
+
+func (c serverCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if _, ok := conn.(internalConn); ok {
+		return conn, internalAuthInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity}}, nil
+	}
+
+	return c.TransportCredentials.ServerHandshake(conn)
+}
+
+func (c serverCredentials) Clone() credentials.TransportCredentials {
+	return serverCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+const bearerPrefix = "bearer "
+
+// jwtMethods are the signing algorithms accepted, so tokens can't pick a weaker one.
+var jwtMethods = []string{"RS256", "ES256"}
+
+// JWTAuthenticator verifies bearer tokens signed with RS256 or ES256 by one of the keys of a local
+// JWKS file. Tokens must have the sub and exp claims, and match the issuer and audience when set.
+// The file is read again by Reload, so keys can be rotated without a restart.
+type JWTAuthenticator struct {
+	file     string
+	keys     atomic.Value // *keyfunc.JWKS
+	parser   *jwt.Parser
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+func NewJWTAuthenticator(jwksFile, issuer, audience string) (*JWTAuthenticator, error) {
+	if jwksFile == "" {
+		return nil, fmt.Errorf("%w: jwt requires a jwks file", app.ErrInvalidAuthConfig)
+	}
+
+	a := &JWTAuthenticator{
+		file: jwksFile,
+		// The claims are validated against now, which tests can replace.
+		parser:   jwt.NewParser(jwt.WithValidMethods(jwtMethods), jwt.WithoutClaimsValidation()),
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}
+
+	if err := a.Reload(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Reload reads the JWKS file again. The previous keys are kept when it fails.
+func (a *JWTAuthenticator) Reload() error {
+	content, err := os.ReadFile(a.file)
+	if err != nil {
+		return fmt.Errorf("failed to read jwks: %w", err)
+	}
+
+	keys, err := keyfunc.NewJSON(content)
+	if err != nil {
+		return fmt.Errorf("%w: failed to decode jwks: %s", app.ErrInvalidAuthConfig, err)
+	}
+
+	if keys.Len() == 0 {
+		return fmt.Errorf("%w: no keys in %s", app.ErrInvalidAuthConfig, a.file)
+	}
+
+	a.keys.Store(keys)
+
+	return nil
+}
+
+// Run reloads the JWKS file every interval until ctx is done.
+func (a *JWTAuthenticator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.Reload(); err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Str("file", a.file).Msg("failed to reload jwks")
+			}
+		}
+	}
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(strings.ToLower(values[0]), bearerPrefix) {
+		return Principal{}, errNoCredentials
+	}
+
+	claims, err := a.verify(values[0][len(bearerPrefix):])
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %s", app.ErrUnauthenticated, err)
+	}
+
+	return Principal{Subject: claims.Subject, Method: JWTMethod}, nil
+}
+
+var errInvalidToken = errors.New("invalid token")
+
+func (a *JWTAuthenticator) verify(token string) (*jwt.RegisteredClaims, error) {
+	keys := a.keys.Load().(*keyfunc.JWKS)
+
+	var claims jwt.RegisteredClaims
+	if _, err := a.parser.ParseWithClaims(token, &claims, keys.Keyfunc); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidToken, err)
+	}
+
+	return &claims, a.validate(&claims)
+}
+
+func (a *JWTAuthenticator) validate(claims *jwt.RegisteredClaims) error {
+	now := a.now()
+
+	switch {
+	case claims.Subject == "":
+		return fmt.Errorf("%w: missing subject", errInvalidToken)
+	case !claims.VerifyExpiresAt(now, true):
+		return fmt.Errorf("%w: missing or past expiration", errInvalidToken)
+	case !claims.VerifyNotBefore(now, false):
+		return fmt.Errorf("%w: not valid yet", errInvalidToken)
+	case a.issuer != "" && !claims.VerifyIssuer(a.issuer, true):
+		return fmt.Errorf("%w: unexpected issuer", errInvalidToken)
+	case a.audience != "" && !claims.VerifyAudience(a.audience, true):
+		return fmt.Errorf("%w: unexpected audience", errInvalidToken)
+	}
+
+	return nil
+}
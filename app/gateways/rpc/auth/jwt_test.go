@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwksFile := writeJWKS(t, rsaKey, ecKey)
+
+	authenticator, err := NewJWTAuthenticator(jwksFile, "issuer", "ledger")
+	require.NoError(t, err)
+
+	now := time.Now()
+	authenticator.now = func() time.Time { return now }
+
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "svc-payments",
+			"iss": "issuer",
+			"aud": []string{"other", "ledger"},
+			"exp": now.Add(time.Minute).Unix(),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		authorization string
+		expectedErr   error
+	}{
+		{
+			name:          "should authenticate a RS256 token",
+			authorization: "Bearer " + signRS256(t, rsaKey, "rsa", validClaims()),
+		},
+		{
+			name:          "should authenticate a ES256 token",
+			authorization: "Bearer " + signES256(t, ecKey, "ec", validClaims()),
+		},
+		{
+			name:          "should not find credentials without a bearer token",
+			authorization: "Basic abc",
+			expectedErr:   errNoCredentials,
+		},
+		{
+			name:          "should reject a malformed token",
+			authorization: "Bearer abc",
+			expectedErr:   app.ErrUnauthenticated,
+		},
+		{
+			name:          "should reject a token signed by an unknown key",
+			authorization: "Bearer " + signRS256(t, rsaKey, "unknown", validClaims()),
+			expectedErr:   app.ErrUnauthenticated,
+		},
+		{
+			name:          "should reject a token signed by another key",
+			authorization: "Bearer " + signES256(t, mustECKey(t), "ec", validClaims()),
+			expectedErr:   app.ErrUnauthenticated,
+		},
+		{
+			name:          "should reject a token signed with another algorithm",
+			authorization: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte("secret"), "rsa", validClaims()),
+			expectedErr:   app.ErrUnauthenticated,
+		},
+		{
+			name: "should reject an expired token",
+			authorization: func() string {
+				claims := validClaims()
+				claims["exp"] = now.Add(-time.Second).Unix()
+				return "Bearer " + signRS256(t, rsaKey, "rsa", claims)
+			}(),
+			expectedErr: app.ErrUnauthenticated,
+		},
+		{
+			name: "should reject a token not valid yet",
+			authorization: func() string {
+				claims := validClaims()
+				claims["nbf"] = now.Add(time.Minute).Unix()
+				return "Bearer " + signRS256(t, rsaKey, "rsa", claims)
+			}(),
+			expectedErr: app.ErrUnauthenticated,
+		},
+		{
+			name: "should reject a token of another audience",
+			authorization: func() string {
+				claims := validClaims()
+				claims["aud"] = "other"
+				return "Bearer " + signRS256(t, rsaKey, "rsa", claims)
+			}(),
+			expectedErr: app.ErrUnauthenticated,
+		},
+		{
+			name: "should reject a token of another issuer",
+			authorization: func() string {
+				claims := validClaims()
+				claims["iss"] = "other"
+				return "Bearer " + signRS256(t, rsaKey, "rsa", claims)
+			}(),
+			expectedErr: app.ErrUnauthenticated,
+		},
+		{
+			name: "should reject a token without subject",
+			authorization: func() string {
+				claims := validClaims()
+				delete(claims, "sub")
+				return "Bearer " + signRS256(t, rsaKey, "rsa", claims)
+			}(),
+			expectedErr: app.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", tt.authorization))
+
+			principal, err := authenticator.Authenticate(ctx)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, Principal{Subject: "svc-payments", Method: JWTMethod}, principal)
+		})
+	}
+}
+
+func TestNewJWTAuthenticator_InvalidConfig(t *testing.T) {
+	_, err := NewJWTAuthenticator("", "", "")
+	assert.ErrorIs(t, err, app.ErrInvalidAuthConfig)
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"keys":[]}`), 0o600))
+
+	_, err = NewJWTAuthenticator(file, "", "")
+	assert.ErrorIs(t, err, app.ErrInvalidAuthConfig)
+}
+
+func TestJWTAuthenticator_Reload(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwksFile := writeJWKS(t, rsaKey, mustECKey(t))
+
+	authenticator, err := NewJWTAuthenticator(jwksFile, "", "")
+	require.NoError(t, err)
+
+	rotated := mustECKey(t)
+	claims := map[string]interface{}{"sub": "svc-payments", "exp": time.Now().Add(time.Minute).Unix()}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signES256(t, rotated, "ec", claims)))
+
+	_, err = authenticator.Authenticate(ctx)
+	assert.ErrorIs(t, err, app.ErrUnauthenticated)
+
+	storeJWKS(t, jwksFile, rsaKey, rotated)
+	require.NoError(t, authenticator.Reload())
+
+	_, err = authenticator.Authenticate(ctx)
+	assert.NoError(t, err)
+
+	// A file that can't be read keeps the keys loaded before.
+	require.NoError(t, os.WriteFile(jwksFile, []byte("{"), 0o600))
+	assert.ErrorIs(t, authenticator.Reload(), app.ErrInvalidAuthConfig)
+
+	_, err = authenticator.Authenticate(ctx)
+	assert.NoError(t, err)
+}
+
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	storeJWKS(t, file, rsaKey, ecKey)
+
+	return file
+}
+
+func storeJWKS(t *testing.T, file string, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) {
+	t.Helper()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+	set := map[string]interface{}{"keys": []map[string]string{
+		{
+			"kty": "RSA",
+			"kid": "rsa",
+			"n":   encode(rsaKey.N.Bytes()),
+			"e":   encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		{
+			"kty": "EC",
+			"kid": "ec",
+			"crv": "P-256",
+			"x":   encode(ecKey.X.FillBytes(make([]byte, 32))),
+			"y":   encode(ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+	}}
+
+	content, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(file, content, 0o600))
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, jwt.MapClaims(claims))
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	return sign(t, jwt.SigningMethodRS256, key, kid, claims)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	return sign(t, jwt.SigningMethodES256, key, kid, claims)
+}
+
+func mustECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
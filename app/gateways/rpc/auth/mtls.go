@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+// ForwardedSubjectKey carries the subject of the client certificate verified by the gateway. It's
+// only trusted on the internal connection between the gateway and the rpc server.
+const ForwardedSubjectKey = "x-ledger-client-subject"
+
+// MTLSAuthenticator identifies callers by the common name of their verified client certificate.
+type MTLSAuthenticator struct{}
+
+func (MTLSAuthenticator) Authenticate(ctx context.Context) (Principal, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Principal{}, errNoCredentials
+	}
+
+	var subject string
+
+	switch info := p.AuthInfo.(type) {
+	case credentials.TLSInfo:
+		if len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+			return Principal{}, errNoCredentials
+		}
+
+		subject = info.State.VerifiedChains[0][0].Subject.CommonName
+	case internalAuthInfo:
+		md, _ := metadata.FromIncomingContext(ctx)
+		subjects := md.Get(ForwardedSubjectKey)
+		if len(subjects) == 0 {
+			return Principal{}, errNoCredentials
+		}
+
+		if len(subjects) > 1 {
+			return Principal{}, fmt.Errorf("%w: many forwarded subjects", app.ErrUnauthenticated)
+		}
+
+		subject = subjects[0]
+	default:
+		return Principal{}, errNoCredentials
+	}
+
+	if subject == "" {
+		return Principal{}, fmt.Errorf("%w: client certificate without common name", app.ErrUnauthenticated)
+	}
+
+	return Principal{Subject: subject, Method: MTLSMethod}, nil
+}
+
+// ForwardClientCertificate is a gateway annotator that forwards the subject of the verified client
+// certificate of the http request to the rpc server.
+func ForwardClientCertificate(_ context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return metadata.Pairs(ForwardedSubjectKey, r.TLS.VerifiedChains[0][0].Subject.CommonName)
+}
+
+// IncomingHeaderMatcher is the default gateway header matcher, except that http clients can't set
+// the forwarded subject themselves.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, runtime.MetadataHeaderPrefix+ForwardedSubjectKey) {
+		return "", false
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// LoadServerTLS loads the certificate of the servers. When clientCAFile is set, client certificates
+// are verified against it, but they're still optional so callers can use other methods.
+func LoadServerTLS(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, fmt.Errorf("%w: client CA requires a server certificate", app.ErrInvalidAuthConfig)
+		}
+
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		var pem []byte
+		if pem, err = os.ReadFile(clientCAFile); err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificates in %s", app.ErrInvalidAuthConfig, clientCAFile)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return config, nil
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestMTLSAuthenticator_Authenticate(t *testing.T) {
+	verified := tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "payments"}}}},
+	}
+
+	testCases := []struct {
+		name            string
+		ctx             context.Context
+		expectedSubject string
+		expectedErr     error
+	}{
+		{
+			name:            "should authenticate a verified client certificate",
+			ctx:             peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: verified}}),
+			expectedSubject: "payments",
+		},
+		{
+			name:        "should not find credentials without client certificate",
+			ctx:         peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}}),
+			expectedErr: errNoCredentials,
+		},
+		{
+			name: "should authenticate the subject forwarded by the gateway",
+			ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{AuthInfo: internalAuthInfo{}}),
+				metadata.Pairs(ForwardedSubjectKey, "payments"),
+			),
+			expectedSubject: "payments",
+		},
+		{
+			name: "should reject many forwarded subjects",
+			ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{AuthInfo: internalAuthInfo{}}),
+				metadata.Pairs(ForwardedSubjectKey, "payments", ForwardedSubjectKey, "operator"),
+			),
+			expectedErr: app.ErrUnauthenticated,
+		},
+		{
+			name: "should ignore forwarded subjects from external connections",
+			ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}}),
+				metadata.Pairs(ForwardedSubjectKey, "payments"),
+			),
+			expectedErr: errNoCredentials,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := MTLSAuthenticator{}.Authenticate(tt.ctx)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, Principal{Subject: tt.expectedSubject, Method: MTLSMethod}, principal)
+		})
+	}
+}
+
+func TestForwardClientCertificate(t *testing.T) {
+	r := &http.Request{TLS: &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "payments"}}}},
+	}}
+
+	assert.Equal(t, []string{"payments"}, ForwardClientCertificate(context.Background(), r).Get(ForwardedSubjectKey))
+	assert.Nil(t, ForwardClientCertificate(context.Background(), &http.Request{}))
+}
+
+func TestIncomingHeaderMatcher(t *testing.T) {
+	_, ok := IncomingHeaderMatcher("Grpc-Metadata-X-Ledger-Client-Subject")
+	assert.False(t, ok)
+
+	key, ok := IncomingHeaderMatcher("Authorization")
+	assert.True(t, ok)
+	assert.Equal(t, "grpcgateway-Authorization", key)
+}
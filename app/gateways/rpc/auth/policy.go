@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// Wildcard grants every book, company or account.
+const Wildcard = "*"
+
+type Action int
+
+const (
+	ReadAction Action = iota + 1
+	PostAction
+	AdminAction
+)
+
+// Grant binds a principal to what it may do in a book, the default one when Book is empty. Accounts
+// are given as path prefixes, matched by whole labels: "liability.clients" grants
+// "liability.clients.abc" but not "liability.clients_abc". Admin access isn't bound to books.
+type Grant struct {
+	Subject   string   `json:"subject"`
+	Book      string   `json:"book"`
+	Admin     bool     `json:"admin"`
+	Companies []string `json:"companies"`
+	Read      []string `json:"read"`
+	Post      []string `json:"post"`
+}
+
+// Access describes what a call needs. An empty Book is the default one.
+type Access struct {
+	Action    Action
+	Book      string
+	Companies []string
+	Accounts  []string
+	// CompanyScoped calls return data of the given companies only, so principals bound to some
+	// companies must name them.
+	CompanyScoped bool
+}
+
+// Policy authorizes principals by their grants. Principals without a grant can't do anything, and a
+// principal may have a grant per book.
+type Policy struct {
+	grants map[string][]Grant
+}
+
+func NewPolicy(grants ...Grant) *Policy {
+	p := &Policy{grants: make(map[string][]Grant, len(grants))}
+	for _, grant := range grants {
+		p.grants[grant.Subject] = append(p.grants[grant.Subject], grant)
+	}
+
+	return p
+}
+
+// LoadPolicy reads a policy file, a json object with the list of grants: {"grants": [...]}.
+func LoadPolicy(file string) (*Policy, error) {
+	if file == "" {
+		return nil, fmt.Errorf("%w: missing policy file", app.ErrInvalidAuthConfig)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var policy struct {
+		Grants []Grant `json:"grants"`
+	}
+
+	if err = json.Unmarshal(content, &policy); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode policy: %s", app.ErrInvalidAuthConfig, err)
+	}
+
+	return NewPolicy(policy.Grants...), nil
+}
+
+func (p *Policy) Authorize(principal Principal, access Access) error {
+	grants, ok := p.grants[principal.Subject]
+	if !ok {
+		return fmt.Errorf("%w: no grants for %s", app.ErrPermissionDenied, principal.Subject)
+	}
+
+	if access.Action == AdminAction {
+		for _, grant := range grants {
+			if grant.Admin {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: admin access required", app.ErrPermissionDenied)
+	}
+
+	book := bookOf(access.Book)
+	err := fmt.Errorf("%w: no grants for %s in book %s", app.ErrPermissionDenied, principal.Subject, book)
+
+	for _, grant := range grants {
+		if grant.Book != Wildcard && bookOf(grant.Book) != book {
+			continue
+		}
+
+		if err = grant.authorize(access); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (g Grant) authorize(access Access) error {
+	var prefixes []string
+
+	switch access.Action {
+	case ReadAction:
+		prefixes = g.Read
+	case PostAction:
+		prefixes = g.Post
+	default:
+		return fmt.Errorf("%w: unknown action", app.ErrPermissionDenied)
+	}
+
+	if access.CompanyScoped && len(access.Companies) == 0 && !contains(g.Companies, Wildcard) {
+		return fmt.Errorf("%w: companies must be given", app.ErrPermissionDenied)
+	}
+
+	for _, company := range access.Companies {
+		if !contains(g.Companies, Wildcard) && !contains(g.Companies, company) {
+			return fmt.Errorf("%w: company %s", app.ErrPermissionDenied, company)
+		}
+	}
+
+	for _, account := range access.Accounts {
+		if !allowsAccount(prefixes, account) {
+			return fmt.Errorf("%w: account %s", app.ErrPermissionDenied, account)
+		}
+	}
+
+	return nil
+}
+
+// bookOf returns the book of a grant or access, where an empty name is the default book.
+func bookOf(name string) string {
+	if name == "" {
+		return vos.DefaultBook
+	}
+
+	return name
+}
+
+// allowsAccount reports whether the account is under one of the prefixes. Synthetic accounts are
+// checked by their labels before the first wildcard, as they may match anything below them.
+func allowsAccount(prefixes []string, account string) bool {
+	labels := strings.Split(account, ".")
+	for i, label := range labels {
+		if strings.Contains(label, Wildcard) {
+			labels = labels[:i]
+			break
+		}
+	}
+
+	for _, prefix := range prefixes {
+		if prefix == Wildcard {
+			return true
+		}
+
+		prefixLabels := strings.Split(prefix, ".")
+		if len(prefixLabels) > len(labels) {
+			continue
+		}
+
+		matches := true
+		for i, label := range prefixLabels {
+			if labels[i] != label {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
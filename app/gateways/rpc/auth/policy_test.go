@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	policy := NewPolicy(
+		Grant{
+			Subject:   "payments",
+			Companies: []string{"abc"},
+			Read:      []string{"liability.clients", "asset.bank"},
+			Post:      []string{"liability.clients"},
+		},
+		Grant{
+			Subject:   "auditor",
+			Companies: []string{Wildcard},
+			Read:      []string{Wildcard},
+		},
+		Grant{
+			Subject:   "payments",
+			Book:      "cards",
+			Companies: []string{"abc"},
+			Read:      []string{"asset.cards"},
+		},
+		Grant{
+			Subject:   "auditor",
+			Book:      Wildcard,
+			Companies: []string{Wildcard},
+			Read:      []string{"asset"},
+		},
+		Grant{
+			Subject: "operator",
+			Admin:   true,
+		},
+	)
+
+	payments := Principal{Subject: "payments"}
+	auditor := Principal{Subject: "auditor"}
+	operator := Principal{Subject: "operator"}
+
+	testCases := []struct {
+		name        string
+		principal   Principal
+		access      Access
+		expectedErr error
+	}{
+		{
+			name:      "should allow posting under a granted prefix",
+			principal: payments,
+			access: Access{
+				Action:    PostAction,
+				Companies: []string{"abc"},
+				Accounts:  []string{"liability.clients.available.111", "liability.clients.blocked.222"},
+			},
+		},
+		{
+			name:      "should deny posting when one account is not granted",
+			principal: payments,
+			access: Access{
+				Action:    PostAction,
+				Companies: []string{"abc"},
+				Accounts:  []string{"liability.clients.available.111", "asset.bank.account.222"},
+			},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:      "should deny posting to another company",
+			principal: payments,
+			access: Access{
+				Action:    PostAction,
+				Companies: []string{"xyz"},
+				Accounts:  []string{"liability.clients.available.111"},
+			},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:        "should match prefixes by whole labels",
+			principal:   payments,
+			access:      Access{Action: ReadAction, Accounts: []string{"liability.clients_other.available.111"}},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:      "should allow reading a synthetic account under a granted prefix",
+			principal: payments,
+			access:    Access{Action: ReadAction, Accounts: []string{"liability.clients.*"}},
+		},
+		{
+			name:        "should deny reading a synthetic account wider than the grant",
+			principal:   payments,
+			access:      Access{Action: ReadAction, Accounts: []string{"liability.*"}},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:        "should deny company scoped reads without companies",
+			principal:   payments,
+			access:      Access{Action: ReadAction, Accounts: []string{"liability.clients.available.111"}, CompanyScoped: true},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:      "should allow company scoped reads of every company with a wildcard",
+			principal: auditor,
+			access:    Access{Action: ReadAction, Accounts: []string{"asset.*"}, CompanyScoped: true},
+		},
+		{
+			name:        "should deny posting without post grants",
+			principal:   auditor,
+			access:      Access{Action: PostAction, Companies: []string{"abc"}, Accounts: []string{"asset.bank.account.222"}},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:        "should deny admin calls to non admins",
+			principal:   auditor,
+			access:      Access{Action: AdminAction},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:      "should allow admin calls to admins",
+			principal: operator,
+			access:    Access{Action: AdminAction},
+		},
+		{
+			name:      "should allow the default book by name",
+			principal: payments,
+			access:    Access{Action: ReadAction, Book: "default", Accounts: []string{"asset.bank.account.111"}},
+		},
+		{
+			name:        "should deny accounts granted in another book",
+			principal:   payments,
+			access:      Access{Action: ReadAction, Book: "cards", Accounts: []string{"asset.bank.account.111"}},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:      "should allow accounts granted in the book",
+			principal: payments,
+			access:    Access{Action: ReadAction, Book: "cards", Accounts: []string{"asset.cards.issued.111"}},
+		},
+		{
+			name:        "should deny books without grants",
+			principal:   payments,
+			access:      Access{Action: ReadAction, Book: "loans", Accounts: []string{"liability.clients.available.111"}},
+			expectedErr: app.ErrPermissionDenied,
+		},
+		{
+			name:      "should allow every book with a wildcard",
+			principal: auditor,
+			access:    Access{Action: ReadAction, Book: "loans", Accounts: []string{"asset.loans.*"}},
+		},
+		{
+			name:        "should deny principals without grants",
+			principal:   Principal{Subject: "unknown"},
+			access:      Access{Action: ReadAction, Accounts: []string{"liability.clients.available.111"}},
+			expectedErr: app.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.principal, tt.access)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.json")
+	content := `{"grants":[{"subject":"payments","companies":["abc"],"read":["liability"],"post":["liability.clients"]}]}`
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	policy, err := LoadPolicy(file)
+	require.NoError(t, err)
+
+	err = policy.Authorize(Principal{Subject: "payments"}, Access{
+		Action:    PostAction,
+		Companies: []string{"abc"},
+		Accounts:  []string{"liability.clients.available.111"},
+	})
+	assert.NoError(t, err)
+
+	_, err = LoadPolicy("")
+	assert.ErrorIs(t, err, app.ErrInvalidAuthConfig)
+}
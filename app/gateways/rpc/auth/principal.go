@@ -0,0 +1,41 @@
+// Package auth authenticates the callers of the rpc server and authorizes them against a policy
+// that binds each principal to the companies and accounts it may read or post to.
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// Authentication methods accepted by NewAuthenticator.
+const (
+	MTLSMethod = "mtls"
+	JWTMethod  = "jwt"
+)
+
+// errNoCredentials is returned by an Authenticator when the call has no credentials of its kind,
+// so the next one can be tried.
+var errNoCredentials = errors.New("no credentials")
+
+// Principal is an authenticated caller.
+type Principal struct {
+	Subject string
+	Method  string
+}
+
+// Authenticator identifies the caller of a gRPC call.
+type Authenticator interface {
+	Authenticate(context.Context) (Principal, error)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+
+	return principal, ok
+}
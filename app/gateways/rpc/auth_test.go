@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/gateways/rpc/auth"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+type authenticatorFunc func(context.Context) (auth.Principal, error)
+
+func (f authenticatorFunc) Authenticate(ctx context.Context) (auth.Principal, error) {
+	return f(ctx)
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	policy := auth.NewPolicy(auth.Grant{
+		Subject:   "payments",
+		Companies: []string{"abc"},
+		Read:      []string{"liability.clients"},
+		Post:      []string{"liability.clients"},
+	})
+
+	payments := authenticatorFunc(func(context.Context) (auth.Principal, error) {
+		return auth.Principal{Subject: "payments", Method: auth.JWTMethod}, nil
+	})
+	anonymous := authenticatorFunc(func(context.Context) (auth.Principal, error) {
+		return auth.Principal{}, app.ErrUnauthenticated
+	})
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		principal, _ := auth.PrincipalFromContext(ctx)
+		return principal.Subject, nil
+	}
+
+	testCases := []struct {
+		name          string
+		authenticator auth.Authenticator
+		method        string
+		request       interface{}
+		expectedCode  codes.Code
+		expectedReply interface{}
+	}{
+		{
+			name:          "should call the handler with the principal when authorized",
+			authenticator: payments,
+			method:        "/ledger.LedgerService/GetAccountBalance",
+			request:       &proto.GetAccountBalanceRequest{Account: "liability.clients.available.111"},
+			expectedCode:  codes.OK,
+			expectedReply: "payments",
+		},
+		{
+			name:          "should not authenticate health checks",
+			authenticator: anonymous,
+			method:        "/ledger.Health/Check",
+			request:       &emptypb.Empty{},
+			expectedCode:  codes.OK,
+			expectedReply: "",
+		},
+		{
+			name:          "should return unauthenticated when authentication fails",
+			authenticator: anonymous,
+			method:        "/ledger.LedgerService/GetAccountBalance",
+			request:       &proto.GetAccountBalanceRequest{Account: "liability.clients.available.111"},
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:          "should return permission denied for accounts not granted",
+			authenticator: payments,
+			method:        "/ledger.LedgerService/GetAccountBalance",
+			request:       &proto.GetAccountBalanceRequest{Account: "asset.bank.account.111"},
+			expectedCode:  codes.PermissionDenied,
+		},
+		{
+			name:          "should return permission denied for accounts granted in another book",
+			authenticator: payments,
+			method:        "/ledger.LedgerService/GetAccountBalance",
+			request:       &proto.GetAccountBalanceRequest{Account: "liability.clients.available.111", Book: "cards"},
+			expectedCode:  codes.PermissionDenied,
+		},
+		{
+			name:          "should return permission denied for companies not granted",
+			authenticator: payments,
+			method:        "/ledger.LedgerService/ListAccountEntries",
+			request: &proto.ListAccountEntriesRequest{
+				Account: "liability.clients.available.111",
+				Filter:  &proto.ListAccountEntriesRequest_Filter{Companies: []string{"xyz"}},
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:          "should authorize account listings by their pattern",
+			authenticator: payments,
+			method:        "/ledger.LedgerService/ListAccounts",
+			request:       &proto.ListAccountsRequest{Pattern: "liability.clients.*"},
+			expectedCode:  codes.OK,
+			expectedReply: "payments",
+		},
+		{
+			name:          "should return permission denied for children above the granted accounts",
+			authenticator: payments,
+			method:        "/ledger.LedgerService/ListAccountChildren",
+			request:       &proto.ListAccountChildrenRequest{Prefix: "liability"},
+			expectedCode:  codes.PermissionDenied,
+		},
+		{
+			name:          "should return permission denied for admin calls",
+			authenticator: payments,
+			method:        "/ledger.AdminService/RebuildSnapshots",
+			request:       &proto.RebuildSnapshotsRequest{},
+			expectedCode:  codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := authInterceptor(tt.authenticator, policy)
+
+			got, err := interceptor(context.Background(), tt.request, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedReply, got)
+		})
+	}
+}
+
+func TestAccessFor(t *testing.T) {
+	request := &proto.CreateTransactionRequest{
+		Book:    "cards",
+		Company: "abc",
+		Entries: []*proto.Entry{
+			{Account: "liability.clients.available.111"},
+			{Account: "liability.clients.available.222"},
+		},
+	}
+
+	assert.Equal(t, auth.Access{
+		Action:    auth.PostAction,
+		Book:      "cards",
+		Companies: []string{"abc"},
+		Accounts:  []string{"liability.clients.available.111", "liability.clients.available.222"},
+	}, accessFor("/ledger.LedgerService/CreateTransaction", request))
+
+	assert.Equal(t, auth.Access{
+		Action:    auth.PostAction,
+		Book:      "cards",
+		Companies: []string{"abc"},
+	}, accessFor("/ledger.LedgerService/PostEvent", &proto.PostEventRequest{Book: "cards", Company: "abc"}))
+
+	assert.Equal(t, auth.Access{Action: auth.AdminAction}, accessFor("/ledger.LedgerService/Unknown", nil))
+}
+
+func TestAuthorize(t *testing.T) {
+	access := auth.Access{Action: auth.PostAction, Companies: []string{"abc"}, Accounts: []string{"asset.bank.account.111"}}
+
+	// Without the interceptor, authentication is disabled.
+	assert.NoError(t, authorize(context.Background(), access))
+
+	policy := auth.NewPolicy(auth.Grant{
+		Subject:   "payments",
+		Companies: []string{"abc"},
+		Post:      []string{"liability.clients"},
+	})
+	payments := authenticatorFunc(func(context.Context) (auth.Principal, error) {
+		return auth.Principal{Subject: "payments", Method: auth.JWTMethod}, nil
+	})
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		granted := authorize(ctx, auth.Access{Action: auth.PostAction, Companies: []string{"abc"}, Accounts: []string{"liability.clients.available.111"}})
+		assert.NoError(t, granted)
+
+		return nil, authorize(ctx, access)
+	}
+
+	interceptor := authInterceptor(payments, policy)
+
+	_, err := interceptor(context.Background(), &proto.PostEventRequest{Company: "abc"}, &grpc.UnaryServerInfo{FullMethod: "/ledger.LedgerService/PostEvent"}, handler)
+	assert.ErrorIs(t, err, app.ErrPermissionDenied)
+}
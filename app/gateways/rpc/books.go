@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func (a *API) CreateBook(ctx context.Context, request *proto.CreateBookRequest) (*proto.Book, error) {
+	book, err := a.AdminUseCase.CreateBook(ctx, request.Name)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to create book")
+		if errors.Is(err, app.ErrInvalidBook) {
+			return nil, errorStatus(err, fieldViolation("name", err.Error()))
+		}
+
+		return nil, errorStatus(err)
+	}
+
+	return bookToProto(book), nil
+}
+
+func (a *API) ListBooks(ctx context.Context, _ *emptypb.Empty) (*proto.ListBooksResponse, error) {
+	books, err := a.AdminUseCase.ListBooks(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list books")
+		return nil, errorStatus(err)
+	}
+
+	protoBooks := make([]*proto.Book, 0, len(books))
+	for _, book := range books {
+		protoBooks = append(protoBooks, bookToProto(book))
+	}
+
+	return &proto.ListBooksResponse{
+		Books: protoBooks,
+	}, nil
+}
+
+func bookToProto(book vos.Book) *proto.Book {
+	return &proto.Book{
+		Name:      book.Name,
+		CreatedAt: timestamppb.New(book.CreatedAt),
+	}
+}
+
+// withBook scopes ctx to the book of the request, which is the default book when empty.
+func withBook(ctx context.Context, name string) (context.Context, error) {
+	book, err := vos.NewBookName(name)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid book")
+		return nil, errorStatus(err, fieldViolation("book", err.Error()))
+	}
+
+	return vos.WithBook(ctx, book), nil
+}
@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_CreateBook(t *testing.T) {
+	createdAt := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		useCaseErr   error
+		expectedCode codes.Code
+		expected     *proto.Book
+	}{
+		{
+			name:     "should create a book successfully",
+			expected: &proto.Book{Name: "banking", CreatedAt: timestamppb.New(createdAt)},
+		},
+		{
+			name:         "should return invalid argument for an invalid name",
+			useCaseErr:   fmt.Errorf("failed to create book Banking: %w", app.ErrInvalidBook),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "should return already exists for a duplicated name",
+			useCaseErr:   fmt.Errorf("failed to create book banking: %w", app.ErrBookAlreadyExists),
+			expectedCode: codes.AlreadyExists,
+		},
+		{
+			name:         "should return internal error when the creation fails",
+			useCaseErr:   errors.New("database unavailable"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedAdminUsecase := &mocks.AdminUseCaseMock{
+				CreateBookFunc: func(ctx context.Context, name string) (vos.Book, error) {
+					return vos.Book{Name: name, CreatedAt: createdAt}, tt.useCaseErr
+				},
+			}
+			api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+			got, err := api.CreateBook(context.Background(), &proto.CreateBookRequest{Name: "banking"})
+			if tt.expected != nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, got)
+				return
+			}
+
+			respStatus, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedCode, respStatus.Code())
+		})
+	}
+}
+
+func TestWithBook(t *testing.T) {
+	ctx, err := withBook(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, vos.DefaultBook, vos.BookFromContext(ctx))
+
+	ctx, err = withBook(context.Background(), "banking")
+	assert.NoError(t, err)
+	assert.Equal(t, "banking", vos.BookFromContext(ctx))
+
+	_, err = withBook(context.Background(), "Banking")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
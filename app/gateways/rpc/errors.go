@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// errorDomain is the domain of the ErrorInfo details, which scopes their reasons to the ledger.
+const errorDomain = "the-amazing-ledger"
+
+// invalidArgumentReason is the reason of the invalid arguments that aren't described by a domain
+// error, such as missing fields.
+const invalidArgumentReason = "INVALID_ARGUMENT"
+
+const internalErrorMessage = "internal server error"
+
+// statusCodes maps domain errors to the code of their status. Domain errors missing here are invalid
+// arguments, since most of them come from validating requests.
+var statusCodes = map[app.DomainError]codes.Code{
+	app.ErrIdempotencyKeyViolation:  codes.AlreadyExists,
+	app.ErrInvalidVersion:           codes.Aborted,
+	app.ErrAccountNotFound:          codes.NotFound,
+	app.ErrPartitionNotFound:        codes.NotFound,
+	app.ErrBookNotFound:             codes.NotFound,
+	app.ErrEventNotFound:            codes.NotFound,
+	app.ErrEventSchemaNotFound:      codes.NotFound,
+	app.ErrPostingTemplateNotFound:  codes.NotFound,
+	app.ErrScheduleNotFound:         codes.NotFound,
+	app.ErrAccrualRuleNotFound:      codes.NotFound,
+	app.ErrInvalidScheduleStatus:    codes.FailedPrecondition,
+	app.ErrScheduleConflict:         codes.Aborted,
+	app.ErrInvariantCheckInProgress: codes.Aborted,
+	app.ErrBookAlreadyExists:        codes.AlreadyExists,
+	app.ErrUnauthenticated:          codes.Unauthenticated,
+	app.ErrPermissionDenied:         codes.PermissionDenied,
+	app.ErrStaleBalances:            codes.Unavailable,
+	app.ErrInvalidBalanceStrategy:   codes.Internal,
+	app.ErrInvalidAuthConfig:        codes.Internal,
+	app.ErrInvalidTracer:            codes.Internal,
+	app.ErrInvalidChart:             codes.Internal,
+	app.ErrInvalidPostingTemplates:  codes.Internal,
+}
+
+// entryFields are the fields of an entry that the domain errors of entities.NewEntry are about. The
+// other errors come from parsing the account.
+var entryFields = map[app.DomainError]string{
+	app.ErrInvalidEntryID:   "id",
+	app.ErrInvalidOperation: "operation",
+	app.ErrInvalidAmount:    "amount",
+}
+
+// errorStatus converts err into a status error. Domain errors keep their message, carry their code as
+// the reason of an ErrorInfo and the given field violations in a BadRequest. Any other error is
+// internal, and its message is not exposed.
+func errorStatus(err error, violations ...*errdetails.BadRequest_FieldViolation) error {
+	var domainErr app.DomainError
+	if !errors.As(err, &domainErr) {
+		return status.Error(codes.Internal, internalErrorMessage)
+	}
+
+	code, ok := statusCodes[domainErr]
+	if !ok {
+		code = codes.InvalidArgument
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason: domainErr.Code(),
+		Domain: errorDomain,
+	}
+
+	var conflict vos.VersionConflictError
+	if errors.As(err, &conflict) {
+		info.Metadata = map[string]string{
+			"account":         conflict.Account,
+			"current_version": strconv.FormatInt(conflict.CurrentVersion.AsInt64(), 10),
+		}
+	}
+
+	var schemaErr vos.MetadataViolationError
+	if errors.As(err, &schemaErr) {
+		info.Metadata = map[string]string{
+			"event":          strconv.FormatUint(uint64(schemaErr.Event), 10),
+			"schema_version": strconv.Itoa(schemaErr.Version),
+		}
+	}
+
+	return withDetails(status.New(code, err.Error()), info, violations)
+}
+
+// invalidArgument is the status error of a field that failed a validation not described by a domain
+// error.
+func invalidArgument(field, description string) error {
+	info := &errdetails.ErrorInfo{
+		Reason: invalidArgumentReason,
+		Domain: errorDomain,
+	}
+
+	return withDetails(status.New(codes.InvalidArgument, description), info, []*errdetails.BadRequest_FieldViolation{
+		fieldViolation(field, description),
+	})
+}
+
+func fieldViolation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	}
+}
+
+// entryField is the path of a field of the entry at index, as in entries[3].account.
+func entryField(index int, field string) string {
+	return fmt.Sprintf("entries[%d].%s", index, field)
+}
+
+// entryViolation is the field violation of an error returned by entities.NewEntry for the entry at
+// index.
+func entryViolation(index int, err error) *errdetails.BadRequest_FieldViolation {
+	field := "account"
+
+	var domainErr app.DomainError
+	if errors.As(err, &domainErr) {
+		if f, ok := entryFields[domainErr]; ok {
+			field = f
+		}
+	}
+
+	return fieldViolation(entryField(index, field), err.Error())
+}
+
+func withDetails(st *status.Status, info *errdetails.ErrorInfo, violations []*errdetails.BadRequest_FieldViolation) error {
+	var (
+		detailed *status.Status
+		err      error
+	)
+
+	if len(violations) == 0 {
+		detailed, err = st.WithDetails(info)
+	} else {
+		detailed, err = st.WithDetails(info, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,237 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	"github.com/stone-co/the-amazing-ledger/app/tests/testdata"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		violations     []*errdetails.BadRequest_FieldViolation
+		expectedCode   codes.Code
+		expectedMsg    string
+		expectedReason string
+	}{
+		{
+			name:           "should map a domain error to its status code",
+			err:            app.ErrBookNotFound,
+			expectedCode:   codes.NotFound,
+			expectedMsg:    app.ErrBookNotFound.Error(),
+			expectedReason: "BOOK_NOT_FOUND",
+		},
+		{
+			name:           "should map a wrapped domain error",
+			err:            fmt.Errorf("failed to get account balance: %w", app.ErrAccountNotFound),
+			expectedCode:   codes.NotFound,
+			expectedMsg:    "failed to get account balance: account not found",
+			expectedReason: "ACCOUNT_NOT_FOUND",
+		},
+		{
+			name:           "should default domain errors to invalid arguments",
+			err:            app.ErrInvalidAmount,
+			violations:     []*errdetails.BadRequest_FieldViolation{fieldViolation("entries[3].amount", app.ErrInvalidAmount.Error())},
+			expectedCode:   codes.InvalidArgument,
+			expectedMsg:    app.ErrInvalidAmount.Error(),
+			expectedReason: "INVALID_AMOUNT",
+		},
+		{
+			name:         "should hide errors that are not domain errors",
+			err:          errors.New("connection refused"),
+			expectedCode: codes.Internal,
+			expectedMsg:  internalErrorMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(errorStatus(tt.err, tt.violations...))
+
+			assert.Equal(t, tt.expectedCode, st.Code())
+			assert.Equal(t, tt.expectedMsg, st.Message())
+
+			info, badRequest := statusDetails(t, st)
+			if tt.expectedReason == "" {
+				assert.Nil(t, info)
+				return
+			}
+
+			require.NotNil(t, info)
+			assert.Equal(t, tt.expectedReason, info.Reason)
+			assert.Equal(t, errorDomain, info.Domain)
+
+			if len(tt.violations) == 0 {
+				assert.Nil(t, badRequest)
+			} else {
+				require.NotNil(t, badRequest)
+				assert.Equal(t, tt.violations[0].Field, badRequest.FieldViolations[0].Field)
+			}
+		})
+	}
+}
+
+func TestInvalidArgument(t *testing.T) {
+	st := status.Convert(invalidArgument("competence_date", "competence_date must have a value"))
+
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "competence_date must have a value", st.Message())
+
+	info, badRequest := statusDetails(t, st)
+	require.NotNil(t, info)
+	assert.Equal(t, invalidArgumentReason, info.Reason)
+	require.NotNil(t, badRequest)
+	assert.Equal(t, "competence_date", badRequest.FieldViolations[0].Field)
+}
+
+func TestAPI_CreateTransaction_ErrorDetails(t *testing.T) {
+	debitAccount := testdata.GenerateAccountPath()
+	creditAccount := testdata.GenerateAccountPath()
+
+	newRequest := func() *proto.CreateTransactionRequest {
+		return &proto.CreateTransactionRequest{
+			Id: uuid.New().String(),
+			Entries: []*proto.Entry{
+				{
+					Id:              uuid.New().String(),
+					Account:         debitAccount,
+					ExpectedVersion: vos.IgnoreAccountVersion.AsInt64(),
+					Operation:       proto.Operation_OPERATION_DEBIT,
+					Amount:          123,
+				},
+				{
+					Id:              uuid.New().String(),
+					Account:         creditAccount,
+					ExpectedVersion: 3,
+					Operation:       proto.Operation_OPERATION_CREDIT,
+					Amount:          123,
+				},
+			},
+			Company:        "abc",
+			Event:          1,
+			CompetenceDate: timestamppb.Now(),
+		}
+	}
+
+	t.Run("should point invalid entries to their fields", func(t *testing.T) {
+		request := newRequest()
+		request.Entries[1].Amount = 0
+
+		api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{})
+		_, err := api.CreateTransaction(context.Background(), request)
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		info, badRequest := statusDetails(t, st)
+		require.NotNil(t, info)
+		assert.Equal(t, app.ErrInvalidAmount.Code(), info.Reason)
+		require.NotNil(t, badRequest)
+		assert.Equal(t, "entries[1].amount", badRequest.FieldViolations[0].Field)
+	})
+
+	t.Run("should carry the current version on version conflicts", func(t *testing.T) {
+		useCase := &mocks.UseCaseMock{
+			CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+				return vos.TransactionResult{}, vos.VersionConflictError{Account: creditAccount, CurrentVersion: 5}
+			},
+		}
+
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+		_, err := api.CreateTransaction(context.Background(), newRequest())
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.Aborted, st.Code())
+
+		info, badRequest := statusDetails(t, st)
+		require.NotNil(t, info)
+		assert.Equal(t, "INVALID_VERSION", info.Reason)
+		assert.Equal(t, map[string]string{"account": creditAccount, "current_version": "5"}, info.Metadata)
+		require.NotNil(t, badRequest)
+		require.Len(t, badRequest.FieldViolations, 1)
+		assert.Equal(t, "entries[1].expected_version", badRequest.FieldViolations[0].Field)
+	})
+
+	t.Run("should map idempotency violations to already exists", func(t *testing.T) {
+		useCase := &mocks.UseCaseMock{
+			CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+				return vos.TransactionResult{}, app.ErrIdempotencyKeyViolation
+			},
+		}
+
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+		_, err := api.CreateTransaction(context.Background(), newRequest())
+
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("should point schema violations to the metadata of their entries", func(t *testing.T) {
+		request := newRequest()
+
+		useCase := &mocks.UseCaseMock{
+			CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+				return vos.TransactionResult{}, vos.MetadataViolationError{
+					Event:   1,
+					Version: 2,
+					Violations: []vos.MetadataViolation{{
+						EntryID: uuid.MustParse(request.Entries[1].Id),
+						Account: creditAccount,
+						Errors:  []string{"(root): order_id is required", "channel: must be a string"},
+					}},
+				}
+			},
+		}
+
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+		_, err := api.CreateTransaction(context.Background(), request)
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		info, badRequest := statusDetails(t, st)
+		require.NotNil(t, info)
+		assert.Equal(t, "METADATA_SCHEMA_VIOLATION", info.Reason)
+		assert.Equal(t, map[string]string{"event": "1", "schema_version": "2"}, info.Metadata)
+		require.NotNil(t, badRequest)
+		require.Len(t, badRequest.FieldViolations, 1)
+		assert.Equal(t, "entries[1].metadata", badRequest.FieldViolations[0].Field)
+		assert.Equal(t, "(root): order_id is required; channel: must be a string", badRequest.FieldViolations[0].Description)
+	})
+}
+
+func statusDetails(t *testing.T, st *status.Status) (*errdetails.ErrorInfo, *errdetails.BadRequest) {
+	t.Helper()
+
+	var (
+		info       *errdetails.ErrorInfo
+		badRequest *errdetails.BadRequest
+	)
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+
+	return info, badRequest
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func (a *API) CreateEventSchema(ctx context.Context, request *proto.CreateEventSchemaRequest) (*proto.EventSchema, error) {
+	if request.Schema == nil {
+		return nil, invalidArgument("schema", "schema must have a value")
+	}
+
+	schema, err := request.Schema.MarshalJSON()
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to marshal event schema")
+		return nil, invalidArgument("schema", "invalid schema")
+	}
+
+	eventSchema, err := a.AdminUseCase.CreateEventSchema(ctx, request.Event, schema)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to create event schema")
+		if errors.Is(err, app.ErrInvalidMetadataSchema) {
+			return nil, errorStatus(err, fieldViolation("schema", err.Error()))
+		}
+
+		return nil, errorStatus(err)
+	}
+
+	return eventSchemaToProto(eventSchema)
+}
+
+func (a *API) ListEventSchemas(ctx context.Context, request *proto.ListEventSchemasRequest) (*proto.ListEventSchemasResponse, error) {
+	schemas, err := a.AdminUseCase.ListEventSchemas(ctx, request.Event)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list event schemas")
+		return nil, errorStatus(err)
+	}
+
+	protoSchemas := make([]*proto.EventSchema, 0, len(schemas))
+	for _, schema := range schemas {
+		protoSchema, err := eventSchemaToProto(schema)
+		if err != nil {
+			return nil, err
+		}
+
+		protoSchemas = append(protoSchemas, protoSchema)
+	}
+
+	return &proto.ListEventSchemasResponse{
+		Schemas: protoSchemas,
+	}, nil
+}
+
+func eventSchemaToProto(eventSchema vos.EventSchema) (*proto.EventSchema, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(eventSchema.Schema, &document); err != nil {
+		return nil, errorStatus(err)
+	}
+
+	schema, err := structpb.NewStruct(document)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+
+	return &proto.EventSchema{
+		Event:     eventSchema.Event,
+		Version:   int32(eventSchema.Version),
+		Schema:    schema,
+		CreatedAt: timestamppb.New(eventSchema.CreatedAt),
+	}, nil
+}
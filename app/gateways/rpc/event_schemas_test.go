@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_CreateEventSchema(t *testing.T) {
+	createdAt := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	schema, err := structpb.NewStruct(map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"order_id"},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		schema       *structpb.Struct
+		useCaseErr   error
+		expectedCode codes.Code
+		expectedRule string
+	}{
+		{
+			name:   "should create a version of the schema",
+			schema: schema,
+		},
+		{
+			name:         "should return invalid argument without a schema",
+			expectedCode: codes.InvalidArgument,
+			expectedRule: "schema",
+		},
+		{
+			name:         "should return invalid argument for an invalid schema",
+			schema:       schema,
+			useCaseErr:   fmt.Errorf("%w: type must be a string", app.ErrInvalidMetadataSchema),
+			expectedCode: codes.InvalidArgument,
+			expectedRule: "schema",
+		},
+		{
+			name:         "should return not found for an unknown event",
+			schema:       schema,
+			useCaseErr:   fmt.Errorf("failed to create schema of event 9: %w", app.ErrEventNotFound),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "should return internal error when the creation fails",
+			schema:       schema,
+			useCaseErr:   errors.New("database unavailable"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedAdminUsecase := &mocks.AdminUseCaseMock{
+				CreateEventSchemaFunc: func(ctx context.Context, event uint32, schema json.RawMessage) (vos.EventSchema, error) {
+					return vos.EventSchema{Event: event, Version: 2, Schema: schema, CreatedAt: createdAt}, tt.useCaseErr
+				},
+			}
+			api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+			got, err := api.CreateEventSchema(context.Background(), &proto.CreateEventSchemaRequest{Event: 1, Schema: tt.schema})
+			if tt.expectedCode == codes.OK {
+				require.NoError(t, err)
+				assert.Equal(t, uint32(1), got.Event)
+				assert.Equal(t, int32(2), got.Version)
+				assert.Equal(t, schema.AsMap(), got.Schema.AsMap())
+				assert.Equal(t, createdAt, got.CreatedAt.AsTime())
+				return
+			}
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.expectedCode, st.Code())
+
+			if tt.expectedRule != "" {
+				_, badRequest := statusDetails(t, st)
+				require.NotNil(t, badRequest)
+				assert.Equal(t, tt.expectedRule, badRequest.FieldViolations[0].Field)
+			}
+		})
+	}
+}
+
+func TestAPI_ListEventSchemas(t *testing.T) {
+	t.Run("should list the versions of the schema", func(t *testing.T) {
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			ListEventSchemasFunc: func(ctx context.Context, event uint32) ([]vos.EventSchema, error) {
+				return []vos.EventSchema{
+					{Event: event, Version: 1, Schema: json.RawMessage(`{"type": "object"}`)},
+					{Event: event, Version: 2, Schema: json.RawMessage(`{"required": ["order_id"]}`)},
+				}, nil
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		got, err := api.ListEventSchemas(context.Background(), &proto.ListEventSchemasRequest{Event: 1})
+		require.NoError(t, err)
+		require.Len(t, got.Schemas, 2)
+		assert.Equal(t, int32(1), got.Schemas[0].Version)
+		assert.Equal(t, map[string]interface{}{"required": []interface{}{"order_id"}}, got.Schemas[1].Schema.AsMap())
+	})
+
+	t.Run("should return internal error when the listing fails", func(t *testing.T) {
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			ListEventSchemasFunc: func(ctx context.Context, event uint32) ([]vos.EventSchema, error) {
+				return nil, errors.New("database unavailable")
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		_, err := api.ListEventSchemas(context.Background(), &proto.ListEventSchemasRequest{Event: 1})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
@@ -15,7 +13,13 @@ func (a *API) GetSyntheticReport(ctx context.Context, request *proto.GetSyntheti
 	account, err := vos.NewAccount(request.Account)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid account")
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, errorStatus(err, fieldViolation("account", err.Error()))
+	}
+
+	account, err = withSearch(account, request.Search)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid search")
+		return nil, err
 	}
 
 	var level int
@@ -24,21 +28,27 @@ func (a *API) GetSyntheticReport(ctx context.Context, request *proto.GetSyntheti
 	}
 
 	if request.StartDate == nil {
-		return nil, status.Error(codes.InvalidArgument, "start_date must have a value")
+		return nil, invalidArgument("start_date", "start_date must have a value")
 	} else if !request.StartDate.IsValid() {
-		return nil, status.Error(codes.InvalidArgument, "start_date must be valid")
+		return nil, invalidArgument("start_date", "start_date must be valid")
 	}
 
 	if request.EndDate == nil {
-		return nil, status.Error(codes.InvalidArgument, "end_date must have a value")
+		return nil, invalidArgument("end_date", "end_date must have a value")
 	} else if !request.EndDate.IsValid() {
-		return nil, status.Error(codes.InvalidArgument, "end_date must be valid")
+		return nil, invalidArgument("end_date", "end_date must be valid")
+	}
+
+	ctx, err = withBook(ctx, request.Book)
+	if err != nil {
+		return nil, err
 	}
 
+	ctx = vos.WithConsistencyToken(ctx, vos.ConsistencyToken(request.ConsistencyToken))
 	syntheticReport, err := a.UseCase.GetSyntheticReport(ctx, account, level, request.StartDate.AsTime(), request.EndDate.AsTime())
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("can't get synthetic report")
-		return nil, status.Error(codes.Internal, "internal server error")
+		return nil, errorStatus(err)
 	}
 
 	return &proto.GetSyntheticReportResponse{
@@ -24,7 +24,7 @@ func TestAPI_GetSyntheticReport(t *testing.T) {
 				return &vos.SyntheticReport{}, nil
 			},
 		}
-		api := NewAPI(mockedUsecase)
+		api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
 
 		request := &proto.GetSyntheticReportRequest{
 			Account:   testdata.GenerateAccount(),
@@ -44,7 +44,7 @@ func TestAPI_GetSyntheticReport(t *testing.T) {
 				return nil, app.ErrInvalidAccountComponentSize
 			},
 		}
-		api := NewAPI(mockedUsecase)
+		api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
 
 		request := &proto.GetSyntheticReportRequest{
 			Account:   testdata.GenerateAccount(),
@@ -57,8 +57,8 @@ func TestAPI_GetSyntheticReport(t *testing.T) {
 		respStatus, ok := status.FromError(err)
 
 		assert.True(t, ok)
-		assert.Equal(t, codes.Internal, respStatus.Code())
-		assert.Equal(t, "internal server error", respStatus.Message())
+		assert.Equal(t, codes.InvalidArgument, respStatus.Code())
+		assert.Equal(t, app.ErrInvalidAccountComponentSize.Error(), respStatus.Message())
 	})
 
 	t.Run("should not get synthetic report successfully, missing dates", func(t *testing.T) {
@@ -67,7 +67,7 @@ func TestAPI_GetSyntheticReport(t *testing.T) {
 				return &vos.SyntheticReport{}, nil
 			},
 		}
-		api := NewAPI(mockedUsecase)
+		api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
 
 		request := &proto.GetSyntheticReportRequest{
 			Account: testdata.GenerateAccount(),
@@ -89,7 +89,7 @@ func TestAPI_GetSyntheticReport(t *testing.T) {
 				return &vos.SyntheticReport{}, nil
 			},
 		}
-		api := NewAPI(mockedUsecase)
+		api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
 
 		request := &proto.GetSyntheticReportRequest{
 			Account:   testdata.GenerateAccount(),
@@ -109,7 +109,7 @@ func TestAPI_GetSyntheticReport(t *testing.T) {
 				return &vos.SyntheticReport{}, nil
 			},
 		}
-		api := NewAPI(mockedUsecase)
+		api := NewAPI(mockedUsecase, &mocks.AdminUseCaseMock{})
 
 		request := &proto.GetSyntheticReportRequest{
 			Account:   testdata.GenerateAccount(),
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+// Health keeps the serving status of the server, given by the last check of its dependencies. It
+// backs the grpc.health.v1 service, the Health service and the readiness endpoint of the gateway.
+type Health struct {
+	checker domain.HealthChecker
+	server  *health.Server
+
+	mu       sync.RWMutex
+	services []string
+	statuses []vos.DependencyStatus
+	serving  bool
+	shutdown bool
+}
+
+// NewHealth creates a Health checking the dependencies of checker, which may be nil when the server
+// has none.
+func NewHealth(checker domain.HealthChecker) *Health {
+	return &Health{
+		checker: checker,
+		server:  health.NewServer(),
+		serving: true,
+	}
+}
+
+// register exposes the status of the given services through the grpc.health.v1 service.
+func (h *Health) register(services []string) {
+	h.mu.Lock()
+	h.services = services
+	h.mu.Unlock()
+
+	h.setServingStatus()
+}
+
+// Update checks the dependencies. Every service shares them, so they are all serving unless a
+// dependency that isn't optional failed.
+func (h *Health) Update(ctx context.Context) {
+	var statuses []vos.DependencyStatus
+	if h.checker != nil {
+		statuses = h.checker.CheckHealth(ctx)
+	}
+
+	serving := true
+	for _, status := range statuses {
+		if status.Err == nil {
+			continue
+		}
+
+		log.Warn().Err(status.Err).Str("dependency", status.Name).Bool("optional", status.Optional).Msg("unhealthy dependency")
+		if !status.Optional {
+			serving = false
+		}
+	}
+
+	h.mu.Lock()
+	h.statuses = statuses
+	h.serving = serving
+	h.mu.Unlock()
+
+	h.setServingStatus()
+}
+
+// Run updates the status on every interval until ctx is done. A zero interval keeps the status of
+// the last update.
+func (h *Health) Run(ctx context.Context, interval, timeout time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.updateWithin(ctx, timeout)
+		}
+	}
+}
+
+// updateWithin updates the status, giving up the checks after timeout, when positive.
+func (h *Health) updateWithin(ctx context.Context, timeout time.Duration) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	h.Update(ctx)
+}
+
+// Shutdown marks every service as not serving for good, so clients stop sending calls while the
+// server drains.
+func (h *Health) Shutdown() {
+	h.mu.Lock()
+	h.shutdown = true
+	h.mu.Unlock()
+
+	h.server.Shutdown()
+}
+
+// Ready reports whether the server is serving, along with the last status of its dependencies.
+func (h *Health) Ready() (bool, []vos.DependencyStatus) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.serving && !h.shutdown, h.statuses
+}
+
+func (h *Health) setServingStatus() {
+	h.mu.RLock()
+	services := h.services
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if h.serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	h.mu.RUnlock()
+
+	// The empty service is the status of the server as a whole.
+	h.server.SetServingStatus("", status)
+	for _, service := range services {
+		h.server.SetServingStatus(service, status)
+	}
+}
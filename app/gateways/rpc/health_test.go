@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+type healthCheckerFunc func(context.Context) []vos.DependencyStatus
+
+func (f healthCheckerFunc) CheckHealth(ctx context.Context) []vos.DependencyStatus {
+	return f(ctx)
+}
+
+func TestHealth(t *testing.T) {
+	errUnavailable := errors.New("connection refused")
+
+	testCases := []struct {
+		name            string
+		statuses        []vos.DependencyStatus
+		expectedServing bool
+	}{
+		{
+			name:            "should serve when every dependency is healthy",
+			statuses:        []vos.DependencyStatus{{Name: "postgres"}, {Name: "migrations"}},
+			expectedServing: true,
+		},
+		{
+			name:            "should serve when an optional dependency fails",
+			statuses:        []vos.DependencyStatus{{Name: "postgres"}, {Name: "replica_0", Optional: true, Err: errUnavailable}},
+			expectedServing: true,
+		},
+		{
+			name:            "should not serve when a required dependency fails",
+			statuses:        []vos.DependencyStatus{{Name: "postgres", Err: errUnavailable}},
+			expectedServing: false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			health := NewHealth(healthCheckerFunc(func(context.Context) []vos.DependencyStatus {
+				return tt.statuses
+			}))
+			health.register([]string{"ledger.LedgerService"})
+			health.Update(context.Background())
+
+			ready, statuses := health.Ready()
+			assert.Equal(t, tt.expectedServing, ready)
+			assert.Equal(t, tt.statuses, statuses)
+
+			expected := healthpb.HealthCheckResponse_NOT_SERVING
+			if tt.expectedServing {
+				expected = healthpb.HealthCheckResponse_SERVING
+			}
+
+			for _, service := range []string{"", "ledger.LedgerService"} {
+				got, err := health.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+				require.NoError(t, err)
+				assert.Equal(t, expected, got.Status, service)
+			}
+		})
+	}
+
+	t.Run("should stop serving on shutdown", func(t *testing.T) {
+		health := NewHealth(nil)
+		health.register([]string{"ledger.LedgerService"})
+		health.Update(context.Background())
+
+		api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{})
+		api.health = health
+
+		got, err := api.Check(context.Background(), &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, proto.HealthCheckResponse_SERVING_STATUS_SERVING, got.Status)
+
+		health.Shutdown()
+		health.Update(context.Background())
+
+		ready, _ := health.Ready()
+		assert.False(t, ready)
+
+		got, err = api.Check(context.Background(), &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, proto.HealthCheckResponse_SERVING_STATUS_NOT_SERVING, got.Status)
+
+		status, err := health.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "ledger.LedgerService"})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status.Status)
+	})
+}
@@ -8,7 +8,15 @@ import (
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
 )
 
-func (API) Check(_ context.Context, _ *emptypb.Empty) (*proto.HealthCheckResponse, error) {
+func (a *API) Check(_ context.Context, _ *emptypb.Empty) (*proto.HealthCheckResponse, error) {
+	if a.health != nil {
+		if ready, _ := a.health.Ready(); !ready {
+			return &proto.HealthCheckResponse{
+				Status: proto.HealthCheckResponse_SERVING_STATUS_NOT_SERVING,
+			}, nil
+		}
+	}
+
 	return &proto.HealthCheckResponse{
 		Status: proto.HealthCheckResponse_SERVING_STATUS_SERVING,
 	}, nil
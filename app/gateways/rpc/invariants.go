@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+var (
+	_invariantChecksToProto = map[vos.InvariantCheck]proto.InvariantCheck{
+		vos.TransactionBalanceCheck: proto.InvariantCheck_INVARIANT_CHECK_TRANSACTION_BALANCE,
+		vos.AccountVersionCheck:     proto.InvariantCheck_INVARIANT_CHECK_ACCOUNT_VERSION,
+		vos.VersionGapCheck:         proto.InvariantCheck_INVARIANT_CHECK_VERSION_GAP,
+		vos.BalanceSnapshotCheck:    proto.InvariantCheck_INVARIANT_CHECK_BALANCE_SNAPSHOT,
+	}
+
+	_invariantChecksFromProto = map[proto.InvariantCheck]vos.InvariantCheck{
+		proto.InvariantCheck_INVARIANT_CHECK_TRANSACTION_BALANCE: vos.TransactionBalanceCheck,
+		proto.InvariantCheck_INVARIANT_CHECK_ACCOUNT_VERSION:     vos.AccountVersionCheck,
+		proto.InvariantCheck_INVARIANT_CHECK_VERSION_GAP:         vos.VersionGapCheck,
+		proto.InvariantCheck_INVARIANT_CHECK_BALANCE_SNAPSHOT:    vos.BalanceSnapshotCheck,
+	}
+)
+
+func (a *API) CheckInvariants(ctx context.Context, _ *emptypb.Empty) (*proto.CheckInvariantsResponse, error) {
+	report, err := a.AdminUseCase.CheckInvariants(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to check invariants")
+		return nil, errorStatus(err)
+	}
+
+	return &proto.CheckInvariantsResponse{
+		From:       timestamppb.New(report.From),
+		To:         timestamppb.New(report.To),
+		Violations: invariantViolationsToProto(report.Violations),
+	}, nil
+}
+
+func (a *API) ListInvariantViolations(ctx context.Context, request *proto.ListInvariantViolationsRequest) (*proto.ListInvariantViolationsResponse, error) {
+	page, err := pagination.NewPage(request.GetPage())
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create page reference")
+		return nil, errorStatus(err, pageViolation(err))
+	}
+
+	req := vos.InvariantViolationRequest{
+		Check: _invariantChecksFromProto[request.Check],
+		Page:  page,
+	}
+
+	violations, err := a.AdminUseCase.ListInvariantViolations(ctx, req)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list invariant violations")
+		return nil, errorStatus(err)
+	}
+
+	return &proto.ListInvariantViolationsResponse{
+		Violations:    invariantViolationsToProto(violations.Violations),
+		NextPageToken: violations.NextPage.Tokenize(),
+	}, nil
+}
+
+func invariantViolationsToProto(violations []vos.InvariantViolation) []*proto.InvariantViolation {
+	protoViolations := make([]*proto.InvariantViolation, 0, len(violations))
+
+	for _, violation := range violations {
+		protoViolation := &proto.InvariantViolation{
+			Id:      violation.ID,
+			Check:   _invariantChecksToProto[violation.Check],
+			Book:    violation.Book,
+			Subject: violation.Subject,
+			Detail:  violation.Detail,
+		}
+
+		if !violation.DetectedAt.IsZero() {
+			protoViolation.DetectedAt = timestamppb.New(violation.DetectedAt)
+		}
+
+		protoViolations = append(protoViolations, protoViolation)
+	}
+
+	return protoViolations
+}
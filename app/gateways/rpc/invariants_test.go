@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_CheckInvariants(t *testing.T) {
+	t.Run("should check invariants successfully", func(t *testing.T) {
+		from := time.Now().Add(-time.Hour).UTC()
+		to := time.Now().UTC()
+
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			CheckInvariantsFunc: func(ctx context.Context) (vos.InvariantReport, error) {
+				return vos.InvariantReport{
+					From: from,
+					To:   to,
+					Violations: []vos.InvariantViolation{
+						{
+							Check:   vos.AccountVersionCheck,
+							Subject: "liability.clients.available.abc",
+							Detail:  "account_version is 2 but the greatest entry version is 3",
+						},
+					},
+				}, nil
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		got, err := api.CheckInvariants(context.Background(), &emptypb.Empty{})
+		assert.NoError(t, err)
+		assert.Equal(t, &proto.CheckInvariantsResponse{
+			From: timestamppb.New(from),
+			To:   timestamppb.New(to),
+			Violations: []*proto.InvariantViolation{
+				{
+					Check:   proto.InvariantCheck_INVARIANT_CHECK_ACCOUNT_VERSION,
+					Subject: "liability.clients.available.abc",
+					Detail:  "account_version is 2 but the greatest entry version is 3",
+				},
+			},
+		}, got)
+	})
+
+	t.Run("should return internal error when the check fails", func(t *testing.T) {
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			CheckInvariantsFunc: func(ctx context.Context) (vos.InvariantReport, error) {
+				return vos.InvariantReport{}, errors.New("database unavailable")
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		_, err := api.CheckInvariants(context.Background(), &emptypb.Empty{})
+
+		respStatus, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, respStatus.Code())
+	})
+}
+
+func TestAPI_ListInvariantViolations(t *testing.T) {
+	t.Run("should list invariant violations successfully", func(t *testing.T) {
+		detectedAt := time.Now().UTC()
+		cursor, err := pagination.NewCursor(map[string]int64{"id": 1})
+		assert.NoError(t, err)
+
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			ListInvariantViolationsFunc: func(ctx context.Context, req vos.InvariantViolationRequest) (vos.InvariantViolationResponse, error) {
+				assert.Equal(t, vos.BalanceSnapshotCheck, req.Check)
+				assert.Equal(t, 1, req.Page.Size)
+
+				return vos.InvariantViolationResponse{
+					Violations: []vos.InvariantViolation{
+						{
+							ID:         2,
+							Check:      vos.BalanceSnapshotCheck,
+							Subject:    "liability.clients.*",
+							Detail:     "snapshot balance is 10 but entries sum to 20",
+							DetectedAt: detectedAt,
+						},
+					},
+					NextPage: cursor,
+				}, nil
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		got, err := api.ListInvariantViolations(context.Background(), &proto.ListInvariantViolationsRequest{
+			Check: proto.InvariantCheck_INVARIANT_CHECK_BALANCE_SNAPSHOT,
+			Page:  &proto.RequestPagination{PageSize: 1},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &proto.ListInvariantViolationsResponse{
+			Violations: []*proto.InvariantViolation{
+				{
+					Id:         2,
+					Check:      proto.InvariantCheck_INVARIANT_CHECK_BALANCE_SNAPSHOT,
+					Subject:    "liability.clients.*",
+					Detail:     "snapshot balance is 10 but entries sum to 20",
+					DetectedAt: timestamppb.New(detectedAt),
+				},
+			},
+			NextPageToken: cursor.Tokenize(),
+		}, got)
+	})
+
+	t.Run("should return invalid argument when page size is invalid", func(t *testing.T) {
+		api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{})
+
+		_, err := api.ListInvariantViolations(context.Background(), &proto.ListInvariantViolationsRequest{
+			Page: &proto.RequestPagination{PageSize: -1},
+		})
+
+		respStatus, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, respStatus.Code())
+	})
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func (a *API) ListAccounts(ctx context.Context, request *proto.ListAccountsRequest) (*proto.ListAccountsResponse, error) {
+	pattern, err := vos.NewAccount(request.Pattern)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create account pattern")
+		return nil, errorStatus(err, fieldViolation("pattern", err.Error()))
+	}
+
+	page, err := pagination.NewPage(request.GetPage())
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create page reference")
+		return nil, errorStatus(err, pageViolation(err))
+	}
+
+	ctx, err = withBook(ctx, request.Book)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = vos.WithConsistencyToken(ctx, vos.ConsistencyToken(request.ConsistencyToken))
+	accounts, err := a.UseCase.ListAccounts(ctx, vos.AccountListRequest{Pattern: pattern, Page: page})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list accounts")
+		return nil, errorStatus(err)
+	}
+
+	protoAccounts := make([]*proto.AccountSummary, 0, len(accounts.Accounts))
+	for _, account := range accounts.Accounts {
+		protoAccounts = append(protoAccounts, &proto.AccountSummary{
+			Account:   account.Account.Value(),
+			CreatedAt: timestamppb.New(account.CreatedAt),
+		})
+	}
+
+	return &proto.ListAccountsResponse{
+		Accounts:      protoAccounts,
+		NextPageToken: accounts.NextPage.Tokenize(),
+	}, nil
+}
+
+func (a *API) ListAccountChildren(ctx context.Context, request *proto.ListAccountChildrenRequest) (*proto.ListAccountChildrenResponse, error) {
+	prefix, err := vos.NewAccountPrefix(request.Prefix)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create account prefix")
+		return nil, errorStatus(err, fieldViolation("prefix", err.Error()))
+	}
+
+	page, err := pagination.NewPage(request.GetPage())
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create page reference")
+		return nil, errorStatus(err, pageViolation(err))
+	}
+
+	ctx, err = withBook(ctx, request.Book)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = vos.WithConsistencyToken(ctx, vos.ConsistencyToken(request.ConsistencyToken))
+	children, err := a.UseCase.ListAccountChildren(ctx, vos.AccountChildrenRequest{
+		Prefix:          prefix,
+		IncludeBalances: request.IncludeBalances,
+		Page:            page,
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list account children")
+		return nil, errorStatus(err)
+	}
+
+	protoChildren := make([]*proto.AccountChild, 0, len(children.Children))
+	for _, child := range children.Children {
+		protoChild := &proto.AccountChild{
+			Prefix:   child.Prefix.Value(),
+			Accounts: int64(child.Accounts),
+		}
+
+		if child.Balance != nil {
+			protoChild.Balance = accountBalanceToProto(*child.Balance)
+		}
+
+		protoChildren = append(protoChildren, protoChild)
+	}
+
+	return &proto.ListAccountChildrenResponse{
+		Children:      protoChildren,
+		NextPageToken: children.NextPage.Tokenize(),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_ListAccounts(t *testing.T) {
+	createdAt := time.Now().UTC()
+	account, err := vos.NewAnalyticAccount("liability.clients.available")
+	require.NoError(t, err)
+
+	useCase := &mocks.UseCaseMock{
+		ListAccountsFunc: func(_ context.Context, _ vos.AccountListRequest) (vos.AccountListResponse, error) {
+			return vos.AccountListResponse{
+				Accounts: []vos.AccountSummary{{Account: account, CreatedAt: createdAt}},
+			}, nil
+		},
+	}
+
+	api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+	got, err := api.ListAccounts(context.Background(), &proto.ListAccountsRequest{Pattern: "liability.Clients.*"})
+	require.NoError(t, err)
+
+	assert.Equal(t, &proto.ListAccountsResponse{
+		Accounts: []*proto.AccountSummary{{Account: "liability.clients.available", CreatedAt: timestamppb.New(createdAt)}},
+	}, got)
+
+	require.Len(t, useCase.ListAccountsCalls(), 1)
+	page, _ := pagination.NewPage(nil)
+	assert.Equal(t, "liability.clients.*", useCase.ListAccountsCalls()[0].AccountListRequest.Pattern.Value())
+	assert.Equal(t, page, useCase.ListAccountsCalls()[0].AccountListRequest.Page)
+}
+
+func TestAPI_ListAccounts_InvalidPattern(t *testing.T) {
+	api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{})
+
+	_, err := api.ListAccounts(context.Background(), &proto.ListAccountsRequest{Pattern: "liability.$"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAPI_ListAccountChildren(t *testing.T) {
+	prefix, err := vos.NewAccountPrefix("liability")
+	require.NoError(t, err)
+
+	useCase := &mocks.UseCaseMock{
+		ListAccountChildrenFunc: func(_ context.Context, req vos.AccountChildrenRequest) (vos.AccountChildrenResponse, error) {
+			return vos.AccountChildrenResponse{
+				Children: []vos.AccountChild{
+					{
+						Prefix:   req.Prefix.Child("clients"),
+						Accounts: 2,
+						Balance:  &vos.AccountBalance{Account: req.Prefix.Child("clients").Query(), Balance: 150},
+					},
+				},
+			}, nil
+		},
+	}
+
+	api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+	got, err := api.ListAccountChildren(context.Background(), &proto.ListAccountChildrenRequest{
+		Prefix:          "liability",
+		IncludeBalances: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &proto.ListAccountChildrenResponse{
+		Children: []*proto.AccountChild{
+			{
+				Prefix:   "liability.clients",
+				Accounts: 2,
+				Balance:  &proto.GetAccountBalanceResponse{Account: "liability.clients.*", Balance: 150},
+			},
+		},
+	}, got)
+
+	require.Len(t, useCase.ListAccountChildrenCalls(), 1)
+	req := useCase.ListAccountChildrenCalls()[0].AccountChildrenRequest
+	assert.Equal(t, prefix, req.Prefix)
+	assert.True(t, req.IncludeBalances)
+}
+
+func TestAPI_ListAccountChildren_InvalidPrefix(t *testing.T) {
+	api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{})
+
+	_, err := api.ListAccountChildren(context.Background(), &proto.ListAccountChildrenRequest{Prefix: "liability.*"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
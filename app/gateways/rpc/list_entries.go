@@ -2,55 +2,71 @@ package rpc
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rs/zerolog"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	"github.com/stone-co/the-amazing-ledger/app/pagination"
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
 )
 
 func (a *API) ListAccountEntries(ctx context.Context, request *proto.ListAccountEntriesRequest) (*proto.ListAccountEntriesResponse, error) {
-	account, err := vos.NewAnalyticAccount(request.Account)
+	account, err := vos.NewAccount(request.Account)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create account name")
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, errorStatus(err, fieldViolation("account", err.Error()))
 	}
 
 	if request.StartDate == nil {
-		return nil, status.Error(codes.InvalidArgument, "start_date must have a value")
+		return nil, invalidArgument("start_date", "start_date must have a value")
 	} else if !request.StartDate.IsValid() {
-		return nil, status.Error(codes.InvalidArgument, "start_date must be valid")
+		return nil, invalidArgument("start_date", "start_date must be valid")
 	}
 
 	if request.EndDate == nil {
-		return nil, status.Error(codes.InvalidArgument, "end_date must have a value")
+		return nil, invalidArgument("end_date", "end_date must have a value")
 	} else if !request.EndDate.IsValid() {
-		return nil, status.Error(codes.InvalidArgument, "end_date must be valid")
+		return nil, invalidArgument("end_date", "end_date must be valid")
 	}
 
 	page, err := pagination.NewPage(request.GetPage())
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create page reference")
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, errorStatus(err, pageViolation(err))
+	}
+
+	filter, err := vos.NewEntryFilter(request.Filter)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create entry filter")
+		return nil, errorStatus(err, filterViolation(request.Filter, err))
 	}
 
 	req := vos.AccountEntryRequest{
 		Account:   account,
 		StartDate: request.StartDate.AsTime(),
 		EndDate:   request.EndDate.AsTime(),
-		Filter:    vos.NewEntryFilter(request.Filter),
+		Filter:    filter,
 		Page:      page,
+
+		IncludeOpeningBalance: request.IncludeOpeningBalance,
 	}
 
+	ctx, err = withBook(ctx, request.Book)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = vos.WithConsistencyToken(ctx, vos.ConsistencyToken(request.ConsistencyToken))
 	entries, err := a.UseCase.ListAccountEntries(ctx, req)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list account entries")
-		return nil, status.Error(codes.Internal, "internal server error")
+		return nil, errorStatus(err)
 	}
 
 	protoEntries := make([]*proto.AccountEntry, 0, len(entries.Entries))
@@ -58,7 +74,7 @@ func (a *API) ListAccountEntries(ctx context.Context, request *proto.ListAccount
 		metadata, err := structpb.NewStruct(entry.Metadata)
 		if err != nil {
 			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to convert map to structpb")
-			return nil, status.Error(codes.Internal, "internal server error")
+			return nil, errorStatus(err)
 		}
 
 		protoEntries = append(protoEntries, &proto.AccountEntry{
@@ -69,11 +85,46 @@ func (a *API) ListAccountEntries(ctx context.Context, request *proto.ListAccount
 			Event:          int32(entry.Event),
 			CompetenceDate: timestamppb.New(entry.CompetenceDate),
 			Metadata:       metadata,
+			Account:        entry.Account.Value(),
+			CreatedAt:      timestamppb.New(entry.CreatedAt),
+			TxId:           entry.TxID.String(),
+			Company:        entry.Company,
+			Balance:        int64(entry.Balance),
 		})
 	}
 
-	return &proto.ListAccountEntriesResponse{
+	response := &proto.ListAccountEntriesResponse{
 		Entries:       protoEntries,
 		NextPageToken: entries.NextPage.Tokenize(),
-	}, nil
+	}
+
+	if entries.OpeningBalance != nil {
+		response.OpeningBalance = wrapperspb.Int64(int64(*entries.OpeningBalance))
+	}
+
+	return response, nil
+}
+
+// pageViolation is the field violation of an error returned by pagination.NewPage.
+func pageViolation(err error) *errdetails.BadRequest_FieldViolation {
+	if errors.Is(err, app.ErrInvalidPageSize) {
+		return fieldViolation("page.page_size", err.Error())
+	}
+
+	return fieldViolation("page.page_token", err.Error())
+}
+
+// filterViolation is the field violation of an error returned by vos.NewEntryFilter for filter.
+func filterViolation(filter *proto.ListAccountEntriesRequest_Filter, err error) *errdetails.BadRequest_FieldViolation {
+	if errors.Is(err, app.ErrInvalidMetadataFilter) {
+		return fieldViolation("filter.metadata_contains", err.Error())
+	}
+
+	for _, key := range filter.MetadataKeys {
+		if _, keyErr := vos.NewMetadataPath(key); keyErr != nil {
+			return fieldViolation("filter.metadata_keys", err.Error())
+		}
+	}
+
+	return fieldViolation("filter.metadata_equals", err.Error())
 }
@@ -3,11 +3,16 @@ package rpc
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	"github.com/stone-co/the-amazing-ledger/app/pagination"
@@ -78,6 +83,63 @@ func TestAPI_ListAccountEntries_Success(t *testing.T) {
 				}, nil
 			},
 		},
+		{
+			name: "should succeed when listing the entries of a synthetic account",
+			useCaseSetup: &mocks.UseCaseMock{
+				ListAccountEntriesFunc: func(_ context.Context, _ vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
+					account, err := vos.NewAnalyticAccount("liability.credit_card.account1")
+					if err != nil {
+						return vos.AccountEntryResponse{}, err
+					}
+
+					opening := 50
+
+					return vos.AccountEntryResponse{
+						Entries: []vos.AccountEntry{{
+							ID:             uuid.Nil,
+							Version:        2,
+							Operation:      vos.CreditOperation,
+							Amount:         100,
+							Event:          1,
+							CompetenceDate: time.Unix(10, 0),
+							Metadata:       map[string]interface{}{},
+							Account:        account,
+							CreatedAt:      time.Unix(20, 0),
+							TxID:           uuid.Nil,
+							Company:        "abc",
+							Balance:        150,
+						}},
+						OpeningBalance: &opening,
+					}, nil
+				},
+			},
+			request: &proto.ListAccountEntriesRequest{
+				Account:   "liability.credit_card.*",
+				StartDate: timestamppb.Now(),
+				EndDate:   timestamppb.Now(),
+
+				IncludeOpeningBalance: true,
+			},
+			want: func() (*proto.ListAccountEntriesResponse, error) {
+				return &proto.ListAccountEntriesResponse{
+					Entries: []*proto.AccountEntry{{
+						Id:             uuid.Nil.String(),
+						Version:        2,
+						Operation:      proto.Operation_OPERATION_CREDIT,
+						Amount:         100,
+						Event:          1,
+						CompetenceDate: timestamppb.New(time.Unix(10, 0)),
+						Metadata:       &structpb.Struct{Fields: map[string]*structpb.Value{}},
+						Account:        "liability.credit_card.account1",
+						CreatedAt:      timestamppb.New(time.Unix(20, 0)),
+						TxId:           uuid.Nil.String(),
+						Company:        "abc",
+						Balance:        150,
+					}},
+					OpeningBalance: wrapperspb.Int64(50),
+				}, nil
+			},
+		},
 		{
 			name: "should succeed when listing account entries - with filters",
 			useCaseSetup: &mocks.UseCaseMock{
@@ -92,8 +154,13 @@ func TestAPI_ListAccountEntries_Success(t *testing.T) {
 				Account:   "liability.credit_card.account1",
 				StartDate: timestamppb.Now(),
 				EndDate:   timestamppb.Now(),
-				Filter:    &proto.ListAccountEntriesRequest_Filter{Operation: proto.Operation_OPERATION_CREDIT},
-				Page:      nil,
+				Filter: &proto.ListAccountEntriesRequest_Filter{
+					Operation:        proto.Operation_OPERATION_CREDIT,
+					MetadataContains: `{"order": {"id": 123}}`,
+					MetadataKeys:     []string{"external_id"},
+					MetadataEquals:   map[string]string{"order.status": "paid"},
+				},
+				Page: nil,
 			},
 			want: func() (*proto.ListAccountEntriesResponse, error) {
 				return &proto.ListAccountEntriesResponse{
@@ -105,7 +172,7 @@ func TestAPI_ListAccountEntries_Success(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api := NewAPI(tt.useCaseSetup)
+			api := NewAPI(tt.useCaseSetup, &mocks.AdminUseCaseMock{})
 
 			got, err := api.ListAccountEntries(context.Background(), tt.request)
 			assert.NoError(t, err)
@@ -116,14 +183,17 @@ func TestAPI_ListAccountEntries_Success(t *testing.T) {
 			assert.Equal(t, want, got)
 			assert.Len(t, tt.useCaseSetup.ListAccountEntriesCalls(), 1)
 
-			account, _ := vos.NewAnalyticAccount(tt.request.Account)
+			account, _ := vos.NewAccount(tt.request.Account)
 			page, _ := pagination.NewPage(nil)
+			filter, _ := vos.NewEntryFilter(tt.request.Filter)
 			assert.Equal(t, vos.AccountEntryRequest{
 				Account:   account,
 				StartDate: tt.request.StartDate.AsTime(),
 				EndDate:   tt.request.EndDate.AsTime(),
-				Filter:    vos.NewEntryFilter(tt.request.Filter),
+				Filter:    filter,
 				Page:      page,
+
+				IncludeOpeningBalance: tt.request.IncludeOpeningBalance,
 			}, tt.useCaseSetup.ListAccountEntriesCalls()[0].AccountEntryRequest)
 		})
 	}
@@ -147,7 +217,7 @@ func TestAPI_ListAccountEntries_InvalidRequest(t *testing.T) {
 				Page:      nil,
 			},
 			expectedCode:    codes.InvalidArgument,
-			expectedMessage: "only alphanumeric, underscore and star (*) characters are supported",
+			expectedMessage: "only alphanumeric, underscore and lquery operator characters are supported",
 		},
 		{
 			name:         "should return an error with nil start date",
@@ -218,10 +288,34 @@ func TestAPI_ListAccountEntries_InvalidRequest(t *testing.T) {
 			expectedCode:    codes.InvalidArgument,
 			expectedMessage: "invalid page size",
 		},
+		{
+			name:         "should return an error with a metadata filter that is not an object",
+			useCaseSetup: &mocks.UseCaseMock{},
+			request: &proto.ListAccountEntriesRequest{
+				Account:   "liability.credit_card.account1",
+				StartDate: timestamppb.Now(),
+				EndDate:   timestamppb.Now(),
+				Filter:    &proto.ListAccountEntriesRequest_Filter{MetadataContains: `["abc"]`},
+			},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: `metadata filter must be a JSON object: ["abc"]`,
+		},
+		{
+			name:         "should return an error with an empty metadata key",
+			useCaseSetup: &mocks.UseCaseMock{},
+			request: &proto.ListAccountEntriesRequest{
+				Account:   "liability.credit_card.account1",
+				StartDate: timestamppb.Now(),
+				EndDate:   timestamppb.Now(),
+				Filter:    &proto.ListAccountEntriesRequest_Filter{MetadataEquals: map[string]string{"order..id": "123"}},
+			},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "metadata path keys cannot be empty: order..id",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api := NewAPI(tt.useCaseSetup)
+			api := NewAPI(tt.useCaseSetup, &mocks.AdminUseCaseMock{})
 
 			_, err := api.ListAccountEntries(context.Background(), tt.request)
 			respStatus, ok := status.FromError(err)
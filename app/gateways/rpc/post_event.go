@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/gateways/rpc/auth"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func (a *API) PostEvent(ctx context.Context, req *proto.PostEventRequest) (*proto.PostEventResponse, error) {
+	tid, err := uuid.Parse(req.Id)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to parse transaction id")
+		return nil, errorStatus(app.ErrInvalidTransactionID, fieldViolation("id", app.ErrInvalidTransactionID.Error()))
+	}
+
+	if req.Amount <= 0 {
+		return nil, errorStatus(app.ErrInvalidAmount, fieldViolation("amount", app.ErrInvalidAmount.Error()))
+	}
+
+	competenceDate, err := parseCompetenceDate(req.CompetenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := req.Metadata.MarshalJSON()
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to marshal event metadata")
+		return nil, invalidArgument("metadata", "invalid metadata")
+	}
+
+	ctx, err = withBook(ctx, req.Book)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := a.UseCase.ExpandEvent(ctx, vos.EventPosting{
+		ID:             tid,
+		Event:          req.Event,
+		Company:        req.Company,
+		CompetenceDate: competenceDate,
+		Amount:         int(req.Amount),
+		Params:         req.Params,
+		Metadata:       metadata,
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Uint32("event", req.Event).Msg("failed to expand event")
+		return nil, errorStatus(err, expansionViolations(err)...)
+	}
+
+	accounts := make([]string, 0, len(tx.Entries))
+	for _, entry := range tx.Entries {
+		accounts = append(accounts, entry.Account.Value())
+	}
+
+	if err = authorize(ctx, auth.Access{Action: auth.PostAction, Book: req.Book, Companies: []string{req.Company}, Accounts: accounts}); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to authorize expanded entries")
+		return nil, errorStatus(err)
+	}
+
+	if req.IncludeBalances {
+		ctx = vos.WithResultingBalances(ctx)
+	}
+
+	if req.DryRun {
+		ctx = vos.WithSimulation(ctx)
+	}
+
+	result, err := a.UseCase.CreateTransaction(ctx, tx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to save transaction")
+		return nil, errorStatus(err, postedMetadataViolations(err)...)
+	}
+
+	return &proto.PostEventResponse{
+		Entries:     entriesToProto(tx),
+		Transaction: transactionResultToProto(result),
+	}, nil
+}
+
+func entriesToProto(tx entities.Transaction) []*proto.Entry {
+	entries := make([]*proto.Entry, 0, len(tx.Entries))
+	for _, entry := range tx.Entries {
+		entries = append(entries, &proto.Entry{
+			Id:              entry.ID.String(),
+			Account:         entry.Account.Value(),
+			ExpectedVersion: entry.Version.AsInt64(),
+			Operation:       proto.Operation(entry.Operation),
+			Amount:          int64(entry.Amount),
+		})
+	}
+
+	return entries
+}
+
+// expansionViolations points an error of ExpandEvent to the field of the request it comes from. The
+// errors of the entries come from the parameters, as templates are validated when loaded.
+func expansionViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var domainErr app.DomainError
+	switch {
+	case errors.Is(err, app.ErrPostingTemplateNotFound), errors.Is(err, app.ErrInvalidBalance):
+		return []*errdetails.BadRequest_FieldViolation{fieldViolation("event", err.Error())}
+	case errors.As(err, &domainErr):
+		return []*errdetails.BadRequest_FieldViolation{fieldViolation("params", err.Error())}
+	default:
+		return nil
+	}
+}
+
+// postedMetadataViolations points a schema violation to the metadata of the event, which is the
+// metadata of every entry.
+func postedMetadataViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var schemaErr vos.MetadataViolationError
+	if !errors.As(err, &schemaErr) || len(schemaErr.Violations) == 0 {
+		return nil
+	}
+
+	return []*errdetails.BadRequest_FieldViolation{
+		fieldViolation("metadata", strings.Join(schemaErr.Violations[0].Errors, "; ")),
+	}
+}
@@ -0,0 +1,194 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	"github.com/stone-co/the-amazing-ledger/app/tests/testdata"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_PostEvent(t *testing.T) {
+	debitAccount := testdata.GenerateAccountPath()
+	creditAccount := testdata.GenerateAccountPath()
+
+	expand := func(ctx context.Context, posting vos.EventPosting) (entities.Transaction, error) {
+		debit, err := entities.NewEntry(uuid.New(), vos.DebitOperation, debitAccount, vos.NextAccountVersion, posting.Amount, posting.Metadata)
+		require.NoError(t, err)
+
+		credit, err := entities.NewEntry(uuid.New(), vos.CreditOperation, creditAccount, vos.NextAccountVersion, posting.Amount, posting.Metadata)
+		require.NoError(t, err)
+
+		return entities.NewTransaction(posting.ID, posting.Event, posting.Company, posting.CompetenceDate, debit, credit)
+	}
+
+	newRequest := func() *proto.PostEventRequest {
+		return &proto.PostEventRequest{
+			Id:             uuid.New().String(),
+			Event:          7,
+			Amount:         1000,
+			Params:         map[string]string{"client": "abc"},
+			CompetenceDate: timestamppb.Now(),
+			Company:        "abc",
+		}
+	}
+
+	t.Run("should expand and create the transaction", func(t *testing.T) {
+		useCase := &mocks.UseCaseMock{
+			ExpandEventFunc: expand,
+			CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+				return vos.TransactionResult{PostedAt: time.Now()}, nil
+			},
+		}
+
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+		request := newRequest()
+
+		got, err := api.PostEvent(context.Background(), request)
+		require.NoError(t, err)
+		require.Len(t, got.Entries, 2)
+		assert.Equal(t, int64(1000), got.Entries[0].Amount)
+		assert.NotNil(t, got.Transaction)
+
+		calls := useCase.ExpandEventCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, request.Id, calls[0].EventPosting.ID.String())
+		assert.Equal(t, uint32(7), calls[0].EventPosting.Event)
+		assert.Equal(t, map[string]string{"client": "abc"}, calls[0].EventPosting.Params)
+
+		require.Len(t, useCase.CreateTransactionCalls(), 1)
+		assert.False(t, vos.SimulationFromContext(useCase.CreateTransactionCalls()[0].ContextMoqParam))
+	})
+
+	t.Run("should simulate the transaction on dry runs", func(t *testing.T) {
+		useCase := &mocks.UseCaseMock{
+			ExpandEventFunc: expand,
+			CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+				return vos.TransactionResult{PostedAt: time.Now()}, nil
+			},
+		}
+
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+		request := newRequest()
+		request.DryRun = true
+
+		got, err := api.PostEvent(context.Background(), request)
+		require.NoError(t, err)
+		assert.Len(t, got.Entries, 2)
+		assert.NotNil(t, got.Transaction)
+
+		calls := useCase.CreateTransactionCalls()
+		require.Len(t, calls, 1)
+		assert.True(t, vos.SimulationFromContext(calls[0].ContextMoqParam))
+	})
+
+	testCases := []struct {
+		name          string
+		request       func() *proto.PostEventRequest
+		expandErr     error
+		createErr     error
+		expectedCode  codes.Code
+		expectedField string
+	}{
+		{
+			name: "should return invalid argument for an invalid id",
+			request: func() *proto.PostEventRequest {
+				request := newRequest()
+				request.Id = "invalid"
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "id",
+		},
+		{
+			name: "should return invalid argument for a non positive amount",
+			request: func() *proto.PostEventRequest {
+				request := newRequest()
+				request.Amount = 0
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "amount",
+		},
+		{
+			name: "should return invalid argument for a future competence date",
+			request: func() *proto.PostEventRequest {
+				request := newRequest()
+				request.CompetenceDate = timestamppb.New(time.Now().Add(time.Hour))
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "competence_date",
+		},
+		{
+			name:          "should return not found for events without a template",
+			request:       newRequest,
+			expandErr:     fmt.Errorf("%w: event 7", app.ErrPostingTemplateNotFound),
+			expectedCode:  codes.NotFound,
+			expectedField: "event",
+		},
+		{
+			name:          "should return invalid argument for invalid parameters",
+			request:       newRequest,
+			expandErr:     fmt.Errorf("failed to expand event 7: %w", app.ErrInvalidPostingParams),
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "params",
+		},
+		{
+			name:          "should point metadata schema violations to the metadata",
+			request:       newRequest,
+			createErr:     vos.MetadataViolationError{Event: 7, Version: 1, Violations: []vos.MetadataViolation{{Errors: []string{"(root): order_id is required"}}}},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "metadata",
+		},
+		{
+			name:         "should return already exists for a posted event",
+			request:      newRequest,
+			createErr:    app.ErrIdempotencyKeyViolation,
+			expectedCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := &mocks.UseCaseMock{
+				ExpandEventFunc: func(ctx context.Context, posting vos.EventPosting) (entities.Transaction, error) {
+					if tt.expandErr != nil {
+						return entities.Transaction{}, tt.expandErr
+					}
+
+					return expand(ctx, posting)
+				},
+				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+					return vos.TransactionResult{}, tt.createErr
+				},
+			}
+
+			api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+
+			_, err := api.PostEvent(context.Background(), tt.request())
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.expectedCode, st.Code())
+
+			if tt.expectedField != "" {
+				_, badRequest := statusDetails(t, st)
+				require.NotNil(t, badRequest)
+				assert.Equal(t, tt.expectedField, badRequest.FieldViolations[0].Field)
+			}
+		})
+	}
+}
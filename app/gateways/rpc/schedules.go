@@ -0,0 +1,221 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+var (
+	_scheduleStatusesToProto = map[vos.ScheduleStatus]proto.ScheduleStatus{
+		vos.ActiveSchedule:    proto.ScheduleStatus_SCHEDULE_STATUS_ACTIVE,
+		vos.PausedSchedule:    proto.ScheduleStatus_SCHEDULE_STATUS_PAUSED,
+		vos.CanceledSchedule:  proto.ScheduleStatus_SCHEDULE_STATUS_CANCELED,
+		vos.CompletedSchedule: proto.ScheduleStatus_SCHEDULE_STATUS_COMPLETED,
+	}
+
+	_scheduleStatusesFromProto = map[proto.ScheduleStatus]vos.ScheduleStatus{
+		proto.ScheduleStatus_SCHEDULE_STATUS_ACTIVE:    vos.ActiveSchedule,
+		proto.ScheduleStatus_SCHEDULE_STATUS_PAUSED:    vos.PausedSchedule,
+		proto.ScheduleStatus_SCHEDULE_STATUS_CANCELED:  vos.CanceledSchedule,
+		proto.ScheduleStatus_SCHEDULE_STATUS_COMPLETED: vos.CompletedSchedule,
+	}
+)
+
+func (a *API) CreateSchedule(ctx context.Context, request *proto.CreateScheduleRequest) (*proto.Schedule, error) {
+	id, err := uuid.Parse(request.Id)
+	if err != nil || id == uuid.Nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to parse schedule id")
+		return nil, invalidArgument("id", "invalid schedule id")
+	}
+
+	if request.StartAt == nil {
+		return nil, invalidArgument("start_at", "start_at must have a value")
+	}
+
+	recurrence, err := vos.ParseRecurrence(request.Recurrence)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to parse recurrence")
+		return nil, errorStatus(err, fieldViolation("recurrence", err.Error()))
+	}
+
+	book, err := vos.NewBookName(request.Book)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid book")
+		return nil, errorStatus(err, fieldViolation("book", err.Error()))
+	}
+
+	entries := make([]vos.ScheduledEntry, len(request.Entries))
+	for i, entry := range request.Entries {
+		metadata, mErr := entry.Metadata.MarshalJSON()
+		if mErr != nil {
+			zerolog.Ctx(ctx).Error().Err(mErr).Int("index", i).Msg("failed to marshal entry metadata")
+			return nil, invalidArgument(entryField(i, "metadata"), "invalid entry metadata")
+		}
+
+		version := vos.Version(entry.ExpectedVersion)
+		if version != vos.NextAccountVersion && version != vos.IgnoreAccountVersion {
+			return nil, errorStatus(app.ErrInvalidSchedule, fieldViolation(entryField(i, "expected_version"), "must be 0 (next version) or -1 (ignore version)"))
+		}
+
+		entries[i] = vos.ScheduledEntry{
+			Operation: vos.OperationType(proto.Operation_value[entry.Operation.String()]),
+			Account:   entry.Account,
+			Version:   version,
+			Amount:    int(entry.Amount),
+			Metadata:  metadata,
+		}
+
+		// The entries are validated here, where the failing one is known.
+		if _, entryErr := entities.NewEntry(uuid.New(), entries[i].Operation, entries[i].Account, version, entries[i].Amount, metadata); entryErr != nil {
+			zerolog.Ctx(ctx).Error().Err(entryErr).Int("index", i).Msg("failed to create entry")
+			return nil, errorStatus(entryErr, entryViolation(i, entryErr))
+		}
+	}
+
+	schedule, err := vos.NewSchedule(id, book, request.Company, request.Event, entries, request.StartAt.AsTime(), recurrence, time.Now())
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid schedule")
+		return nil, errorStatus(err, fieldViolation("start_at", err.Error()))
+	}
+
+	created, err := a.AdminUseCase.CreateSchedule(ctx, schedule)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to create schedule")
+		if errors.Is(err, app.ErrInvalidBalance) || errors.Is(err, app.ErrInvalidEntriesNumber) {
+			return nil, errorStatus(err, fieldViolation("entries", err.Error()))
+		}
+
+		return nil, errorStatus(err)
+	}
+
+	return scheduleToProto(created)
+}
+
+func (a *API) ListSchedules(ctx context.Context, request *proto.ListSchedulesRequest) (*proto.ListSchedulesResponse, error) {
+	page, err := pagination.NewPage(request.GetPage())
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create page reference")
+		return nil, errorStatus(err, pageViolation(err))
+	}
+
+	req := vos.ScheduleListRequest{
+		Status: _scheduleStatusesFromProto[request.Status],
+		Page:   page,
+	}
+
+	response, err := a.AdminUseCase.ListSchedules(ctx, req)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list schedules")
+		return nil, errorStatus(err)
+	}
+
+	schedules := make([]*proto.Schedule, 0, len(response.Schedules))
+	for _, schedule := range response.Schedules {
+		protoSchedule, err := scheduleToProto(schedule)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, protoSchedule)
+	}
+
+	return &proto.ListSchedulesResponse{
+		Schedules:     schedules,
+		NextPageToken: response.NextPage.Tokenize(),
+	}, nil
+}
+
+func (a *API) PauseSchedule(ctx context.Context, request *proto.ScheduleRequest) (*proto.Schedule, error) {
+	return a.changeSchedule(ctx, request, "pause", a.AdminUseCase.PauseSchedule)
+}
+
+func (a *API) ResumeSchedule(ctx context.Context, request *proto.ScheduleRequest) (*proto.Schedule, error) {
+	return a.changeSchedule(ctx, request, "resume", a.AdminUseCase.ResumeSchedule)
+}
+
+func (a *API) CancelSchedule(ctx context.Context, request *proto.ScheduleRequest) (*proto.Schedule, error) {
+	return a.changeSchedule(ctx, request, "cancel", a.AdminUseCase.CancelSchedule)
+}
+
+func (a *API) changeSchedule(
+	ctx context.Context,
+	request *proto.ScheduleRequest,
+	action string,
+	change func(context.Context, uuid.UUID) (vos.Schedule, error),
+) (*proto.Schedule, error) {
+	id, err := uuid.Parse(request.Id)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to parse schedule id")
+		return nil, invalidArgument("id", "invalid schedule id")
+	}
+
+	schedule, err := change(ctx, id)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("action", action).Msg("failed to change schedule")
+		return nil, errorStatus(err)
+	}
+
+	return scheduleToProto(schedule)
+}
+
+func scheduleToProto(schedule vos.Schedule) (*proto.Schedule, error) {
+	entries := make([]*proto.ScheduledEntry, 0, len(schedule.Entries))
+	for _, entry := range schedule.Entries {
+		protoEntry := &proto.ScheduledEntry{
+			Operation:       proto.Operation(entry.Operation),
+			Account:         entry.Account,
+			Amount:          int64(entry.Amount),
+			ExpectedVersion: entry.Version.AsInt64(),
+		}
+
+		if len(entry.Metadata) > 0 {
+			var document map[string]interface{}
+			if err := json.Unmarshal(entry.Metadata, &document); err != nil {
+				return nil, errorStatus(err)
+			}
+
+			metadata, err := structpb.NewStruct(document)
+			if err != nil {
+				return nil, errorStatus(err)
+			}
+
+			protoEntry.Metadata = metadata
+		}
+
+		entries = append(entries, protoEntry)
+	}
+
+	protoSchedule := &proto.Schedule{
+		Id:          schedule.ID.String(),
+		Book:        schedule.Book,
+		Company:     schedule.Company,
+		Event:       schedule.Event,
+		Entries:     entries,
+		StartAt:     timestamppb.New(schedule.StartAt),
+		Recurrence:  schedule.Recurrence.String(),
+		Status:      _scheduleStatusesToProto[schedule.Status],
+		Occurrences: int32(schedule.Occurrence),
+		LastError:   schedule.LastError,
+		CreatedAt:   timestamppb.New(schedule.CreatedAt),
+		UpdatedAt:   timestamppb.New(schedule.UpdatedAt),
+	}
+
+	if !schedule.NextRunAt.IsZero() {
+		protoSchedule.NextRunAt = timestamppb.New(schedule.NextRunAt)
+	}
+
+	return protoSchedule, nil
+}
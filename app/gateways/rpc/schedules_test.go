@@ -0,0 +1,293 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_CreateSchedule(t *testing.T) {
+	newRequest := func() *proto.CreateScheduleRequest {
+		return &proto.CreateScheduleRequest{
+			Id: uuid.New().String(),
+			Entries: []*proto.ScheduledEntry{
+				{
+					Operation: proto.Operation_OPERATION_DEBIT,
+					Account:   "liability.abc.account1",
+					Amount:    100,
+					Metadata:  &structpb.Struct{Fields: map[string]*structpb.Value{"rent": structpb.NewBoolValue(true)}},
+				},
+				{
+					Operation:       proto.Operation_OPERATION_CREDIT,
+					Account:         "liability.abc.account2",
+					Amount:          100,
+					ExpectedVersion: vos.IgnoreAccountVersion.AsInt64(),
+				},
+			},
+			StartAt:    timestamppb.New(time.Now().Add(time.Hour)),
+			Recurrence: "FREQ=MONTHLY;COUNT=12",
+			Company:    "abc",
+			Event:      1,
+		}
+	}
+
+	t.Run("should create the schedule", func(t *testing.T) {
+		adminUseCase := &mocks.AdminUseCaseMock{
+			CreateScheduleFunc: func(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+				schedule.CreatedAt = time.Now()
+				schedule.UpdatedAt = schedule.CreatedAt
+				return schedule, nil
+			},
+		}
+
+		api := NewAPI(&mocks.UseCaseMock{}, adminUseCase)
+		request := newRequest()
+
+		got, err := api.CreateSchedule(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, request.Id, got.Id)
+		assert.Equal(t, vos.DefaultBook, got.Book)
+		assert.Equal(t, "FREQ=MONTHLY;INTERVAL=1;COUNT=12", got.Recurrence)
+		assert.Equal(t, proto.ScheduleStatus_SCHEDULE_STATUS_ACTIVE, got.Status)
+		assert.Equal(t, request.StartAt.AsTime(), got.NextRunAt.AsTime())
+		require.Len(t, got.Entries, 2)
+		assert.True(t, got.Entries[0].Metadata.Fields["rent"].GetBoolValue())
+		assert.Equal(t, vos.IgnoreAccountVersion.AsInt64(), got.Entries[1].ExpectedVersion)
+
+		calls := adminUseCase.CreateScheduleCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, vos.DebitOperation, calls[0].Schedule.Entries[0].Operation)
+		assert.Equal(t, vos.Monthly, calls[0].Schedule.Recurrence.Frequency)
+	})
+
+	testCases := []struct {
+		name          string
+		request       func() *proto.CreateScheduleRequest
+		useCaseErr    error
+		expectedCode  codes.Code
+		expectedField string
+	}{
+		{
+			name: "should return invalid argument for an invalid id",
+			request: func() *proto.CreateScheduleRequest {
+				request := newRequest()
+				request.Id = "invalid"
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "id",
+		},
+		{
+			name: "should return invalid argument for a missing start",
+			request: func() *proto.CreateScheduleRequest {
+				request := newRequest()
+				request.StartAt = nil
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "start_at",
+		},
+		{
+			name: "should return invalid argument for a past start",
+			request: func() *proto.CreateScheduleRequest {
+				request := newRequest()
+				request.StartAt = timestamppb.New(time.Now().Add(-time.Hour))
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "start_at",
+		},
+		{
+			name: "should return invalid argument for an invalid recurrence",
+			request: func() *proto.CreateScheduleRequest {
+				request := newRequest()
+				request.Recurrence = "FREQ=HOURLY"
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "recurrence",
+		},
+		{
+			name: "should return invalid argument for an invalid book",
+			request: func() *proto.CreateScheduleRequest {
+				request := newRequest()
+				request.Book = "Invalid.Book"
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "book",
+		},
+		{
+			name: "should return invalid argument for an expected version",
+			request: func() *proto.CreateScheduleRequest {
+				request := newRequest()
+				request.Entries[0].ExpectedVersion = 3
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "entries[0].expected_version",
+		},
+		{
+			name: "should return invalid argument for an invalid entry amount",
+			request: func() *proto.CreateScheduleRequest {
+				request := newRequest()
+				request.Entries[1].Amount = 0
+				return request
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "entries[1].amount",
+		},
+		{
+			name:          "should return invalid argument for unbalanced entries",
+			request:       newRequest,
+			useCaseErr:    app.ErrInvalidBalance,
+			expectedCode:  codes.InvalidArgument,
+			expectedField: "entries",
+		},
+		{
+			name:         "should return already exists for a created schedule",
+			request:      newRequest,
+			useCaseErr:   app.ErrIdempotencyKeyViolation,
+			expectedCode: codes.AlreadyExists,
+		},
+		{
+			name:         "should return not found for an unknown book",
+			request:      newRequest,
+			useCaseErr:   app.ErrBookNotFound,
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			adminUseCase := &mocks.AdminUseCaseMock{
+				CreateScheduleFunc: func(ctx context.Context, schedule vos.Schedule) (vos.Schedule, error) {
+					return vos.Schedule{}, tt.useCaseErr
+				},
+			}
+
+			api := NewAPI(&mocks.UseCaseMock{}, adminUseCase)
+
+			_, err := api.CreateSchedule(context.Background(), tt.request())
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.expectedCode, st.Code())
+
+			if tt.expectedField != "" {
+				_, badRequest := statusDetails(t, st)
+				require.NotNil(t, badRequest)
+				assert.Equal(t, tt.expectedField, badRequest.FieldViolations[0].Field)
+			}
+		})
+	}
+}
+
+func TestAPI_ListSchedules(t *testing.T) {
+	t.Run("should list the schedules with the status", func(t *testing.T) {
+		schedule := vos.Schedule{
+			ID:        uuid.New(),
+			Book:      vos.DefaultBook,
+			StartAt:   time.Now(),
+			Status:    vos.CompletedSchedule,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		adminUseCase := &mocks.AdminUseCaseMock{
+			ListSchedulesFunc: func(ctx context.Context, req vos.ScheduleListRequest) (vos.ScheduleListResponse, error) {
+				return vos.ScheduleListResponse{Schedules: []vos.Schedule{schedule}}, nil
+			},
+		}
+
+		api := NewAPI(&mocks.UseCaseMock{}, adminUseCase)
+
+		got, err := api.ListSchedules(context.Background(), &proto.ListSchedulesRequest{Status: proto.ScheduleStatus_SCHEDULE_STATUS_COMPLETED})
+		require.NoError(t, err)
+		require.Len(t, got.Schedules, 1)
+		assert.Equal(t, schedule.ID.String(), got.Schedules[0].Id)
+		assert.Equal(t, proto.ScheduleStatus_SCHEDULE_STATUS_COMPLETED, got.Schedules[0].Status)
+		assert.Nil(t, got.Schedules[0].NextRunAt)
+		assert.Empty(t, got.NextPageToken)
+
+		calls := adminUseCase.ListSchedulesCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, vos.CompletedSchedule, calls[0].ScheduleListRequest.Status)
+		assert.Equal(t, 10, calls[0].ScheduleListRequest.Page.Size)
+	})
+
+	t.Run("should return invalid argument for an invalid page token", func(t *testing.T) {
+		api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{})
+
+		_, err := api.ListSchedules(context.Background(), &proto.ListSchedulesRequest{Page: &proto.RequestPagination{PageToken: "invalid"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestAPI_ChangeSchedule(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("should change the schedule", func(t *testing.T) {
+		change := func(status vos.ScheduleStatus) func(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+			return func(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+				return vos.Schedule{ID: id, Status: status}, nil
+			}
+		}
+
+		api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{
+			PauseScheduleFunc:  change(vos.PausedSchedule),
+			ResumeScheduleFunc: change(vos.ActiveSchedule),
+			CancelScheduleFunc: change(vos.CanceledSchedule),
+		})
+		request := &proto.ScheduleRequest{Id: id.String()}
+
+		got, err := api.PauseSchedule(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, proto.ScheduleStatus_SCHEDULE_STATUS_PAUSED, got.Status)
+
+		got, err = api.ResumeSchedule(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, proto.ScheduleStatus_SCHEDULE_STATUS_ACTIVE, got.Status)
+
+		got, err = api.CancelSchedule(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, proto.ScheduleStatus_SCHEDULE_STATUS_CANCELED, got.Status)
+	})
+
+	testCases := []struct {
+		name         string
+		id           string
+		useCaseErr   error
+		expectedCode codes.Code
+	}{
+		{name: "should return invalid argument for an invalid id", id: "invalid", expectedCode: codes.InvalidArgument},
+		{name: "should return not found for an unknown schedule", id: id.String(), useCaseErr: app.ErrScheduleNotFound, expectedCode: codes.NotFound},
+		{name: "should return failed precondition for a finished schedule", id: id.String(), useCaseErr: app.ErrInvalidScheduleStatus, expectedCode: codes.FailedPrecondition},
+		{name: "should return aborted for a concurrent change", id: id.String(), useCaseErr: app.ErrScheduleConflict, expectedCode: codes.Aborted},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(&mocks.UseCaseMock{}, &mocks.AdminUseCaseMock{
+				PauseScheduleFunc: func(ctx context.Context, id uuid.UUID) (vos.Schedule, error) {
+					return vos.Schedule{}, tt.useCaseErr
+				},
+			})
+
+			_, err := api.PauseSchedule(context.Background(), &proto.ScheduleRequest{Id: tt.id})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,24 +10,57 @@ import (
 	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpcRecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"github.com/newrelic/go-agent/v3/integrations/nrgrpc"
-	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
 	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain"
 	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
 	httpHandlers "github.com/stone-co/the-amazing-ledger/app/gateways/http"
+	"github.com/stone-co/the-amazing-ledger/app/gateways/rpc/auth"
+	"github.com/stone-co/the-amazing-ledger/app/instrumentation"
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
 )
 
-func NewServer(ctx context.Context, useCase *usecases.LedgerUseCase, nr *newrelic.Application, cfg *app.Config, commit, time string) (*grpc.Server, *http.Server, error) {
-	api := NewAPI(useCase)
+func NewServer(ctx context.Context, useCase *usecases.LedgerUseCase, adminUseCase *usecases.AdminUseCase, health *Health, tracer instrumentation.Tracer, cfg *app.Config, commit, time string) (*grpc.Server, *http.Server, error) {
+	// The admin service is left out when there is no admin use case, as happens with the memory storage.
+	var admin domain.AdminUseCase
+	if adminUseCase != nil {
+		admin = adminUseCase
+	}
+
+	api := NewAPI(useCase, admin)
+	api.health = health
+
+	tlsConfig, err := auth.LoadServerTLS(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tls config: %w", err)
+	}
+
+	interceptors, err := newAuthInterceptors(ctx, cfg.Auth, tlsConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to configure authentication: %w", err)
+	}
+
+	grpcServer := newRPCServer(api, tracer, auth.NewServerCredentials(tlsConfig), interceptors...)
+
+	// The first check happens before serving, so calls aren't accepted while dependencies are down.
+	health.updateWithin(ctx, cfg.Health.CheckTimeout)
+	go health.Run(ctx, cfg.Health.CheckInterval, cfg.Health.CheckTimeout)
 
-	grpcServer := newRPCServer(api, nr)
+	// The gateway reaches the rpc server in process, so its calls go through the same interceptors.
+	internal := auth.NewInternalListener()
+	go func() {
+		if serveErr := grpcServer.Serve(internal); serveErr != nil {
+			zerolog.Ctx(ctx).Error().Err(serveErr).Msg("internal rpc listener stopped")
+		}
+	}()
 
-	server, err := newGatewayServer(ctx, cfg, commit, time)
+	server, err := newGatewayServer(ctx, cfg, health, tracer, internal, tlsConfig, commit, time)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create new GRPC server: %w", err)
 	}
@@ -34,7 +68,32 @@ func NewServer(ctx context.Context, useCase *usecases.LedgerUseCase, nr *newreli
 	return grpcServer, server, nil
 }
 
-func newRPCServer(api *API, nr *newrelic.Application) *grpc.Server {
+func newAuthInterceptors(ctx context.Context, cfg app.AuthConfig, tlsConfig *tls.Config) ([]grpc.UnaryServerInterceptor, error) {
+	if len(cfg.Methods) == 0 {
+		return nil, nil
+	}
+
+	authenticator, err := auth.NewAuthenticator(ctx, auth.AuthenticatorConfig{
+		Methods:            cfg.Methods,
+		MTLS:               tlsConfig != nil && tlsConfig.ClientCAs != nil,
+		JWKSFile:           cfg.JWKSFile,
+		JWTIssuer:          cfg.JWTIssuer,
+		JWTAudience:        cfg.JWTAudience,
+		JWKSReloadInterval: cfg.JWKSReloadInterval,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := auth.LoadPolicy(cfg.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.UnaryServerInterceptor{authInterceptor(authenticator, policy)}, nil
+}
+
+func newRPCServer(api *API, tracer instrumentation.Tracer, creds credentials.TransportCredentials, interceptors ...grpc.UnaryServerInterceptor) *grpc.Server {
 	// Define a func to handle panic
 	dealPanic := func(p interface{}) (err error) {
 		log.Printf("panic triggered: %v", p)
@@ -45,29 +104,51 @@ func newRPCServer(api *API, nr *newrelic.Application) *grpc.Server {
 		grpcRecovery.WithRecoveryHandler(dealPanic),
 	}
 
+	unary := append([]grpc.UnaryServerInterceptor{
+		grpcRecovery.UnaryServerInterceptor(opts...),
+		tracer.UnaryServerInterceptor(),
+		loggerInterceptor,
+	}, interceptors...)
+
 	srv := grpc.NewServer(
-		grpcMiddleware.WithUnaryServerChain(
-			grpcRecovery.UnaryServerInterceptor(opts...),
-			nrgrpc.UnaryServerInterceptor(nr),
-			loggerInterceptor,
-		),
+		grpc.Creds(creds),
+		grpcMiddleware.WithUnaryServerChain(unary...),
 		grpcMiddleware.WithStreamServerChain(
 			grpcRecovery.StreamServerInterceptor(opts...),
-			nrgrpc.StreamServerInterceptor(nr),
+			tracer.StreamServerInterceptor(),
 		),
 	)
 
 	proto.RegisterLedgerServiceServer(srv, api)
+	if api.AdminUseCase != nil {
+		proto.RegisterAdminServiceServer(srv, api)
+	}
 	proto.RegisterHealthServer(srv, api)
 
+	if api.health != nil {
+		healthpb.RegisterHealthServer(srv, api.health.server)
+
+		services := make([]string, 0, len(srv.GetServiceInfo()))
+		for service := range srv.GetServiceInfo() {
+			services = append(services, service)
+		}
+		api.health.register(services)
+	}
+
 	return srv
 }
 
-func newGatewayServer(ctx context.Context, cfg *app.Config, commit, time string) (*http.Server, error) {
-	gwMux := runtime.NewServeMux()
-	gwEndpoint := fmt.Sprintf("%s:%d", cfg.RPCServer.Host, cfg.RPCServer.Port)
+func newGatewayServer(ctx context.Context, cfg *app.Config, health *Health, tracer instrumentation.Tracer, internal *auth.InternalListener, tlsConfig *tls.Config, commit, time string) (*http.Server, error) {
+	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(auth.IncomingHeaderMatcher),
+		runtime.WithMetadata(auth.ForwardClientCertificate),
+	)
 
-	conn, err := grpc.DialContext(ctx, gwEndpoint, grpc.WithInsecure())
+	conn, err := grpc.DialContext(ctx, "internal",
+		grpc.WithContextDialer(internal.Dial),
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(tracer.UnaryClientInterceptor()),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial server: %w", err)
 	}
@@ -77,6 +158,11 @@ func newGatewayServer(ctx context.Context, cfg *app.Config, commit, time string)
 		return nil, fmt.Errorf("failed to register ledger handler: %w", err)
 	}
 
+	err = proto.RegisterAdminServiceHandler(ctx, gwMux, conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register admin handler: %w", err)
+	}
+
 	err = proto.RegisterHealthHandler(ctx, gwMux, conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register health handler: %w", err)
@@ -87,6 +173,16 @@ func newGatewayServer(ctx context.Context, cfg *app.Config, commit, time string)
 		return nil, fmt.Errorf("failed to configure metrics handler: %w", err)
 	}
 
+	err = gwMux.HandlePath(http.MethodGet, "/health/live", httpHandlers.LivenessHandler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure liveness handler: %w", err)
+	}
+
+	err = gwMux.HandlePath(http.MethodGet, "/health/ready", httpHandlers.ReadinessHandler(health.Ready))
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure readiness handler: %w", err)
+	}
+
 	err = gwMux.HandlePath(http.MethodGet, "/version", httpHandlers.VersionHandler(commit, time))
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure version handler: %w", err)
@@ -94,9 +190,10 @@ func newGatewayServer(ctx context.Context, cfg *app.Config, commit, time string)
 
 	gwServer := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.HttpServer.Host, cfg.HttpServer.Port),
-		Handler:      gwMux,
+		Handler:      tracer.HTTPHandler(gwMux),
 		ReadTimeout:  cfg.HttpServer.ReadTimeout,
 		WriteTimeout: cfg.HttpServer.WriteTimeout,
+		TLSConfig:    tlsConfig,
 	}
 
 	return gwServer, nil
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func (a *API) RebuildSnapshots(ctx context.Context, request *proto.RebuildSnapshotsRequest) (*proto.RebuildSnapshotsResponse, error) {
+	account, err := vos.NewAccount(request.Account)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("can't create account name")
+		return nil, errorStatus(err, fieldViolation("account", err.Error()))
+	}
+
+	ctx, err = withBook(ctx, request.Book)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := a.AdminUseCase.RebuildSnapshots(ctx, account)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to rebuild snapshots")
+		return nil, errorStatus(err)
+	}
+
+	return &proto.RebuildSnapshotsResponse{
+		Rebuilt: int64(total),
+	}, nil
+}
+
+func (a *API) PruneSnapshots(ctx context.Context, request *proto.PruneSnapshotsRequest) (*proto.PruneSnapshotsResponse, error) {
+	retention := time.Duration(request.UnreadDays) * 24 * time.Hour
+
+	total, err := a.AdminUseCase.PruneSnapshots(ctx, retention)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to prune snapshots")
+		if errors.Is(err, app.ErrInvalidSnapshotRetention) {
+			return nil, errorStatus(err, fieldViolation("unread_days", err.Error()))
+		}
+
+		return nil, errorStatus(err)
+	}
+
+	return &proto.PruneSnapshotsResponse{
+		Pruned: int64(total),
+	}, nil
+}
+
+func (a *API) PrecomputeSnapshots(ctx context.Context, request *proto.PrecomputeSnapshotsRequest) (*proto.PrecomputeSnapshotsResponse, error) {
+	total, err := a.AdminUseCase.PrecomputeSnapshots(ctx, int(request.Limit))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to precompute snapshots")
+		if errors.Is(err, app.ErrInvalidSnapshotLimit) {
+			return nil, errorStatus(err, fieldViolation("limit", err.Error()))
+		}
+
+		return nil, errorStatus(err)
+	}
+
+	return &proto.PrecomputeSnapshotsResponse{
+		Precomputed: int64(total),
+	}, nil
+}
@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
+	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
+)
+
+func TestAPI_RebuildSnapshots(t *testing.T) {
+	testCases := []struct {
+		name         string
+		request      *proto.RebuildSnapshotsRequest
+		useCaseErr   error
+		expectedCode codes.Code
+		expected     *proto.RebuildSnapshotsResponse
+	}{
+		{
+			name:     "should rebuild snapshots successfully",
+			request:  &proto.RebuildSnapshotsRequest{Account: "liability.clients.*"},
+			expected: &proto.RebuildSnapshotsResponse{Rebuilt: 3},
+		},
+		{
+			name:         "should return invalid argument for an invalid account",
+			request:      &proto.RebuildSnapshotsRequest{Account: "liability..clients"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "should return internal error when the rebuild fails",
+			request:      &proto.RebuildSnapshotsRequest{Account: "liability.clients.*"},
+			useCaseErr:   errors.New("database unavailable"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedAdminUsecase := &mocks.AdminUseCaseMock{
+				RebuildSnapshotsFunc: func(ctx context.Context, account vos.Account) (int, error) {
+					return 3, tt.useCaseErr
+				},
+			}
+			api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+			got, err := api.RebuildSnapshots(context.Background(), tt.request)
+			if tt.expected != nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, got)
+				return
+			}
+
+			respStatus, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedCode, respStatus.Code())
+		})
+	}
+}
+
+func TestAPI_PruneSnapshots(t *testing.T) {
+	t.Run("should prune snapshots successfully", func(t *testing.T) {
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			PruneSnapshotsFunc: func(ctx context.Context, retention time.Duration) (int, error) {
+				return 7, nil
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		got, err := api.PruneSnapshots(context.Background(), &proto.PruneSnapshotsRequest{UnreadDays: 30})
+		assert.NoError(t, err)
+		assert.Equal(t, &proto.PruneSnapshotsResponse{Pruned: 7}, got)
+
+		calls := mockedAdminUsecase.PruneSnapshotsCalls()
+		assert.Len(t, calls, 1)
+		assert.Equal(t, 30*24*time.Hour, calls[0].Duration)
+	})
+
+	t.Run("should return invalid argument for an invalid retention", func(t *testing.T) {
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			PruneSnapshotsFunc: func(ctx context.Context, retention time.Duration) (int, error) {
+				return 0, app.ErrInvalidSnapshotRetention
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		_, err := api.PruneSnapshots(context.Background(), &proto.PruneSnapshotsRequest{})
+
+		respStatus, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, respStatus.Code())
+	})
+}
+
+func TestAPI_PrecomputeSnapshots(t *testing.T) {
+	t.Run("should precompute snapshots successfully", func(t *testing.T) {
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			PrecomputeSnapshotsFunc: func(ctx context.Context, limit int) (int, error) {
+				return 4, nil
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		got, err := api.PrecomputeSnapshots(context.Background(), &proto.PrecomputeSnapshotsRequest{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, &proto.PrecomputeSnapshotsResponse{Precomputed: 4}, got)
+	})
+
+	t.Run("should return internal error when the precompute fails", func(t *testing.T) {
+		mockedAdminUsecase := &mocks.AdminUseCaseMock{
+			PrecomputeSnapshotsFunc: func(ctx context.Context, limit int) (int, error) {
+				return 0, errors.New("database unavailable")
+			},
+		}
+		api := NewAPI(&mocks.UseCaseMock{}, mockedAdminUsecase)
+
+		_, err := api.PrecomputeSnapshots(context.Background(), &proto.PrecomputeSnapshotsRequest{Limit: 10})
+
+		respStatus, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, respStatus.Code())
+	})
+}
@@ -3,13 +3,13 @@ package rpc
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
@@ -17,17 +17,16 @@ import (
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
 )
 
-func (a *API) CreateTransaction(ctx context.Context, req *proto.CreateTransactionRequest) (*emptypb.Empty, error) {
+func (a *API) CreateTransaction(ctx context.Context, req *proto.CreateTransactionRequest) (*proto.CreateTransactionResponse, error) {
 	tid, err := uuid.Parse(req.Id)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to parse transaction id")
-		return nil, status.Error(codes.InvalidArgument, "invalid transaction id")
+		return nil, errorStatus(app.ErrInvalidTransactionID, fieldViolation("id", app.ErrInvalidTransactionID.Error()))
 	}
 
-	if req.CompetenceDate == nil {
-		return nil, status.Error(codes.InvalidArgument, "competence_date must have a value")
-	} else if !req.CompetenceDate.IsValid() {
-		return nil, status.Error(codes.InvalidArgument, "competence_date must be valid")
+	competenceDate, err := parseCompetenceDate(req.CompetenceDate)
+	if err != nil {
+		return nil, err
 	}
 
 	domainEntries := make([]entities.Entry, len(req.Entries))
@@ -35,13 +34,13 @@ func (a *API) CreateTransaction(ctx context.Context, req *proto.CreateTransactio
 		entryID, entryErr := uuid.Parse(entry.Id)
 		if entryErr != nil {
 			zerolog.Ctx(ctx).Error().Err(entryErr).Int("index", i).Msg("failed to parse entry id")
-			return nil, status.Error(codes.InvalidArgument, "invalid entry id")
+			return nil, errorStatus(app.ErrInvalidEntryID, entryViolation(i, app.ErrInvalidEntryID))
 		}
 
 		metadata, mErr := entry.Metadata.MarshalJSON()
 		if mErr != nil {
 			zerolog.Ctx(ctx).Error().Err(mErr).Int("index", i).Msg("failed to marshal entry metadata")
-			return nil, status.Error(codes.InvalidArgument, "invalid entry metadata")
+			return nil, invalidArgument(entryField(i, "metadata"), "invalid entry metadata")
 		}
 
 		domainEntry, domainErr := entities.NewEntry(
@@ -54,34 +53,128 @@ func (a *API) CreateTransaction(ctx context.Context, req *proto.CreateTransactio
 		)
 		if domainErr != nil {
 			zerolog.Ctx(ctx).Error().Err(domainErr).Int("index", i).Msg("failed to create entry")
-			return nil, status.Error(codes.InvalidArgument, domainErr.Error())
+			return nil, errorStatus(domainErr, entryViolation(i, domainErr))
 		}
 
 		domainEntries[i] = domainEntry
 	}
 
-	competenceDate := time.Unix(req.CompetenceDate.Seconds, 0).UTC()
-	if competenceDate.After(time.Now().UTC()) {
-		return nil, status.Error(codes.InvalidArgument, "competence date set to the future")
-	}
-
 	tx, err := entities.NewTransaction(tid, req.Event, req.Company, competenceDate, domainEntries...)
 	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to create transaction")
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, errorStatus(err, transactionViolation(err))
+	}
+
+	ctx, err = withBook(ctx, req.Book)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := a.UseCase.CreateTransaction(ctx, tx); err != nil {
+	if req.IncludeBalances {
+		ctx = vos.WithResultingBalances(ctx)
+	}
+
+	if req.DryRun {
+		ctx = vos.WithSimulation(ctx)
+	}
+
+	result, err := a.UseCase.CreateTransaction(ctx, tx)
+	if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to save transaction")
-		switch {
-		case errors.Is(err, app.ErrInvalidVersion):
-			return nil, status.Error(codes.InvalidArgument, "invalid account version")
-		case errors.Is(err, app.ErrIdempotencyKeyViolation):
-			return nil, status.Error(codes.InvalidArgument, "invalid idempotency key")
-		default:
-			return nil, status.Error(codes.Internal, "internal server error")
+		return nil, errorStatus(err, append(conflictViolations(req, err), metadataViolations(req, err)...)...)
+	}
+
+	return transactionResultToProto(result), nil
+}
+
+// parseCompetenceDate reads the competence date of a transaction, which can't be in the future.
+func parseCompetenceDate(date *timestamppb.Timestamp) (time.Time, error) {
+	if date == nil {
+		return time.Time{}, invalidArgument("competence_date", "competence_date must have a value")
+	} else if !date.IsValid() {
+		return time.Time{}, invalidArgument("competence_date", "competence_date must be valid")
+	}
+
+	competenceDate := time.Unix(date.Seconds, 0).UTC()
+	if competenceDate.After(time.Now().UTC()) {
+		return time.Time{}, invalidArgument("competence_date", "competence date set to the future")
+	}
+
+	return competenceDate, nil
+}
+
+func transactionResultToProto(result vos.TransactionResult) *proto.CreateTransactionResponse {
+	entries := make([]*proto.PostedEntry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		entries = append(entries, &proto.PostedEntry{
+			Id:      entry.ID.String(),
+			Account: entry.Account.Value(),
+			Version: entry.Version.AsInt64(),
+		})
+	}
+
+	balances := make([]*proto.GetAccountBalanceResponse, 0, len(result.Balances))
+	for _, balance := range result.Balances {
+		balances = append(balances, accountBalanceToProto(balance))
+	}
+
+	response := &proto.CreateTransactionResponse{
+		ConsistencyToken: string(result.ConsistencyToken),
+		Entries:          entries,
+		Balances:         balances,
+	}
+
+	if !result.PostedAt.IsZero() {
+		response.PostedAt = timestamppb.New(result.PostedAt)
+	}
+
+	return response
+}
+
+// transactionViolation is the field violation of an error returned by entities.NewTransaction.
+func transactionViolation(err error) *errdetails.BadRequest_FieldViolation {
+	if errors.Is(err, app.ErrInvalidTransactionID) {
+		return fieldViolation("id", err.Error())
+	}
+
+	return fieldViolation("entries", err.Error())
+}
+
+// conflictViolations points a version conflict to the expected versions of the entries of its account.
+func conflictViolations(req *proto.CreateTransactionRequest, err error) []*errdetails.BadRequest_FieldViolation {
+	var conflict vos.VersionConflictError
+	if !errors.As(err, &conflict) {
+		return nil
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, entry := range req.Entries {
+		if entry.Account == conflict.Account && vos.Version(entry.ExpectedVersion) != vos.IgnoreAccountVersion {
+			violations = append(violations, fieldViolation(entryField(i, "expected_version"), conflict.Error()))
+		}
+	}
+
+	return violations
+}
+
+// metadataViolations points a schema violation to the metadata of the entries that break it.
+func metadataViolations(req *proto.CreateTransactionRequest, err error) []*errdetails.BadRequest_FieldViolation {
+	var schemaErr vos.MetadataViolationError
+	if !errors.As(err, &schemaErr) {
+		return nil
+	}
+
+	byEntry := make(map[string][]string, len(schemaErr.Violations))
+	for _, violation := range schemaErr.Violations {
+		byEntry[violation.EntryID.String()] = violation.Errors
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, entry := range req.Entries {
+		if errs, ok := byEntry[strings.ToLower(entry.Id)]; ok {
+			violations = append(violations, fieldViolation(entryField(i, "metadata"), strings.Join(errs, "; ")))
 		}
 	}
 
-	return &emptypb.Empty{}, nil
+	return violations
 }
@@ -3,17 +3,18 @@ package rpc
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	"github.com/stone-co/the-amazing-ledger/app/tests/mocks"
 	"github.com/stone-co/the-amazing-ledger/app/tests/testdata"
 	proto "github.com/stone-co/the-amazing-ledger/gen/ledger"
@@ -28,8 +29,8 @@ func TestAPI_CreateTransaction_Success(t *testing.T) {
 		{
 			name: "should succeed when create a transaction",
 			useCaseSetup: &mocks.UseCaseMock{
-				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) error {
-					return nil
+				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+					return vos.TransactionResult{ConsistencyToken: "0/16B3748"}, nil
 				},
 			},
 			request: &proto.CreateTransactionRequest{
@@ -77,11 +78,11 @@ func TestAPI_CreateTransaction_Success(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api := NewAPI(tt.useCaseSetup)
+			api := NewAPI(tt.useCaseSetup, &mocks.AdminUseCaseMock{})
 
 			got, err := api.CreateTransaction(context.Background(), tt.request)
 			assert.NoError(t, err)
-			assert.Equal(t, &emptypb.Empty{}, got)
+			assert.Equal(t, "0/16B3748", got.ConsistencyToken)
 		})
 	}
 }
@@ -209,8 +210,13 @@ func TestAPI_CreateTransaction_InvalidRequest(t *testing.T) {
 			expectedMessage: "invalid entries number",
 		},
 		{
-			name:         "should not create transaction when account is invalid",
-			useCaseSetup: &mocks.UseCaseMock{},
+			name: "should not create transaction when account is invalid",
+			// The chart of accounts is checked by the use case.
+			useCaseSetup: &mocks.UseCaseMock{
+				CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+					return vos.TransactionResult{}, app.ErrAccountPathViolation
+				},
+			},
 			request: &proto.CreateTransactionRequest{
 				Id: uuid.New().String(),
 				Entries: []*proto.Entry{
@@ -240,7 +246,7 @@ func TestAPI_CreateTransaction_InvalidRequest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api := NewAPI(tt.useCaseSetup)
+			api := NewAPI(tt.useCaseSetup, &mocks.AdminUseCaseMock{})
 
 			_, err := api.CreateTransaction(context.Background(), tt.request)
 			respStatus, ok := status.FromError(err)
@@ -251,3 +257,97 @@ func TestAPI_CreateTransaction_InvalidRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestAPI_CreateTransaction_Result(t *testing.T) {
+	postedAt := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	debitAccount := testdata.GenerateAccountPath()
+	creditAccount := testdata.GenerateAccountPath()
+
+	useCase := &mocks.UseCaseMock{
+		CreateTransactionFunc: func(ctx context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
+			result := vos.TransactionResult{ConsistencyToken: "0/16B3748", PostedAt: postedAt}
+			for i, entry := range transaction.Entries {
+				result.Entries = append(result.Entries, vos.PostedEntry{ID: entry.ID, Account: entry.Account, Version: vos.Version(i + 1)})
+
+				if vos.ResultingBalancesFromContext(ctx) || vos.SimulationFromContext(ctx) {
+					result.Balances = append(result.Balances, vos.NewAnalyticAccountBalance(entry.Account, vos.Version(i+1), 123))
+				}
+			}
+
+			return result, nil
+		},
+	}
+
+	newRequest := func(includeBalances bool) *proto.CreateTransactionRequest {
+		return &proto.CreateTransactionRequest{
+			Id: uuid.New().String(),
+			Entries: []*proto.Entry{
+				{
+					Id:              uuid.New().String(),
+					Account:         debitAccount,
+					ExpectedVersion: vos.NextAccountVersion.AsInt64(),
+					Operation:       proto.Operation_OPERATION_DEBIT,
+					Amount:          123,
+				},
+				{
+					Id:              uuid.New().String(),
+					Account:         creditAccount,
+					ExpectedVersion: vos.NextAccountVersion.AsInt64(),
+					Operation:       proto.Operation_OPERATION_CREDIT,
+					Amount:          123,
+				},
+			},
+			Company:         "abc",
+			Event:           1,
+			CompetenceDate:  timestamppb.Now(),
+			IncludeBalances: includeBalances,
+		}
+	}
+
+	t.Run("should return the versions of the entries", func(t *testing.T) {
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+
+		request := newRequest(false)
+		got, err := api.CreateTransaction(context.Background(), request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "0/16B3748", got.ConsistencyToken)
+		assert.Equal(t, postedAt, got.PostedAt.AsTime())
+		assert.Empty(t, got.Balances)
+		assert.Len(t, got.Entries, 2)
+
+		for _, entry := range got.Entries {
+			assert.NotZero(t, entry.Version)
+			assert.Contains(t, []string{request.Entries[0].Id, request.Entries[1].Id}, entry.Id)
+		}
+	})
+
+	t.Run("should return the balances when requested", func(t *testing.T) {
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+
+		got, err := api.CreateTransaction(context.Background(), newRequest(true))
+		assert.NoError(t, err)
+
+		assert.Len(t, got.Balances, 2)
+		for _, balance := range got.Balances {
+			assert.Contains(t, []string{debitAccount, creditAccount}, balance.Account)
+			assert.Equal(t, int64(123), balance.Balance)
+		}
+	})
+
+	t.Run("should simulate the transaction on dry runs", func(t *testing.T) {
+		api := NewAPI(useCase, &mocks.AdminUseCaseMock{})
+
+		request := newRequest(false)
+		request.DryRun = true
+
+		got, err := api.CreateTransaction(context.Background(), request)
+		assert.NoError(t, err)
+
+		assert.Len(t, got.Entries, 2)
+		assert.Len(t, got.Balances, 2)
+
+		calls := useCase.CreateTransactionCalls()
+		assert.True(t, vos.SimulationFromContext(calls[len(calls)-1].ContextMoqParam))
+	})
+}
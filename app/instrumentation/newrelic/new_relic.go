@@ -3,10 +3,16 @@ package newrelic
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/newrelic/go-agent/v3/integrations/nrgrpc"
 	"github.com/newrelic/go-agent/v3/integrations/nrlogrus"
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain"
 )
 
 func NewApp(appName, licenseKey string, log *logrus.Entry) (*newrelic.Application, error) {
@@ -39,7 +45,28 @@ func NewApp(appName, licenseKey string, log *logrus.Entry) (*newrelic.Applicatio
 	return nrApp, nil
 }
 
-func NewDatastoreSegment(ctx context.Context, collection, operation, query string) *newrelic.DatastoreSegment {
+var _ domain.Instrumentator = &Tracer{}
+
+// Tracer records segments in the New Relic transaction of the context, which is started by the
+// rpc interceptors.
+type Tracer struct {
+	app *newrelic.Application
+}
+
+func NewTracer(app *newrelic.Application) *Tracer {
+	return &Tracer{
+		app: app,
+	}
+}
+
+func (t Tracer) MonitorSegment(ctx context.Context, name string) (context.Context, domain.Segment) {
+	txn := newrelic.FromContext(ctx)
+	seg := &newrelic.Segment{Name: name}
+	seg.StartTime = txn.StartSegmentNow()
+	return ctx, seg
+}
+
+func (t Tracer) MonitorDataSegment(ctx context.Context, collection, operation, query string) domain.Segment {
 	txn := newrelic.FromContext(ctx)
 	seg := &newrelic.DatastoreSegment{
 		Product:            newrelic.DatastorePostgres,
@@ -50,3 +77,30 @@ func NewDatastoreSegment(ctx context.Context, collection, operation, query strin
 	seg.StartTime = txn.StartSegmentNow()
 	return seg
 }
+
+func (t Tracer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return nrgrpc.UnaryServerInterceptor(t.app)
+}
+
+func (t Tracer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return nrgrpc.StreamServerInterceptor(t.app)
+}
+
+func (t Tracer) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return nrgrpc.UnaryClientInterceptor
+}
+
+// HTTPHandler returns the handler as is: the gateway calls are traced by the rpc interceptors.
+func (t Tracer) HTTPHandler(handler http.Handler) http.Handler {
+	return handler
+}
+
+func (t Tracer) Shutdown(ctx context.Context) error {
+	timeout := 5 * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	t.app.Shutdown(timeout)
+	return nil
+}
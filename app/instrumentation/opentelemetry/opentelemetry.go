@@ -0,0 +1,133 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+)
+
+const instrumentationName = "github.com/stone-co/the-amazing-ledger"
+
+var _ domain.Instrumentator = &Tracer{}
+
+// Tracer records segments as OpenTelemetry spans, exported through OTLP. The trace context is
+// propagated in the W3C traceparent and baggage headers, both from http requests and rpc metadata.
+type Tracer struct {
+	provider   trace.TracerProvider
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	shutdown   func(context.Context) error
+}
+
+// NewTracer creates a Tracer that exports its spans to the OTLP collector of cfg.
+func NewTracer(ctx context.Context, cfg app.OpenTelemetryConfig) (*Tracer, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otel resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	return newTracer(provider, provider.Shutdown), nil
+}
+
+func newTracer(provider trace.TracerProvider, shutdown func(context.Context) error) *Tracer {
+	return &Tracer{
+		provider:   provider,
+		tracer:     provider.Tracer(instrumentationName),
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+		shutdown:   shutdown,
+	}
+}
+
+func (t Tracer) MonitorSegment(ctx context.Context, name string) (context.Context, domain.Segment) {
+	ctx, span := t.tracer.Start(ctx, name)
+	return ctx, segment{span: span}
+}
+
+func (t Tracer) MonitorDataSegment(ctx context.Context, collection, operation, query string) domain.Segment {
+	_, span := t.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBSQLTableKey.String(collection),
+			semconv.DBOperationKey.String(operation),
+			semconv.DBStatementKey.String(query),
+		),
+	)
+	return segment{span: span}
+}
+
+func (t Tracer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor(t.grpcOptions()...)
+}
+
+func (t Tracer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return otelgrpc.StreamServerInterceptor(t.grpcOptions()...)
+}
+
+func (t Tracer) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return otelgrpc.UnaryClientInterceptor(t.grpcOptions()...)
+}
+
+// HTTPHandler starts a span for each gateway request, which parents the span of the rpc call made
+// by the gateway.
+func (t Tracer) HTTPHandler(handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(handler, "gateway",
+		otelhttp.WithTracerProvider(t.provider),
+		otelhttp.WithPropagators(t.propagator),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
+}
+
+// Shutdown exports the pending spans.
+func (t Tracer) Shutdown(ctx context.Context) error {
+	return t.shutdown(ctx)
+}
+
+func (t Tracer) grpcOptions() []otelgrpc.Option {
+	return []otelgrpc.Option{
+		otelgrpc.WithTracerProvider(t.provider),
+		otelgrpc.WithPropagators(t.propagator),
+	}
+}
+
+type segment struct {
+	span trace.Span
+}
+
+func (s segment) End() {
+	s.span.End()
+}
@@ -0,0 +1,72 @@
+package opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func newTestTracer() (*Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return newTracer(provider, provider.Shutdown), recorder
+}
+
+func TestTracer_MonitorSegment(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	ctx, segment := tracer.MonitorSegment(context.Background(), "LedgerUseCase.CreateTransaction")
+	tracer.MonitorDataSegment(ctx, "entry", "Repository.CreateTransaction", "insert into entry").End()
+	segment.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	data, parent := spans[0], spans[1]
+	assert.Equal(t, "Repository.CreateTransaction", data.Name())
+	assert.Equal(t, trace.SpanKindClient, data.SpanKind())
+	assert.Equal(t, "LedgerUseCase.CreateTransaction", parent.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), data.Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), data.SpanContext().TraceID())
+}
+
+func TestTracer_UnaryServerInterceptor(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"traceparent", "00-"+traceID+"-"+spanID+"-01",
+	))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, segment := tracer.MonitorSegment(ctx, "LedgerUseCase.GetAccountBalance")
+		segment.End()
+		return nil, nil
+	}
+
+	interceptor := tracer.UnaryServerInterceptor()
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/ledger.LedgerService/GetAccountBalance"}, handler)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	useCase, server := spans[0], spans[1]
+	assert.Equal(t, "ledger.LedgerService/GetAccountBalance", server.Name())
+	assert.Equal(t, traceID, server.SpanContext().TraceID().String())
+	assert.Equal(t, spanID, server.Parent().SpanID().String())
+	assert.True(t, server.Parent().IsRemote())
+	assert.Equal(t, server.SpanContext().SpanID(), useCase.Parent().SpanID())
+}
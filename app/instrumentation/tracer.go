@@ -0,0 +1,43 @@
+package instrumentation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/instrumentation/newrelic"
+	"github.com/stone-co/the-amazing-ledger/app/instrumentation/opentelemetry"
+)
+
+// Tracer traces the ledger operations, along with the rpc and http calls that start them.
+type Tracer interface {
+	domain.Instrumentator
+	UnaryServerInterceptor() grpc.UnaryServerInterceptor
+	StreamServerInterceptor() grpc.StreamServerInterceptor
+	// UnaryClientInterceptor propagates the trace of the gateway requests to the rpc server.
+	UnaryClientInterceptor() grpc.UnaryClientInterceptor
+	HTTPHandler(http.Handler) http.Handler
+	Shutdown(context.Context) error
+}
+
+// NewTracer creates the tracer selected by cfg.Tracer.
+func NewTracer(ctx context.Context, cfg *app.Config) (Tracer, error) {
+	switch cfg.Tracer {
+	case app.NewRelicTracer:
+		nr, err := newrelic.NewApp(cfg.NewRelic.AppName, cfg.NewRelic.LicenseKey, logrus.NewEntry(logrus.New()))
+		if err != nil {
+			return nil, err
+		}
+
+		return newrelic.NewTracer(nr), nil
+	case app.OpenTelemetryTracer:
+		return opentelemetry.NewTracer(ctx, cfg.OpenTelemetry)
+	default:
+		return nil, fmt.Errorf("%w: %s", app.ErrInvalidTracer, cfg.Tracer)
+	}
+}
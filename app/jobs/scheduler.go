@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a background task executed periodically by the Scheduler.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs each registered job on its own ticker until the context is canceled.
+// Executions of the same job never overlap: a tick is skipped while the previous run is busy.
+type Scheduler struct {
+	jobs   []Job
+	logger zerolog.Logger
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(logger zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+	}
+}
+
+// Add registers a job. Jobs with a non-positive interval are disabled and ignored.
+func (s *Scheduler) Add(job Job) {
+	if job.Interval <= 0 {
+		s.logger.Info().Str("job", job.Name).Msg("job disabled")
+		return
+	}
+
+	s.jobs = append(s.jobs, job)
+}
+
+// Start launches every registered job in background.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+
+		go s.run(ctx, job)
+	}
+}
+
+// Wait blocks until every job has stopped.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	logger := s.logger.With().Str("job", job.Name).Logger()
+	ctx = logger.WithContext(ctx)
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	logger.Info().Dur("interval", job.Interval).Msg("job scheduled")
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("job stopped")
+			return
+		case <-ticker.C:
+			start := time.Now()
+
+			if err := job.Run(ctx); err != nil {
+				logger.Error().Err(err).Msg("job failed")
+				continue
+			}
+
+			logger.Info().Dur("elapsed", time.Since(start)).Msg("job finished")
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Run("should run jobs periodically until the context is canceled", func(t *testing.T) {
+		var runs, failures int32
+
+		s := NewScheduler(zerolog.Nop())
+		s.Add(Job{
+			Name:     "counter",
+			Interval: time.Millisecond,
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&runs, 1)
+				return nil
+			},
+		})
+		s.Add(Job{
+			Name:     "failure",
+			Interval: time.Millisecond,
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&failures, 1)
+				return errors.New("failed")
+			},
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s.Start(ctx)
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&runs) >= 3 && atomic.LoadInt32(&failures) >= 3
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		s.Wait()
+
+		total := atomic.LoadInt32(&runs)
+		time.Sleep(5 * time.Millisecond)
+		assert.Equal(t, total, atomic.LoadInt32(&runs))
+	})
+
+	t.Run("should ignore disabled jobs", func(t *testing.T) {
+		s := NewScheduler(zerolog.Nop())
+		s.Add(Job{
+			Name:     "disabled",
+			Interval: 0,
+			Run: func(ctx context.Context) error {
+				t.Fatal("disabled job must not run")
+				return nil
+			},
+		})
+
+		assert.Empty(t, s.jobs)
+	})
+}
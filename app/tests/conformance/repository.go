@@ -0,0 +1,922 @@
+// Package conformance pins down the observable contract of domain.Repository, so every backend
+// (and decorator) can prove it behaves like the others by running the same tests.
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain"
+	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/pagination"
+)
+
+// RepositoryFactory returns the repository under test. Repositories may be shared between tests,
+// since every test works under its own account prefix.
+type RepositoryFactory func(t *testing.T) domain.Repository
+
+// BookCreator is implemented by the repositories that can create books. The book isolation test is
+// skipped for the others.
+type BookCreator interface {
+	CreateBook(context.Context, string) (vos.Book, error)
+}
+
+// EventSchemaCreator is implemented by the repositories that can create event schemas. The event
+// schema test is skipped for the others.
+type EventSchemaCreator interface {
+	CreateEventSchema(context.Context, uint32, json.RawMessage) (vos.EventSchema, error)
+}
+
+// TestRepository runs the conformance suite against the repositories built by newRepository.
+func TestRepository(t *testing.T, newRepository RepositoryFactory) {
+	t.Run("version increments", func(t *testing.T) {
+		testVersionIncrements(t, newRepository(t))
+	})
+	t.Run("ignore account version", func(t *testing.T) {
+		testIgnoreAccountVersion(t, newRepository(t))
+	})
+	t.Run("idempotency", func(t *testing.T) {
+		testIdempotency(t, newRepository(t))
+	})
+	t.Run("transaction result", func(t *testing.T) {
+		testTransactionResult(t, newRepository(t))
+	})
+	t.Run("simulation", func(t *testing.T) {
+		testSimulation(t, newRepository(t))
+	})
+	t.Run("synthetic wildcard matching", func(t *testing.T) {
+		testSyntheticWildcards(t, newRepository(t))
+	})
+	t.Run("synthetic query syntax", func(t *testing.T) {
+		testSyntheticQueries(t, newRepository(t))
+	})
+	t.Run("report grouping by level", func(t *testing.T) {
+		testReportLevels(t, newRepository(t))
+	})
+	t.Run("pagination boundaries", func(t *testing.T) {
+		testPagination(t, newRepository(t))
+	})
+	t.Run("synthetic account entries", func(t *testing.T) {
+		testSyntheticAccountEntries(t, newRepository(t))
+	})
+	t.Run("running balance", func(t *testing.T) {
+		testRunningBalance(t, newRepository(t))
+	})
+	t.Run("metadata search", func(t *testing.T) {
+		testMetadataSearch(t, newRepository(t))
+	})
+	t.Run("account listing", func(t *testing.T) {
+		testAccountListing(t, newRepository(t))
+	})
+	t.Run("concurrent posting", func(t *testing.T) {
+		testConcurrentPosting(t, newRepository(t))
+	})
+	t.Run("book isolation", func(t *testing.T) {
+		testBookIsolation(t, newRepository(t))
+	})
+	t.Run("event schema versions", func(t *testing.T) {
+		testEventSchemaVersions(t, newRepository(t))
+	})
+}
+
+func testVersionIncrements(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	account := prefix + ".account"
+
+	post(t, r, time.Now(), credit(t, account, vos.NextAccountVersion, 100), debit(t, prefix+".other", vos.NextAccountVersion, 100))
+	assertBalance(t, r, account, 100, vos.Version(1))
+
+	post(t, r, time.Now(), debit(t, account, vos.Version(2), 30), credit(t, prefix+".other", vos.IgnoreAccountVersion, 30))
+	assertBalance(t, r, account, 70, vos.Version(2))
+
+	tx := newTransaction(t, time.Now(), debit(t, account, vos.Version(2), 30), credit(t, prefix+".other", vos.IgnoreAccountVersion, 30))
+	_, err := r.CreateTransaction(ctx, tx)
+	assert.ErrorIs(t, err, app.ErrInvalidVersion)
+
+	tx = newTransaction(t, time.Now(), debit(t, account, vos.Version(4), 30), credit(t, prefix+".other", vos.IgnoreAccountVersion, 30))
+	_, err = r.CreateTransaction(ctx, tx)
+	assert.ErrorIs(t, err, app.ErrInvalidVersion)
+
+	var conflict vos.VersionConflictError
+	if assert.ErrorAs(t, err, &conflict) {
+		assert.Equal(t, account, conflict.Account)
+		assert.Equal(t, vos.Version(2), conflict.CurrentVersion)
+	}
+
+	// A failed transaction leaves nothing behind, including the entries that were valid.
+	tx = newTransaction(t, time.Now(), debit(t, account, vos.NextAccountVersion, 30), credit(t, prefix+".other", vos.Version(5), 30))
+	_, err = r.CreateTransaction(ctx, tx)
+	assert.ErrorIs(t, err, app.ErrInvalidVersion)
+	assertBalance(t, r, account, 70, vos.Version(2))
+
+	post(t, r, time.Now(), debit(t, account, vos.NextAccountVersion, 70), credit(t, prefix+".other", vos.IgnoreAccountVersion, 70))
+	assertBalance(t, r, account, 0, vos.Version(3))
+
+	// The first versioned entry of an account always takes version 1.
+	post(t, r, time.Now(), credit(t, prefix+".new", vos.Version(5), 10), debit(t, prefix+".other", vos.IgnoreAccountVersion, 10))
+	assertBalance(t, r, prefix+".new", 10, vos.Version(1))
+}
+
+func testIgnoreAccountVersion(t *testing.T, r domain.Repository) {
+	prefix := newPrefix()
+	account := prefix + ".account"
+
+	post(t, r, time.Now(), credit(t, account, vos.IgnoreAccountVersion, 100), debit(t, prefix+".other", vos.IgnoreAccountVersion, 100))
+	post(t, r, time.Now(), credit(t, account, vos.IgnoreAccountVersion, 50), debit(t, prefix+".other", vos.IgnoreAccountVersion, 50))
+
+	balance, err := r.GetAnalyticAccountBalance(context.Background(), mustAccount(t, account))
+	require.NoError(t, err)
+	assert.Equal(t, 150, balance.Balance)
+
+	// Ignored entries don't move the account version.
+	post(t, r, time.Now(), debit(t, account, vos.NextAccountVersion, 20), credit(t, prefix+".other", vos.IgnoreAccountVersion, 20))
+	assertBalance(t, r, account, 130, vos.Version(1))
+
+	versions := listVersions(t, r, account, 10)
+	assert.Equal(t, []vos.Version{1, vos.IgnoreAccountVersion, vos.IgnoreAccountVersion}, versions)
+}
+
+func testIdempotency(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	account := prefix + ".account"
+
+	tx := post(t, r, time.Now(), credit(t, account, vos.IgnoreAccountVersion, 100), debit(t, prefix+".other", vos.IgnoreAccountVersion, 100))
+
+	_, err := r.CreateTransaction(ctx, tx)
+	assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation)
+
+	// Entry ids are unique whatever the competence date, which may even fall in another month.
+	for _, competenceDate := range []time.Time{tx.CompetenceDate.Add(time.Second), tx.CompetenceDate.AddDate(0, -1, 0)} {
+		_, err = r.CreateTransaction(ctx, newTransaction(t, competenceDate, tx.Entries...))
+		assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation, "competence date %s", competenceDate)
+	}
+
+	// Reusing a single entry id is enough to reject the whole transaction.
+	reused := tx.Entries[0]
+	counterpart := newEntry(t, vos.CreditOperation, prefix+".third", vos.IgnoreAccountVersion, reused.Amount)
+	if reused.Operation == vos.CreditOperation {
+		counterpart.Operation = vos.DebitOperation
+	}
+
+	retry := newTransaction(t, tx.CompetenceDate, reused, counterpart)
+	_, err = r.CreateTransaction(ctx, retry)
+	assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation)
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, mustAccount(t, account))
+	require.NoError(t, err)
+	assert.Equal(t, 100, balance.Balance)
+}
+
+func testTransactionResult(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	account := prefix + ".account"
+	other := prefix + ".other"
+
+	post(t, r, time.Now(), credit(t, account, vos.NextAccountVersion, 100), debit(t, other, vos.IgnoreAccountVersion, 100))
+
+	tx := newTransaction(t, time.Now(),
+		credit(t, account, vos.NextAccountVersion, 30),
+		credit(t, account, vos.NextAccountVersion, 20),
+		debit(t, other, vos.IgnoreAccountVersion, 50),
+	)
+
+	result, err := r.CreateTransaction(vos.WithResultingBalances(ctx), tx)
+	require.NoError(t, err)
+	assert.False(t, result.PostedAt.IsZero())
+
+	versions := make(map[string][]vos.Version)
+	for _, entry := range result.Entries {
+		versions[entry.Account.Value()] = append(versions[entry.Account.Value()], entry.Version)
+	}
+
+	assert.ElementsMatch(t, []vos.Version{2, 3}, versions[account])
+	assert.Equal(t, []vos.Version{vos.IgnoreAccountVersion}, versions[other])
+
+	// Each account is reported once, with its balance right after the transaction.
+	require.Len(t, result.Balances, 2)
+	assert.Equal(t, account, result.Balances[0].Account.Value())
+	assert.Equal(t, 150, result.Balances[0].Balance)
+	assert.Equal(t, vos.Version(3), result.Balances[0].CurrentVersion)
+	assert.Equal(t, other, result.Balances[1].Account.Value())
+	assert.Equal(t, -150, result.Balances[1].Balance)
+
+	// Balances are only read when requested.
+	result, err = r.CreateTransaction(ctx, newTransaction(t, time.Now(), credit(t, account, vos.NextAccountVersion, 10), debit(t, other, vos.IgnoreAccountVersion, 10)))
+	require.NoError(t, err)
+	assert.Len(t, result.Entries, 2)
+	assert.Empty(t, result.Balances)
+}
+
+func testSimulation(t *testing.T, r domain.Repository) {
+	ctx := vos.WithSimulation(context.Background())
+	prefix := newPrefix()
+	account := prefix + ".account"
+	other := prefix + ".other"
+
+	post(t, r, time.Now(), credit(t, account, vos.NextAccountVersion, 100), debit(t, other, vos.IgnoreAccountVersion, 100))
+
+	tx := newTransaction(t, time.Now(),
+		credit(t, account, vos.NextAccountVersion, 30),
+		credit(t, account, vos.NextAccountVersion, 20),
+		debit(t, other, vos.IgnoreAccountVersion, 50),
+	)
+
+	// Simulations report the versions and balances without being asked for them.
+	result, err := r.CreateTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.Len(t, result.Entries, 3)
+
+	require.Len(t, result.Balances, 2)
+	assert.Equal(t, account, result.Balances[0].Account.Value())
+	assert.Equal(t, 150, result.Balances[0].Balance)
+	assert.Equal(t, vos.Version(3), result.Balances[0].CurrentVersion)
+	assert.Equal(t, other, result.Balances[1].Account.Value())
+	assert.Equal(t, -150, result.Balances[1].Balance)
+
+	// Nothing is saved, so the same transaction can be simulated again and then posted.
+	assertBalance(t, r, account, 100, vos.Version(1))
+
+	_, err = r.CreateTransaction(ctx, tx)
+	require.NoError(t, err)
+
+	_, err = r.CreateTransaction(context.Background(), tx)
+	require.NoError(t, err)
+	assertBalance(t, r, account, 150, vos.Version(3))
+
+	// Simulations fail as the transaction would.
+	_, err = r.CreateTransaction(ctx, tx)
+	assert.ErrorIs(t, err, app.ErrIdempotencyKeyViolation)
+
+	stale := newTransaction(t, time.Now(), debit(t, account, vos.Version(3), 10), credit(t, other, vos.IgnoreAccountVersion, 10))
+	_, err = r.CreateTransaction(ctx, stale)
+	assert.ErrorIs(t, err, app.ErrInvalidVersion)
+
+	// Accounts without entries start from zero.
+	fresh := newTransaction(t, time.Now(), credit(t, prefix+".new", vos.NextAccountVersion, 10), debit(t, other, vos.IgnoreAccountVersion, 10))
+	result, err = r.CreateTransaction(ctx, fresh)
+	require.NoError(t, err)
+	require.Len(t, result.Balances, 2)
+	assert.Equal(t, 10, result.Balances[0].Balance)
+	assert.Equal(t, vos.Version(1), result.Balances[0].CurrentVersion)
+
+	_, err = r.GetAnalyticAccountBalance(context.Background(), mustAccount(t, prefix+".new"))
+	assert.ErrorIs(t, err, app.ErrAccountNotFound)
+}
+
+func testSyntheticWildcards(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+
+	post(t, r, time.Now(),
+		credit(t, prefix+".clients.abc.available", vos.IgnoreAccountVersion, 100),
+		credit(t, prefix+".clients.abd.available", vos.IgnoreAccountVersion, 40),
+		credit(t, prefix+".clients.xyz.blocked", vos.IgnoreAccountVersion, 10),
+		debit(t, prefix+".bank", vos.IgnoreAccountVersion, 150),
+	)
+
+	testCases := []struct {
+		query   string
+		balance int
+		err     error
+	}{
+		{query: prefix + ".*", balance: 0},
+		{query: prefix + ".clients.*", balance: 150},
+		{query: prefix + ".*.available", balance: 140},
+		{query: prefix + ".clients.ab*.*", balance: 140},
+		{query: prefix + ".bank.*", balance: -150},
+		{query: prefix + ".missing.*", err: app.ErrAccountNotFound},
+	}
+
+	for _, tt := range testCases {
+		got, err := r.GetSyntheticAccountBalance(ctx, mustAccount(t, tt.query))
+		assert.ErrorIs(t, err, tt.err, tt.query)
+
+		if tt.err == nil {
+			assert.Equal(t, tt.balance, got.Balance, tt.query)
+		}
+	}
+}
+
+func testSyntheticQueries(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	depth := len(strings.Split(prefix, "."))
+
+	post(t, r, time.Now(),
+		credit(t, prefix+".clients.abc.available", vos.IgnoreAccountVersion, 100),
+		credit(t, prefix+".clients.abd.blocked", vos.IgnoreAccountVersion, 40),
+		credit(t, prefix+".clients.xyz.reserved", vos.IgnoreAccountVersion, 10),
+		credit(t, prefix+".clients.xyz.available.detail", vos.IgnoreAccountVersion, 5),
+		debit(t, prefix+".bank", vos.IgnoreAccountVersion, 155),
+	)
+
+	testCases := []struct {
+		query   string
+		search  string
+		balance int
+		err     error
+	}{
+		{query: prefix + ".clients.*.(available|blocked)", balance: 140},
+		{query: prefix + ".clients.*.available|blocked.*", balance: 145},
+		{query: prefix + ".clients.*{1}.!available", balance: 50},
+		{query: prefix + ".clients.ab*|xyz.*{1}", balance: 150},
+		{query: prefix + ".*{3}", balance: 150},
+		{query: prefix + ".*{,1}", balance: -155},
+		{query: prefix + ".*", search: "available", balance: 105},
+		{query: prefix + ".*", search: "available & !detail | blocked", balance: 140},
+		{query: prefix + ".clients.*", search: "ab*", balance: 140},
+		{query: prefix + ".clients.*", search: "bank", err: app.ErrAccountNotFound},
+	}
+
+	for _, tt := range testCases {
+		search, err := vos.NewAccountSearch(tt.search)
+		require.NoError(t, err)
+
+		got, err := r.GetSyntheticAccountBalance(ctx, mustAccount(t, tt.query).WithSearch(search))
+		assert.ErrorIs(t, err, tt.err, tt.query+" @ "+tt.search)
+
+		if tt.err == nil {
+			assert.Equal(t, tt.balance, got.Balance, tt.query+" @ "+tt.search)
+		}
+	}
+
+	search, err := vos.NewAccountSearch("available")
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	end := time.Now().Add(time.Hour)
+
+	got, err := r.GetSyntheticReport(ctx, mustAccount(t, prefix+".clients.*").WithSearch(search), depth+2, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(105), got.TotalCredit)
+	assert.ElementsMatch(t, []vos.AccountResult{
+		{Account: mustAccount(t, prefix+".clients.abc"), Credit: 100},
+		{Account: mustAccount(t, prefix+".clients.xyz"), Credit: 5},
+	}, got.Results)
+}
+
+func testReportLevels(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	depth := len(strings.Split(prefix, "."))
+
+	post(t, r, time.Now(),
+		credit(t, prefix+".clients.abc", vos.IgnoreAccountVersion, 100),
+		credit(t, prefix+".clients.xyz", vos.IgnoreAccountVersion, 50),
+		debit(t, prefix+".bank.main", vos.IgnoreAccountVersion, 150),
+	)
+
+	start := time.Now().Add(-time.Hour)
+	end := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		level   int
+		results []vos.AccountResult
+	}{
+		{
+			level: depth + 1,
+			results: []vos.AccountResult{
+				{Account: mustAccount(t, prefix+".bank"), Debit: 150},
+				{Account: mustAccount(t, prefix+".clients"), Credit: 150},
+			},
+		},
+		{
+			level: depth + 2,
+			results: []vos.AccountResult{
+				{Account: mustAccount(t, prefix+".bank.main"), Debit: 150},
+				{Account: mustAccount(t, prefix+".clients.abc"), Credit: 100},
+				{Account: mustAccount(t, prefix+".clients.xyz"), Credit: 50},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		got, err := r.GetSyntheticReport(ctx, mustAccount(t, prefix+".*"), tt.level, start, end)
+		require.NoError(t, err)
+		assert.Equal(t, int64(150), got.TotalCredit)
+		assert.Equal(t, int64(150), got.TotalDebit)
+		assert.ElementsMatch(t, tt.results, got.Results)
+	}
+
+	got, err := r.GetSyntheticReport(ctx, mustAccount(t, prefix+".*"), depth+1, end, end.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, got.Results)
+}
+
+func testPagination(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	account := prefix + ".account"
+
+	start := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	end := start.Add(time.Hour)
+
+	// Entries at both period boundaries: the start is included and the end is not.
+	for i, date := range []time.Time{start, start.Add(time.Minute), start.Add(2 * time.Minute), end.Add(-time.Minute), end} {
+		post(t, r, date, credit(t, account, vos.NextAccountVersion, i+1), debit(t, prefix+".other", vos.IgnoreAccountVersion, i+1))
+	}
+
+	req := vos.AccountEntryRequest{
+		Account:   mustAccount(t, account),
+		StartDate: start,
+		EndDate:   end,
+		Page:      pagination.Page{Size: 4},
+	}
+
+	entries, cursor, err := listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	assert.Nil(t, cursor, "a page with exactly the remaining entries has no next page")
+	assert.Equal(t, []vos.Version{4, 3, 2, 1}, entryVersions(entries))
+
+	req.Page.Size = 3
+	entries, cursor, err = listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	assert.Equal(t, []vos.Version{4, 3, 2}, entryVersions(entries))
+
+	req.Page.Cursor = cursor
+	entries, cursor, err = listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	assert.Equal(t, []vos.Version{1}, entryVersions(entries))
+	assert.True(t, start.Equal(entries[0].CompetenceDate))
+
+	req.Page = pagination.Page{Size: 1}
+	req.StartDate = end
+	req.EndDate = end.Add(time.Hour)
+	entries, cursor, err = listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	assert.Equal(t, []vos.Version{5}, entryVersions(entries))
+}
+
+func testSyntheticAccountEntries(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	date := time.Now().UTC().Truncate(time.Second)
+
+	post(t, r, date.Add(-time.Minute), credit(t, prefix+".clients.abc", vos.NextAccountVersion, 1), debit(t, prefix+".bank", vos.IgnoreAccountVersion, 1))
+	post(t, r, date, credit(t, prefix+".clients.abc", vos.NextAccountVersion, 2), debit(t, prefix+".bank", vos.IgnoreAccountVersion, 2))
+	post(t, r, date, credit(t, prefix+".clients.xyz", vos.NextAccountVersion, 3), debit(t, prefix+".bank", vos.IgnoreAccountVersion, 3))
+
+	req := vos.AccountEntryRequest{
+		Account:   mustAccount(t, prefix+".clients.*"),
+		StartDate: date.Add(-time.Hour),
+		EndDate:   date.Add(time.Hour),
+		Page:      pagination.Page{Size: 2},
+	}
+
+	entries, cursor, err := listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	require.Len(t, entries, 2)
+
+	req.Page.Cursor = cursor
+	last, cursor, err := listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	entries = append(entries, last...)
+
+	// The entries with the same competence date are sorted by creation time, then by id.
+	got := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		got = append(got, entry.Account.Value())
+		assert.Equal(t, vos.Analytic, entry.Account.Type())
+		assert.False(t, entry.CreatedAt.IsZero())
+	}
+	assert.Equal(t, []string{prefix + ".clients.xyz", prefix + ".clients.abc", prefix + ".clients.abc"}, got)
+	assert.Equal(t, []int{3, 2, 1}, []int{entries[0].Amount, entries[1].Amount, entries[2].Amount})
+
+	account := mustAccount(t, prefix+".clients.abc")
+	entries, _, err = listAccountEntries(ctx, r, vos.AccountEntryRequest{
+		Account:   account,
+		StartDate: date.Add(-time.Hour),
+		EndDate:   date.Add(time.Hour),
+		Page:      pagination.Page{Size: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, account, entries[0].Account)
+}
+
+func testRunningBalance(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	account := prefix + ".account"
+	start := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+
+	post(t, r, start.Add(-time.Minute), credit(t, account, vos.NextAccountVersion, 1000), debit(t, prefix+".other", vos.IgnoreAccountVersion, 1000))
+	posted := []entities.Transaction{
+		post(t, r, start, credit(t, account, vos.NextAccountVersion, 100), debit(t, prefix+".other", vos.IgnoreAccountVersion, 100)),
+		post(t, r, start.Add(2*time.Minute), debit(t, account, vos.NextAccountVersion, 30), credit(t, prefix+".other", vos.IgnoreAccountVersion, 30)),
+		// Posted last but earlier in the history, so it comes before the previous entry.
+		post(t, r, start.Add(time.Minute), credit(t, account, vos.NextAccountVersion, 5), debit(t, prefix+".other", vos.IgnoreAccountVersion, 5)),
+	}
+	// After the period, so it only counts in the current balance.
+	post(t, r, start.Add(2*time.Hour), credit(t, account, vos.NextAccountVersion, 7), debit(t, prefix+".other", vos.IgnoreAccountVersion, 7))
+
+	req := vos.AccountEntryRequest{
+		Account:   mustAccount(t, account),
+		StartDate: start,
+		EndDate:   start.Add(time.Hour),
+		Page:      pagination.Page{Size: 2},
+	}
+
+	entries, cursor, err := listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+
+	req.Page.Cursor = cursor
+	last, _, err := listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	entries = append(entries, last...)
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, []int{1075, 1105, 1100}, []int{entries[0].Balance, entries[1].Balance, entries[2].Balance})
+	assert.Equal(t, posted[1].ID, entries[0].TxID)
+	assert.Equal(t, posted[1].Company, entries[0].Company)
+
+	// Filters don't change the balances, which belong to the account.
+	req.Page = pagination.Page{Size: 10}
+	req.Filter.Operation = vos.DebitOperation
+	entries, _, err = listAccountEntries(ctx, r, req)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 1075, entries[0].Balance)
+
+	// The opening balance is only computed when requested, along with the entries.
+	resp, err := r.ListAccountEntries(ctx, req)
+	require.NoError(t, err)
+	assert.Nil(t, resp.OpeningBalance)
+
+	req.IncludeOpeningBalance = true
+	resp, err = r.ListAccountEntries(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, resp.OpeningBalance)
+	assert.Equal(t, 1000, *resp.OpeningBalance)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, 1075, resp.Entries[0].Balance)
+
+	// Without entries in the period, the opening balance is still returned.
+	req.Account = mustAccount(t, prefix+".*")
+	req.StartDate, req.EndDate = start.Add(3*time.Hour), start.Add(4*time.Hour)
+	resp, err = r.ListAccountEntries(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Entries)
+	require.NotNil(t, resp.OpeningBalance)
+	assert.Equal(t, 0, *resp.OpeningBalance)
+
+	req.Account = mustAccount(t, prefix+".unknown")
+	resp, err = r.ListAccountEntries(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, resp.OpeningBalance)
+	assert.Equal(t, 0, *resp.OpeningBalance)
+}
+
+func testMetadataSearch(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+	account := prefix + ".account"
+
+	for i, metadata := range []string{
+		`{"external_id": "abc", "order": {"id": 1, "status": "paid"}}`,
+		`{"external_id": "abd", "order": {"id": 2, "status": "open"}, "tags": ["refund"]}`,
+		`{}`,
+	} {
+		entry, err := entities.NewEntry(uuid.New(), vos.CreditOperation, account, vos.NextAccountVersion, i+1, json.RawMessage(metadata))
+		require.NoError(t, err)
+
+		post(t, r, time.Now(), entry, debit(t, prefix+".other", vos.IgnoreAccountVersion, i+1))
+	}
+
+	testCases := []struct {
+		name     string
+		contains string
+		keys     []string
+		equals   map[string]string
+		versions []vos.Version
+	}{
+		{name: "equality", equals: map[string]string{"external_id": "abc"}, versions: []vos.Version{1}},
+		{name: "nested equality", equals: map[string]string{"order.status": "open"}, versions: []vos.Version{2}},
+		{name: "containment", contains: `{"order": {"id": 1}}`, versions: []vos.Version{1}},
+		{name: "array containment", contains: `{"tags": ["refund"]}`, versions: []vos.Version{2}},
+		{name: "key existence", keys: []string{"order.id"}, versions: []vos.Version{2, 1}},
+		{name: "every condition", contains: `{"order": {"id": 2}}`, keys: []string{"tags"}, equals: map[string]string{"external_id": "abc"}, versions: []vos.Version{}},
+	}
+
+	for _, tt := range testCases {
+		metadata, err := vos.NewMetadataFilter(tt.contains, tt.keys, tt.equals)
+		require.NoError(t, err)
+
+		entries, _, err := listAccountEntries(ctx, r, vos.AccountEntryRequest{
+			Account:   mustAccount(t, account),
+			StartDate: time.Now().Add(-time.Hour),
+			EndDate:   time.Now().Add(time.Hour),
+			Filter:    vos.AccountEntryFilter{Metadata: metadata},
+			Page:      pagination.Page{Size: 10},
+		})
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.versions, entryVersions(entries), tt.name)
+	}
+}
+
+func testAccountListing(t *testing.T, r domain.Repository) {
+	ctx := context.Background()
+	prefix := newPrefix()
+
+	post(t, r, time.Now(),
+		credit(t, prefix+".clients.abc.available", vos.NextAccountVersion, 100),
+		credit(t, prefix+".clients.abc.blocked", vos.IgnoreAccountVersion, 40),
+		credit(t, prefix+".clients.xyz.available", vos.NextAccountVersion, 10),
+		debit(t, prefix+".bank", vos.IgnoreAccountVersion, 150),
+	)
+
+	req := vos.AccountListRequest{Pattern: mustAccount(t, prefix+".*"), Page: pagination.Page{Size: 3}}
+	accounts, cursor, err := r.ListAccounts(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	assert.Equal(t, []string{prefix + ".bank", prefix + ".clients.abc.available", prefix + ".clients.abc.blocked"}, summaryAccounts(accounts))
+	assert.False(t, accounts[0].CreatedAt.IsZero())
+
+	req.Page.Cursor = cursor
+	accounts, cursor, err = r.ListAccounts(ctx, req)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	assert.Equal(t, []string{prefix + ".clients.xyz.available"}, summaryAccounts(accounts))
+
+	req = vos.AccountListRequest{Pattern: mustAccount(t, prefix+".*.available"), Page: pagination.Page{Size: 10}}
+	accounts, _, err = r.ListAccounts(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + ".clients.abc.available", prefix + ".clients.xyz.available"}, summaryAccounts(accounts))
+
+	parent, err := vos.NewAccountPrefix(prefix)
+	require.NoError(t, err)
+
+	children, cursor, err := r.ListAccountChildren(ctx, vos.AccountChildrenRequest{Prefix: parent, Page: pagination.Page{Size: 1}})
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	assert.Equal(t, []vos.AccountChild{{Prefix: parent.Child("bank"), Accounts: 1}}, children)
+
+	children, cursor, err = r.ListAccountChildren(ctx, vos.AccountChildrenRequest{Prefix: parent, Page: pagination.Page{Size: 1, Cursor: cursor}})
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	assert.Equal(t, []vos.AccountChild{{Prefix: parent.Child("clients"), Accounts: 3}}, children)
+
+	children, _, err = r.ListAccountChildren(ctx, vos.AccountChildrenRequest{Prefix: parent.Child("bank"), Page: pagination.Page{Size: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, children)
+}
+
+func testConcurrentPosting(t *testing.T, r domain.Repository) {
+	const workers = 10
+
+	ctx := context.Background()
+	prefix := newPrefix()
+	account := prefix + ".account"
+
+	// The account exists beforehand, as postgres doesn't serialize the creation of its version.
+	post(t, r, time.Now(), credit(t, account, vos.NextAccountVersion, 10), debit(t, prefix+".other", vos.IgnoreAccountVersion, 10))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		tx := newTransaction(t, time.Now(), credit(t, account, vos.NextAccountVersion, 10), debit(t, prefix+".other", vos.IgnoreAccountVersion, 10))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.CreateTransaction(ctx, tx)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, mustAccount(t, account))
+	require.NoError(t, err)
+	assert.Equal(t, (workers+1)*10, balance.Balance)
+
+	// Every posting takes its own version, even though the order between them is not defined.
+	want := make([]vos.Version, 0, workers+1)
+	for i := 1; i <= workers+1; i++ {
+		want = append(want, vos.Version(i))
+	}
+
+	assert.ElementsMatch(t, want, listVersions(t, r, account, workers+1))
+}
+
+func testBookIsolation(t *testing.T, r domain.Repository) {
+	creator, ok := r.(BookCreator)
+	if !ok {
+		t.Skip("the repository can't create books")
+	}
+
+	prefix := newPrefix()
+	account := prefix + ".account"
+	entries := func() []entities.Entry {
+		return []entities.Entry{
+			credit(t, account, vos.NextAccountVersion, 100),
+			debit(t, prefix+".other", vos.NextAccountVersion, 100),
+		}
+	}
+
+	unknown := vos.WithBook(context.Background(), "missing_"+strings.ReplaceAll(uuid.New().String(), "-", "_"))
+	_, err := r.CreateTransaction(unknown, newTransaction(t, time.Now(), entries()...))
+	assert.ErrorIs(t, err, app.ErrBookNotFound)
+
+	name := "conformance_" + strings.ReplaceAll(uuid.New().String(), "-", "_")
+	book, err := creator.CreateBook(context.Background(), name)
+	require.NoError(t, err)
+	assert.Equal(t, name, book.Name)
+
+	_, err = creator.CreateBook(context.Background(), name)
+	assert.ErrorIs(t, err, app.ErrBookAlreadyExists)
+
+	ctx := vos.WithBook(context.Background(), name)
+
+	post(t, r, time.Now(), entries()...)
+	post(t, r, time.Now(), entries()...)
+
+	// The same accounts start over in another book.
+	_, err = r.CreateTransaction(ctx, newTransaction(t, time.Now(), entries()...))
+	require.NoError(t, err)
+
+	assertBalance(t, r, account, 200, vos.Version(2))
+
+	balance, err := r.GetAnalyticAccountBalance(ctx, mustAccount(t, account))
+	require.NoError(t, err)
+	assert.Equal(t, 100, balance.Balance)
+	assert.Equal(t, vos.Version(1), balance.CurrentVersion)
+
+	balance, err = r.GetSyntheticAccountBalance(ctx, mustAccount(t, prefix+".*"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance.Balance)
+
+	report, err := r.GetSyntheticReport(ctx, mustAccount(t, prefix+".*"), len(strings.Split(prefix, "."))+1, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), report.TotalCredit)
+
+	listed, _, err := listAccountEntries(ctx, r, vos.AccountEntryRequest{
+		Account:   mustAccount(t, account),
+		StartDate: time.Now().Add(-time.Hour),
+		EndDate:   time.Now().Add(time.Hour),
+		Page:      pagination.Page{Size: 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []vos.Version{1}, entryVersions(listed))
+
+	_, err = r.GetAnalyticAccountBalance(ctx, mustAccount(t, prefix+".missing"))
+	assert.ErrorIs(t, err, app.ErrAccountNotFound)
+}
+
+func newPrefix() string {
+	return "liability.conformance." + strings.ReplaceAll(uuid.New().String(), "-", "_")
+}
+
+// listAccountEntries lists a page of entries, without the opening balance.
+func listAccountEntries(ctx context.Context, r domain.Repository, req vos.AccountEntryRequest) ([]vos.AccountEntry, pagination.Cursor, error) {
+	resp, err := r.ListAccountEntries(ctx, req)
+
+	return resp.Entries, resp.NextPage, err
+}
+
+func mustAccount(t *testing.T, account string) vos.Account {
+	t.Helper()
+
+	acc, err := vos.NewAccount(account)
+	require.NoError(t, err)
+
+	return acc
+}
+
+func credit(t *testing.T, account string, version vos.Version, amount int) entities.Entry {
+	t.Helper()
+
+	return newEntry(t, vos.CreditOperation, account, version, amount)
+}
+
+func debit(t *testing.T, account string, version vos.Version, amount int) entities.Entry {
+	t.Helper()
+
+	return newEntry(t, vos.DebitOperation, account, version, amount)
+}
+
+func newEntry(t *testing.T, op vos.OperationType, account string, version vos.Version, amount int) entities.Entry {
+	t.Helper()
+
+	entry, err := entities.NewEntry(uuid.New(), op, account, version, amount, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	return entry
+}
+
+func newTransaction(t *testing.T, competenceDate time.Time, entries ...entities.Entry) entities.Transaction {
+	t.Helper()
+
+	// Postgres keeps microseconds, so dates are rounded to compare them after a round trip.
+	tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", competenceDate.Round(time.Microsecond), entries...)
+	require.NoError(t, err)
+
+	return tx
+}
+
+func post(t *testing.T, r domain.Repository, competenceDate time.Time, entries ...entities.Entry) entities.Transaction {
+	t.Helper()
+
+	tx := newTransaction(t, competenceDate, entries...)
+	_, err := r.CreateTransaction(context.Background(), tx)
+	require.NoError(t, err)
+
+	return tx
+}
+
+func assertBalance(t *testing.T, r domain.Repository, account string, balance int, version vos.Version) {
+	t.Helper()
+
+	got, err := r.GetAnalyticAccountBalance(context.Background(), mustAccount(t, account))
+	require.NoError(t, err)
+	assert.Equal(t, balance, got.Balance)
+	assert.Equal(t, version, got.CurrentVersion)
+}
+
+func listVersions(t *testing.T, r domain.Repository, account string, size int) []vos.Version {
+	t.Helper()
+
+	entries, _, err := listAccountEntries(context.Background(), r, vos.AccountEntryRequest{
+		Account:   mustAccount(t, account),
+		StartDate: time.Now().Add(-time.Hour),
+		EndDate:   time.Now().Add(time.Hour),
+		Page:      pagination.Page{Size: size},
+	})
+	require.NoError(t, err)
+
+	return entryVersions(entries)
+}
+
+func summaryAccounts(summaries []vos.AccountSummary) []string {
+	accounts := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		accounts = append(accounts, summary.Account.Value())
+	}
+
+	return accounts
+}
+
+func entryVersions(entries []vos.AccountEntry) []vos.Version {
+	versions := make([]vos.Version, 0, len(entries))
+	for _, e := range entries {
+		versions = append(versions, e.Version)
+	}
+
+	return versions
+}
+
+func testEventSchemaVersions(t *testing.T, r domain.Repository) {
+	creator, ok := r.(EventSchemaCreator)
+	if !ok {
+		t.Skip("the repository can't create event schemas")
+	}
+
+	// Event 2 is never posted by the suite, so its schemas don't affect the other tests.
+	const event = 2
+
+	version := 0
+	if current, err := r.GetEventSchema(context.Background(), event); err == nil {
+		version = current.Version
+	} else {
+		assert.ErrorIs(t, err, app.ErrEventSchemaNotFound)
+	}
+
+	first, err := creator.CreateEventSchema(context.Background(), event, json.RawMessage(`{"type": "object"}`))
+	require.NoError(t, err)
+	assert.Equal(t, version+1, first.Version)
+
+	second, err := creator.CreateEventSchema(context.Background(), event, json.RawMessage(`{"required": ["order_id"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, version+2, second.Version)
+
+	got, err := r.GetEventSchema(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(event), got.Event)
+	assert.Equal(t, second.Version, got.Version)
+	assert.JSONEq(t, `{"required": ["order_id"]}`, string(got.Schema))
+}
@@ -1,10 +1,8 @@
 package gateway
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"io"
 	"net/http"
 	"testing"
 	"time"
@@ -12,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	"github.com/stone-co/the-amazing-ledger/app/tests"
@@ -73,15 +72,29 @@ func TestE2E_Gateway_CreateTransactionSuccess(t *testing.T) {
 
 		defer resp.Body.Close()
 
-		var body bytes.Buffer
+		var body struct {
+			ConsistencyToken string `json:"consistencyToken"`
+			PostedAt         string `json:"postedAt"`
+			Entries          []struct {
+				ID      string `json:"id"`
+				Account string `json:"account"`
+				Version string `json:"version"`
+			} `json:"entries"`
+		}
 
-		_, err = io.Copy(&body, resp.Body)
+		err = json.NewDecoder(resp.Body).Decode(&body)
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, `{}`, body.String())
+		assert.Empty(t, body.ConsistencyToken)
+		assert.NotEmpty(t, body.PostedAt)
+		assert.Len(t, body.Entries, 2)
+
+		for _, e := range body.Entries {
+			assert.Equal(t, "1", e.Version)
+		}
 
-		tests.TruncateTables(context.Background(), testenv.DB, "entry", "account_version")
+		tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
 	})
 }
 
@@ -321,10 +334,10 @@ func TestE2E_Gateway_CreateTransactionFailure(t *testing.T) {
 				Event:          1,
 			},
 			wants: wants{
-				status: http.StatusBadRequest,
+				status: http.StatusConflict,
 				body: responseBody{
-					Code:    3,
-					Message: "invalid idempotency key",
+					Code:    6,
+					Message: app.ErrIdempotencyKeyViolation.Error(),
 				},
 			},
 		},
@@ -338,7 +351,7 @@ func TestE2E_Gateway_CreateTransactionFailure(t *testing.T) {
 				tx := tt.seedRepo(t)
 				request.Entries[0].ID = tx.Entries[0].ID.String()
 
-				defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "account_version")
+				defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
 			}
 
 			req := testutils.NewRequest(t, http.MethodPost, testenv.GatewayServer+"/api/v1/transactions", tt.request)
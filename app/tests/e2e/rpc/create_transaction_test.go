@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/stone-co/the-amazing-ledger/app"
 	"github.com/stone-co/the-amazing-ledger/app/domain/entities"
 	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
 	"github.com/stone-co/the-amazing-ledger/app/tests"
@@ -49,6 +50,99 @@ func TestE2E_RPC_CreateTransactionSuccess(t *testing.T) {
 		_, err := testenv.RPCClient.CreateTransaction(context.Background(), request)
 		assert.NoError(t, err)
 	})
+
+	t.Run("should return the resulting versions and balances", func(t *testing.T) {
+		defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
+
+		debitAccount := testdata.GenerateAccountPath()
+		creditAccount := testdata.GenerateAccountPath()
+
+		request := &proto.CreateTransactionRequest{
+			Id: uuid.New().String(),
+			Entries: []*proto.Entry{
+				{
+					Id:              uuid.New().String(),
+					Account:         debitAccount,
+					ExpectedVersion: vos.NextAccountVersion.AsInt64(),
+					Operation:       proto.Operation_OPERATION_DEBIT,
+					Amount:          123,
+				},
+				{
+					Id:              uuid.New().String(),
+					Account:         creditAccount,
+					ExpectedVersion: vos.IgnoreAccountVersion.AsInt64(),
+					Operation:       proto.Operation_OPERATION_CREDIT,
+					Amount:          123,
+				},
+			},
+			Company:         "abc",
+			Event:           1,
+			CompetenceDate:  timestamppb.Now(),
+			IncludeBalances: true,
+		}
+
+		response, err := testenv.RPCClient.CreateTransaction(context.Background(), request)
+		assert.NoError(t, err)
+		assert.NotNil(t, response.PostedAt)
+
+		versions := make(map[string]int64)
+		for _, entry := range response.Entries {
+			versions[entry.Account] = entry.Version
+		}
+
+		assert.Equal(t, map[string]int64{debitAccount: 1, creditAccount: vos.IgnoreAccountVersion.AsInt64()}, versions)
+
+		balances := make(map[string]int64)
+		for _, balance := range response.Balances {
+			balances[balance.Account] = balance.Balance
+		}
+
+		assert.Equal(t, map[string]int64{debitAccount: -123, creditAccount: 123}, balances)
+	})
+
+	t.Run("should simulate the transaction without saving it", func(t *testing.T) {
+		defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
+
+		debitAccount := testdata.GenerateAccountPath()
+		creditAccount := testdata.GenerateAccountPath()
+
+		request := &proto.CreateTransactionRequest{
+			Id: uuid.New().String(),
+			Entries: []*proto.Entry{
+				{
+					Id:              uuid.New().String(),
+					Account:         debitAccount,
+					ExpectedVersion: vos.NextAccountVersion.AsInt64(),
+					Operation:       proto.Operation_OPERATION_DEBIT,
+					Amount:          123,
+				},
+				{
+					Id:              uuid.New().String(),
+					Account:         creditAccount,
+					ExpectedVersion: vos.NextAccountVersion.AsInt64(),
+					Operation:       proto.Operation_OPERATION_CREDIT,
+					Amount:          123,
+				},
+			},
+			Company:        "abc",
+			Event:          1,
+			CompetenceDate: timestamppb.Now(),
+			DryRun:         true,
+		}
+
+		response, err := testenv.RPCClient.CreateTransaction(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Len(t, response.Balances, 2)
+
+		_, err = testenv.RPCClient.GetAccountBalance(context.Background(), &proto.GetAccountBalanceRequest{Account: debitAccount})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		// The simulated transaction can still be posted.
+		request.DryRun = false
+
+		_, err = testenv.RPCClient.CreateTransaction(context.Background(), request)
+		assert.NoError(t, err)
+	})
 }
 
 func TestE2E_RPC_CreateTransactionFailure(t *testing.T) {
@@ -272,8 +366,8 @@ func TestE2E_RPC_CreateTransactionFailure(t *testing.T) {
 				Event:          1,
 				CompetenceDate: timestamppb.Now(),
 			},
-			expectedCode: codes.InvalidArgument,
-			expectedMsg:  "invalid idempotency key",
+			expectedCode: codes.AlreadyExists,
+			expectedMsg:  app.ErrIdempotencyKeyViolation.Error(),
 		},
 	}
 
@@ -285,7 +379,7 @@ func TestE2E_RPC_CreateTransactionFailure(t *testing.T) {
 				tx := tt.seedRepo(t)
 				request.Entries[0].Id = tx.Entries[0].ID.String()
 
-				defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "account_version")
+				defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
 			}
 
 			response, err := testenv.RPCClient.CreateTransaction(context.Background(), request)
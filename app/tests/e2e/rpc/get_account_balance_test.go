@@ -24,7 +24,7 @@ func TestE2E_RPC_GetAccountBalanceSuccess_Analytic(t *testing.T) {
 
 		_ = testseed.CreateTransaction(t, e1, e2)
 
-		defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "account_version")
+		defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
 
 		request := &proto.GetAccountBalanceRequest{
 			Account: e1.Account.Value(),
@@ -76,7 +76,7 @@ func TestE2E_RPC_GetAccountBalanceSuccess_Synthetic(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.seedRepo(t)
 
-			defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "account_version")
+			defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
 
 			request := &proto.GetAccountBalanceRequest{
 				Account: tt.account,
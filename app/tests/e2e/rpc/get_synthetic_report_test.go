@@ -133,7 +133,7 @@ func TestE2E_RPC_GetSyntheticReportSuccess(t *testing.T) {
 			tx := tt.repoSeed(t)
 			request := tt.requestSetup(tx)
 
-			defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "account_version")
+			defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
 
 			report, err := testenv.RPCClient.GetSyntheticReport(context.Background(), request)
 			assert.NoError(t, err)
@@ -147,7 +147,7 @@ func TestE2E_RPC_ListAccountEntriesSuccess(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.seedRepo(t)
 
-			defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "account_version")
+			defer tests.TruncateTables(context.Background(), testenv.DB, "entry", "entry_id", "account_version")
 
 			request := tt.requestSetup(t)
 
@@ -2,3 +2,5 @@ package mocks
 
 //go:generate moq -pkg mocks -out ledger_repository_mock.go ../../domain Repository
 //go:generate moq -pkg mocks -out ledger_usecase_mock.go ../../domain UseCase
+//go:generate moq -pkg mocks -out admin_repository_mock.go ../../domain AdminRepository
+//go:generate moq -pkg mocks -out admin_usecase_mock.go ../../domain AdminUseCase
@@ -23,21 +23,30 @@ var _ domain.Repository = &RepositoryMock{}
 //
 // 		// make and configure a mocked domain.Repository
 // 		mockedRepository := &RepositoryMock{
-// 			CreateTransactionFunc: func(contextMoqParam context.Context, transaction entities.Transaction) error {
+// 			CreateTransactionFunc: func(contextMoqParam context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
 // 				panic("mock out the CreateTransaction method")
 // 			},
 // 			GetAnalyticAccountBalanceFunc: func(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error) {
 // 				panic("mock out the GetAnalyticAccountBalance method")
 // 			},
+// 			GetEventSchemaFunc: func(contextMoqParam context.Context, v uint32) (vos.EventSchema, error) {
+// 				panic("mock out the GetEventSchema method")
+// 			},
 // 			GetSyntheticAccountBalanceFunc: func(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error) {
 // 				panic("mock out the GetSyntheticAccountBalance method")
 // 			},
 // 			GetSyntheticReportFunc: func(contextMoqParam context.Context, account vos.Account, n int, timeMoqParam1 time.Time, timeMoqParam2 time.Time) (*vos.SyntheticReport, error) {
 // 				panic("mock out the GetSyntheticReport method")
 // 			},
-// 			ListAccountEntriesFunc: func(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) ([]vos.AccountEntry, pagination.Cursor, error) {
+// 			ListAccountChildrenFunc: func(contextMoqParam context.Context, accountChildrenRequest vos.AccountChildrenRequest) ([]vos.AccountChild, pagination.Cursor, error) {
+// 				panic("mock out the ListAccountChildren method")
+// 			},
+// 			ListAccountEntriesFunc: func(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
 // 				panic("mock out the ListAccountEntries method")
 // 			},
+// 			ListAccountsFunc: func(contextMoqParam context.Context, accountListRequest vos.AccountListRequest) ([]vos.AccountSummary, pagination.Cursor, error) {
+// 				panic("mock out the ListAccounts method")
+// 			},
 // 		}
 //
 // 		// use mockedRepository in code that requires domain.Repository
@@ -46,19 +55,28 @@ var _ domain.Repository = &RepositoryMock{}
 // 	}
 type RepositoryMock struct {
 	// CreateTransactionFunc mocks the CreateTransaction method.
-	CreateTransactionFunc func(contextMoqParam context.Context, transaction entities.Transaction) error
+	CreateTransactionFunc func(contextMoqParam context.Context, transaction entities.Transaction) (vos.TransactionResult, error)
 
 	// GetAnalyticAccountBalanceFunc mocks the GetAnalyticAccountBalance method.
 	GetAnalyticAccountBalanceFunc func(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error)
 
+	// GetEventSchemaFunc mocks the GetEventSchema method.
+	GetEventSchemaFunc func(contextMoqParam context.Context, v uint32) (vos.EventSchema, error)
+
 	// GetSyntheticAccountBalanceFunc mocks the GetSyntheticAccountBalance method.
 	GetSyntheticAccountBalanceFunc func(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error)
 
 	// GetSyntheticReportFunc mocks the GetSyntheticReport method.
 	GetSyntheticReportFunc func(contextMoqParam context.Context, account vos.Account, n int, timeMoqParam1 time.Time, timeMoqParam2 time.Time) (*vos.SyntheticReport, error)
 
+	// ListAccountChildrenFunc mocks the ListAccountChildren method.
+	ListAccountChildrenFunc func(contextMoqParam context.Context, accountChildrenRequest vos.AccountChildrenRequest) ([]vos.AccountChild, pagination.Cursor, error)
+
 	// ListAccountEntriesFunc mocks the ListAccountEntries method.
-	ListAccountEntriesFunc func(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) ([]vos.AccountEntry, pagination.Cursor, error)
+	ListAccountEntriesFunc func(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) (vos.AccountEntryResponse, error)
+
+	// ListAccountsFunc mocks the ListAccounts method.
+	ListAccountsFunc func(contextMoqParam context.Context, accountListRequest vos.AccountListRequest) ([]vos.AccountSummary, pagination.Cursor, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -76,6 +94,13 @@ type RepositoryMock struct {
 			// Account is the account argument value.
 			Account vos.Account
 		}
+		// GetEventSchema holds details about calls to the GetEventSchema method.
+		GetEventSchema []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// V is the v argument value.
+			V uint32
+		}
 		// GetSyntheticAccountBalance holds details about calls to the GetSyntheticAccountBalance method.
 		GetSyntheticAccountBalance []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -96,6 +121,13 @@ type RepositoryMock struct {
 			// TimeMoqParam2 is the timeMoqParam2 argument value.
 			TimeMoqParam2 time.Time
 		}
+		// ListAccountChildren holds details about calls to the ListAccountChildren method.
+		ListAccountChildren []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// AccountChildrenRequest is the accountChildrenRequest argument value.
+			AccountChildrenRequest vos.AccountChildrenRequest
+		}
 		// ListAccountEntries holds details about calls to the ListAccountEntries method.
 		ListAccountEntries []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -103,16 +135,26 @@ type RepositoryMock struct {
 			// AccountEntryRequest is the accountEntryRequest argument value.
 			AccountEntryRequest vos.AccountEntryRequest
 		}
+		// ListAccounts holds details about calls to the ListAccounts method.
+		ListAccounts []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// AccountListRequest is the accountListRequest argument value.
+			AccountListRequest vos.AccountListRequest
+		}
 	}
 	lockCreateTransaction          sync.RWMutex
 	lockGetAnalyticAccountBalance  sync.RWMutex
+	lockGetEventSchema             sync.RWMutex
 	lockGetSyntheticAccountBalance sync.RWMutex
 	lockGetSyntheticReport         sync.RWMutex
+	lockListAccountChildren        sync.RWMutex
 	lockListAccountEntries         sync.RWMutex
+	lockListAccounts               sync.RWMutex
 }
 
 // CreateTransaction calls CreateTransactionFunc.
-func (mock *RepositoryMock) CreateTransaction(contextMoqParam context.Context, transaction entities.Transaction) error {
+func (mock *RepositoryMock) CreateTransaction(contextMoqParam context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
 	if mock.CreateTransactionFunc == nil {
 		panic("RepositoryMock.CreateTransactionFunc: method is nil but Repository.CreateTransaction was just called")
 	}
@@ -181,6 +223,41 @@ func (mock *RepositoryMock) GetAnalyticAccountBalanceCalls() []struct {
 	return calls
 }
 
+// GetEventSchema calls GetEventSchemaFunc.
+func (mock *RepositoryMock) GetEventSchema(contextMoqParam context.Context, v uint32) (vos.EventSchema, error) {
+	if mock.GetEventSchemaFunc == nil {
+		panic("RepositoryMock.GetEventSchemaFunc: method is nil but Repository.GetEventSchema was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		V               uint32
+	}{
+		ContextMoqParam: contextMoqParam,
+		V:               v,
+	}
+	mock.lockGetEventSchema.Lock()
+	mock.calls.GetEventSchema = append(mock.calls.GetEventSchema, callInfo)
+	mock.lockGetEventSchema.Unlock()
+	return mock.GetEventSchemaFunc(contextMoqParam, v)
+}
+
+// GetEventSchemaCalls gets all the calls that were made to GetEventSchema.
+// Check the length with:
+//     len(mockedRepository.GetEventSchemaCalls())
+func (mock *RepositoryMock) GetEventSchemaCalls() []struct {
+	ContextMoqParam context.Context
+	V               uint32
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		V               uint32
+	}
+	mock.lockGetEventSchema.RLock()
+	calls = mock.calls.GetEventSchema
+	mock.lockGetEventSchema.RUnlock()
+	return calls
+}
+
 // GetSyntheticAccountBalance calls GetSyntheticAccountBalanceFunc.
 func (mock *RepositoryMock) GetSyntheticAccountBalance(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error) {
 	if mock.GetSyntheticAccountBalanceFunc == nil {
@@ -263,8 +340,43 @@ func (mock *RepositoryMock) GetSyntheticReportCalls() []struct {
 	return calls
 }
 
+// ListAccountChildren calls ListAccountChildrenFunc.
+func (mock *RepositoryMock) ListAccountChildren(contextMoqParam context.Context, accountChildrenRequest vos.AccountChildrenRequest) ([]vos.AccountChild, pagination.Cursor, error) {
+	if mock.ListAccountChildrenFunc == nil {
+		panic("RepositoryMock.ListAccountChildrenFunc: method is nil but Repository.ListAccountChildren was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam        context.Context
+		AccountChildrenRequest vos.AccountChildrenRequest
+	}{
+		ContextMoqParam:        contextMoqParam,
+		AccountChildrenRequest: accountChildrenRequest,
+	}
+	mock.lockListAccountChildren.Lock()
+	mock.calls.ListAccountChildren = append(mock.calls.ListAccountChildren, callInfo)
+	mock.lockListAccountChildren.Unlock()
+	return mock.ListAccountChildrenFunc(contextMoqParam, accountChildrenRequest)
+}
+
+// ListAccountChildrenCalls gets all the calls that were made to ListAccountChildren.
+// Check the length with:
+//     len(mockedRepository.ListAccountChildrenCalls())
+func (mock *RepositoryMock) ListAccountChildrenCalls() []struct {
+	ContextMoqParam        context.Context
+	AccountChildrenRequest vos.AccountChildrenRequest
+} {
+	var calls []struct {
+		ContextMoqParam        context.Context
+		AccountChildrenRequest vos.AccountChildrenRequest
+	}
+	mock.lockListAccountChildren.RLock()
+	calls = mock.calls.ListAccountChildren
+	mock.lockListAccountChildren.RUnlock()
+	return calls
+}
+
 // ListAccountEntries calls ListAccountEntriesFunc.
-func (mock *RepositoryMock) ListAccountEntries(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) ([]vos.AccountEntry, pagination.Cursor, error) {
+func (mock *RepositoryMock) ListAccountEntries(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
 	if mock.ListAccountEntriesFunc == nil {
 		panic("RepositoryMock.ListAccountEntriesFunc: method is nil but Repository.ListAccountEntries was just called")
 	}
@@ -297,3 +409,38 @@ func (mock *RepositoryMock) ListAccountEntriesCalls() []struct {
 	mock.lockListAccountEntries.RUnlock()
 	return calls
 }
+
+// ListAccounts calls ListAccountsFunc.
+func (mock *RepositoryMock) ListAccounts(contextMoqParam context.Context, accountListRequest vos.AccountListRequest) ([]vos.AccountSummary, pagination.Cursor, error) {
+	if mock.ListAccountsFunc == nil {
+		panic("RepositoryMock.ListAccountsFunc: method is nil but Repository.ListAccounts was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam    context.Context
+		AccountListRequest vos.AccountListRequest
+	}{
+		ContextMoqParam:    contextMoqParam,
+		AccountListRequest: accountListRequest,
+	}
+	mock.lockListAccounts.Lock()
+	mock.calls.ListAccounts = append(mock.calls.ListAccounts, callInfo)
+	mock.lockListAccounts.Unlock()
+	return mock.ListAccountsFunc(contextMoqParam, accountListRequest)
+}
+
+// ListAccountsCalls gets all the calls that were made to ListAccounts.
+// Check the length with:
+//     len(mockedRepository.ListAccountsCalls())
+func (mock *RepositoryMock) ListAccountsCalls() []struct {
+	ContextMoqParam    context.Context
+	AccountListRequest vos.AccountListRequest
+} {
+	var calls []struct {
+		ContextMoqParam    context.Context
+		AccountListRequest vos.AccountListRequest
+	}
+	mock.lockListAccounts.RLock()
+	calls = mock.calls.ListAccounts
+	mock.lockListAccounts.RUnlock()
+	return calls
+}
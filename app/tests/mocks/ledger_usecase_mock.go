@@ -22,18 +22,27 @@ var _ domain.UseCase = &UseCaseMock{}
 //
 // 		// make and configure a mocked domain.UseCase
 // 		mockedUseCase := &UseCaseMock{
-// 			CreateTransactionFunc: func(contextMoqParam context.Context, transaction entities.Transaction) error {
+// 			CreateTransactionFunc: func(contextMoqParam context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
 // 				panic("mock out the CreateTransaction method")
 // 			},
+// 			ExpandEventFunc: func(contextMoqParam context.Context, eventPosting vos.EventPosting) (entities.Transaction, error) {
+// 				panic("mock out the ExpandEvent method")
+// 			},
 // 			GetAccountBalanceFunc: func(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error) {
 // 				panic("mock out the GetAccountBalance method")
 // 			},
 // 			GetSyntheticReportFunc: func(contextMoqParam context.Context, account vos.Account, n int, timeMoqParam1 time.Time, timeMoqParam2 time.Time) (*vos.SyntheticReport, error) {
 // 				panic("mock out the GetSyntheticReport method")
 // 			},
+// 			ListAccountChildrenFunc: func(contextMoqParam context.Context, accountChildrenRequest vos.AccountChildrenRequest) (vos.AccountChildrenResponse, error) {
+// 				panic("mock out the ListAccountChildren method")
+// 			},
 // 			ListAccountEntriesFunc: func(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
 // 				panic("mock out the ListAccountEntries method")
 // 			},
+// 			ListAccountsFunc: func(contextMoqParam context.Context, accountListRequest vos.AccountListRequest) (vos.AccountListResponse, error) {
+// 				panic("mock out the ListAccounts method")
+// 			},
 // 		}
 //
 // 		// use mockedUseCase in code that requires domain.UseCase
@@ -42,7 +51,10 @@ var _ domain.UseCase = &UseCaseMock{}
 // 	}
 type UseCaseMock struct {
 	// CreateTransactionFunc mocks the CreateTransaction method.
-	CreateTransactionFunc func(contextMoqParam context.Context, transaction entities.Transaction) error
+	CreateTransactionFunc func(contextMoqParam context.Context, transaction entities.Transaction) (vos.TransactionResult, error)
+
+	// ExpandEventFunc mocks the ExpandEvent method.
+	ExpandEventFunc func(contextMoqParam context.Context, eventPosting vos.EventPosting) (entities.Transaction, error)
 
 	// GetAccountBalanceFunc mocks the GetAccountBalance method.
 	GetAccountBalanceFunc func(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error)
@@ -50,9 +62,15 @@ type UseCaseMock struct {
 	// GetSyntheticReportFunc mocks the GetSyntheticReport method.
 	GetSyntheticReportFunc func(contextMoqParam context.Context, account vos.Account, n int, timeMoqParam1 time.Time, timeMoqParam2 time.Time) (*vos.SyntheticReport, error)
 
+	// ListAccountChildrenFunc mocks the ListAccountChildren method.
+	ListAccountChildrenFunc func(contextMoqParam context.Context, accountChildrenRequest vos.AccountChildrenRequest) (vos.AccountChildrenResponse, error)
+
 	// ListAccountEntriesFunc mocks the ListAccountEntries method.
 	ListAccountEntriesFunc func(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) (vos.AccountEntryResponse, error)
 
+	// ListAccountsFunc mocks the ListAccounts method.
+	ListAccountsFunc func(contextMoqParam context.Context, accountListRequest vos.AccountListRequest) (vos.AccountListResponse, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateTransaction holds details about calls to the CreateTransaction method.
@@ -62,6 +80,13 @@ type UseCaseMock struct {
 			// Transaction is the transaction argument value.
 			Transaction entities.Transaction
 		}
+		// ExpandEvent holds details about calls to the ExpandEvent method.
+		ExpandEvent []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// EventPosting is the eventPosting argument value.
+			EventPosting vos.EventPosting
+		}
 		// GetAccountBalance holds details about calls to the GetAccountBalance method.
 		GetAccountBalance []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -82,6 +107,13 @@ type UseCaseMock struct {
 			// TimeMoqParam2 is the timeMoqParam2 argument value.
 			TimeMoqParam2 time.Time
 		}
+		// ListAccountChildren holds details about calls to the ListAccountChildren method.
+		ListAccountChildren []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// AccountChildrenRequest is the accountChildrenRequest argument value.
+			AccountChildrenRequest vos.AccountChildrenRequest
+		}
 		// ListAccountEntries holds details about calls to the ListAccountEntries method.
 		ListAccountEntries []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -89,15 +121,25 @@ type UseCaseMock struct {
 			// AccountEntryRequest is the accountEntryRequest argument value.
 			AccountEntryRequest vos.AccountEntryRequest
 		}
+		// ListAccounts holds details about calls to the ListAccounts method.
+		ListAccounts []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// AccountListRequest is the accountListRequest argument value.
+			AccountListRequest vos.AccountListRequest
+		}
 	}
-	lockCreateTransaction  sync.RWMutex
-	lockGetAccountBalance  sync.RWMutex
-	lockGetSyntheticReport sync.RWMutex
-	lockListAccountEntries sync.RWMutex
+	lockCreateTransaction   sync.RWMutex
+	lockExpandEvent         sync.RWMutex
+	lockGetAccountBalance   sync.RWMutex
+	lockGetSyntheticReport  sync.RWMutex
+	lockListAccountChildren sync.RWMutex
+	lockListAccountEntries  sync.RWMutex
+	lockListAccounts        sync.RWMutex
 }
 
 // CreateTransaction calls CreateTransactionFunc.
-func (mock *UseCaseMock) CreateTransaction(contextMoqParam context.Context, transaction entities.Transaction) error {
+func (mock *UseCaseMock) CreateTransaction(contextMoqParam context.Context, transaction entities.Transaction) (vos.TransactionResult, error) {
 	if mock.CreateTransactionFunc == nil {
 		panic("UseCaseMock.CreateTransactionFunc: method is nil but UseCase.CreateTransaction was just called")
 	}
@@ -131,6 +173,41 @@ func (mock *UseCaseMock) CreateTransactionCalls() []struct {
 	return calls
 }
 
+// ExpandEvent calls ExpandEventFunc.
+func (mock *UseCaseMock) ExpandEvent(contextMoqParam context.Context, eventPosting vos.EventPosting) (entities.Transaction, error) {
+	if mock.ExpandEventFunc == nil {
+		panic("UseCaseMock.ExpandEventFunc: method is nil but UseCase.ExpandEvent was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		EventPosting    vos.EventPosting
+	}{
+		ContextMoqParam: contextMoqParam,
+		EventPosting:    eventPosting,
+	}
+	mock.lockExpandEvent.Lock()
+	mock.calls.ExpandEvent = append(mock.calls.ExpandEvent, callInfo)
+	mock.lockExpandEvent.Unlock()
+	return mock.ExpandEventFunc(contextMoqParam, eventPosting)
+}
+
+// ExpandEventCalls gets all the calls that were made to ExpandEvent.
+// Check the length with:
+//     len(mockedUseCase.ExpandEventCalls())
+func (mock *UseCaseMock) ExpandEventCalls() []struct {
+	ContextMoqParam context.Context
+	EventPosting    vos.EventPosting
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		EventPosting    vos.EventPosting
+	}
+	mock.lockExpandEvent.RLock()
+	calls = mock.calls.ExpandEvent
+	mock.lockExpandEvent.RUnlock()
+	return calls
+}
+
 // GetAccountBalance calls GetAccountBalanceFunc.
 func (mock *UseCaseMock) GetAccountBalance(contextMoqParam context.Context, account vos.Account) (vos.AccountBalance, error) {
 	if mock.GetAccountBalanceFunc == nil {
@@ -213,6 +290,41 @@ func (mock *UseCaseMock) GetSyntheticReportCalls() []struct {
 	return calls
 }
 
+// ListAccountChildren calls ListAccountChildrenFunc.
+func (mock *UseCaseMock) ListAccountChildren(contextMoqParam context.Context, accountChildrenRequest vos.AccountChildrenRequest) (vos.AccountChildrenResponse, error) {
+	if mock.ListAccountChildrenFunc == nil {
+		panic("UseCaseMock.ListAccountChildrenFunc: method is nil but UseCase.ListAccountChildren was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam        context.Context
+		AccountChildrenRequest vos.AccountChildrenRequest
+	}{
+		ContextMoqParam:        contextMoqParam,
+		AccountChildrenRequest: accountChildrenRequest,
+	}
+	mock.lockListAccountChildren.Lock()
+	mock.calls.ListAccountChildren = append(mock.calls.ListAccountChildren, callInfo)
+	mock.lockListAccountChildren.Unlock()
+	return mock.ListAccountChildrenFunc(contextMoqParam, accountChildrenRequest)
+}
+
+// ListAccountChildrenCalls gets all the calls that were made to ListAccountChildren.
+// Check the length with:
+//     len(mockedUseCase.ListAccountChildrenCalls())
+func (mock *UseCaseMock) ListAccountChildrenCalls() []struct {
+	ContextMoqParam        context.Context
+	AccountChildrenRequest vos.AccountChildrenRequest
+} {
+	var calls []struct {
+		ContextMoqParam        context.Context
+		AccountChildrenRequest vos.AccountChildrenRequest
+	}
+	mock.lockListAccountChildren.RLock()
+	calls = mock.calls.ListAccountChildren
+	mock.lockListAccountChildren.RUnlock()
+	return calls
+}
+
 // ListAccountEntries calls ListAccountEntriesFunc.
 func (mock *UseCaseMock) ListAccountEntries(contextMoqParam context.Context, accountEntryRequest vos.AccountEntryRequest) (vos.AccountEntryResponse, error) {
 	if mock.ListAccountEntriesFunc == nil {
@@ -247,3 +359,38 @@ func (mock *UseCaseMock) ListAccountEntriesCalls() []struct {
 	mock.lockListAccountEntries.RUnlock()
 	return calls
 }
+
+// ListAccounts calls ListAccountsFunc.
+func (mock *UseCaseMock) ListAccounts(contextMoqParam context.Context, accountListRequest vos.AccountListRequest) (vos.AccountListResponse, error) {
+	if mock.ListAccountsFunc == nil {
+		panic("UseCaseMock.ListAccountsFunc: method is nil but UseCase.ListAccounts was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam    context.Context
+		AccountListRequest vos.AccountListRequest
+	}{
+		ContextMoqParam:    contextMoqParam,
+		AccountListRequest: accountListRequest,
+	}
+	mock.lockListAccounts.Lock()
+	mock.calls.ListAccounts = append(mock.calls.ListAccounts, callInfo)
+	mock.lockListAccounts.Unlock()
+	return mock.ListAccountsFunc(contextMoqParam, accountListRequest)
+}
+
+// ListAccountsCalls gets all the calls that were made to ListAccounts.
+// Check the length with:
+//     len(mockedUseCase.ListAccountsCalls())
+func (mock *UseCaseMock) ListAccountsCalls() []struct {
+	ContextMoqParam    context.Context
+	AccountListRequest vos.AccountListRequest
+} {
+	var calls []struct {
+		ContextMoqParam    context.Context
+		AccountListRequest vos.AccountListRequest
+	}
+	mock.lockListAccounts.RLock()
+	calls = mock.calls.ListAccounts
+	mock.lockListAccounts.RUnlock()
+	return calls
+}
@@ -18,7 +18,7 @@ func CreateTransaction(t *testing.T, entries ...entities.Entry) entities.Transac
 	tx, err := entities.NewTransaction(uuid.New(), uint32(1), "abc", time.Now(), entries...)
 	assert.NoError(t, err)
 
-	err = testenv.LedgerRepository.CreateTransaction(context.Background(), tx)
+	_, err = testenv.LedgerRepository.CreateTransaction(context.Background(), tx)
 	assert.NoError(t, err)
 
 	return tx
@@ -6,7 +6,7 @@ import (
 	"net"
 
 	"github.com/jackc/pgx/v4/pgxpool"
-	"github.com/newrelic/go-agent/v3/newrelic"
+	gonewrelic "github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
@@ -15,20 +15,24 @@ import (
 	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
 	"github.com/stone-co/the-amazing-ledger/app/gateways/db/postgres"
 	"github.com/stone-co/the-amazing-ledger/app/gateways/rpc"
+	"github.com/stone-co/the-amazing-ledger/app/instrumentation/newrelic"
 	"github.com/stone-co/the-amazing-ledger/app/tests/testenv"
 )
 
 func StartServer(ctx context.Context, db *pgxpool.Pool, cfg *app.Config, startGatewayServer bool) {
 	zerolog.SetGlobalLevel(zerolog.FatalLevel)
 
-	nr, err := newrelic.NewApplication(newrelic.ConfigEnabled(false))
+	nr, err := gonewrelic.NewApplication(gonewrelic.ConfigEnabled(false))
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create newrelic application")
 	}
 
-	ledgerInstrumentator := instrumentators.NewLedgerInstrumentator(nr)
+	tracer := newrelic.NewTracer(nr)
+	ledgerInstrumentator := instrumentators.NewLedgerInstrumentator(tracer)
 	ledgerRepository := postgres.NewLedgerRepository(db, ledgerInstrumentator)
 	ledgerUsecase := usecases.NewLedgerUseCase(ledgerRepository, ledgerInstrumentator)
+	adminUsecase := usecases.NewAdminUseCase(ledgerRepository, ledgerInstrumentator)
+	adminUsecase.SetLedger(ledgerUsecase)
 
 	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", cfg.RPCServer.Host, cfg.RPCServer.Port))
 	if err != nil {
@@ -38,7 +42,7 @@ func StartServer(ctx context.Context, db *pgxpool.Pool, cfg *app.Config, startGa
 	buildCommit := "undefined"
 	buildTime := "undefined"
 
-	rpcServer, gwServer, err := rpc.NewServer(ctx, ledgerUsecase, nr, cfg, buildCommit, buildTime)
+	rpcServer, gwServer, err := rpc.NewServer(ctx, ledgerUsecase, adminUsecase, rpc.NewHealth(ledgerRepository), tracer, cfg, buildCommit, buildTime)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create servers")
 	}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var (
+	errMissingEvent     = errors.New("missing -event flag")
+	errMetadataViolated = errors.New("entry metadata breaks the event schema")
+)
+
+func validateMetadata(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("validate-metadata", flag.ExitOnError)
+	event := flags.Uint("event", 0, "event whose entries are validated")
+	version := flags.Int("version", 0, "schema version to validate against, the latest when zero")
+	since := flags.String("since", "", "only validate entries created since this RFC 3339 date")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *event == 0 {
+		return errMissingEvent
+	}
+
+	req := vos.MetadataValidationRequest{Event: uint32(*event), Version: *version}
+	if *since != "" {
+		date, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			return fmt.Errorf("invalid -since flag: %w", err)
+		}
+
+		req.Since = date
+	}
+
+	// The entries are checked a page at a time, so the violations are printed as they are found.
+	var checked, violations int
+
+	for {
+		report, err := adminUseCase.ValidateEventMetadata(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, violation := range report.Violations {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", violation.EntryID, violation.TxID, violation.Book, violation.Account, strings.Join(violation.Errors, "; "))
+		}
+
+		checked += report.Checked
+		violations += len(report.Violations)
+		// The version is kept, so a version created meanwhile doesn't change the pages left.
+		req.Version = report.Version
+
+		if report.NextPage == nil {
+			break
+		}
+
+		req.Page.Cursor = report.NextPage
+	}
+
+	fmt.Printf("checked %d entries against version %d of the schema of event %d\n", checked, req.Version, req.Event)
+
+	if violations > 0 {
+		return fmt.Errorf("found %d violation(s): %w", violations, errMetadataViolated)
+	}
+
+	return nil
+}
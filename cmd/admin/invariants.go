@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
+)
+
+var errInvariantsViolated = errors.New("ledger invariants violated")
+
+func checkInvariants(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("check-invariants", flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := adminUseCase.CheckInvariants(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, violation := range report.Violations {
+		fmt.Printf("%s\t%s\t%s\n", violation.Check, violation.Subject, violation.Detail)
+	}
+
+	if len(report.Violations) > 0 {
+		return fmt.Errorf("found %d violation(s): %w", len(report.Violations), errInvariantsViolated)
+	}
+
+	return nil
+}
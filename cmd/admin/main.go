@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
+	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
+	"github.com/stone-co/the-amazing-ledger/app/gateways/db/postgres"
+)
+
+const usage = `usage: admin <command> [flags]
+
+Commands:
+  check-invariants        verify the ledger invariants for the entries created since the last check
+  rebuild-snapshots       discard and recompute the balance snapshots of an account or account query
+  prune-snapshots         delete the balance snapshots not read in the last days
+  precompute-snapshots    bring the snapshots of the most read account queries up to date
+  manage-partitions       create future entry partitions and detach the ones out of retention
+  archive-partitions      export detached entry partitions to compressed files and drop them
+  validate-metadata       report the entries of an event whose metadata breaks its schema (dry run)
+`
+
+type command func(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error
+
+var commands = map[string]command{
+	"check-invariants":     checkInvariants,
+	"rebuild-snapshots":    rebuildSnapshots,
+	"prune-snapshots":      pruneSnapshots,
+	"precompute-snapshots": precomputeSnapshots,
+	"manage-partitions":    managePartitions,
+	"archive-partitions":   archivePartitions,
+	"validate-metadata":    validateMetadata,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	logger := log.With().
+		Str("module", "admin").
+		Str("command", os.Args[1]).
+		Logger()
+
+	cfg, err := app.LoadConfig()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to load app configurations")
+	}
+
+	// Maintenance commands aren't traced.
+	ledgerInstrumentator := &instrumentators.LedgerInstrumentator{}
+
+	conn, err := postgres.ConnectPool(cfg.Postgres.DSN(), zerolog.New(os.Stderr).Level(zerolog.WarnLevel))
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to connect to database")
+	}
+	defer conn.Close()
+
+	ledgerRepository, err := postgres.NewRepository(conn, ledgerInstrumentator, cfg.Postgres.BalanceStrategy)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to create ledger repository")
+	}
+	adminUseCase := usecases.NewAdminUseCase(ledgerRepository, ledgerInstrumentator)
+
+	ctx := logger.WithContext(context.Background())
+
+	if err = cmd(ctx, adminUseCase, os.Args[2:]); err != nil {
+		logger.Error().Err(err).Msg("command failed")
+		conn.Close()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
+)
+
+func managePartitions(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("manage-partitions", flag.ExitOnError)
+	monthsAhead := flags.Int("months-ahead", 3, "create partitions up to this number of months in the future")
+	retentionMonths := flags.Int("retention-months", 0, "detach partitions older than this number of months (0 keeps all)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := adminUseCase.ManagePartitions(ctx, *monthsAhead, *retentionMonths)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range report.Created {
+		fmt.Printf("created\t%s\n", name)
+	}
+
+	for _, name := range report.Detached {
+		fmt.Printf("detached\t%s\n", name)
+	}
+
+	return nil
+}
+
+func archivePartitions(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("archive-partitions", flag.ExitOnError)
+	dir := flags.String("dir", ".", "directory where the archive files are written")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	archived, err := adminUseCase.ArchivePartitions(ctx, *dir)
+	if err != nil {
+		return err
+	}
+
+	for _, partition := range archived {
+		fmt.Printf("archived\t%s\t%s\n", partition.Name, partition.Archive)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+)
+
+var errMissingAccount = errors.New("missing -account flag")
+
+func rebuildSnapshots(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("rebuild-snapshots", flag.ExitOnError)
+	accountFlag := flags.String("account", "", "account or account query whose snapshots are rebuilt")
+	bookFlag := flags.String("book", vos.DefaultBook, "book of the account")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *accountFlag == "" {
+		return errMissingAccount
+	}
+
+	account, err := vos.NewAccount(*accountFlag)
+	if err != nil {
+		return err
+	}
+
+	book, err := vos.NewBookName(*bookFlag)
+	if err != nil {
+		return err
+	}
+
+	total, err := adminUseCase.RebuildSnapshots(vos.WithBook(ctx, book), account)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("rebuilt %d snapshot(s)\n", total)
+
+	return nil
+}
+
+func pruneSnapshots(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("prune-snapshots", flag.ExitOnError)
+	days := flags.Uint("days", 30, "delete snapshots not read in this number of days")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	total, err := adminUseCase.PruneSnapshots(ctx, time.Duration(*days)*24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("pruned %d snapshot(s)\n", total)
+
+	return nil
+}
+
+func precomputeSnapshots(ctx context.Context, adminUseCase *usecases.AdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("precompute-snapshots", flag.ExitOnError)
+	limit := flags.Int("limit", 100, "maximum number of account queries to precompute")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	total, err := adminUseCase.PrecomputeSnapshots(ctx, *limit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("precomputed %d snapshot(s)\n", total)
+
+	return nil
+}
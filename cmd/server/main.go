@@ -2,22 +2,29 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"github.com/sirupsen/logrus"
 
 	"github.com/stone-co/the-amazing-ledger/app"
+	"github.com/stone-co/the-amazing-ledger/app/domain"
 	"github.com/stone-co/the-amazing-ledger/app/domain/instrumentators"
 	"github.com/stone-co/the-amazing-ledger/app/domain/usecases"
+	"github.com/stone-co/the-amazing-ledger/app/domain/vos"
+	"github.com/stone-co/the-amazing-ledger/app/gateways/db/memory"
 	"github.com/stone-co/the-amazing-ledger/app/gateways/db/postgres"
 	"github.com/stone-co/the-amazing-ledger/app/gateways/rpc"
-	"github.com/stone-co/the-amazing-ledger/app/instrumentation/newrelic"
+	"github.com/stone-co/the-amazing-ledger/app/instrumentation"
+	"github.com/stone-co/the-amazing-ledger/app/jobs"
 )
 
 func main() {
@@ -34,23 +41,105 @@ func main() {
 		logger.Panic().Err(err).Msg("failed to load app configurations")
 	}
 
-	nr, err := newrelic.NewApp(cfg.NewRelic.AppName, cfg.NewRelic.LicenseKey, logrus.NewEntry(logrus.New()))
+	tracer, err := instrumentation.NewTracer(context.Background(), cfg)
 	if err != nil {
-		logger.Panic().Err(err).Msg("failed to start new relic")
+		logger.Panic().Err(err).Msg("failed to start tracer")
 	}
+	logger.Info().Str("tracer", cfg.Tracer).Msg("started tracer")
 
-	ledgerInstrumentator := instrumentators.NewLedgerInstrumentator(nr)
+	ledgerInstrumentator := instrumentators.NewLedgerInstrumentator(tracer)
 
-	conn, err := postgres.ConnectPool(cfg.Postgres.DSN(), zerolog.New(os.Stderr))
-	if err != nil {
-		logger.Panic().Err(err).Msg("failed to connect to database")
+	var (
+		ledgerRepository domain.Repository
+		adminUseCase     *usecases.AdminUseCase
+		healthChecker    domain.HealthChecker
+	)
+
+	switch cfg.Storage {
+	case app.PostgresStorage:
+		conn, err := postgres.ConnectPool(cfg.Postgres.DSN(), zerolog.New(os.Stderr))
+		if err != nil {
+			logger.Panic().Err(err).Msg("failed to connect to database")
+		}
+		logger.Info().Msg("connected to postgres pool")
+		defer conn.Close()
+
+		prometheus.MustRegister(postgres.NewPoolCollector("primary", conn))
+
+		logger.Info().Msg("running migrations")
+		if err = postgres.RunMigrations(cfg.Postgres.URL()); err != nil {
+			logger.Panic().Err(err).Msg("failed to run database migrations")
+		}
+
+		replicas := make([]*pgxpool.Pool, 0, len(cfg.Postgres.ReplicaDSNs))
+		for i, dsn := range cfg.Postgres.ReplicaDSNs {
+			replica, connErr := postgres.ConnectPool(dsn, zerolog.New(os.Stderr))
+			if connErr != nil {
+				logger.Panic().Err(connErr).Msg("failed to connect to read replica")
+			}
+			defer replica.Close()
+
+			prometheus.MustRegister(postgres.NewPoolCollector(fmt.Sprintf("replica_%d", i), replica))
+
+			replicas = append(replicas, replica)
+		}
+		if len(replicas) > 0 {
+			logger.Info().Int("replicas", len(replicas)).Msg("connected to read replicas")
+		}
+
+		repository, err := postgres.NewRepository(conn, ledgerInstrumentator, cfg.Postgres.BalanceStrategy,
+			postgres.WithReplicas(replicas, cfg.Postgres.ReplicaMaxLag),
+		)
+		if err != nil {
+			logger.Panic().Err(err).Msg("failed to create ledger repository")
+		}
+
+		// The eager strategy rebuilds stale running balances here, before anything is served.
+		if err = repository.PrepareBalances(context.Background()); err != nil {
+			logger.Panic().Err(err).Msg("failed to prepare balances")
+		}
+
+		ledgerRepository = repository
+		adminUseCase = usecases.NewAdminUseCase(repository, ledgerInstrumentator)
+		healthChecker = repository
+	case app.MemoryStorage:
+		logger.Warn().Msg("using memory storage, the ledger will be lost on shutdown and admin features are disabled")
+		ledgerRepository = memory.NewLedgerRepository()
+	default:
+		logger.Panic().Str("storage", cfg.Storage).Msg("unknown storage")
 	}
-	logger.Info().Msg("connected to postgres pool")
-	defer conn.Close()
 
-	logger.Info().Msg("running migrations")
-	if err = postgres.RunMigrations(cfg.Postgres.URL()); err != nil {
-		logger.Panic().Err(err).Msg("failed to run database migrations")
+	ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepository, ledgerInstrumentator)
+
+	if cfg.Chart.File != "" {
+		chart, err := vos.LoadChart(cfg.Chart.File)
+		if err != nil {
+			logger.Panic().Err(err).Msg("failed to load chart of accounts")
+		}
+		ledgerUseCase.SetChart(chart)
+		logger.Info().Str("file", cfg.Chart.File).Msg("loaded chart of accounts")
+	}
+
+	if cfg.Templates.File != "" {
+		templates, err := vos.LoadPostingTemplates(cfg.Templates.File)
+		if err != nil {
+			logger.Panic().Err(err).Msg("failed to load posting templates")
+		}
+		ledgerUseCase.SetPostingTemplates(templates)
+		logger.Info().Str("file", cfg.Templates.File).Msg("loaded posting templates")
+	}
+
+	if adminUseCase != nil {
+		adminUseCase.SetLedger(ledgerUseCase)
+
+		if cfg.Accruals.File != "" {
+			rules, err := vos.LoadAccrualRules(cfg.Accruals.File)
+			if err != nil {
+				logger.Panic().Err(err).Msg("failed to load accrual rules")
+			}
+			adminUseCase.SetAccrualRules(rules)
+			logger.Info().Str("file", cfg.Accruals.File).Msg("loaded accrual rules")
+		}
 	}
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", cfg.RPCServer.Host, cfg.RPCServer.Port))
@@ -58,13 +147,124 @@ func main() {
 		logger.Panic().Err(err).Msg("failed to listen")
 	}
 
-	ledgerRepository := postgres.NewLedgerRepository(conn, ledgerInstrumentator)
-	ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepository, ledgerInstrumentator)
-
-	ctx := context.Background()
+	ctx := logger.WithContext(context.Background())
 	ctx, cancel := context.WithCancel(ctx)
 
-	rpcServer, gwServer, err := rpc.NewServer(ctx, ledgerUseCase, nr, cfg, BuildGitCommit, BuildTime)
+	scheduler := jobs.NewScheduler(log.With().Str("module", "jobs").Logger())
+	if adminUseCase != nil {
+		scheduler.Add(jobs.Job{
+			Name:     "check_invariants",
+			Interval: cfg.Jobs.InvariantCheckInterval,
+			Run: func(ctx context.Context) error {
+				// Another replica is already checking the same window.
+				_, err := adminUseCase.CheckInvariants(ctx)
+				if errors.Is(err, app.ErrInvariantCheckInProgress) {
+					return nil
+				}
+
+				return err
+			},
+		})
+		scheduler.Add(jobs.Job{
+			Name:     "prune_snapshots",
+			Interval: cfg.Jobs.SnapshotPruneInterval,
+			Run: func(ctx context.Context) error {
+				_, err := adminUseCase.PruneSnapshots(ctx, cfg.Jobs.SnapshotRetention)
+				return err
+			},
+		})
+		scheduler.Add(jobs.Job{
+			Name:     "precompute_snapshots",
+			Interval: cfg.Jobs.SnapshotPrecomputeInterval,
+			Run: func(ctx context.Context) error {
+				_, err := adminUseCase.PrecomputeSnapshots(ctx, cfg.Jobs.SnapshotPrecomputeLimit)
+				return err
+			},
+		})
+		scheduler.Add(jobs.Job{
+			Name:     "manage_partitions",
+			Interval: cfg.Jobs.PartitionInterval,
+			Run: func(ctx context.Context) error {
+				_, err := adminUseCase.ManagePartitions(ctx, cfg.Jobs.PartitionMonthsAhead, cfg.Jobs.PartitionRetentionMonths)
+				if err != nil || cfg.Jobs.PartitionArchiveDir == "" {
+					return err
+				}
+
+				_, err = adminUseCase.ArchivePartitions(ctx, cfg.Jobs.PartitionArchiveDir)
+				return err
+			},
+		})
+		scheduler.Add(jobs.Job{
+			Name:     "run_schedules",
+			Interval: cfg.Jobs.ScheduleInterval,
+			Run: func(ctx context.Context) error {
+				_, err := adminUseCase.RunSchedules(ctx, cfg.Jobs.ScheduleBatchSize)
+				return err
+			},
+		})
+
+		if cfg.Accruals.File != "" {
+			scheduler.Add(jobs.Job{
+				Name:     "run_accruals",
+				Interval: cfg.Jobs.AccrualInterval,
+				Run: func(ctx context.Context) error {
+					_, err := adminUseCase.RunDueAccruals(ctx)
+					return err
+				},
+			})
+			// Rules that fail to load are reported by the job, and the previous ones are kept.
+			scheduler.Add(jobs.Job{
+				Name:     "reload_accrual_rules",
+				Interval: cfg.Accruals.ReloadInterval,
+				Run: func(ctx context.Context) error {
+					rules, err := vos.LoadAccrualRules(cfg.Accruals.File)
+					if err != nil {
+						return err
+					}
+
+					adminUseCase.SetAccrualRules(rules)
+					return nil
+				},
+			})
+		}
+	}
+	if cfg.Chart.File != "" {
+		// A chart that fails to load is reported by the job, and the previous one is kept.
+		scheduler.Add(jobs.Job{
+			Name:     "reload_chart",
+			Interval: cfg.Chart.ReloadInterval,
+			Run: func(ctx context.Context) error {
+				chart, err := vos.LoadChart(cfg.Chart.File)
+				if err != nil {
+					return err
+				}
+
+				ledgerUseCase.SetChart(chart)
+				return nil
+			},
+		})
+	}
+	if cfg.Templates.File != "" {
+		// Templates that fail to load are reported by the job, and the previous ones are kept.
+		scheduler.Add(jobs.Job{
+			Name:     "reload_posting_templates",
+			Interval: cfg.Templates.ReloadInterval,
+			Run: func(ctx context.Context) error {
+				templates, err := vos.LoadPostingTemplates(cfg.Templates.File)
+				if err != nil {
+					return err
+				}
+
+				ledgerUseCase.SetPostingTemplates(templates)
+				return nil
+			},
+		})
+	}
+	scheduler.Start(ctx)
+
+	health := rpc.NewHealth(healthChecker)
+
+	rpcServer, gwServer, err := rpc.NewServer(ctx, ledgerUseCase, adminUseCase, health, tracer, cfg, BuildGitCommit, BuildTime)
 	if err != nil {
 		logger.Panic().Err(err).Msg("failed to create servers")
 	}
@@ -81,23 +281,38 @@ func main() {
 		<-ctx.Done()
 		logger.Info().Msg("context canceled, initiating graceful stop")
 
+		health.Shutdown()
+		logger.Info().Dur("drain_period", cfg.Health.DrainPeriod).Msg("health set to not serving, draining")
+
+		// Calls keep being served while load balancers notice the server is not serving.
+		time.Sleep(cfg.Health.DrainPeriod)
+
 		ctx, cancel = context.WithTimeout(context.Background(), cfg.HttpServer.ShutdownTimeout)
 		defer cancel()
 
-		rpcServer.GracefulStop()
-		logger.Info().Msg("rpcServer stopped")
-
+		// The gateway calls the rpc server through the internal listener, so it drains first.
 		if err = gwServer.Shutdown(ctx); err != nil {
 			_ = gwServer.Close()
 			logger.Error().Err(err).Msg("failed to stop gateway server gracefully")
 		}
 		logger.Info().Msg("gateway stopped")
+
+		rpcServer.GracefulStop()
+		logger.Info().Msg("rpcServer stopped")
+
+		if err = tracer.Shutdown(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to flush traces")
+		}
 	}()
 
 	go handleInterrupt(cancel)
 
 	logger.Info().Msg("gatewayServer up")
-	err = gwServer.ListenAndServe()
+	if gwServer.TLSConfig != nil {
+		err = gwServer.ListenAndServeTLS("", "")
+	} else {
+		err = gwServer.ListenAndServe()
+	}
 	if err != nil {
 		logger.Panic().Err(err).Msg("failed to listen and serve gateway server")
 	}
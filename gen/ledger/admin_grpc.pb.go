@@ -0,0 +1,682 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package ledger
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// CheckInvariants verifies the ledger invariants for the entries created since the last check.
+	CheckInvariants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CheckInvariantsResponse, error)
+	// ListInvariantViolations lists the violations found by previous checks, most recent first.
+	ListInvariantViolations(ctx context.Context, in *ListInvariantViolationsRequest, opts ...grpc.CallOption) (*ListInvariantViolationsResponse, error)
+	// RebuildSnapshots discards and recomputes the balance snapshots of an account. When the account is
+	// synthetic, the snapshots of every analytic account matching it are rebuilt as well.
+	RebuildSnapshots(ctx context.Context, in *RebuildSnapshotsRequest, opts ...grpc.CallOption) (*RebuildSnapshotsResponse, error)
+	// PruneSnapshots deletes the balance snapshots that were not read in the given number of days.
+	PruneSnapshots(ctx context.Context, in *PruneSnapshotsRequest, opts ...grpc.CallOption) (*PruneSnapshotsResponse, error)
+	// PrecomputeSnapshots brings the snapshots of the most read synthetic accounts up to date.
+	PrecomputeSnapshots(ctx context.Context, in *PrecomputeSnapshotsRequest, opts ...grpc.CallOption) (*PrecomputeSnapshotsResponse, error)
+	// CreateBook creates a book, an isolated ledger with its own accounts, versions and balances.
+	CreateBook(ctx context.Context, in *CreateBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// ListBooks lists every book, including the default one.
+	ListBooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBooksResponse, error)
+	// CreateEventSchema adds a version to the JSON Schema that the metadata of every entry of an event
+	// must follow. The new version applies to the transactions created after a few seconds.
+	CreateEventSchema(ctx context.Context, in *CreateEventSchemaRequest, opts ...grpc.CallOption) (*EventSchema, error)
+	// ListEventSchemas lists the versions of the schema of an event, oldest first.
+	ListEventSchemas(ctx context.Context, in *ListEventSchemasRequest, opts ...grpc.CallOption) (*ListEventSchemasResponse, error)
+	// CreateSchedule schedules a transaction for a future date, repeated on the occurrences of a
+	// recurrence rule when one is given. Transactions are posted by the schedules worker.
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// ListSchedules lists the schedules, most recently created first.
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	// PauseSchedule stops an active schedule from posting its transactions.
+	PauseSchedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// ResumeSchedule activates a paused schedule. The occurrences missed while it was paused are skipped.
+	ResumeSchedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// CancelSchedule finishes an active or paused schedule for good.
+	CancelSchedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// RunAccruals requests a back-run of the rate rules on each day between the dates, which the
+	// accrual job accrues a few days at a time, skipping the days a rule already accrued, so missed
+	// days can be back-run.
+	RunAccruals(ctx context.Context, in *RunAccrualsRequest, opts ...grpc.CallOption) (*RunAccrualsResponse, error)
+	// ListAccrualRuns lists the days accrued by the rate rules between the dates, oldest first.
+	ListAccrualRuns(ctx context.Context, in *ListAccrualRunsRequest, opts ...grpc.CallOption) (*ListAccrualRunsResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) CheckInvariants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CheckInvariantsResponse, error) {
+	out := new(CheckInvariantsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/CheckInvariants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListInvariantViolations(ctx context.Context, in *ListInvariantViolationsRequest, opts ...grpc.CallOption) (*ListInvariantViolationsResponse, error) {
+	out := new(ListInvariantViolationsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/ListInvariantViolations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RebuildSnapshots(ctx context.Context, in *RebuildSnapshotsRequest, opts ...grpc.CallOption) (*RebuildSnapshotsResponse, error) {
+	out := new(RebuildSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/RebuildSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PruneSnapshots(ctx context.Context, in *PruneSnapshotsRequest, opts ...grpc.CallOption) (*PruneSnapshotsResponse, error) {
+	out := new(PruneSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/PruneSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PrecomputeSnapshots(ctx context.Context, in *PrecomputeSnapshotsRequest, opts ...grpc.CallOption) (*PrecomputeSnapshotsResponse, error) {
+	out := new(PrecomputeSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/PrecomputeSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateBook(ctx context.Context, in *CreateBookRequest, opts ...grpc.CallOption) (*Book, error) {
+	out := new(Book)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/CreateBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListBooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBooksResponse, error) {
+	out := new(ListBooksResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/ListBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateEventSchema(ctx context.Context, in *CreateEventSchemaRequest, opts ...grpc.CallOption) (*EventSchema, error) {
+	out := new(EventSchema)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/CreateEventSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListEventSchemas(ctx context.Context, in *ListEventSchemasRequest, opts ...grpc.CallOption) (*ListEventSchemasResponse, error) {
+	out := new(ListEventSchemasResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/ListEventSchemas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/CreateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PauseSchedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/PauseSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumeSchedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/ResumeSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CancelSchedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/CancelSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RunAccruals(ctx context.Context, in *RunAccrualsRequest, opts ...grpc.CallOption) (*RunAccrualsResponse, error) {
+	out := new(RunAccrualsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/RunAccruals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListAccrualRuns(ctx context.Context, in *ListAccrualRunsRequest, opts ...grpc.CallOption) (*ListAccrualRunsResponse, error) {
+	out := new(ListAccrualRunsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/ListAccrualRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations should embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	// CheckInvariants verifies the ledger invariants for the entries created since the last check.
+	CheckInvariants(context.Context, *emptypb.Empty) (*CheckInvariantsResponse, error)
+	// ListInvariantViolations lists the violations found by previous checks, most recent first.
+	ListInvariantViolations(context.Context, *ListInvariantViolationsRequest) (*ListInvariantViolationsResponse, error)
+	// RebuildSnapshots discards and recomputes the balance snapshots of an account. When the account is
+	// synthetic, the snapshots of every analytic account matching it are rebuilt as well.
+	RebuildSnapshots(context.Context, *RebuildSnapshotsRequest) (*RebuildSnapshotsResponse, error)
+	// PruneSnapshots deletes the balance snapshots that were not read in the given number of days.
+	PruneSnapshots(context.Context, *PruneSnapshotsRequest) (*PruneSnapshotsResponse, error)
+	// PrecomputeSnapshots brings the snapshots of the most read synthetic accounts up to date.
+	PrecomputeSnapshots(context.Context, *PrecomputeSnapshotsRequest) (*PrecomputeSnapshotsResponse, error)
+	// CreateBook creates a book, an isolated ledger with its own accounts, versions and balances.
+	CreateBook(context.Context, *CreateBookRequest) (*Book, error)
+	// ListBooks lists every book, including the default one.
+	ListBooks(context.Context, *emptypb.Empty) (*ListBooksResponse, error)
+	// CreateEventSchema adds a version to the JSON Schema that the metadata of every entry of an event
+	// must follow. The new version applies to the transactions created after a few seconds.
+	CreateEventSchema(context.Context, *CreateEventSchemaRequest) (*EventSchema, error)
+	// ListEventSchemas lists the versions of the schema of an event, oldest first.
+	ListEventSchemas(context.Context, *ListEventSchemasRequest) (*ListEventSchemasResponse, error)
+	// CreateSchedule schedules a transaction for a future date, repeated on the occurrences of a
+	// recurrence rule when one is given. Transactions are posted by the schedules worker.
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*Schedule, error)
+	// ListSchedules lists the schedules, most recently created first.
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	// PauseSchedule stops an active schedule from posting its transactions.
+	PauseSchedule(context.Context, *ScheduleRequest) (*Schedule, error)
+	// ResumeSchedule activates a paused schedule. The occurrences missed while it was paused are skipped.
+	ResumeSchedule(context.Context, *ScheduleRequest) (*Schedule, error)
+	// CancelSchedule finishes an active or paused schedule for good.
+	CancelSchedule(context.Context, *ScheduleRequest) (*Schedule, error)
+	// RunAccruals requests a back-run of the rate rules on each day between the dates, which the
+	// accrual job accrues a few days at a time, skipping the days a rule already accrued, so missed
+	// days can be back-run.
+	RunAccruals(context.Context, *RunAccrualsRequest) (*RunAccrualsResponse, error)
+	// ListAccrualRuns lists the days accrued by the rate rules between the dates, oldest first.
+	ListAccrualRuns(context.Context, *ListAccrualRunsRequest) (*ListAccrualRunsResponse, error)
+}
+
+// UnimplementedAdminServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) CheckInvariants(context.Context, *emptypb.Empty) (*CheckInvariantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInvariants not implemented")
+}
+func (UnimplementedAdminServiceServer) ListInvariantViolations(context.Context, *ListInvariantViolationsRequest) (*ListInvariantViolationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvariantViolations not implemented")
+}
+func (UnimplementedAdminServiceServer) RebuildSnapshots(context.Context, *RebuildSnapshotsRequest) (*RebuildSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildSnapshots not implemented")
+}
+func (UnimplementedAdminServiceServer) PruneSnapshots(context.Context, *PruneSnapshotsRequest) (*PruneSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneSnapshots not implemented")
+}
+func (UnimplementedAdminServiceServer) PrecomputeSnapshots(context.Context, *PrecomputeSnapshotsRequest) (*PrecomputeSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrecomputeSnapshots not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateBook(context.Context, *CreateBookRequest) (*Book, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBook not implemented")
+}
+func (UnimplementedAdminServiceServer) ListBooks(context.Context, *emptypb.Empty) (*ListBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBooks not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateEventSchema(context.Context, *CreateEventSchemaRequest) (*EventSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEventSchema not implemented")
+}
+func (UnimplementedAdminServiceServer) ListEventSchemas(context.Context, *ListEventSchemasRequest) (*ListEventSchemasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEventSchemas not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateSchedule(context.Context, *CreateScheduleRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (UnimplementedAdminServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedAdminServiceServer) PauseSchedule(context.Context, *ScheduleRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSchedule not implemented")
+}
+func (UnimplementedAdminServiceServer) ResumeSchedule(context.Context, *ScheduleRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSchedule not implemented")
+}
+func (UnimplementedAdminServiceServer) CancelSchedule(context.Context, *ScheduleRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
+}
+func (UnimplementedAdminServiceServer) RunAccruals(context.Context, *RunAccrualsRequest) (*RunAccrualsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunAccruals not implemented")
+}
+func (UnimplementedAdminServiceServer) ListAccrualRuns(context.Context, *ListAccrualRunsRequest) (*ListAccrualRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccrualRuns not implemented")
+}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_CheckInvariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CheckInvariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/CheckInvariants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CheckInvariants(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListInvariantViolations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvariantViolationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListInvariantViolations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/ListInvariantViolations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListInvariantViolations(ctx, req.(*ListInvariantViolationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RebuildSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RebuildSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/RebuildSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RebuildSnapshots(ctx, req.(*RebuildSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PruneSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PruneSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/PruneSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PruneSnapshots(ctx, req.(*PruneSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PrecomputeSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrecomputeSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PrecomputeSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/PrecomputeSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PrecomputeSnapshots(ctx, req.(*PrecomputeSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/CreateBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateBook(ctx, req.(*CreateBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/ListBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListBooks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateEventSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEventSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateEventSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/CreateEventSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateEventSchema(ctx, req.(*CreateEventSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListEventSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListEventSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/ListEventSchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListEventSchemas(ctx, req.(*ListEventSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/CreateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PauseSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PauseSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/PauseSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PauseSchedule(ctx, req.(*ScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/ResumeSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumeSchedule(ctx, req.(*ScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/CancelSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CancelSchedule(ctx, req.(*ScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RunAccruals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunAccrualsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RunAccruals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/RunAccruals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RunAccruals(ctx, req.(*RunAccrualsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListAccrualRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccrualRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListAccrualRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.AdminService/ListAccrualRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListAccrualRuns(ctx, req.(*ListAccrualRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ledger.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckInvariants",
+			Handler:    _AdminService_CheckInvariants_Handler,
+		},
+		{
+			MethodName: "ListInvariantViolations",
+			Handler:    _AdminService_ListInvariantViolations_Handler,
+		},
+		{
+			MethodName: "RebuildSnapshots",
+			Handler:    _AdminService_RebuildSnapshots_Handler,
+		},
+		{
+			MethodName: "PruneSnapshots",
+			Handler:    _AdminService_PruneSnapshots_Handler,
+		},
+		{
+			MethodName: "PrecomputeSnapshots",
+			Handler:    _AdminService_PrecomputeSnapshots_Handler,
+		},
+		{
+			MethodName: "CreateBook",
+			Handler:    _AdminService_CreateBook_Handler,
+		},
+		{
+			MethodName: "ListBooks",
+			Handler:    _AdminService_ListBooks_Handler,
+		},
+		{
+			MethodName: "CreateEventSchema",
+			Handler:    _AdminService_CreateEventSchema_Handler,
+		},
+		{
+			MethodName: "ListEventSchemas",
+			Handler:    _AdminService_ListEventSchemas_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _AdminService_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _AdminService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "PauseSchedule",
+			Handler:    _AdminService_PauseSchedule_Handler,
+		},
+		{
+			MethodName: "ResumeSchedule",
+			Handler:    _AdminService_ResumeSchedule_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _AdminService_CancelSchedule_Handler,
+		},
+		{
+			MethodName: "RunAccruals",
+			Handler:    _AdminService_RunAccruals_Handler,
+		},
+		{
+			MethodName: "ListAccrualRuns",
+			Handler:    _AdminService_ListAccrualRuns_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ledger/admin.proto",
+}
@@ -53,9 +53,13 @@ func main() {
 func getScenarios() []scenarios.Scenario {
 	ls := scenarios.NewLedgerScenario(totalClientAccounts, totalRequests)
 	rs := scenarios.NewReportScenario(ls.GetRandomClientAccount())
+	abs := scenarios.NewBalanceScenario(ls.GetRandomClientAccount())
+	sbs := scenarios.NewBalanceScenario("liability.aaa.bbb.*")
 
 	return []scenarios.Scenario{
 		ls,
 		rs,
+		abs,
+		sbs,
 	}
 }
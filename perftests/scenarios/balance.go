@@ -0,0 +1,34 @@
+package scenarios
+
+import "fmt"
+
+// Scenario 03
+//
+// Type: banking/output
+// Description: get account balances after an insert benchmark
+// Steps:
+// 	- execute Scenario 01
+// 	- query for the balance of a client account (analytic) or of all client accounts (synthetic)
+//
+// Running it against servers with different DATABASE_BALANCE_STRATEGY values compares the lazy
+// (computed on read) and eager (maintained on write) balance strategies.
+
+type BalanceScenario struct {
+	method  string
+	account string
+}
+
+func NewBalanceScenario(account string) BalanceScenario {
+	s := BalanceScenario{}
+	s.method = "ledger.LedgerService.GetAccountBalance"
+	s.account = fmt.Sprintf(`{"account": "%s"}`, account)
+	return s
+}
+
+func (s BalanceScenario) GetMethod() string {
+	return s.method
+}
+
+func (s BalanceScenario) GetJSON() string {
+	return s.account
+}